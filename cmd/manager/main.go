@@ -19,6 +19,7 @@ package main
 import (
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
 	"runtime"
 
@@ -29,7 +30,14 @@ import (
 	sdkVersion "github.com/operator-framework/operator-sdk/version"
 	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/spf13/pflag"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -39,7 +47,10 @@ import (
 	"kubevirt.io/hostpath-provisioner-operator/pkg/apis"
 	hppv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
 	"kubevirt.io/hostpath-provisioner-operator/pkg/controller"
+	"kubevirt.io/hostpath-provisioner-operator/pkg/controller/hostpathprovisioner"
+	"kubevirt.io/hostpath-provisioner-operator/pkg/monitoring/metrics"
 	"kubevirt.io/hostpath-provisioner-operator/pkg/util/cryptopolicy"
+	"kubevirt.io/hostpath-provisioner-operator/pkg/util/maxprocs"
 )
 
 var log = logf.Log.WithName("cmd")
@@ -73,12 +84,23 @@ func main() {
 
 	printVersion()
 
+	// Without this the runtime uses the CPUs of the node, not the CPU limit of the operator container.
+	if procs, err := maxprocs.Set(); err != nil {
+		log.Error(err, "Unable to set GOMAXPROCS from the CPU quota", "GOMAXPROCS", procs)
+	} else {
+		log.Info(fmt.Sprintf("GOMAXPROCS: %d", procs))
+	}
+
 	namespace, err := k8sutil.GetWatchNamespace()
 	if err != nil {
 		log.Error(err, "Failed to get watch namespace")
 		os.Exit(1)
 	}
 
+	allNamespaces := map[string]cache.Config{
+		cache.AllNamespaces: {},
+	}
+
 	// Get a config to talk to the apiserver
 	cfg, err := config.GetConfig()
 	if err != nil {
@@ -86,12 +108,50 @@ func main() {
 		os.Exit(1)
 	}
 
+	// The diagnostics endpoint is served by the metrics server, it is off by default.
+	extraHandlers := map[string]http.Handler{}
+	var diagnostics *hostpathprovisioner.DiagnosticsHandler
+	if hostpathprovisioner.IsDiagnosticsEnabled() {
+		diagnostics = hostpathprovisioner.NewDiagnosticsHandler()
+		extraHandlers[hostpathprovisioner.DiagnosticsPath] = diagnostics
+	}
+
+	metricsOptions, err := hostpathprovisioner.GetMetricsServerOptions(extraHandlers)
+	if err != nil {
+		log.Error(err, "Invalid metrics server configuration")
+		os.Exit(1)
+	}
+
 	// Create a new Cmd to provide shared dependencies and start components
-	mgr, err := manager.New(cfg, manager.Options{
+	options := manager.Options{
+		Metrics: metricsOptions,
 		Cache: cache.Options{
 			DefaultNamespaces: map[string]cache.Config{
 				namespace: {},
 			},
+			// Storage pools can create their deployments, PVCs and cleanup jobs in other namespaces.
+			ByObject: map[client.Object]cache.ByObject{
+				&appsv1.Deployment{}:            {Namespaces: allNamespaces},
+				&batchv1.Job{}:                  {Namespaces: allNamespaces},
+				&corev1.PersistentVolumeClaim{}: {Namespaces: allNamespaces},
+				&corev1.ServiceAccount{}:        {Namespaces: allNamespaces},
+				// Only the pods of the provisioner are read, the storage pool status reports the nodes of their pods.
+				&corev1.Pod{}: {
+					Namespaces: allNamespaces,
+					Label:      labels.SelectorFromSet(labels.Set{"k8s-app": hostpathprovisioner.MultiPurposeHostPathProvisionerName}),
+				},
+				// Only the scheduling failures are read, to detect pods with a volume node affinity conflict.
+				&corev1.Event{}: {
+					Namespaces: allNamespaces,
+					Field: fields.AndSelectors(
+						fields.OneTermEqualSelector("type", corev1.EventTypeWarning),
+						fields.OneTermEqualSelector("reason", hostpathprovisioner.FailedSchedulingReason),
+					),
+				},
+				// The RBAC of the CSI driver can be created in the namespaces selected by spec.rbac.namespaceSelector.
+				&rbacv1.Role{}:        {Namespaces: allNamespaces},
+				&rbacv1.RoleBinding{}: {Namespaces: allNamespaces},
+			},
 		},
 		LeaderElectionNamespace: namespace,
 		HealthProbeBindAddress:  "0.0.0.0:6060",
@@ -100,7 +160,12 @@ func main() {
 		LeaderElection:          true,
 		LeaderElectionID:        "hostpath-provisioner-operator-lock",
 		WebhookServer:           cryptopolicy.GetWebhookServerSpec(),
-	})
+	}
+	if err := hostpathprovisioner.SetLeaderElectionOptions(&options); err != nil {
+		log.Error(err, "Invalid leader election configuration")
+		os.Exit(1)
+	}
+	mgr, err := manager.New(cfg, options)
 	if err != nil {
 		log.Error(err, "")
 		os.Exit(1)
@@ -129,6 +194,10 @@ func main() {
 		os.Exit(1)
 	}
 
+	if diagnostics != nil {
+		diagnostics.SetClient(mgr.GetClient())
+	}
+
 	// Setup all Controllers
 	if err := controller.AddToManager(mgr); err != nil {
 		log.Error(err, "")
@@ -147,6 +216,13 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Only the leader reconciles, so only the leader should update the metrics.
+	go func() {
+		<-mgr.Elected()
+		log.Info("Elected leader, enabling metrics updates")
+		metrics.SetLeader(true)
+	}()
+
 	log.Info("Starting the Cmd.")
 	// Start the Cmd
 	if err := mgr.Start(signals.SetupSignalHandler()); err != nil {
@@ -16,10 +16,11 @@
 script_dir="$(cd "$(dirname "$0")" && pwd -P)"
 source "${script_dir}"/common.sh
 ensureArmAvailable
+ldflags="-X kubevirt.io/hostpath-provisioner-operator/version.gitCommit=$(git rev-parse HEAD 2>/dev/null) -X kubevirt.io/hostpath-provisioner-operator/version.buildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
 if [ "${GOARCH}" != "amd64" ]; then
   #disable dynamic linking for non amd64 architectures. Don't have a proper cross compiler to make this
   #work. In particular can't find a glibc that can be installed.
-  CGO_ENABLED=0 go build -a -o _out/hostpath-provisioner-operator cmd/manager/main.go
+  CGO_ENABLED=0 go build -a -ldflags "${ldflags}" -o _out/hostpath-provisioner-operator cmd/manager/main.go
 else
-  CGO_ENABLED=1 go build -a -tags strictfipsruntime -o _out/hostpath-provisioner-operator cmd/manager/main.go
+  CGO_ENABLED=1 go build -a -tags strictfipsruntime -ldflags "${ldflags}" -o _out/hostpath-provisioner-operator cmd/manager/main.go
 fi
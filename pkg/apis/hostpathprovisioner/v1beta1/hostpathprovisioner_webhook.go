@@ -18,18 +18,42 @@ package v1beta1
 
 import (
 	"fmt"
+	"path"
+	"slices"
+	"strings"
 
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"kubevirt.io/hostpath-provisioner-operator/pkg/util"
 )
 
 const (
-	maxStoragePoolNameLength = 50
-	maxPathLength            = 255
+	maxStoragePoolNameLength      = 50
+	maxAdditionalVolumeNameLength = 50
+	maxPathLength                 = 255
+	maxWorkerThreads              = 1000
+	// maxDriverNameLength is the maximum length of a CSI driver name
+	maxDriverNameLength = 63
+	// storagePoolMountSuffix is the suffix of the storage pool mount directories in the root of the provisioner container
+	storagePoolMountSuffix = "-data-dir"
 )
 
+// managedMountPaths are the paths the operator mounts in the provisioner container
+var managedMountPaths = []string{"/csi", "/var/lib/kubelet"}
+
+// managedContainerNames are the names of the containers the operator runs in the provisioner pods
+var managedContainerNames = []string{"hostpath-provisioner", "node-driver-registrar", "liveness-probe", "csi-provisioner", "csi-snapshotter", "csi-resizer"}
+
+// managedCSIDriverFlags are the command line flags the operator sets on the CSI driver container
+var managedCSIDriverFlags = []string{"drivername", "v", "endpoint", "nodeid", "version", "datadir"}
+
 // SetupWebhookWithManager configures the webhook for the passed in manager
 func (r *HostPathProvisioner) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
@@ -41,12 +65,12 @@ var _ webhook.Validator = &HostPathProvisioner{}
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *HostPathProvisioner) ValidateCreate() (admission.Warnings, error) {
-	return r.validatePathConfigAndStoragePools()
+	return r.validate()
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (r *HostPathProvisioner) ValidateUpdate(_ runtime.Object) (admission.Warnings, error) {
-	return r.validatePathConfigAndStoragePools()
+	return r.validate()
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -54,6 +78,80 @@ func (r *HostPathProvisioner) ValidateDelete() (admission.Warnings, error) {
 	return nil, nil
 }
 
+func (r *HostPathProvisioner) validate() (admission.Warnings, error) {
+	if err := validateWorkload(r.Spec.Workload); err != nil {
+		return nil, err
+	}
+	if err := r.validateAdditionalVolumes(); err != nil {
+		return nil, err
+	}
+	if err := r.validateExtraArgs(); err != nil {
+		return nil, err
+	}
+	if err := r.validateDriverName(); err != nil {
+		return nil, err
+	}
+	if r.Spec.CSIDriver != nil && r.Spec.CSIDriver.WorkerThreads != nil {
+		if threads := *r.Spec.CSIDriver.WorkerThreads; threads < 1 || threads > maxWorkerThreads {
+			return nil, fmt.Errorf("spec.csiDriver.workerThreads must be between 1 and %d", maxWorkerThreads)
+		}
+	}
+	if r.Spec.Monitoring != nil && r.Spec.Monitoring.NotReadyGracePeriod != nil && r.Spec.Monitoring.NotReadyGracePeriod.Duration < 0 {
+		return nil, fmt.Errorf("spec.monitoring.notReadyGracePeriod cannot be negative")
+	}
+	if r.Spec.Monitoring != nil && r.Spec.Monitoring.DegradedGracePeriod != nil && r.Spec.Monitoring.DegradedGracePeriod.Duration < 0 {
+		return nil, fmt.Errorf("spec.monitoring.degradedGracePeriod cannot be negative")
+	}
+	if r.Spec.Monitoring != nil && r.Spec.Monitoring.MinReadyNodes != nil && *r.Spec.Monitoring.MinReadyNodes < 1 {
+		return nil, fmt.Errorf("spec.monitoring.minReadyNodes must be at least 1")
+	}
+	if r.Spec.RBAC != nil && r.Spec.RBAC.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(r.Spec.RBAC.NamespaceSelector); err != nil {
+			return nil, fmt.Errorf("spec.rbac.namespaceSelector is invalid: %v", err)
+		}
+	}
+	return r.validatePathConfigAndStoragePools()
+}
+
+func (r *HostPathProvisioner) validateExtraArgs() error {
+	if r.Spec.CSIDriver == nil {
+		return nil
+	}
+	usedFlags := make(map[string]int, 0)
+	for i, arg := range r.Spec.CSIDriver.ExtraArgs {
+		flag := util.GetFlagName(arg)
+		if !strings.HasPrefix(arg, "-") || flag == "" {
+			return fmt.Errorf("spec.csiDriver.extraArgs[%d] %q is not a flag", i, arg)
+		}
+		for _, managed := range managedCSIDriverFlags {
+			if flag == managed {
+				return fmt.Errorf("spec.csiDriver.extraArgs[%d] %q collides with a flag managed by the operator", i, arg)
+			}
+		}
+		if index, ok := usedFlags[flag]; !ok {
+			usedFlags[flag] = i
+		} else {
+			return fmt.Errorf("spec.csiDriver.extraArgs[%d] sets the same flag as spec.csiDriver.extraArgs[%d], cannot have duplicate flags", i, index)
+		}
+	}
+	return nil
+}
+
+// validateDriverName checks the driver name is a valid CSI driver name, like the API server does for the CSIDriver.
+func (r *HostPathProvisioner) validateDriverName() error {
+	if r.Spec.CSIDriver == nil || r.Spec.CSIDriver.DriverName == "" {
+		return nil
+	}
+	name := r.Spec.CSIDriver.DriverName
+	if len(name) > maxDriverNameLength {
+		return fmt.Errorf("spec.csiDriver.driverName cannot be longer than %d characters", maxDriverNameLength)
+	}
+	if errs := validation.IsDNS1123Subdomain(strings.ToLower(name)); len(errs) > 0 {
+		return fmt.Errorf("spec.csiDriver.driverName is invalid: %s", strings.Join(errs, ", "))
+	}
+	return nil
+}
+
 func (r *HostPathProvisioner) validatePathConfigAndStoragePools() (admission.Warnings, error) {
 	if r.Spec.PathConfig != nil && len(r.Spec.StoragePools) > 0 {
 		return nil, fmt.Errorf("pathConfig and storage pools cannot be both set")
@@ -80,6 +178,13 @@ func (r *HostPathProvisioner) validatePathConfigAndStoragePools() (admission.War
 			return nil, fmt.Errorf("spec.storagePools[%d].name is the same as spec.storagePools[%d].name, cannot have duplicate names", i, index)
 		}
 	}
+	for i, source := range r.Spec.StoragePools {
+		for index := 0; index < i; index++ {
+			if util.PathsOverlap(source.Path, r.Spec.StoragePools[index].Path) {
+				return nil, fmt.Errorf("spec.storagePools[%d].path overlaps spec.storagePools[%d].path, cannot have nested paths", i, index)
+			}
+		}
+	}
 	return nil, nil
 }
 
@@ -96,5 +201,211 @@ func validateStoragePool(storagePool StoragePool) error {
 	if len(storagePool.Path) > maxPathLength {
 		return fmt.Errorf("storagePool.path cannot have a length greater than 255")
 	}
+	if storagePool.CreateStorageClass {
+		if errs := validation.IsDNS1123Subdomain(storagePool.Name); len(errs) > 0 {
+			return fmt.Errorf("storagePool.name %q is not a valid StorageClass name: %s", storagePool.Name, strings.Join(errs, ", "))
+		}
+	}
+	if storagePool.Namespace != "" {
+		if errs := validation.IsDNS1123Label(storagePool.Namespace); len(errs) > 0 {
+			return fmt.Errorf("storagePool.namespace %q is not a valid namespace name: %s", storagePool.Namespace, strings.Join(errs, ", "))
+		}
+	}
+	for i, constraint := range storagePool.TopologySpreadConstraints {
+		if err := validateTopologySpreadConstraint(constraint); err != nil {
+			return fmt.Errorf("storagePool.topologySpreadConstraints[%d].%s", i, err)
+		}
+	}
+	if storagePool.DeploymentStrategy != nil {
+		switch storagePool.DeploymentStrategy.Type {
+		case appsv1.RollingUpdateDeploymentStrategyType:
+		case appsv1.RecreateDeploymentStrategyType:
+			if storagePool.DeploymentStrategy.RollingUpdate != nil {
+				return fmt.Errorf("storagePool.deploymentStrategy.rollingUpdate can only be set when type is %s", appsv1.RollingUpdateDeploymentStrategyType)
+			}
+		default:
+			return fmt.Errorf("storagePool.deploymentStrategy.type %q is invalid, must be one of %s, %s", storagePool.DeploymentStrategy.Type,
+				appsv1.RecreateDeploymentStrategyType, appsv1.RollingUpdateDeploymentStrategyType)
+		}
+	}
+	return nil
+}
+
+// validateTopologySpreadConstraint checks the fields the API server would reject on the pod template of the deployment.
+func validateTopologySpreadConstraint(constraint corev1.TopologySpreadConstraint) error {
+	if constraint.MaxSkew <= 0 {
+		return fmt.Errorf("maxSkew must be greater than zero")
+	}
+	if errs := validation.IsQualifiedName(constraint.TopologyKey); len(errs) > 0 {
+		return fmt.Errorf("topologyKey is invalid: %s", strings.Join(errs, ", "))
+	}
+	switch constraint.WhenUnsatisfiable {
+	case corev1.DoNotSchedule:
+	case corev1.ScheduleAnyway:
+		if constraint.MinDomains != nil {
+			return fmt.Errorf("minDomains can only be set when whenUnsatisfiable is %s", corev1.DoNotSchedule)
+		}
+	default:
+		return fmt.Errorf("whenUnsatisfiable %q is invalid, must be one of %s, %s", constraint.WhenUnsatisfiable, corev1.DoNotSchedule, corev1.ScheduleAnyway)
+	}
+	if constraint.MinDomains != nil && *constraint.MinDomains <= 0 {
+		return fmt.Errorf("minDomains must be greater than zero")
+	}
+	if _, err := metav1.LabelSelectorAsSelector(constraint.LabelSelector); err != nil {
+		return fmt.Errorf("labelSelector is invalid: %v", err)
+	}
 	return nil
 }
+
+func validateTrustedCABundle(bundle *TrustedCABundleConfig) error {
+	if bundle == nil {
+		return nil
+	}
+	if bundle.InjectClusterTrustBundle {
+		if bundle.ConfigMapName != "" || bundle.Key != "" {
+			return fmt.Errorf("workload.trustedCABundle.injectClusterTrustBundle cannot be combined with configMapName or key")
+		}
+		return nil
+	}
+	if bundle.ConfigMapName == "" {
+		return fmt.Errorf("workload.trustedCABundle.configMapName is required unless injectClusterTrustBundle is set")
+	}
+	if errs := validation.IsDNS1123Subdomain(bundle.ConfigMapName); len(errs) > 0 {
+		return fmt.Errorf("workload.trustedCABundle.configMapName is invalid: %s", strings.Join(errs, ", "))
+	}
+	if bundle.Key != "" {
+		if errs := validation.IsConfigMapKey(bundle.Key); len(errs) > 0 {
+			return fmt.Errorf("workload.trustedCABundle.key is invalid: %s", strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+func validateWorkload(workload NodePlacement) error {
+	if workload.TerminationGracePeriodSeconds != nil && *workload.TerminationGracePeriodSeconds < 0 {
+		return fmt.Errorf("workload.terminationGracePeriodSeconds cannot be negative")
+	}
+	if workload.RevisionHistoryLimit != nil && *workload.RevisionHistoryLimit < 0 {
+		return fmt.Errorf("workload.revisionHistoryLimit cannot be negative")
+	}
+	if workload.FSGroup != nil && *workload.FSGroup < 0 {
+		return fmt.Errorf("workload.fsGroup cannot be negative")
+	}
+	if workload.CleanupJob != nil {
+		if workload.CleanupJob.BackoffLimit != nil && *workload.CleanupJob.BackoffLimit < 0 {
+			return fmt.Errorf("workload.cleanupJob.backoffLimit cannot be negative")
+		}
+		if workload.CleanupJob.TTLSecondsAfterFinished != nil && *workload.CleanupJob.TTLSecondsAfterFinished < 0 {
+			return fmt.Errorf("workload.cleanupJob.ttlSecondsAfterFinished cannot be negative")
+		}
+		if workload.CleanupJob.ActiveDeadlineSeconds != nil && *workload.CleanupJob.ActiveDeadlineSeconds <= 0 {
+			return fmt.Errorf("workload.cleanupJob.activeDeadlineSeconds must be positive")
+		}
+	}
+	if err := validateTrustedCABundle(workload.TrustedCABundle); err != nil {
+		return err
+	}
+	usedEnvNames := make(map[string]int, 0)
+	for i, env := range workload.Env {
+		if errs := validation.IsEnvVarName(env.Name); len(errs) > 0 {
+			return fmt.Errorf("workload.env[%d].name is invalid: %s", i, strings.Join(errs, ", "))
+		}
+		if index, ok := usedEnvNames[env.Name]; !ok {
+			usedEnvNames[env.Name] = i
+		} else {
+			return fmt.Errorf("workload.env[%d].name is the same as workload.env[%d].name, cannot have duplicate names", i, index)
+		}
+	}
+	usedInitContainerNames := make(map[string]int, 0)
+	for i, container := range workload.InitContainers {
+		if errs := validation.IsDNS1123Label(container.Name); len(errs) > 0 {
+			return fmt.Errorf("workload.initContainers[%d].name is invalid: %s", i, strings.Join(errs, ", "))
+		}
+		if slices.Contains(managedContainerNames, container.Name) {
+			return fmt.Errorf("workload.initContainers[%d].name %s collides with a container managed by the operator", i, container.Name)
+		}
+		if index, ok := usedInitContainerNames[container.Name]; !ok {
+			usedInitContainerNames[container.Name] = i
+		} else {
+			return fmt.Errorf("workload.initContainers[%d].name is the same as workload.initContainers[%d].name, cannot have duplicate names", i, index)
+		}
+		if container.Image == "" {
+			return fmt.Errorf("workload.initContainers[%d].image is required", i)
+		}
+	}
+	switch workload.ResourceProfile {
+	case "", ResourceProfileMinimal, ResourceProfileDefault, ResourceProfileHighThroughput:
+	default:
+		return fmt.Errorf("workload.resourceProfile %q is invalid, must be one of %s, %s, %s", workload.ResourceProfile,
+			ResourceProfileMinimal, ResourceProfileDefault, ResourceProfileHighThroughput)
+	}
+	switch workload.DNSPolicy {
+	case "", corev1.DNSClusterFirstWithHostNet, corev1.DNSDefault:
+	case corev1.DNSClusterFirst:
+		if workload.HostNetwork {
+			return fmt.Errorf("workload.dnsPolicy ClusterFirst cannot be used with workload.hostNetwork, use ClusterFirstWithHostNet instead")
+		}
+	case corev1.DNSNone:
+		if workload.DNSConfig == nil || len(workload.DNSConfig.Nameservers) == 0 {
+			return fmt.Errorf("workload.dnsConfig must specify nameservers when workload.dnsPolicy is None")
+		}
+	default:
+		return fmt.Errorf("workload.dnsPolicy %q is invalid, must be one of %s, %s, %s, %s", workload.DNSPolicy,
+			corev1.DNSClusterFirstWithHostNet, corev1.DNSClusterFirst, corev1.DNSDefault, corev1.DNSNone)
+	}
+	return nil
+}
+
+func (r *HostPathProvisioner) validateAdditionalVolumes() error {
+	reservedMountPaths := append([]string{}, managedMountPaths...)
+	if r.Spec.PathConfig != nil && len(r.Spec.PathConfig.Path) > 0 {
+		reservedMountPaths = append(reservedMountPaths, path.Clean(r.Spec.PathConfig.Path))
+	}
+	usedNames := make(map[string]int, 0)
+	usedMountPaths := make(map[string]int, 0)
+	for i, additionalVolume := range r.Spec.AdditionalVolumes {
+		if errs := validation.IsDNS1123Label(additionalVolume.Name); len(errs) > 0 {
+			return fmt.Errorf("spec.additionalVolumes[%d].name is invalid: %s", i, strings.Join(errs, ", "))
+		}
+		if len(additionalVolume.Name) > maxAdditionalVolumeNameLength {
+			return fmt.Errorf("spec.additionalVolumes[%d].name cannot have a length greater than 50", i)
+		}
+		if !path.IsAbs(additionalVolume.HostPath) {
+			return fmt.Errorf("spec.additionalVolumes[%d].hostPath must be an absolute path", i)
+		}
+		if !path.IsAbs(additionalVolume.MountPath) {
+			return fmt.Errorf("spec.additionalVolumes[%d].mountPath must be an absolute path", i)
+		}
+		mountPath := path.Clean(additionalVolume.MountPath)
+		if isReservedMountPath(mountPath, reservedMountPaths) {
+			return fmt.Errorf("spec.additionalVolumes[%d].mountPath %s collides with a mount path managed by the operator", i, additionalVolume.MountPath)
+		}
+		if index, ok := usedNames[additionalVolume.Name]; !ok {
+			usedNames[additionalVolume.Name] = i
+		} else {
+			return fmt.Errorf("spec.additionalVolumes[%d].name is the same as spec.additionalVolumes[%d].name, cannot have duplicate names", i, index)
+		}
+		if index, ok := usedMountPaths[mountPath]; !ok {
+			usedMountPaths[mountPath] = i
+		} else {
+			return fmt.Errorf("spec.additionalVolumes[%d].mountPath is the same as spec.additionalVolumes[%d].mountPath, cannot have duplicate mount paths", i, index)
+		}
+	}
+	return nil
+}
+
+// isReservedMountPath checks if the mount path is, contains, or is inside one of the reserved mount paths, or is a storage pool mount.
+func isReservedMountPath(mountPath string, reservedMountPaths []string) bool {
+	if mountPath == "/" {
+		return true
+	}
+	if strings.HasSuffix(strings.Split(mountPath, "/")[1], storagePoolMountSuffix) {
+		return true
+	}
+	for _, reserved := range reservedMountPaths {
+		if mountPath == reserved || strings.HasPrefix(mountPath, reserved+"/") || strings.HasPrefix(reserved, mountPath+"/") {
+			return true
+		}
+	}
+	return false
+}
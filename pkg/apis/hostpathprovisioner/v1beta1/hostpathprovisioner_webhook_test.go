@@ -17,10 +17,15 @@ package v1beta1
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	ginkgo "github.com/onsi/ginkgo/v2"
 	gomega "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 )
 
 const (
@@ -182,6 +187,20 @@ var (
 			},
 		},
 	}
+	negativeGracePeriodCr = HostPathProvisioner{
+		Spec: HostPathProvisionerSpec{
+			Workload: NodePlacement{
+				TerminationGracePeriodSeconds: &negativeGracePeriod,
+			},
+			StoragePools: []StoragePool{
+				{
+					Name: "test",
+					Path: "test",
+				},
+			},
+		},
+	}
+	negativeGracePeriod = int64(-1)
 )
 
 var _ = ginkgo.Describe("validating webhook", func() {
@@ -215,6 +234,34 @@ var _ = ginkgo.Describe("validating webhook", func() {
 			_, err := multiSourceVolumeDuplicatePathCR.ValidateCreate()
 			gomega.Expect(err).To(gomega.BeEquivalentTo(fmt.Errorf("spec.storagePools[2].path is the same as spec.storagePools[0].path, cannot have duplicate paths")))
 		})
+		ginkgo.DescribeTable("Should not allow overlapping paths", func(first, second string, expectedErr error) {
+			hppCr := HostPathProvisioner{
+				Spec: HostPathProvisionerSpec{
+					StoragePools: []StoragePool{
+						{
+							Name: "first",
+							Path: first,
+						},
+						{
+							Name: "second",
+							Path: second,
+						},
+					},
+				},
+			}
+			_, err := hppCr.ValidateCreate()
+			if expectedErr == nil {
+				gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			} else {
+				gomega.Expect(err).To(gomega.BeEquivalentTo(expectedErr))
+			}
+		},
+			ginkgo.Entry("nested path", "/var/hpvolumes", "/var/hpvolumes/fast", fmt.Errorf("spec.storagePools[1].path overlaps spec.storagePools[0].path, cannot have nested paths")),
+			ginkgo.Entry("parent path", "/var/hpvolumes/fast/", "/var/hpvolumes", fmt.Errorf("spec.storagePools[1].path overlaps spec.storagePools[0].path, cannot have nested paths")),
+			ginkgo.Entry("identical path", "/var/hpvolumes", "/var/hpvolumes", fmt.Errorf("spec.storagePools[1].path is the same as spec.storagePools[0].path, cannot have duplicate paths")),
+			ginkgo.Entry("disjoint paths with a common prefix", "/var/hpvolumes", "/var/hpvolumes2", nil),
+			ginkgo.Entry("sibling paths", "/var/hpvolumes/fast", "/var/hpvolumes/slow", nil),
+		)
 		ginkgo.It("Should not allow duplicate names", func() {
 			_, err := multiSourceVolumeDuplicateNameCR.ValidateCreate()
 			gomega.Expect(err).To(gomega.BeEquivalentTo(fmt.Errorf("spec.storagePools[2].name is the same as spec.storagePools[0].name, cannot have duplicate names")))
@@ -227,6 +274,538 @@ var _ = ginkgo.Describe("validating webhook", func() {
 			_, err := longPathCr.ValidateCreate()
 			gomega.Expect(err).To(gomega.BeEquivalentTo(fmt.Errorf("storagePool.path cannot have a length greater than 255")))
 		})
+		ginkgo.It("Should not allow negative workload.terminationGracePeriodSeconds", func() {
+			_, err := negativeGracePeriodCr.ValidateCreate()
+			gomega.Expect(err).To(gomega.BeEquivalentTo(fmt.Errorf("workload.terminationGracePeriodSeconds cannot be negative")))
+		})
+		ginkgo.It("Should not allow negative workload.revisionHistoryLimit", func() {
+			hppCr := HostPathProvisioner{
+				Spec: HostPathProvisionerSpec{
+					Workload: NodePlacement{
+						RevisionHistoryLimit: pointer.Int32(-1),
+					},
+					StoragePools: []StoragePool{
+						{
+							Name: "test",
+							Path: "test",
+						},
+					},
+				},
+			}
+			_, err := hppCr.ValidateCreate()
+			gomega.Expect(err).To(gomega.BeEquivalentTo(fmt.Errorf("workload.revisionHistoryLimit cannot be negative")))
+			hppCr.Spec.Workload.RevisionHistoryLimit = pointer.Int32(0)
+			_, err = hppCr.ValidateCreate()
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		})
+		ginkgo.It("Should not allow an unknown workload.resourceProfile", func() {
+			hppCr := HostPathProvisioner{
+				Spec: HostPathProvisionerSpec{
+					Workload: NodePlacement{
+						ResourceProfile: "tiny",
+					},
+					StoragePools: []StoragePool{
+						{
+							Name: "test",
+							Path: "test",
+						},
+					},
+				},
+			}
+			_, err := hppCr.ValidateCreate()
+			gomega.Expect(err).To(gomega.BeEquivalentTo(fmt.Errorf("workload.resourceProfile \"tiny\" is invalid, must be one of minimal, default, highThroughput")))
+		})
+		ginkgo.DescribeTable("Should validate the storagePool.deploymentStrategy", func(strategy *appsv1.DeploymentStrategy, expectedErr error) {
+			hppCr := HostPathProvisioner{
+				Spec: HostPathProvisionerSpec{
+					StoragePools: []StoragePool{
+						{
+							Name:               "test",
+							Path:               "test",
+							DeploymentStrategy: strategy,
+						},
+					},
+				},
+			}
+			_, err := hppCr.ValidateCreate()
+			if expectedErr == nil {
+				gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			} else {
+				gomega.Expect(err).To(gomega.BeEquivalentTo(expectedErr))
+			}
+		},
+			ginkgo.Entry("not set", nil, nil),
+			ginkgo.Entry("recreate", &appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}, nil),
+			ginkgo.Entry("rolling update", &appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType}, nil),
+			ginkgo.Entry("rolling update with parameters", &appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType, RollingUpdate: &appsv1.RollingUpdateDeployment{}}, nil),
+			ginkgo.Entry("recreate with rolling update parameters", &appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType, RollingUpdate: &appsv1.RollingUpdateDeployment{}},
+				fmt.Errorf("storagePool.deploymentStrategy.rollingUpdate can only be set when type is RollingUpdate")),
+			ginkgo.Entry("unknown", &appsv1.DeploymentStrategy{Type: "BlueGreen"}, fmt.Errorf("storagePool.deploymentStrategy.type \"BlueGreen\" is invalid, must be one of Recreate, RollingUpdate")),
+			ginkgo.Entry("empty type", &appsv1.DeploymentStrategy{}, fmt.Errorf("storagePool.deploymentStrategy.type \"\" is invalid, must be one of Recreate, RollingUpdate")),
+		)
+		ginkgo.It("Should not allow an invalid spec.rbac.namespaceSelector", func() {
+			hppCr := HostPathProvisioner{
+				Spec: HostPathProvisionerSpec{
+					RBAC: &RBACConfig{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{
+								{
+									Key:      "hpp",
+									Operator: "Matches",
+								},
+							},
+						},
+					},
+					StoragePools: []StoragePool{
+						{
+							Name: "test",
+							Path: "test",
+						},
+					},
+				},
+			}
+			_, err := hppCr.ValidateCreate()
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			gomega.Expect(err.Error()).To(gomega.HavePrefix("spec.rbac.namespaceSelector is invalid: "))
+		})
+		ginkgo.DescribeTable("Should validate the storage pool topology spread constraints", func(constraint corev1.TopologySpreadConstraint, expectedErr string) {
+			hppCr := HostPathProvisioner{
+				Spec: HostPathProvisionerSpec{
+					StoragePools: []StoragePool{
+						{
+							Name:                      "test",
+							Path:                      "test",
+							TopologySpreadConstraints: []corev1.TopologySpreadConstraint{constraint},
+						},
+					},
+				},
+			}
+			_, err := hppCr.ValidateCreate()
+			if expectedErr == "" {
+				gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			} else {
+				gomega.Expect(err).To(gomega.HaveOccurred())
+				gomega.Expect(err.Error()).To(gomega.HavePrefix(expectedErr))
+			}
+		},
+			ginkgo.Entry("valid", corev1.TopologySpreadConstraint{MaxSkew: 1, TopologyKey: corev1.LabelTopologyZone, WhenUnsatisfiable: corev1.DoNotSchedule, MinDomains: pointer.Int32(2)}, ""),
+			ginkgo.Entry("no max skew", corev1.TopologySpreadConstraint{TopologyKey: corev1.LabelTopologyZone, WhenUnsatisfiable: corev1.DoNotSchedule},
+				"storagePool.topologySpreadConstraints[0].maxSkew must be greater than zero"),
+			ginkgo.Entry("no topology key", corev1.TopologySpreadConstraint{MaxSkew: 1, WhenUnsatisfiable: corev1.DoNotSchedule},
+				"storagePool.topologySpreadConstraints[0].topologyKey is invalid: "),
+			ginkgo.Entry("invalid whenUnsatisfiable", corev1.TopologySpreadConstraint{MaxSkew: 1, TopologyKey: corev1.LabelTopologyZone, WhenUnsatisfiable: "Sometimes"},
+				"storagePool.topologySpreadConstraints[0].whenUnsatisfiable \"Sometimes\" is invalid"),
+			ginkgo.Entry("minDomains with ScheduleAnyway", corev1.TopologySpreadConstraint{MaxSkew: 1, TopologyKey: corev1.LabelTopologyZone, WhenUnsatisfiable: corev1.ScheduleAnyway, MinDomains: pointer.Int32(2)},
+				"storagePool.topologySpreadConstraints[0].minDomains can only be set when whenUnsatisfiable is DoNotSchedule"),
+			ginkgo.Entry("invalid label selector", corev1.TopologySpreadConstraint{MaxSkew: 1, TopologyKey: corev1.LabelTopologyZone, WhenUnsatisfiable: corev1.DoNotSchedule,
+				LabelSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Maybe"}}}},
+				"storagePool.topologySpreadConstraints[0].labelSelector is invalid: "),
+		)
+		ginkgo.DescribeTable("Should validate spec.csiDriver.driverName", func(driverName, expectedErr string) {
+			hppCr := HostPathProvisioner{
+				Spec: HostPathProvisionerSpec{
+					CSIDriver: &CSIDriverConfig{
+						DriverName: driverName,
+					},
+					StoragePools: []StoragePool{
+						{
+							Name: "test",
+							Path: "test",
+						},
+					},
+				},
+			}
+			_, err := hppCr.ValidateCreate()
+			if expectedErr == "" {
+				gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			} else {
+				gomega.Expect(err).To(gomega.HaveOccurred())
+				gomega.Expect(err.Error()).To(gomega.HavePrefix(expectedErr))
+			}
+		},
+			ginkgo.Entry("not set", "", ""),
+			ginkgo.Entry("valid", "hostpath.csi.example.com", ""),
+			ginkgo.Entry("valid with capitals", "HostPath.example.com", ""),
+			ginkgo.Entry("invalid characters", "kubevirt.io/hostpath-provisioner", "spec.csiDriver.driverName is invalid: "),
+			ginkgo.Entry("invalid end", "hostpath.example.com-", "spec.csiDriver.driverName is invalid: "),
+			ginkgo.Entry("too long", strings.Repeat("a", 64), "spec.csiDriver.driverName cannot be longer than 63 characters"),
+		)
+		ginkgo.DescribeTable("Should validate spec.csiDriver.extraArgs", func(extraArgs []string, expectedErr string) {
+			hppCr := HostPathProvisioner{
+				Spec: HostPathProvisionerSpec{
+					CSIDriver: &CSIDriverConfig{
+						ExtraArgs: extraArgs,
+					},
+					StoragePools: []StoragePool{
+						{
+							Name: "test",
+							Path: "test",
+						},
+					},
+				},
+			}
+			_, err := hppCr.ValidateCreate()
+			if expectedErr == "" {
+				gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			} else {
+				gomega.Expect(err).To(gomega.HaveOccurred())
+				gomega.Expect(err.Error()).To(gomega.Equal(expectedErr))
+			}
+		},
+			ginkgo.Entry("valid", []string{"--log-format=json", "-enable-feature"}, ""),
+			ginkgo.Entry("not a flag", []string{"--log-format=json", "json"}, "spec.csiDriver.extraArgs[1] \"json\" is not a flag"),
+			ginkgo.Entry("only dashes", []string{"--"}, "spec.csiDriver.extraArgs[0] \"--\" is not a flag"),
+			ginkgo.Entry("managed drivername", []string{"--drivername=other"}, "spec.csiDriver.extraArgs[0] \"--drivername=other\" collides with a flag managed by the operator"),
+			ginkgo.Entry("managed datadir with a single dash", []string{"-datadir=/tmp"}, "spec.csiDriver.extraArgs[0] \"-datadir=/tmp\" collides with a flag managed by the operator"),
+			ginkgo.Entry("managed verbosity", []string{"--v=5"}, "spec.csiDriver.extraArgs[0] \"--v=5\" collides with a flag managed by the operator"),
+			ginkgo.Entry("duplicate flags", []string{"--log-format=json", "--log-format=text"},
+				"spec.csiDriver.extraArgs[1] sets the same flag as spec.csiDriver.extraArgs[0], cannot have duplicate flags"),
+		)
+		ginkgo.DescribeTable("Should validate spec.csiDriver.workerThreads", func(workerThreads *int32, expectedErr string) {
+			hppCr := HostPathProvisioner{
+				Spec: HostPathProvisionerSpec{
+					CSIDriver: &CSIDriverConfig{
+						WorkerThreads: workerThreads,
+					},
+					StoragePools: []StoragePool{
+						{
+							Name: "test",
+							Path: "test",
+						},
+					},
+				},
+			}
+			_, err := hppCr.ValidateCreate()
+			if expectedErr == "" {
+				gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			} else {
+				gomega.Expect(err).To(gomega.HaveOccurred())
+				gomega.Expect(err.Error()).To(gomega.Equal(expectedErr))
+			}
+		},
+			ginkgo.Entry("not set", nil, ""),
+			ginkgo.Entry("minimum", pointer.Int32(1), ""),
+			ginkgo.Entry("maximum", pointer.Int32(1000), ""),
+			ginkgo.Entry("zero", pointer.Int32(0), "spec.csiDriver.workerThreads must be between 1 and 1000"),
+			ginkgo.Entry("negative", pointer.Int32(-1), "spec.csiDriver.workerThreads must be between 1 and 1000"),
+			ginkgo.Entry("too many", pointer.Int32(1001), "spec.csiDriver.workerThreads must be between 1 and 1000"),
+		)
+		ginkgo.DescribeTable("Should validate spec.monitoring.notReadyGracePeriod", func(gracePeriod time.Duration, expectedErr error) {
+			hppCr := HostPathProvisioner{
+				Spec: HostPathProvisionerSpec{
+					Monitoring: &MonitoringConfig{
+						NotReadyGracePeriod: &metav1.Duration{Duration: gracePeriod},
+					},
+					StoragePools: []StoragePool{
+						{
+							Name: "test",
+							Path: "test",
+						},
+					},
+				},
+			}
+			_, err := hppCr.ValidateCreate()
+			if expectedErr == nil {
+				gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			} else {
+				gomega.Expect(err).To(gomega.BeEquivalentTo(expectedErr))
+			}
+		},
+			ginkgo.Entry("zero", time.Duration(0), nil),
+			ginkgo.Entry("positive", 30*time.Minute, nil),
+			ginkgo.Entry("negative", -time.Minute, fmt.Errorf("spec.monitoring.notReadyGracePeriod cannot be negative")),
+		)
+		ginkgo.It("Should not allow a negative spec.monitoring.degradedGracePeriod", func() {
+			hppCr := HostPathProvisioner{
+				Spec: HostPathProvisionerSpec{
+					Monitoring: &MonitoringConfig{
+						DegradedGracePeriod: &metav1.Duration{Duration: -time.Minute},
+					},
+					StoragePools: []StoragePool{
+						{
+							Name: "test",
+							Path: "test",
+						},
+					},
+				},
+			}
+			_, err := hppCr.ValidateCreate()
+			gomega.Expect(err).To(gomega.BeEquivalentTo(fmt.Errorf("spec.monitoring.degradedGracePeriod cannot be negative")))
+		})
+		ginkgo.DescribeTable("Should validate spec.monitoring.minReadyNodes", func(minReadyNodes int32, expectedErr error) {
+			hppCr := HostPathProvisioner{
+				Spec: HostPathProvisionerSpec{
+					Monitoring: &MonitoringConfig{
+						MinReadyNodes: &minReadyNodes,
+					},
+					StoragePools: []StoragePool{
+						{
+							Name: "test",
+							Path: "test",
+						},
+					},
+				},
+			}
+			_, err := hppCr.ValidateCreate()
+			if expectedErr == nil {
+				gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			} else {
+				gomega.Expect(err).To(gomega.BeEquivalentTo(expectedErr))
+			}
+		},
+			ginkgo.Entry("one", int32(1), nil),
+			ginkgo.Entry("several", int32(5), nil),
+			ginkgo.Entry("zero", int32(0), fmt.Errorf("spec.monitoring.minReadyNodes must be at least 1")),
+		)
+		ginkgo.DescribeTable("Should validate workload.env", func(env []corev1.EnvVar, expectedErr string) {
+			hppCr := HostPathProvisioner{
+				Spec: HostPathProvisionerSpec{
+					Workload: NodePlacement{
+						Env: env,
+					},
+					StoragePools: []StoragePool{
+						{
+							Name: "test",
+							Path: "test",
+						},
+					},
+				},
+			}
+			_, err := hppCr.ValidateCreate()
+			if expectedErr == "" {
+				gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			} else {
+				gomega.Expect(err).To(gomega.HaveOccurred())
+				gomega.Expect(err.Error()).To(gomega.HavePrefix(expectedErr))
+			}
+		},
+			ginkgo.Entry("valid", []corev1.EnvVar{{Name: "HTTP_PROXY", Value: "http://proxy:3128"}, {Name: "NO_PROXY", Value: ".svc"}}, ""),
+			ginkgo.Entry("empty name", []corev1.EnvVar{{Value: "value"}}, "workload.env[0].name is invalid: "),
+			ginkgo.Entry("invalid name", []corev1.EnvVar{{Name: "HTTP_PROXY"}, {Name: "1=2"}}, "workload.env[1].name is invalid: "),
+			ginkgo.Entry("duplicate names", []corev1.EnvVar{{Name: "HTTP_PROXY"}, {Name: "HTTP_PROXY"}},
+				"workload.env[1].name is the same as workload.env[0].name, cannot have duplicate names"),
+		)
+		ginkgo.DescribeTable("Should validate workload.fsGroup", func(fsGroup int64, expectedErr error) {
+			hppCr := HostPathProvisioner{
+				Spec: HostPathProvisionerSpec{
+					Workload: NodePlacement{
+						FSGroup: &fsGroup,
+					},
+					StoragePools: []StoragePool{
+						{
+							Name: "test",
+							Path: "test",
+						},
+					},
+				},
+			}
+			_, err := hppCr.ValidateCreate()
+			if expectedErr == nil {
+				gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			} else {
+				gomega.Expect(err).To(gomega.BeEquivalentTo(expectedErr))
+			}
+		},
+			ginkgo.Entry("root group", int64(0), nil),
+			ginkgo.Entry("positive", int64(107), nil),
+			ginkgo.Entry("negative", int64(-1), fmt.Errorf("workload.fsGroup cannot be negative")),
+		)
+		ginkgo.DescribeTable("Should validate workload.initContainers", func(initContainers []corev1.Container, expectedErr string) {
+			hppCr := HostPathProvisioner{
+				Spec: HostPathProvisionerSpec{
+					Workload: NodePlacement{
+						InitContainers: initContainers,
+					},
+					StoragePools: []StoragePool{
+						{
+							Name: "test",
+							Path: "test",
+						},
+					},
+				},
+			}
+			_, err := hppCr.ValidateCreate()
+			if expectedErr == "" {
+				gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			} else {
+				gomega.Expect(err).To(gomega.HaveOccurred())
+				gomega.Expect(err.Error()).To(gomega.HavePrefix(expectedErr))
+			}
+		},
+			ginkgo.Entry("valid", []corev1.Container{{Name: "mkdir", Image: "busybox"}, {Name: "chmod", Image: "busybox"}}, ""),
+			ginkgo.Entry("empty name", []corev1.Container{{Image: "busybox"}}, "workload.initContainers[0].name is invalid: "),
+			ginkgo.Entry("invalid name", []corev1.Container{{Name: "mkdir", Image: "busybox"}, {Name: "Chmod", Image: "busybox"}}, "workload.initContainers[1].name is invalid: "),
+			ginkgo.Entry("duplicate names", []corev1.Container{{Name: "mkdir", Image: "busybox"}, {Name: "mkdir", Image: "busybox"}},
+				"workload.initContainers[1].name is the same as workload.initContainers[0].name, cannot have duplicate names"),
+			ginkgo.Entry("no image", []corev1.Container{{Name: "mkdir"}}, "workload.initContainers[0].image is required"),
+			ginkgo.Entry("provisioner container name", []corev1.Container{{Name: "mkdir", Image: "busybox"}, {Name: "hostpath-provisioner", Image: "busybox"}},
+				"workload.initContainers[1].name hostpath-provisioner collides with a container managed by the operator"),
+			ginkgo.Entry("sidecar container name", []corev1.Container{{Name: "csi-provisioner", Image: "busybox"}},
+				"workload.initContainers[0].name csi-provisioner collides with a container managed by the operator"),
+		)
+		ginkgo.DescribeTable("Should validate workload.cleanupJob", func(backoffLimit, ttl int32, activeDeadline *int64, expectedErr error) {
+			hppCr := HostPathProvisioner{
+				Spec: HostPathProvisionerSpec{
+					Workload: NodePlacement{
+						CleanupJob: &CleanupJobConfig{
+							BackoffLimit:            &backoffLimit,
+							TTLSecondsAfterFinished: &ttl,
+							ActiveDeadlineSeconds:   activeDeadline,
+						},
+					},
+					StoragePools: []StoragePool{
+						{
+							Name: "test",
+							Path: "test",
+						},
+					},
+				},
+			}
+			_, err := hppCr.ValidateCreate()
+			if expectedErr == nil {
+				gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			} else {
+				gomega.Expect(err).To(gomega.BeEquivalentTo(expectedErr))
+			}
+		},
+			ginkgo.Entry("valid", int32(0), int32(0), nil, nil),
+			ginkgo.Entry("valid activeDeadlineSeconds", int32(6), int32(300), pointer.Int64(600), nil),
+			ginkgo.Entry("negative backoffLimit", int32(-1), int32(300), nil, fmt.Errorf("workload.cleanupJob.backoffLimit cannot be negative")),
+			ginkgo.Entry("negative ttlSecondsAfterFinished", int32(6), int32(-1), nil, fmt.Errorf("workload.cleanupJob.ttlSecondsAfterFinished cannot be negative")),
+			ginkgo.Entry("zero activeDeadlineSeconds", int32(6), int32(300), pointer.Int64(0), fmt.Errorf("workload.cleanupJob.activeDeadlineSeconds must be positive")),
+		)
+		ginkgo.DescribeTable("Should validate workload.trustedCABundle", func(bundle TrustedCABundleConfig, expectedErr string) {
+			hppCr := HostPathProvisioner{
+				Spec: HostPathProvisionerSpec{
+					Workload: NodePlacement{
+						TrustedCABundle: &bundle,
+					},
+					StoragePools: []StoragePool{
+						{
+							Name: "test",
+							Path: "test",
+						},
+					},
+				},
+			}
+			_, err := hppCr.ValidateCreate()
+			if expectedErr == "" {
+				gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			} else {
+				gomega.Expect(err).To(gomega.HaveOccurred())
+				gomega.Expect(err.Error()).To(gomega.HavePrefix(expectedErr))
+			}
+		},
+			ginkgo.Entry("valid configMapName", TrustedCABundleConfig{ConfigMapName: "internal-ca"}, ""),
+			ginkgo.Entry("valid configMapName and key", TrustedCABundleConfig{ConfigMapName: "internal-ca", Key: "ca.pem"}, ""),
+			ginkgo.Entry("valid injectClusterTrustBundle", TrustedCABundleConfig{InjectClusterTrustBundle: true}, ""),
+			ginkgo.Entry("missing configMapName", TrustedCABundleConfig{Key: "ca.pem"},
+				"workload.trustedCABundle.configMapName is required unless injectClusterTrustBundle is set"),
+			ginkgo.Entry("invalid configMapName", TrustedCABundleConfig{ConfigMapName: "Internal_CA"}, "workload.trustedCABundle.configMapName is invalid: "),
+			ginkgo.Entry("invalid key", TrustedCABundleConfig{ConfigMapName: "internal-ca", Key: "ca/pem"}, "workload.trustedCABundle.key is invalid: "),
+			ginkgo.Entry("injectClusterTrustBundle with configMapName", TrustedCABundleConfig{ConfigMapName: "internal-ca", InjectClusterTrustBundle: true},
+				"workload.trustedCABundle.injectClusterTrustBundle cannot be combined with configMapName or key"),
+		)
+		ginkgo.It("Should not allow a None workload.dnsPolicy without nameservers", func() {
+			hppCr := HostPathProvisioner{
+				Spec: HostPathProvisionerSpec{
+					Workload: NodePlacement{
+						DNSPolicy: corev1.DNSNone,
+						DNSConfig: &corev1.PodDNSConfig{
+							Searches: []string{"example.com"},
+						},
+					},
+					StoragePools: []StoragePool{
+						{
+							Name: "test",
+							Path: "test",
+						},
+					},
+				},
+			}
+			_, err := hppCr.ValidateCreate()
+			gomega.Expect(err).To(gomega.BeEquivalentTo(fmt.Errorf("workload.dnsConfig must specify nameservers when workload.dnsPolicy is None")))
+			hppCr.Spec.Workload.DNSConfig.Nameservers = []string{"10.0.0.10"}
+			_, err = hppCr.ValidateCreate()
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		})
+		ginkgo.It("Should not allow the ClusterFirst workload.dnsPolicy with workload.hostNetwork", func() {
+			hppCr := HostPathProvisioner{
+				Spec: HostPathProvisionerSpec{
+					Workload: NodePlacement{
+						HostNetwork: true,
+						DNSPolicy:   corev1.DNSClusterFirst,
+					},
+					StoragePools: []StoragePool{
+						{
+							Name: "test",
+							Path: "test",
+						},
+					},
+				},
+			}
+			_, err := hppCr.ValidateCreate()
+			gomega.Expect(err).To(gomega.BeEquivalentTo(fmt.Errorf("workload.dnsPolicy ClusterFirst cannot be used with workload.hostNetwork, use ClusterFirstWithHostNet instead")))
+			hppCr.Spec.Workload.DNSPolicy = ""
+			_, err = hppCr.ValidateCreate()
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		})
+		ginkgo.It("Should allow creating a StorageClass for a storage pool", func() {
+			hppCr := storageClassPoolCr("local-pool")
+			_, err := hppCr.ValidateCreate()
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		})
+		ginkgo.It("Should not allow creating a StorageClass with an invalid name", func() {
+			hppCr := storageClassPoolCr("Local_Pool")
+			_, err := hppCr.ValidateCreate()
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			gomega.Expect(err.Error()).To(gomega.HavePrefix("storagePool.name \"Local_Pool\" is not a valid StorageClass name: a lowercase RFC 1123 subdomain"))
+		})
+		ginkgo.It("Should validate the storage pool namespace", func() {
+			hppCr := storageClassPoolCr("local")
+			hppCr.Spec.StoragePools[0].Namespace = "tenant-a"
+			_, err := hppCr.ValidateCreate()
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			hppCr.Spec.StoragePools[0].Namespace = "Tenant_A"
+			_, err = hppCr.ValidateCreate()
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			gomega.Expect(err.Error()).To(gomega.HavePrefix("storagePool.namespace \"Tenant_A\" is not a valid namespace name: a lowercase RFC 1123 label"))
+		})
+		ginkgo.It("Should allow additional volumes", func() {
+			hppCr := additionalVolumesCr(AdditionalVolume{Name: "extra", HostPath: "/mnt/extra", MountPath: "/mnt/extra"},
+				AdditionalVolume{Name: "extra2", HostPath: "/mnt/extra2", MountPath: "/var/lib/extra2", ReadOnly: true})
+			_, err := hppCr.ValidateCreate()
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		})
+		ginkgo.DescribeTable("Should not allow invalid additional volumes", func(hppCr HostPathProvisioner, expectedErr string) {
+			_, err := hppCr.ValidateCreate()
+			gomega.Expect(err).To(gomega.BeEquivalentTo(fmt.Errorf(expectedErr)))
+		},
+			ginkgo.Entry("invalid name", additionalVolumesCr(AdditionalVolume{Name: "Extra_1", HostPath: "/mnt", MountPath: "/mnt"}),
+				"spec.additionalVolumes[0].name is invalid: a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')"),
+			ginkgo.Entry("name too long", additionalVolumesCr(AdditionalVolume{Name: "l12345678901234567890123456789012345678901234567890", HostPath: "/mnt", MountPath: "/mnt"}),
+				"spec.additionalVolumes[0].name cannot have a length greater than 50"),
+			ginkgo.Entry("relative host path", additionalVolumesCr(AdditionalVolume{Name: "extra", HostPath: "mnt", MountPath: "/mnt"}),
+				"spec.additionalVolumes[0].hostPath must be an absolute path"),
+			ginkgo.Entry("relative mount path", additionalVolumesCr(AdditionalVolume{Name: "extra", HostPath: "/mnt", MountPath: "mnt"}),
+				"spec.additionalVolumes[0].mountPath must be an absolute path"),
+			ginkgo.Entry("root mount path", additionalVolumesCr(AdditionalVolume{Name: "extra", HostPath: "/mnt", MountPath: "/"}),
+				"spec.additionalVolumes[0].mountPath / collides with a mount path managed by the operator"),
+			ginkgo.Entry("socket dir mount path", additionalVolumesCr(AdditionalVolume{Name: "extra", HostPath: "/mnt", MountPath: "/csi/"}),
+				"spec.additionalVolumes[0].mountPath /csi/ collides with a mount path managed by the operator"),
+			ginkgo.Entry("inside kubelet mount path", additionalVolumesCr(AdditionalVolume{Name: "extra", HostPath: "/mnt", MountPath: "/var/lib/kubelet/pods/extra"}),
+				"spec.additionalVolumes[0].mountPath /var/lib/kubelet/pods/extra collides with a mount path managed by the operator"),
+			ginkgo.Entry("parent of kubelet mount path", additionalVolumesCr(AdditionalVolume{Name: "extra", HostPath: "/mnt", MountPath: "/var/lib"}),
+				"spec.additionalVolumes[0].mountPath /var/lib collides with a mount path managed by the operator"),
+			ginkgo.Entry("storage pool mount path", additionalVolumesCr(AdditionalVolume{Name: "extra", HostPath: "/mnt", MountPath: "/" + csiVolume}),
+				"spec.additionalVolumes[0].mountPath /csi-data-dir collides with a mount path managed by the operator"),
+			ginkgo.Entry("legacy mount path", legacyAdditionalVolumesCr(AdditionalVolume{Name: "extra", HostPath: "/mnt", MountPath: "/var/hpvolumes/extra"}),
+				"spec.additionalVolumes[0].mountPath /var/hpvolumes/extra collides with a mount path managed by the operator"),
+			ginkgo.Entry("duplicate names", additionalVolumesCr(AdditionalVolume{Name: "extra", HostPath: "/mnt", MountPath: "/mnt"}, AdditionalVolume{Name: "extra", HostPath: "/mnt2", MountPath: "/mnt2"}),
+				"spec.additionalVolumes[1].name is the same as spec.additionalVolumes[0].name, cannot have duplicate names"),
+			ginkgo.Entry("duplicate mount paths", additionalVolumesCr(AdditionalVolume{Name: "extra", HostPath: "/mnt", MountPath: "/mnt"}, AdditionalVolume{Name: "extra2", HostPath: "/mnt2", MountPath: "/mnt/"}),
+				"spec.additionalVolumes[1].mountPath is the same as spec.additionalVolumes[0].mountPath, cannot have duplicate mount paths"),
+		)
 	})
 
 	ginkgo.Context("update", func() {
@@ -267,5 +846,48 @@ var _ = ginkgo.Describe("validating webhook", func() {
 			_, err := longPathCr.ValidateUpdate(&HostPathProvisioner{})
 			gomega.Expect(err).To(gomega.BeEquivalentTo(fmt.Errorf("storagePool.path cannot have a length greater than 255")))
 		})
+		ginkgo.It("Should not allow negative workload.terminationGracePeriodSeconds", func() {
+			_, err := negativeGracePeriodCr.ValidateUpdate(&HostPathProvisioner{})
+			gomega.Expect(err).To(gomega.BeEquivalentTo(fmt.Errorf("workload.terminationGracePeriodSeconds cannot be negative")))
+		})
 	})
 })
+
+func additionalVolumesCr(additionalVolumes ...AdditionalVolume) HostPathProvisioner {
+	return HostPathProvisioner{
+		Spec: HostPathProvisionerSpec{
+			StoragePools: []StoragePool{
+				{
+					Name: "test",
+					Path: "/var/hpvolumes",
+				},
+			},
+			AdditionalVolumes: additionalVolumes,
+		},
+	}
+}
+
+func legacyAdditionalVolumesCr(additionalVolumes ...AdditionalVolume) HostPathProvisioner {
+	return HostPathProvisioner{
+		Spec: HostPathProvisionerSpec{
+			PathConfig: &PathConfig{
+				Path: "/var/hpvolumes",
+			},
+			AdditionalVolumes: additionalVolumes,
+		},
+	}
+}
+
+func storageClassPoolCr(storagePoolName string) HostPathProvisioner {
+	return HostPathProvisioner{
+		Spec: HostPathProvisionerSpec{
+			StoragePools: []StoragePool{
+				{
+					Name:               storagePoolName,
+					Path:               "/var/hpvolumes",
+					CreateStorageClass: true,
+				},
+			},
+		},
+	}
+}
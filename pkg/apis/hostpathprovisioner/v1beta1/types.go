@@ -18,7 +18,9 @@ package v1beta1
 
 import (
 	conditions "github.com/openshift/custom-resource-status/conditions/v1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -37,6 +39,186 @@ type HostPathProvisionerSpec struct {
 	// StoragePools are a list of storage pools
 	// +listType=atomic
 	StoragePools []StoragePool `json:"storagePools,omitempty" optional:"true"`
+	// AdditionalVolumes are a list of extra host paths mounted into the provisioner container
+	// +listType=atomic
+	AdditionalVolumes []AdditionalVolume `json:"additionalVolumes,omitempty" optional:"true"`
+	// SkipFinalizer stops the operator from adding its finalizer to the CR, an existing finalizer is removed.
+	// Without the finalizer the cluster scoped resources (SecurityContextConstraints, RBAC, CSIDriver) are not
+	// cleaned up automatically when the CR is deleted, and have to be removed manually.
+	SkipFinalizer bool `json:"skipFinalizer,omitempty" optional:"true"`
+	// CSIDriver configures the CSIDriver object created by the operator
+	CSIDriver *CSIDriverConfig `json:"csiDriver,omitempty" optional:"true"`
+	// AdoptExisting makes the operator take over existing DaemonSets with the expected name that have no controller,
+	// for instance when migrating from a helm installed hostpath provisioner. DaemonSets labeled as a different
+	// application are not adopted.
+	AdoptExisting bool `json:"adoptExisting,omitempty" optional:"true"`
+	// RBAC configures the scope of the RBAC resources created by the operator
+	RBAC *RBACConfig `json:"rbac,omitempty" optional:"true"`
+	// Monitoring configures the alerts and the health reporting of the hostpath provisioner
+	Monitoring *MonitoringConfig `json:"monitoring,omitempty" optional:"true"`
+	// Integrations configures the integration of the hostpath provisioner with other projects
+	Integrations *IntegrationsConfig `json:"integrations,omitempty" optional:"true"`
+	// VerifyProvisioning runs a Job once the provisioner is deployed that provisions a volume from the StorageClass of
+	// the first storage pool with createStorageClass, writes a file to it and reads it back. The result is reported
+	// in the ProvisioningVerified condition, and the Job and its PVC are removed afterwards.
+	VerifyProvisioning bool `json:"verifyProvisioning,omitempty" optional:"true"`
+}
+
+// IntegrationsConfig defines the integrations of the hostpath provisioner with other projects.
+// +k8s:openapi-gen=true
+type IntegrationsConfig struct {
+	// cdi makes the operator reconcile the KubeVirt CDI StorageProfiles of the StorageClasses it creates, so CDI
+	// creates its volumes with the access modes and volume mode the hostpath provisioner supports. The StorageProfiles
+	// are skipped if CDI is not installed.
+	// +kubebuilder:validation:Optional
+	// +optional
+	CDI bool `json:"cdi,omitempty"`
+}
+
+// MonitoringConfig defines the configurable fields of the PrometheusRule and the health reporting of the hostpath provisioner.
+// +k8s:openapi-gen=true
+type MonitoringConfig struct {
+	// notReadyGracePeriod is how long the HostPathProvisioner has to be not ready before the HPPNotReady alert fires.
+	// Large clusters take longer to roll out the DaemonSets and may need a longer period. Defaults to 5m.
+	// +kubebuilder:validation:Optional
+	// +optional
+	NotReadyGracePeriod *metav1.Duration `json:"notReadyGracePeriod,omitempty"`
+
+	// degradedGracePeriod is how long the DaemonSets can be not ready, for instance during node reboots, before the
+	// HostPathProvisioner is marked degraded. During the period the HostPathProvisioner is reported as progressing.
+	// If not set it is marked degraded right away.
+	// +kubebuilder:validation:Optional
+	// +optional
+	DegradedGracePeriod *metav1.Duration `json:"degradedGracePeriod,omitempty"`
+
+	// createAlerts controls whether the operator creates the PrometheusRule with the hostpath provisioner alerts.
+	// The ServiceMonitor is always created, so the metrics can be scraped while the alerts are managed elsewhere.
+	// Defaults to true.
+	// +kubebuilder:validation:Optional
+	// +optional
+	CreateAlerts *bool `json:"createAlerts,omitempty"`
+
+	// minReadyNodes is the number of provisioner pods that have to be ready before the HostPathProvisioner is marked
+	// available. On clusters that scale up from zero nodes the DaemonSets can report ready with just the first node.
+	// Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	// +optional
+	MinReadyNodes *int32 `json:"minReadyNodes,omitempty"`
+
+	// emitConditionEvents makes the operator emit an Event each time the status of a condition of the
+	// HostPathProvisioner changes, for monitoring stacks that consume Events but not the status. Defaults to false.
+	// +kubebuilder:validation:Optional
+	// +optional
+	EmitConditionEvents bool `json:"emitConditionEvents,omitempty"`
+}
+
+// RBACConfig defines the scope of the namespaced RBAC resources of the hostpath provisioner.
+// +k8s:openapi-gen=true
+type RBACConfig struct {
+	// namespaceSelector selects the namespaces the Role and RoleBinding of the CSI driver are created in.
+	// The namespace of the operator is always included, the CSI driver keeps its leases and storage capacities there.
+	// The Role and RoleBinding are removed from namespaces that no longer match.
+	// +kubebuilder:validation:Optional
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// CSIDriverConfig defines the configurable fields of the CSIDriver of the hostpath provisioner.
+// +k8s:openapi-gen=true
+type CSIDriverConfig struct {
+	// fsGroupPolicy is the fsGroupPolicy of the CSIDriver. The field is immutable on the CSIDriver, so changing it
+	// makes the operator delete and recreate the CSIDriver. If not set the fsGroupPolicy of an existing CSIDriver is
+	// kept, and ReadWriteOnceWithFSType is used for a new one.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=ReadWriteOnceWithFSType;File;None
+	// +optional
+	FSGroupPolicy *storagev1.FSGroupPolicy `json:"fsGroupPolicy,omitempty"`
+
+	// enableLivenessProbe controls the liveness-probe sidecar of the CSI driver pods. Without the sidecar the provisioner
+	// container has no liveness probe and no healthz port. If not set the sidecar is enabled.
+	// +kubebuilder:validation:Optional
+	// +optional
+	EnableLivenessProbe *bool `json:"enableLivenessProbe,omitempty"`
+
+	// podInfoOnMount makes the kubelet pass the pod information to the CSI driver on mount, which the ephemeral volumes
+	// of KubeVirt need. The field is immutable on the CSIDriver, so changing it makes the operator delete and recreate
+	// the CSIDriver. If not set the podInfoOnMount of an existing CSIDriver is kept, and true is used for a new one.
+	// +kubebuilder:validation:Optional
+	// +optional
+	PodInfoOnMount *bool `json:"podInfoOnMount,omitempty"`
+
+	// attachRequired makes kubernetes attach the volumes of the CSI driver with VolumeAttachments before mounting them.
+	// The hostpath volumes are local directories, there is nothing to attach. The field is immutable on the CSIDriver,
+	// so changing it makes the operator delete and recreate the CSIDriver. If not set the attachRequired of an
+	// existing CSIDriver is kept, and false is used for a new one.
+	// +kubebuilder:validation:Optional
+	// +optional
+	AttachRequired *bool `json:"attachRequired,omitempty"`
+
+	// requiresRepublish makes the kubelet call NodePublishVolume periodically on mounted volumes. If not set it is
+	// false.
+	// +kubebuilder:validation:Optional
+	// +optional
+	RequiresRepublish *bool `json:"requiresRepublish,omitempty"`
+
+	// snapshotClass configures the VolumeSnapshotClass the operator creates for the CSI driver while the Snapshotting
+	// feature gate is enabled.
+	// +kubebuilder:validation:Optional
+	// +optional
+	SnapshotClass *SnapshotClassConfig `json:"snapshotClass,omitempty"`
+
+	// workerThreads is the number of goroutines the csi-provisioner sidecar uses to provision and delete volumes.
+	// Clusters with a high PVC churn can raise it. If not set the default of the external-provisioner is used.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000
+	// +optional
+	WorkerThreads *int32 `json:"workerThreads,omitempty"`
+
+	// extraArgs are extra command line flags appended to the arguments of the CSI driver container. The flags the
+	// operator sets itself, such as --drivername, --v and --datadir, cannot be overridden.
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	// +optional
+	ExtraArgs []string `json:"extraArgs,omitempty"`
+
+	// driverName overrides the name of the CSI driver, which is the name of the CSIDriver object, the name the driver
+	// registers with on the nodes and the provisioner of the storage pool StorageClasses. Hostpath provisioners that
+	// coexist in a cluster, for instance during a migration, need distinct driver names. Changing it recreates the
+	// CSIDriver and the StorageClasses, the existing volumes of the previous driver name can no longer be mounted. If not
+	// set kubevirt.io.hostpath-provisioner is used.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	DriverName string `json:"driverName,omitempty"`
+
+	// unmanaged stops the operator from creating, updating and deleting the CSIDriver object, for platforms that
+	// install a shared CSIDriver managed by another operator. The fields of the CSIDriver in this config have no effect
+	// then. If not set the operator manages the CSIDriver.
+	// +kubebuilder:validation:Optional
+	// +optional
+	Unmanaged bool `json:"unmanaged,omitempty"`
+}
+
+// SnapshotClassConfig defines the VolumeSnapshotClass of the CSI driver.
+// +k8s:openapi-gen=true
+type SnapshotClassConfig struct {
+	// deletionPolicy is the deletionPolicy of the VolumeSnapshotClass, it determines whether the snapshot contents
+	// are deleted with their VolumeSnapshot. If not set Delete is used.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+}
+
+// OperatorBuild identifies the build of the operator.
+// +k8s:openapi-gen=true
+type OperatorBuild struct {
+	// GitCommit is the git commit the operator was built from
+	GitCommit string `json:"gitCommit,omitempty" optional:"true"`
+	// BuildDate is the time the operator was built
+	BuildDate string `json:"buildDate,omitempty" optional:"true"`
 }
 
 // HostPathProvisionerStatus defines the observed state of HostPathProvisioner
@@ -47,12 +229,67 @@ type HostPathProvisionerStatus struct {
 	Conditions []conditions.Condition `json:"conditions,omitempty" optional:"true"`
 	// OperatorVersion The version of the HostPathProvisioner Operator
 	OperatorVersion string `json:"operatorVersion,omitempty" optional:"true"`
+	// OperatorBuild The build of the HostPathProvisioner Operator
+	OperatorBuild *OperatorBuild `json:"operatorBuild,omitempty" optional:"true"`
 	// TargetVersion The targeted version of the HostPathProvisioner deployment
 	TargetVersion string `json:"targetVersion,omitempty" optional:"true"`
 	// ObservedVersion The observed version of the HostPathProvisioner deployment
 	ObservedVersion string `json:"observedVersion,omitempty" optional:"true"`
+	// CSIDriverVersion The version of the CSI driver image all the CSI driver pods run, it is not updated while a rollout is in progress
+	CSIDriverVersion string `json:"csiDriverVersion,omitempty" optional:"true"`
+	// CSIDriverName The name of the CSIDriver the operator manages, the previous CSIDriver is removed once the driver name changes
+	CSIDriverName string `json:"csiDriverName,omitempty" optional:"true"`
+	// ObservedGeneration The most recent generation of the HostPathProvisioner that was successfully reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty" optional:"true"`
+	// LastReconcileTime The time of the last reconcile that updated all the managed resources without errors.
+	// It is refreshed at most once a minute, so successful reconciles don't update the CR every time.
+	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty" optional:"true"`
+	// OperatorNamespace The namespace the operator deploys the hostpath provisioner in, resolved from WATCH_NAMESPACE
+	OperatorNamespace string `json:"operatorNamespace,omitempty" optional:"true"`
 	// +listType=atomic
 	StoragePoolStatuses []StoragePoolStatus `json:"storagePoolStatuses,omitempty" optional:"true"`
+	// EnabledFeatureGates The feature gates from the spec the operator acts on, unknown feature gates are not listed
+	// +listType=set
+	EnabledFeatureGates []string `json:"enabledFeatureGates,omitempty" optional:"true"`
+	// Summary A short summary of the readiness of the storage pools and the observed version, for instance
+	// "3/3 pools ready, v1.2.3". It is shown by kubectl get.
+	Summary string `json:"summary,omitempty" optional:"true"`
+	// ConditionHistory The most recent changes of the status or reason of the conditions, oldest first. Only the last
+	// 20 changes are kept.
+	// +listType=atomic
+	ConditionHistory []ConditionTransition `json:"conditionHistory,omitempty" optional:"true"`
+	// UnschedulableNodes The nodes the provisioner DaemonSets cannot schedule their pods on, with the reason the
+	// scheduler gives, computed from the pending pods
+	// +listType=atomic
+	UnschedulableNodes []UnschedulableNode `json:"unschedulableNodes,omitempty" optional:"true"`
+}
+
+// UnschedulableNode is a node a provisioner DaemonSet cannot schedule its pod on.
+// +k8s:openapi-gen=true
+type UnschedulableNode struct {
+	// Node The name of the node the pod should run on, empty if the pod is not bound to a node
+	Node string `json:"node,omitempty" optional:"true"`
+	// DaemonSet The name of the DaemonSet of the pod
+	DaemonSet string `json:"daemonSet"`
+	// Reason The message of the PodScheduled condition of the pod
+	Reason string `json:"reason"`
+}
+
+// ConditionTransition is a change of the status or reason of one of the conditions of the HostPathProvisioner.
+// +k8s:openapi-gen=true
+type ConditionTransition struct {
+	// Type The type of the condition that changed
+	Type conditions.ConditionType `json:"type"`
+	// Status The status the condition changed to
+	Status corev1.ConditionStatus `json:"status"`
+	// Reason The reason the condition changed to
+	Reason string `json:"reason,omitempty" optional:"true"`
+	// PreviousStatus The status of the condition before the change
+	PreviousStatus corev1.ConditionStatus `json:"previousStatus"`
+	// PreviousReason The reason of the condition before the change
+	PreviousReason string `json:"previousReason,omitempty" optional:"true"`
+	// Time The time of the change
+	Time metav1.Time `json:"time"`
 }
 
 // StoragePool defines how and where hostpath provisioner can use storage to create volumes.
@@ -64,6 +301,38 @@ type StoragePool struct {
 	PVCTemplate *corev1.PersistentVolumeClaimSpec `json:"pvcTemplate,omitempty" optional:"true"`
 	// path the path to use on the host, this is a required field
 	Path string `json:"path" valid:"required"`
+	// CreateStorageClass makes the operator create and manage a StorageClass named after the storage pool.
+	CreateStorageClass bool `json:"createStorageClass,omitempty" optional:"true"`
+	// Namespace is the namespace the storage pool PVCs and deployments are created in, defaults to the operator namespace.
+	// Only used when a PVCTemplate is specified.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// AnnotateProvisionedVolumes makes the CSI driver annotate the PVs it provisions from this storage pool
+	// with the hostpathprovisioner.kubevirt.io/pool annotation, set to the name of the storage pool.
+	// +optional
+	AnnotateProvisionedVolumes bool `json:"annotateProvisionedVolumes,omitempty"`
+	// TopologySpreadConstraints are added to the pod template of the storage pool deployments, to spread the pods
+	// across failure domains. Only used when a PVCTemplate is specified.
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// DeploymentStrategy is the update strategy of the storage pool deployments, for instance Recreate so the old and
+	// new pod never use the storage pool directory at the same time. Defaults to a RollingUpdate. Only used when a
+	// PVCTemplate is specified.
+	// +optional
+	DeploymentStrategy *appsv1.DeploymentStrategy `json:"deploymentStrategy,omitempty"`
+}
+
+// AdditionalVolume defines an extra host path that is mounted into the provisioner container.
+// +k8s:openapi-gen=true
+type AdditionalVolume struct {
+	// Name is a unique identifier of the volume, it must be a valid DNS label.
+	Name string `json:"name" valid:"required"`
+	// HostPath is the absolute path on the host to mount.
+	HostPath string `json:"hostPath" valid:"required"`
+	// MountPath is the absolute path in the provisioner container where the host path is mounted.
+	MountPath string `json:"mountPath" valid:"required"`
+	// ReadOnly mounts the host path read only.
+	ReadOnly bool `json:"readOnly,omitempty"`
 }
 
 // StoragePoolStatus is the status of the named storage pool
@@ -79,6 +348,9 @@ type StoragePoolStatus struct {
 	// The status of all the claims.
 	// +listType=atomic
 	ClaimStatuses []ClaimStatus `json:"claimStatuses,omitempty" optional:"true"`
+	// Nodes are the names of the nodes the deployments of a storage pool with a PVC template have a running pod on, sorted.
+	// +listType=set
+	Nodes []string `json:"nodes,omitempty" optional:"true"`
 }
 
 // ClaimStatus defines the storage claim status for each PVC in a storage pool
@@ -110,7 +382,10 @@ const (
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +k8s:openapi-gen=true
 // +kubebuilder:resource:path=hostpathprovisioners,scope=Cluster
+// +kubebuilder:subresource:status
 // +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.summary`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 type HostPathProvisioner struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -163,4 +438,177 @@ type NodePlacement struct {
 	// +kubebuilder:validation:Optional
 	// +optional
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// tolerateControlPlane adds the tolerations of the node-role.kubernetes.io/control-plane and
+	// node-role.kubernetes.io/master NoSchedule taints to the relevant kind of pods, so they also run on
+	// control plane nodes, for instance on single node OpenShift.
+	// +kubebuilder:validation:Optional
+	// +optional
+	TolerateControlPlane bool `json:"tolerateControlPlane,omitempty"`
+
+	// criticalAddon declares the relevant kind of pods as a critical addon. The pods get the
+	// system-node-critical priority class and the cluster autoscaler is told not to evict them.
+	// +kubebuilder:validation:Optional
+	// +optional
+	CriticalAddon bool `json:"criticalAddon,omitempty"`
+
+	// imagePullSecrets is a list of references to secrets used for pulling the images of the relevant kind of pods.
+	// The secrets are attached to the service accounts the operator manages.
+	// See https://kubernetes.io/docs/concepts/containers/images/#specifying-imagepullsecrets-on-a-pod
+	// +kubebuilder:validation:Optional
+	// +optional
+	// +listType=atomic
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// terminationGracePeriodSeconds is the duration in seconds the relevant kind of pods need to terminate gracefully.
+	// If not set the default of 30 seconds is used.
+	// See https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle/#pod-termination
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+
+	// singleNode makes the operator run the provisioner as a single replica Deployment instead of a DaemonSet.
+	// The Deployment is scheduled on the control plane node unless a nodeSelector or affinity is specified.
+	// This is meant for single node development clusters only.
+	// +kubebuilder:validation:Optional
+	// +optional
+	SingleNode bool `json:"singleNode,omitempty"`
+
+	// resourceProfile is a preset of the resource requests and limits applied to all the provisioner containers.
+	// minimal is meant for memory constrained edge nodes, highThroughput for nodes with a lot of volume activity.
+	// If not set the default requests are used, and the sidecar containers don't request any resources.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=minimal;default;highThroughput
+	// +optional
+	ResourceProfile ResourceProfile `json:"resourceProfile,omitempty"`
+
+	// dnsPolicy is the DNS policy of the provisioner pods. If not set ClusterFirst is used.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	// +optional
+	DNSPolicy corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// dnsConfig is the DNS configuration of the provisioner pods, it is merged with the configuration
+	// generated from the dnsPolicy. It is required to specify nameservers when the dnsPolicy is None.
+	// +kubebuilder:validation:Optional
+	// +optional
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+
+	// hostNetwork makes the provisioner pods use the network namespace of the host. If no dnsPolicy is specified
+	// ClusterFirstWithHostNet is used, the ClusterFirst dnsPolicy cannot be combined with host networking.
+	// +kubebuilder:validation:Optional
+	// +optional
+	HostNetwork bool `json:"hostNetwork,omitempty"`
+
+	// cleanupJob configures the Jobs that clean up the storage pools when the HostPathProvisioner is removed.
+	// +kubebuilder:validation:Optional
+	// +optional
+	CleanupJob *CleanupJobConfig `json:"cleanupJob,omitempty"`
+
+	// env is a list of extra environment variables set on the provisioner container, for instance HTTP_PROXY.
+	// Variables with the name of a variable managed by the operator are ignored.
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// initContainers is a list of extra init containers that run before the provisioner containers start, for instance to
+	// create the storage pool directories or change their mode. They run before the init containers of the operator. The
+	// webhook rejects init containers with the name of a container managed by the operator.
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	// +optional
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+
+	// serviceAccountName is the name of an existing ServiceAccount the provisioner pods run with, for clusters where the
+	// ServiceAccount is managed outside of the operator. It has to exist in the namespace of the operator and in the
+	// namespaces of the storage pools. If not set the operator creates its own ServiceAccounts.
+	// +kubebuilder:validation:Optional
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// automountServiceAccountToken sets automountServiceAccountToken on the pods of the provisioner DaemonSets. If not
+	// set the default of the ServiceAccount applies.
+	// +kubebuilder:validation:Optional
+	// +optional
+	AutomountServiceAccountToken *bool `json:"automountServiceAccountToken,omitempty"`
+
+	// fsGroup is the supplemental group applied to the volumes of the provisioner pods and the storage pool
+	// deployments, for instance when the volumes need a specific group ownership. If not set no fsGroup is used.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FSGroup *int64 `json:"fsGroup,omitempty"`
+
+	// revisionHistoryLimit is the number of old ControllerRevisions of the provisioner DaemonSets, and old ReplicaSets
+	// of the storage pool deployments, that are kept for rollbacks. If not set 2 are kept.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+
+	// trustedCABundle is a ConfigMap with the CA certificates the provisioner containers trust, for instance of an
+	// internal CA. The bundle replaces the trust store of the images.
+	// +kubebuilder:validation:Optional
+	// +optional
+	TrustedCABundle *TrustedCABundleConfig `json:"trustedCABundle,omitempty"`
 }
+
+// TrustedCABundleConfig references the ConfigMap with the trusted CA bundle of the provisioner containers.
+// +k8s:openapi-gen=true
+type TrustedCABundleConfig struct {
+	// configMapName is the name of a ConfigMap in the namespace of the operator with the CA bundle. It is required
+	// unless injectClusterTrustBundle is set.
+	// +kubebuilder:validation:Optional
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// key is the key of the CA bundle in the ConfigMap. If not set ca-bundle.crt is used.
+	// +kubebuilder:validation:Optional
+	// +optional
+	Key string `json:"key,omitempty"`
+
+	// injectClusterTrustBundle makes the operator create its own ConfigMap with the
+	// config.openshift.io/inject-trusted-cabundle label, OpenShift injects the trusted CA bundle of the cluster into it.
+	// It cannot be combined with configMapName or key.
+	// +kubebuilder:validation:Optional
+	// +optional
+	InjectClusterTrustBundle bool `json:"injectClusterTrustBundle,omitempty"`
+}
+
+// CleanupJobConfig defines the configurable fields of the storage pool cleanup Jobs.
+// +k8s:openapi-gen=true
+type CleanupJobConfig struct {
+	// backoffLimit is the number of retries before a cleanup Job is marked as failed. If not set 6 is used.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+
+	// ttlSecondsAfterFinished is the duration in seconds after which a finished cleanup Job is deleted.
+	// If not set 300 is used.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+
+	// activeDeadlineSeconds is the duration in seconds a cleanup Job may run before it is terminated, for
+	// instance when its node is gone. A terminated Job counts as finished. If not set the Jobs run until they complete.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+}
+
+// ResourceProfile is a preset of the resources used by the provisioner containers.
+type ResourceProfile string
+
+const (
+	// ResourceProfileMinimal requests the minimum resources, and limits the memory of the containers.
+	ResourceProfileMinimal ResourceProfile = "minimal"
+	// ResourceProfileDefault requests the default resources for all the containers.
+	ResourceProfileDefault ResourceProfile = "default"
+	// ResourceProfileHighThroughput requests more resources for nodes that create and delete a lot of volumes.
+	ResourceProfileHighThroughput ResourceProfile = "highThroughput"
+)
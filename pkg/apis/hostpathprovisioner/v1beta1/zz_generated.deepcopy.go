@@ -22,11 +22,86 @@ limitations under the License.
 package v1beta1
 
 import (
-	v1 "github.com/openshift/custom-resource-status/conditions/v1"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdditionalVolume) DeepCopyInto(out *AdditionalVolume) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalVolume.
+func (in *AdditionalVolume) DeepCopy() *AdditionalVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(AdditionalVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CSIDriverConfig) DeepCopyInto(out *CSIDriverConfig) {
+	*out = *in
+	if in.FSGroupPolicy != nil {
+		in, out := &in.FSGroupPolicy, &out.FSGroupPolicy
+		*out = new(v1.FSGroupPolicy)
+		**out = **in
+	}
+	if in.EnableLivenessProbe != nil {
+		in, out := &in.EnableLivenessProbe, &out.EnableLivenessProbe
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PodInfoOnMount != nil {
+		in, out := &in.PodInfoOnMount, &out.PodInfoOnMount
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AttachRequired != nil {
+		in, out := &in.AttachRequired, &out.AttachRequired
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RequiresRepublish != nil {
+		in, out := &in.RequiresRepublish, &out.RequiresRepublish
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SnapshotClass != nil {
+		in, out := &in.SnapshotClass, &out.SnapshotClass
+		*out = new(SnapshotClassConfig)
+		**out = **in
+	}
+	if in.WorkerThreads != nil {
+		in, out := &in.WorkerThreads, &out.WorkerThreads
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSIDriverConfig.
+func (in *CSIDriverConfig) DeepCopy() *CSIDriverConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CSIDriverConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClaimStatus) DeepCopyInto(out *ClaimStatus) {
 	*out = *in
@@ -44,6 +119,54 @@ func (in *ClaimStatus) DeepCopy() *ClaimStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CleanupJobConfig) DeepCopyInto(out *CleanupJobConfig) {
+	*out = *in
+	if in.BackoffLimit != nil {
+		in, out := &in.BackoffLimit, &out.BackoffLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupJobConfig.
+func (in *CleanupJobConfig) DeepCopy() *CleanupJobConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupJobConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConditionTransition) DeepCopyInto(out *ConditionTransition) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionTransition.
+func (in *ConditionTransition) DeepCopy() *ConditionTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(ConditionTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostPathProvisioner) DeepCopyInto(out *HostPathProvisioner) {
 	*out = *in
@@ -126,6 +249,31 @@ func (in *HostPathProvisionerSpec) DeepCopyInto(out *HostPathProvisionerSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AdditionalVolumes != nil {
+		in, out := &in.AdditionalVolumes, &out.AdditionalVolumes
+		*out = make([]AdditionalVolume, len(*in))
+		copy(*out, *in)
+	}
+	if in.CSIDriver != nil {
+		in, out := &in.CSIDriver, &out.CSIDriver
+		*out = new(CSIDriverConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RBAC != nil {
+		in, out := &in.RBAC, &out.RBAC
+		*out = new(RBACConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(MonitoringConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Integrations != nil {
+		in, out := &in.Integrations, &out.Integrations
+		*out = new(IntegrationsConfig)
+		**out = **in
+	}
 	return
 }
 
@@ -144,11 +292,20 @@ func (in *HostPathProvisionerStatus) DeepCopyInto(out *HostPathProvisionerStatus
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+		*out = make([]conditionsv1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.OperatorBuild != nil {
+		in, out := &in.OperatorBuild, &out.OperatorBuild
+		*out = new(OperatorBuild)
+		**out = **in
+	}
+	if in.LastReconcileTime != nil {
+		in, out := &in.LastReconcileTime, &out.LastReconcileTime
+		*out = (*in).DeepCopy()
+	}
 	if in.StoragePoolStatuses != nil {
 		in, out := &in.StoragePoolStatuses, &out.StoragePoolStatuses
 		*out = make([]StoragePoolStatus, len(*in))
@@ -156,6 +313,23 @@ func (in *HostPathProvisionerStatus) DeepCopyInto(out *HostPathProvisionerStatus
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.EnabledFeatureGates != nil {
+		in, out := &in.EnabledFeatureGates, &out.EnabledFeatureGates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConditionHistory != nil {
+		in, out := &in.ConditionHistory, &out.ConditionHistory
+		*out = make([]ConditionTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UnschedulableNodes != nil {
+		in, out := &in.UnschedulableNodes, &out.UnschedulableNodes
+		*out = make([]UnschedulableNode, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -169,6 +343,58 @@ func (in *HostPathProvisionerStatus) DeepCopy() *HostPathProvisionerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationsConfig) DeepCopyInto(out *IntegrationsConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationsConfig.
+func (in *IntegrationsConfig) DeepCopy() *IntegrationsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringConfig) DeepCopyInto(out *MonitoringConfig) {
+	*out = *in
+	if in.NotReadyGracePeriod != nil {
+		in, out := &in.NotReadyGracePeriod, &out.NotReadyGracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.DegradedGracePeriod != nil {
+		in, out := &in.DegradedGracePeriod, &out.DegradedGracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.CreateAlerts != nil {
+		in, out := &in.CreateAlerts, &out.CreateAlerts
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MinReadyNodes != nil {
+		in, out := &in.MinReadyNodes, &out.MinReadyNodes
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringConfig.
+func (in *MonitoringConfig) DeepCopy() *MonitoringConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodePlacement) DeepCopyInto(out *NodePlacement) {
 	*out = *in
@@ -191,6 +417,60 @@ func (in *NodePlacement) DeepCopyInto(out *NodePlacement) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CleanupJob != nil {
+		in, out := &in.CleanupJob, &out.CleanupJob
+		*out = new(CleanupJobConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AutomountServiceAccountToken != nil {
+		in, out := &in.AutomountServiceAccountToken, &out.AutomountServiceAccountToken
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FSGroup != nil {
+		in, out := &in.FSGroup, &out.FSGroup
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RevisionHistoryLimit != nil {
+		in, out := &in.RevisionHistoryLimit, &out.RevisionHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TrustedCABundle != nil {
+		in, out := &in.TrustedCABundle, &out.TrustedCABundle
+		*out = new(TrustedCABundleConfig)
+		**out = **in
+	}
 	return
 }
 
@@ -204,6 +484,22 @@ func (in *NodePlacement) DeepCopy() *NodePlacement {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorBuild) DeepCopyInto(out *OperatorBuild) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorBuild.
+func (in *OperatorBuild) DeepCopy() *OperatorBuild {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorBuild)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PathConfig) DeepCopyInto(out *PathConfig) {
 	*out = *in
@@ -220,6 +516,43 @@ func (in *PathConfig) DeepCopy() *PathConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RBACConfig) DeepCopyInto(out *RBACConfig) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBACConfig.
+func (in *RBACConfig) DeepCopy() *RBACConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RBACConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotClassConfig) DeepCopyInto(out *SnapshotClassConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotClassConfig.
+func (in *SnapshotClassConfig) DeepCopy() *SnapshotClassConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotClassConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StoragePool) DeepCopyInto(out *StoragePool) {
 	*out = *in
@@ -228,6 +561,18 @@ func (in *StoragePool) DeepCopyInto(out *StoragePool) {
 		*out = new(corev1.PersistentVolumeClaimSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DeploymentStrategy != nil {
+		in, out := &in.DeploymentStrategy, &out.DeploymentStrategy
+		*out = new(appsv1.DeploymentStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -251,6 +596,11 @@ func (in *StoragePoolStatus) DeepCopyInto(out *StoragePoolStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -263,3 +613,35 @@ func (in *StoragePoolStatus) DeepCopy() *StoragePoolStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrustedCABundleConfig) DeepCopyInto(out *TrustedCABundleConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrustedCABundleConfig.
+func (in *TrustedCABundleConfig) DeepCopy() *TrustedCABundleConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TrustedCABundleConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnschedulableNode) DeepCopyInto(out *UnschedulableNode) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnschedulableNode.
+func (in *UnschedulableNode) DeepCopy() *UnschedulableNode {
+	if in == nil {
+		return nil
+	}
+	out := new(UnschedulableNode)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -83,12 +83,23 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"k8s.io/apimachinery/pkg/runtime.TypeMeta":                                                                 schema_k8sio_apimachinery_pkg_runtime_TypeMeta(ref),
 		"k8s.io/apimachinery/pkg/runtime.Unknown":                                                                  schema_k8sio_apimachinery_pkg_runtime_Unknown(ref),
 		"k8s.io/apimachinery/pkg/version.Info":                                                                     schema_k8sio_apimachinery_pkg_version_Info(ref),
+		"kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.AdditionalVolume":          schema_pkg_apis_hostpathprovisioner_v1beta1_AdditionalVolume(ref),
+		"kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.CSIDriverConfig":           schema_pkg_apis_hostpathprovisioner_v1beta1_CSIDriverConfig(ref),
+		"kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.CleanupJobConfig":          schema_pkg_apis_hostpathprovisioner_v1beta1_CleanupJobConfig(ref),
+		"kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.ConditionTransition":       schema_pkg_apis_hostpathprovisioner_v1beta1_ConditionTransition(ref),
 		"kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.HostPathProvisioner":       schema_pkg_apis_hostpathprovisioner_v1beta1_HostPathProvisioner(ref),
 		"kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.HostPathProvisionerSpec":   schema_pkg_apis_hostpathprovisioner_v1beta1_HostPathProvisionerSpec(ref),
 		"kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.HostPathProvisionerStatus": schema_pkg_apis_hostpathprovisioner_v1beta1_HostPathProvisionerStatus(ref),
+		"kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.IntegrationsConfig":        schema_pkg_apis_hostpathprovisioner_v1beta1_IntegrationsConfig(ref),
+		"kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.MonitoringConfig":          schema_pkg_apis_hostpathprovisioner_v1beta1_MonitoringConfig(ref),
 		"kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.NodePlacement":             schema_pkg_apis_hostpathprovisioner_v1beta1_NodePlacement(ref),
+		"kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.OperatorBuild":             schema_pkg_apis_hostpathprovisioner_v1beta1_OperatorBuild(ref),
 		"kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.PathConfig":                schema_pkg_apis_hostpathprovisioner_v1beta1_PathConfig(ref),
+		"kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.RBACConfig":                schema_pkg_apis_hostpathprovisioner_v1beta1_RBACConfig(ref),
+		"kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.SnapshotClassConfig":       schema_pkg_apis_hostpathprovisioner_v1beta1_SnapshotClassConfig(ref),
 		"kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.StoragePool":               schema_pkg_apis_hostpathprovisioner_v1beta1_StoragePool(ref),
+		"kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.TrustedCABundleConfig":     schema_pkg_apis_hostpathprovisioner_v1beta1_TrustedCABundleConfig(ref),
+		"kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.UnschedulableNode":         schema_pkg_apis_hostpathprovisioner_v1beta1_UnschedulableNode(ref),
 	}
 }
 
@@ -2516,6 +2527,242 @@ func schema_k8sio_apimachinery_pkg_version_Info(ref common.ReferenceCallback) co
 	}
 }
 
+func schema_pkg_apis_hostpathprovisioner_v1beta1_AdditionalVolume(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AdditionalVolume defines an extra host path that is mounted into the provisioner container.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is a unique identifier of the volume, it must be a valid DNS label.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"hostPath": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HostPath is the absolute path on the host to mount.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"mountPath": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MountPath is the absolute path in the provisioner container where the host path is mounted.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"readOnly": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReadOnly mounts the host path read only.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name", "hostPath", "mountPath"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_hostpathprovisioner_v1beta1_CSIDriverConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CSIDriverConfig defines the configurable fields of the CSIDriver of the hostpath provisioner.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"fsGroupPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "fsGroupPolicy is the fsGroupPolicy of the CSIDriver. The field is immutable on the CSIDriver, so changing it makes the operator delete and recreate the CSIDriver. If not set the fsGroupPolicy of an existing CSIDriver is kept, and ReadWriteOnceWithFSType is used for a new one.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"enableLivenessProbe": {
+						SchemaProps: spec.SchemaProps{
+							Description: "enableLivenessProbe controls the liveness-probe sidecar of the CSI driver pods. Without the sidecar the provisioner container has no liveness probe and no healthz port. If not set the sidecar is enabled.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"podInfoOnMount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "podInfoOnMount makes the kubelet pass the pod information to the CSI driver on mount, which the ephemeral volumes of KubeVirt need. The field is immutable on the CSIDriver, so changing it makes the operator delete and recreate the CSIDriver. If not set the podInfoOnMount of an existing CSIDriver is kept, and true is used for a new one.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"attachRequired": {
+						SchemaProps: spec.SchemaProps{
+							Description: "attachRequired makes kubernetes attach the volumes of the CSI driver with VolumeAttachments before mounting them. The hostpath volumes are local directories, there is nothing to attach. The field is immutable on the CSIDriver, so changing it makes the operator delete and recreate the CSIDriver. If not set the attachRequired of an existing CSIDriver is kept, and false is used for a new one.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"requiresRepublish": {
+						SchemaProps: spec.SchemaProps{
+							Description: "requiresRepublish makes the kubelet call NodePublishVolume periodically on mounted volumes. If not set it is false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"snapshotClass": {
+						SchemaProps: spec.SchemaProps{
+							Description: "snapshotClass configures the VolumeSnapshotClass the operator creates for the CSI driver while the Snapshotting feature gate is enabled.",
+							Ref:         ref("kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.SnapshotClassConfig"),
+						},
+					},
+					"workerThreads": {
+						SchemaProps: spec.SchemaProps{
+							Description: "workerThreads is the number of goroutines the csi-provisioner sidecar uses to provision and delete volumes. Clusters with a high PVC churn can raise it. If not set the default of the external-provisioner is used.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"extraArgs": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "extraArgs are extra command line flags appended to the arguments of the CSI driver container. The flags the operator sets itself, such as --drivername, --v and --datadir, cannot be overridden.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"driverName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "driverName overrides the name of the CSI driver, which is the name of the CSIDriver object, the name the driver registers with on the nodes and the provisioner of the storage pool StorageClasses. Hostpath provisioners that coexist in a cluster, for instance during a migration, need distinct driver names. Changing it recreates the CSIDriver and the StorageClasses, the existing volumes of the previous driver name can no longer be mounted. If not set kubevirt.io.hostpath-provisioner is used.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"unmanaged": {
+						SchemaProps: spec.SchemaProps{
+							Description: "unmanaged stops the operator from creating, updating and deleting the CSIDriver object, for platforms that install a shared CSIDriver managed by another operator. The fields of the CSIDriver in this config have no effect then. If not set the operator manages the CSIDriver.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.SnapshotClassConfig"},
+	}
+}
+
+func schema_pkg_apis_hostpathprovisioner_v1beta1_CleanupJobConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CleanupJobConfig defines the configurable fields of the storage pool cleanup Jobs.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"backoffLimit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "backoffLimit is the number of retries before a cleanup Job is marked as failed. If not set 6 is used.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"ttlSecondsAfterFinished": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ttlSecondsAfterFinished is the duration in seconds after which a finished cleanup Job is deleted. If not set 300 is used.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"activeDeadlineSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "activeDeadlineSeconds is the duration in seconds a cleanup Job may run before it is terminated, for instance when its node is gone. A terminated Job counts as finished. If not set the Jobs run until they complete.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_hostpathprovisioner_v1beta1_ConditionTransition(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ConditionTransition is a change of the status or reason of one of the conditions of the HostPathProvisioner.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type The type of the condition that changed",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status The status the condition changed to",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reason The reason the condition changed to",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"previousStatus": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PreviousStatus The status of the condition before the change",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"previousReason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PreviousReason The reason of the condition before the change",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"time": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Time The time of the change",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"type", "status", "previousStatus", "time"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_pkg_apis_hostpathprovisioner_v1beta1_HostPathProvisioner(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -2629,11 +2876,75 @@ func schema_pkg_apis_hostpathprovisioner_v1beta1_HostPathProvisionerSpec(ref com
 							},
 						},
 					},
+					"additionalVolumes": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "AdditionalVolumes are a list of extra host paths mounted into the provisioner container",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.AdditionalVolume"),
+									},
+								},
+							},
+						},
+					},
+					"skipFinalizer": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SkipFinalizer stops the operator from adding its finalizer to the CR, an existing finalizer is removed. Without the finalizer the cluster scoped resources (SecurityContextConstraints, RBAC, CSIDriver) are not cleaned up automatically when the CR is deleted, and have to be removed manually.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"csiDriver": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CSIDriver configures the CSIDriver object created by the operator",
+							Ref:         ref("kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.CSIDriverConfig"),
+						},
+					},
+					"adoptExisting": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AdoptExisting makes the operator take over existing DaemonSets with the expected name that have no controller, for instance when migrating from a helm installed hostpath provisioner. DaemonSets labeled as a different application are not adopted.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"rbac": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RBAC configures the scope of the RBAC resources created by the operator",
+							Ref:         ref("kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.RBACConfig"),
+						},
+					},
+					"monitoring": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Monitoring configures the alerts and the health reporting of the hostpath provisioner",
+							Ref:         ref("kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.MonitoringConfig"),
+						},
+					},
+					"integrations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Integrations configures the integration of the hostpath provisioner with other projects",
+							Ref:         ref("kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.IntegrationsConfig"),
+						},
+					},
+					"verifyProvisioning": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VerifyProvisioning runs a Job once the provisioner is deployed that provisions a volume from the StorageClass of the first storage pool with createStorageClass, writes a file to it and reads it back. The result is reported in the ProvisioningVerified condition, and the Job and its PVC are removed afterwards.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.NodePlacement", "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.PathConfig", "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.StoragePool"},
+			"kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.AdditionalVolume", "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.CSIDriverConfig", "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.IntegrationsConfig", "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.MonitoringConfig", "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.NodePlacement", "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.PathConfig", "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.RBACConfig", "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.StoragePool"},
 	}
 }
 
@@ -2670,6 +2981,12 @@ func schema_pkg_apis_hostpathprovisioner_v1beta1_HostPathProvisionerStatus(ref c
 							Format:      "",
 						},
 					},
+					"operatorBuild": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OperatorBuild The build of the HostPathProvisioner Operator",
+							Ref:         ref("kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.OperatorBuild"),
+						},
+					},
 					"targetVersion": {
 						SchemaProps: spec.SchemaProps{
 							Description: "TargetVersion The targeted version of the HostPathProvisioner deployment",
@@ -2684,6 +3001,40 @@ func schema_pkg_apis_hostpathprovisioner_v1beta1_HostPathProvisionerStatus(ref c
 							Format:      "",
 						},
 					},
+					"csiDriverVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CSIDriverVersion The version of the CSI driver image all the CSI driver pods run, it is not updated while a rollout is in progress",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"csiDriverName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CSIDriverName The name of the CSIDriver the operator manages, the previous CSIDriver is removed once the driver name changes",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObservedGeneration The most recent generation of the HostPathProvisioner that was successfully reconciled",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"lastReconcileTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastReconcileTime The time of the last reconcile that updated all the managed resources without errors. It is refreshed at most once a minute, so successful reconciles don't update the CR every time.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"operatorNamespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OperatorNamespace The namespace the operator deploys the hostpath provisioner in, resolved from WATCH_NAMESPACE",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"storagePoolStatuses": {
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
@@ -2702,11 +3053,144 @@ func schema_pkg_apis_hostpathprovisioner_v1beta1_HostPathProvisionerStatus(ref c
 							},
 						},
 					},
-				},
-			},
+					"enabledFeatureGates": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "EnabledFeatureGates The feature gates from the spec the operator acts on, unknown feature gates are not listed",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"summary": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Summary A short summary of the readiness of the storage pools and the observed version, for instance \"3/3 pools ready, v1.2.3\". It is shown by kubectl get.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"conditionHistory": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "ConditionHistory The most recent changes of the status or reason of the conditions, oldest first. Only the last 20 changes are kept.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.ConditionTransition"),
+									},
+								},
+							},
+						},
+					},
+					"unschedulableNodes": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "UnschedulableNodes The nodes the provisioner DaemonSets cannot schedule their pods on, with the reason the scheduler gives, computed from the pending pods",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.UnschedulableNode"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 		},
 		Dependencies: []string{
-			"github.com/openshift/custom-resource-status/conditions/v1.Condition", "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.StoragePoolStatus"},
+			"github.com/openshift/custom-resource-status/conditions/v1.Condition", "k8s.io/apimachinery/pkg/apis/meta/v1.Time", "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.ConditionTransition", "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.OperatorBuild", "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.StoragePoolStatus", "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.UnschedulableNode"},
+	}
+}
+
+func schema_pkg_apis_hostpathprovisioner_v1beta1_IntegrationsConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "IntegrationsConfig defines the integrations of the hostpath provisioner with other projects.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"cdi": {
+						SchemaProps: spec.SchemaProps{
+							Description: "cdi makes the operator reconcile the KubeVirt CDI StorageProfiles of the StorageClasses it creates, so CDI creates its volumes with the access modes and volume mode the hostpath provisioner supports. The StorageProfiles are skipped if CDI is not installed.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_hostpathprovisioner_v1beta1_MonitoringConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MonitoringConfig defines the configurable fields of the PrometheusRule and the health reporting of the hostpath provisioner.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"notReadyGracePeriod": {
+						SchemaProps: spec.SchemaProps{
+							Description: "notReadyGracePeriod is how long the HostPathProvisioner has to be not ready before the HPPNotReady alert fires. Large clusters take longer to roll out the DaemonSets and may need a longer period. Defaults to 5m.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"degradedGracePeriod": {
+						SchemaProps: spec.SchemaProps{
+							Description: "degradedGracePeriod is how long the DaemonSets can be not ready, for instance during node reboots, before the HostPathProvisioner is marked degraded. During the period the HostPathProvisioner is reported as progressing. If not set it is marked degraded right away.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"createAlerts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "createAlerts controls whether the operator creates the PrometheusRule with the hostpath provisioner alerts. The ServiceMonitor is always created, so the metrics can be scraped while the alerts are managed elsewhere. Defaults to true.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"minReadyNodes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "minReadyNodes is the number of provisioner pods that have to be ready before the HostPathProvisioner is marked available. On clusters that scale up from zero nodes the DaemonSets can report ready with just the first node. Defaults to 1.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"emitConditionEvents": {
+						SchemaProps: spec.SchemaProps{
+							Description: "emitConditionEvents makes the operator emit an Event each time the status of a condition of the HostPathProvisioner changes, for monitoring stacks that consume Events but not the status. Defaults to false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
@@ -2753,11 +3237,190 @@ func schema_pkg_apis_hostpathprovisioner_v1beta1_NodePlacement(ref common.Refere
 							},
 						},
 					},
+					"tolerateControlPlane": {
+						SchemaProps: spec.SchemaProps{
+							Description: "tolerateControlPlane adds the tolerations of the node-role.kubernetes.io/control-plane and node-role.kubernetes.io/master NoSchedule taints to the relevant kind of pods, so they also run on control plane nodes, for instance on single node OpenShift.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"criticalAddon": {
+						SchemaProps: spec.SchemaProps{
+							Description: "criticalAddon declares the relevant kind of pods as a critical addon. The pods get the system-node-critical priority class and the cluster autoscaler is told not to evict them.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"imagePullSecrets": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "imagePullSecrets is a list of references to secrets used for pulling the images of the relevant kind of pods. The secrets are attached to the service accounts the operator manages. See https://kubernetes.io/docs/concepts/containers/images/#specifying-imagepullsecrets-on-a-pod",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.LocalObjectReference"),
+									},
+								},
+							},
+						},
+					},
+					"terminationGracePeriodSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "terminationGracePeriodSeconds is the duration in seconds the relevant kind of pods need to terminate gracefully. If not set the default of 30 seconds is used. See https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle/#pod-termination",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"singleNode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "singleNode makes the operator run the provisioner as a single replica Deployment instead of a DaemonSet. The Deployment is scheduled on the control plane node unless a nodeSelector or affinity is specified. This is meant for single node development clusters only.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"resourceProfile": {
+						SchemaProps: spec.SchemaProps{
+							Description: "resourceProfile is a preset of the resource requests and limits applied to all the provisioner containers. minimal is meant for memory constrained edge nodes, highThroughput for nodes with a lot of volume activity. If not set the default requests are used, and the sidecar containers don't request any resources.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"dnsPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "dnsPolicy is the DNS policy of the provisioner pods. If not set ClusterFirst is used.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"dnsConfig": {
+						SchemaProps: spec.SchemaProps{
+							Description: "dnsConfig is the DNS configuration of the provisioner pods, it is merged with the configuration generated from the dnsPolicy. It is required to specify nameservers when the dnsPolicy is None.",
+							Ref:         ref("k8s.io/api/core/v1.PodDNSConfig"),
+						},
+					},
+					"hostNetwork": {
+						SchemaProps: spec.SchemaProps{
+							Description: "hostNetwork makes the provisioner pods use the network namespace of the host. If no dnsPolicy is specified ClusterFirstWithHostNet is used, the ClusterFirst dnsPolicy cannot be combined with host networking.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"cleanupJob": {
+						SchemaProps: spec.SchemaProps{
+							Description: "cleanupJob configures the Jobs that clean up the storage pools when the HostPathProvisioner is removed.",
+							Ref:         ref("kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.CleanupJobConfig"),
+						},
+					},
+					"env": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "env is a list of extra environment variables set on the provisioner container, for instance HTTP_PROXY. Variables with the name of a variable managed by the operator are ignored.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.EnvVar"),
+									},
+								},
+							},
+						},
+					},
+					"initContainers": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "initContainers is a list of extra init containers that run before the provisioner containers start, for instance to create the storage pool directories or change their mode. They run before the init containers of the operator. The webhook rejects init containers with the name of a container managed by the operator.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Container"),
+									},
+								},
+							},
+						},
+					},
+					"serviceAccountName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "serviceAccountName is the name of an existing ServiceAccount the provisioner pods run with, for clusters where the ServiceAccount is managed outside of the operator. It has to exist in the namespace of the operator and in the namespaces of the storage pools. If not set the operator creates its own ServiceAccounts.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"automountServiceAccountToken": {
+						SchemaProps: spec.SchemaProps{
+							Description: "automountServiceAccountToken sets automountServiceAccountToken on the pods of the provisioner DaemonSets. If not set the default of the ServiceAccount applies.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"fsGroup": {
+						SchemaProps: spec.SchemaProps{
+							Description: "fsGroup is the supplemental group applied to the volumes of the provisioner pods and the storage pool deployments, for instance when the volumes need a specific group ownership. If not set no fsGroup is used.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"revisionHistoryLimit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "revisionHistoryLimit is the number of old ControllerRevisions of the provisioner DaemonSets, and old ReplicaSets of the storage pool deployments, that are kept for rollbacks. If not set 2 are kept.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"trustedCABundle": {
+						SchemaProps: spec.SchemaProps{
+							Description: "trustedCABundle is a ConfigMap with the CA certificates the provisioner containers trust, for instance of an internal CA. The bundle replaces the trust store of the images.",
+							Ref:         ref("kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.TrustedCABundleConfig"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Toleration"},
+			"k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.Toleration", "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.CleanupJobConfig", "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1.TrustedCABundleConfig"},
+	}
+}
+
+func schema_pkg_apis_hostpathprovisioner_v1beta1_OperatorBuild(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "OperatorBuild identifies the build of the operator.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"gitCommit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GitCommit is the git commit the operator was built from",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"buildDate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BuildDate is the time the operator was built",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -2788,6 +3451,47 @@ func schema_pkg_apis_hostpathprovisioner_v1beta1_PathConfig(ref common.Reference
 	}
 }
 
+func schema_pkg_apis_hostpathprovisioner_v1beta1_RBACConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RBACConfig defines the scope of the namespaced RBAC resources of the hostpath provisioner.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"namespaceSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "namespaceSelector selects the namespaces the Role and RoleBinding of the CSI driver are created in. The namespace of the operator is always included, the CSI driver keeps its leases and storage capacities there. The Role and RoleBinding are removed from namespaces that no longer match.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+	}
+}
+
+func schema_pkg_apis_hostpathprovisioner_v1beta1_SnapshotClassConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SnapshotClassConfig defines the VolumeSnapshotClass of the CSI driver.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"deletionPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "deletionPolicy is the deletionPolicy of the VolumeSnapshotClass, it determines whether the snapshot contents are deleted with their VolumeSnapshot. If not set Delete is used.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_hostpathprovisioner_v1beta1_StoragePool(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -2817,11 +3521,123 @@ func schema_pkg_apis_hostpathprovisioner_v1beta1_StoragePool(ref common.Referenc
 							Format:      "",
 						},
 					},
+					"createStorageClass": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CreateStorageClass makes the operator create and manage a StorageClass named after the storage pool.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespace is the namespace the storage pool PVCs and deployments are created in, defaults to the operator namespace. Only used when a PVCTemplate is specified.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"annotateProvisionedVolumes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AnnotateProvisionedVolumes makes the CSI driver annotate the PVs it provisions from this storage pool with the hostpathprovisioner.kubevirt.io/pool annotation, set to the name of the storage pool.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"topologySpreadConstraints": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TopologySpreadConstraints are added to the pod template of the storage pool deployments, to spread the pods across failure domains. Only used when a PVCTemplate is specified.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.TopologySpreadConstraint"),
+									},
+								},
+							},
+						},
+					},
+					"deploymentStrategy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DeploymentStrategy is the update strategy of the storage pool deployments, for instance Recreate so the old and new pod never use the storage pool directory at the same time. Defaults to a RollingUpdate. Only used when a PVCTemplate is specified.",
+							Ref:         ref("k8s.io/api/apps/v1.DeploymentStrategy"),
+						},
+					},
 				},
 				Required: []string{"name", "path"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.PersistentVolumeClaimSpec"},
+			"k8s.io/api/apps/v1.DeploymentStrategy", "k8s.io/api/core/v1.PersistentVolumeClaimSpec", "k8s.io/api/core/v1.TopologySpreadConstraint"},
+	}
+}
+
+func schema_pkg_apis_hostpathprovisioner_v1beta1_TrustedCABundleConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TrustedCABundleConfig references the ConfigMap with the trusted CA bundle of the provisioner containers.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"configMapName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "configMapName is the name of a ConfigMap in the namespace of the operator with the CA bundle. It is required unless injectClusterTrustBundle is set.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"key": {
+						SchemaProps: spec.SchemaProps{
+							Description: "key is the key of the CA bundle in the ConfigMap. If not set ca-bundle.crt is used.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"injectClusterTrustBundle": {
+						SchemaProps: spec.SchemaProps{
+							Description: "injectClusterTrustBundle makes the operator create its own ConfigMap with the config.openshift.io/inject-trusted-cabundle label, OpenShift injects the trusted CA bundle of the cluster into it. It cannot be combined with configMapName or key.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_hostpathprovisioner_v1beta1_UnschedulableNode(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "UnschedulableNode is a node a provisioner DaemonSet cannot schedule its pod on.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"node": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Node The name of the node the pod should run on, empty if the pod is not bound to a node",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"daemonSet": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DaemonSet The name of the DaemonSet of the pod",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reason The message of the PodScheduled condition of the pod",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"daemonSet", "reason"},
+			},
+		},
 	}
 }
@@ -0,0 +1,66 @@
+/*
+Copyright 2020 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// AdditionalVolumeApplyConfiguration represents an declarative configuration of the AdditionalVolume type for use
+// with apply.
+type AdditionalVolumeApplyConfiguration struct {
+	Name      *string `json:"name,omitempty"`
+	HostPath  *string `json:"hostPath,omitempty"`
+	MountPath *string `json:"mountPath,omitempty"`
+	ReadOnly  *bool   `json:"readOnly,omitempty"`
+}
+
+// AdditionalVolumeApplyConfiguration constructs an declarative configuration of the AdditionalVolume type for use with
+// apply.
+func AdditionalVolume() *AdditionalVolumeApplyConfiguration {
+	return &AdditionalVolumeApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *AdditionalVolumeApplyConfiguration) WithName(value string) *AdditionalVolumeApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithHostPath sets the HostPath field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HostPath field is set to the value of the last call.
+func (b *AdditionalVolumeApplyConfiguration) WithHostPath(value string) *AdditionalVolumeApplyConfiguration {
+	b.HostPath = &value
+	return b
+}
+
+// WithMountPath sets the MountPath field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MountPath field is set to the value of the last call.
+func (b *AdditionalVolumeApplyConfiguration) WithMountPath(value string) *AdditionalVolumeApplyConfiguration {
+	b.MountPath = &value
+	return b
+}
+
+// WithReadOnly sets the ReadOnly field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReadOnly field is set to the value of the last call.
+func (b *AdditionalVolumeApplyConfiguration) WithReadOnly(value bool) *AdditionalVolumeApplyConfiguration {
+	b.ReadOnly = &value
+	return b
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2020 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// CleanupJobConfigApplyConfiguration represents an declarative configuration of the CleanupJobConfig type for use
+// with apply.
+type CleanupJobConfigApplyConfiguration struct {
+	BackoffLimit            *int32 `json:"backoffLimit,omitempty"`
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+	ActiveDeadlineSeconds   *int64 `json:"activeDeadlineSeconds,omitempty"`
+}
+
+// CleanupJobConfigApplyConfiguration constructs an declarative configuration of the CleanupJobConfig type for use with
+// apply.
+func CleanupJobConfig() *CleanupJobConfigApplyConfiguration {
+	return &CleanupJobConfigApplyConfiguration{}
+}
+
+// WithBackoffLimit sets the BackoffLimit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BackoffLimit field is set to the value of the last call.
+func (b *CleanupJobConfigApplyConfiguration) WithBackoffLimit(value int32) *CleanupJobConfigApplyConfiguration {
+	b.BackoffLimit = &value
+	return b
+}
+
+// WithTTLSecondsAfterFinished sets the TTLSecondsAfterFinished field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TTLSecondsAfterFinished field is set to the value of the last call.
+func (b *CleanupJobConfigApplyConfiguration) WithTTLSecondsAfterFinished(value int32) *CleanupJobConfigApplyConfiguration {
+	b.TTLSecondsAfterFinished = &value
+	return b
+}
+
+// WithActiveDeadlineSeconds sets the ActiveDeadlineSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ActiveDeadlineSeconds field is set to the value of the last call.
+func (b *CleanupJobConfigApplyConfiguration) WithActiveDeadlineSeconds(value int64) *CleanupJobConfigApplyConfiguration {
+	b.ActiveDeadlineSeconds = &value
+	return b
+}
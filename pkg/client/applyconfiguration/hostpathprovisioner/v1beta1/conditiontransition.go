@@ -0,0 +1,90 @@
+/*
+Copyright 2020 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConditionTransitionApplyConfiguration represents an declarative configuration of the ConditionTransition type for use
+// with apply.
+type ConditionTransitionApplyConfiguration struct {
+	Type           *v1.ConditionType       `json:"type,omitempty"`
+	Status         *corev1.ConditionStatus `json:"status,omitempty"`
+	Reason         *string                 `json:"reason,omitempty"`
+	PreviousStatus *corev1.ConditionStatus `json:"previousStatus,omitempty"`
+	PreviousReason *string                 `json:"previousReason,omitempty"`
+	Time           *metav1.Time            `json:"time,omitempty"`
+}
+
+// ConditionTransitionApplyConfiguration constructs an declarative configuration of the ConditionTransition type for use with
+// apply.
+func ConditionTransition() *ConditionTransitionApplyConfiguration {
+	return &ConditionTransitionApplyConfiguration{}
+}
+
+// WithType sets the Type field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Type field is set to the value of the last call.
+func (b *ConditionTransitionApplyConfiguration) WithType(value v1.ConditionType) *ConditionTransitionApplyConfiguration {
+	b.Type = &value
+	return b
+}
+
+// WithStatus sets the Status field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Status field is set to the value of the last call.
+func (b *ConditionTransitionApplyConfiguration) WithStatus(value corev1.ConditionStatus) *ConditionTransitionApplyConfiguration {
+	b.Status = &value
+	return b
+}
+
+// WithReason sets the Reason field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Reason field is set to the value of the last call.
+func (b *ConditionTransitionApplyConfiguration) WithReason(value string) *ConditionTransitionApplyConfiguration {
+	b.Reason = &value
+	return b
+}
+
+// WithPreviousStatus sets the PreviousStatus field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PreviousStatus field is set to the value of the last call.
+func (b *ConditionTransitionApplyConfiguration) WithPreviousStatus(value corev1.ConditionStatus) *ConditionTransitionApplyConfiguration {
+	b.PreviousStatus = &value
+	return b
+}
+
+// WithPreviousReason sets the PreviousReason field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PreviousReason field is set to the value of the last call.
+func (b *ConditionTransitionApplyConfiguration) WithPreviousReason(value string) *ConditionTransitionApplyConfiguration {
+	b.PreviousReason = &value
+	return b
+}
+
+// WithTime sets the Time field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Time field is set to the value of the last call.
+func (b *ConditionTransitionApplyConfiguration) WithTime(value metav1.Time) *ConditionTransitionApplyConfiguration {
+	b.Time = &value
+	return b
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2020 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/api/storage/v1"
+)
+
+// CSIDriverConfigApplyConfiguration represents an declarative configuration of the CSIDriverConfig type for use
+// with apply.
+type CSIDriverConfigApplyConfiguration struct {
+	FSGroupPolicy       *v1.FSGroupPolicy                      `json:"fsGroupPolicy,omitempty"`
+	EnableLivenessProbe *bool                                  `json:"enableLivenessProbe,omitempty"`
+	PodInfoOnMount      *bool                                  `json:"podInfoOnMount,omitempty"`
+	AttachRequired      *bool                                  `json:"attachRequired,omitempty"`
+	RequiresRepublish   *bool                                  `json:"requiresRepublish,omitempty"`
+	SnapshotClass       *SnapshotClassConfigApplyConfiguration `json:"snapshotClass,omitempty"`
+	WorkerThreads       *int32                                 `json:"workerThreads,omitempty"`
+	ExtraArgs           []string                               `json:"extraArgs,omitempty"`
+	DriverName          *string                                `json:"driverName,omitempty"`
+	Unmanaged           *bool                                  `json:"unmanaged,omitempty"`
+}
+
+// CSIDriverConfigApplyConfiguration constructs an declarative configuration of the CSIDriverConfig type for use with
+// apply.
+func CSIDriverConfig() *CSIDriverConfigApplyConfiguration {
+	return &CSIDriverConfigApplyConfiguration{}
+}
+
+// WithFSGroupPolicy sets the FSGroupPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FSGroupPolicy field is set to the value of the last call.
+func (b *CSIDriverConfigApplyConfiguration) WithFSGroupPolicy(value v1.FSGroupPolicy) *CSIDriverConfigApplyConfiguration {
+	b.FSGroupPolicy = &value
+	return b
+}
+
+// WithEnableLivenessProbe sets the EnableLivenessProbe field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EnableLivenessProbe field is set to the value of the last call.
+func (b *CSIDriverConfigApplyConfiguration) WithEnableLivenessProbe(value bool) *CSIDriverConfigApplyConfiguration {
+	b.EnableLivenessProbe = &value
+	return b
+}
+
+// WithPodInfoOnMount sets the PodInfoOnMount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PodInfoOnMount field is set to the value of the last call.
+func (b *CSIDriverConfigApplyConfiguration) WithPodInfoOnMount(value bool) *CSIDriverConfigApplyConfiguration {
+	b.PodInfoOnMount = &value
+	return b
+}
+
+// WithAttachRequired sets the AttachRequired field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AttachRequired field is set to the value of the last call.
+func (b *CSIDriverConfigApplyConfiguration) WithAttachRequired(value bool) *CSIDriverConfigApplyConfiguration {
+	b.AttachRequired = &value
+	return b
+}
+
+// WithRequiresRepublish sets the RequiresRepublish field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RequiresRepublish field is set to the value of the last call.
+func (b *CSIDriverConfigApplyConfiguration) WithRequiresRepublish(value bool) *CSIDriverConfigApplyConfiguration {
+	b.RequiresRepublish = &value
+	return b
+}
+
+// WithSnapshotClass sets the SnapshotClass field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SnapshotClass field is set to the value of the last call.
+func (b *CSIDriverConfigApplyConfiguration) WithSnapshotClass(value *SnapshotClassConfigApplyConfiguration) *CSIDriverConfigApplyConfiguration {
+	b.SnapshotClass = value
+	return b
+}
+
+// WithWorkerThreads sets the WorkerThreads field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the WorkerThreads field is set to the value of the last call.
+func (b *CSIDriverConfigApplyConfiguration) WithWorkerThreads(value int32) *CSIDriverConfigApplyConfiguration {
+	b.WorkerThreads = &value
+	return b
+}
+
+// WithExtraArgs adds the given value to the ExtraArgs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ExtraArgs field.
+func (b *CSIDriverConfigApplyConfiguration) WithExtraArgs(values ...string) *CSIDriverConfigApplyConfiguration {
+	for i := range values {
+		b.ExtraArgs = append(b.ExtraArgs, values[i])
+	}
+	return b
+}
+
+// WithDriverName sets the DriverName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DriverName field is set to the value of the last call.
+func (b *CSIDriverConfigApplyConfiguration) WithDriverName(value string) *CSIDriverConfigApplyConfiguration {
+	b.DriverName = &value
+	return b
+}
+
+// WithUnmanaged sets the Unmanaged field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Unmanaged field is set to the value of the last call.
+func (b *CSIDriverConfigApplyConfiguration) WithUnmanaged(value bool) *CSIDriverConfigApplyConfiguration {
+	b.Unmanaged = &value
+	return b
+}
@@ -25,11 +25,19 @@ import (
 // HostPathProvisionerSpecApplyConfiguration represents an declarative configuration of the HostPathProvisionerSpec type for use
 // with apply.
 type HostPathProvisionerSpecApplyConfiguration struct {
-	ImagePullPolicy *v1.PullPolicy                   `json:"imagePullPolicy,omitempty"`
-	PathConfig      *PathConfigApplyConfiguration    `json:"pathConfig,omitempty"`
-	Workload        *NodePlacementApplyConfiguration `json:"workload,omitempty"`
-	FeatureGates    []string                         `json:"featureGates,omitempty"`
-	StoragePools    []StoragePoolApplyConfiguration  `json:"storagePools,omitempty"`
+	ImagePullPolicy    *v1.PullPolicy                        `json:"imagePullPolicy,omitempty"`
+	PathConfig         *PathConfigApplyConfiguration         `json:"pathConfig,omitempty"`
+	Workload           *NodePlacementApplyConfiguration      `json:"workload,omitempty"`
+	FeatureGates       []string                              `json:"featureGates,omitempty"`
+	StoragePools       []StoragePoolApplyConfiguration       `json:"storagePools,omitempty"`
+	AdditionalVolumes  []AdditionalVolumeApplyConfiguration  `json:"additionalVolumes,omitempty"`
+	SkipFinalizer      *bool                                 `json:"skipFinalizer,omitempty"`
+	CSIDriver          *CSIDriverConfigApplyConfiguration    `json:"csiDriver,omitempty"`
+	AdoptExisting      *bool                                 `json:"adoptExisting,omitempty"`
+	RBAC               *RBACConfigApplyConfiguration         `json:"rbac,omitempty"`
+	Monitoring         *MonitoringConfigApplyConfiguration   `json:"monitoring,omitempty"`
+	Integrations       *IntegrationsConfigApplyConfiguration `json:"integrations,omitempty"`
+	VerifyProvisioning *bool                                 `json:"verifyProvisioning,omitempty"`
 }
 
 // HostPathProvisionerSpecApplyConfiguration constructs an declarative configuration of the HostPathProvisionerSpec type for use with
@@ -84,3 +92,72 @@ func (b *HostPathProvisionerSpecApplyConfiguration) WithStoragePools(values ...*
 	}
 	return b
 }
+
+// WithAdditionalVolumes adds the given value to the AdditionalVolumes field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the AdditionalVolumes field.
+func (b *HostPathProvisionerSpecApplyConfiguration) WithAdditionalVolumes(values ...*AdditionalVolumeApplyConfiguration) *HostPathProvisionerSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithAdditionalVolumes")
+		}
+		b.AdditionalVolumes = append(b.AdditionalVolumes, *values[i])
+	}
+	return b
+}
+
+// WithSkipFinalizer sets the SkipFinalizer field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SkipFinalizer field is set to the value of the last call.
+func (b *HostPathProvisionerSpecApplyConfiguration) WithSkipFinalizer(value bool) *HostPathProvisionerSpecApplyConfiguration {
+	b.SkipFinalizer = &value
+	return b
+}
+
+// WithCSIDriver sets the CSIDriver field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CSIDriver field is set to the value of the last call.
+func (b *HostPathProvisionerSpecApplyConfiguration) WithCSIDriver(value *CSIDriverConfigApplyConfiguration) *HostPathProvisionerSpecApplyConfiguration {
+	b.CSIDriver = value
+	return b
+}
+
+// WithAdoptExisting sets the AdoptExisting field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AdoptExisting field is set to the value of the last call.
+func (b *HostPathProvisionerSpecApplyConfiguration) WithAdoptExisting(value bool) *HostPathProvisionerSpecApplyConfiguration {
+	b.AdoptExisting = &value
+	return b
+}
+
+// WithRBAC sets the RBAC field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RBAC field is set to the value of the last call.
+func (b *HostPathProvisionerSpecApplyConfiguration) WithRBAC(value *RBACConfigApplyConfiguration) *HostPathProvisionerSpecApplyConfiguration {
+	b.RBAC = value
+	return b
+}
+
+// WithMonitoring sets the Monitoring field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Monitoring field is set to the value of the last call.
+func (b *HostPathProvisionerSpecApplyConfiguration) WithMonitoring(value *MonitoringConfigApplyConfiguration) *HostPathProvisionerSpecApplyConfiguration {
+	b.Monitoring = value
+	return b
+}
+
+// WithIntegrations sets the Integrations field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Integrations field is set to the value of the last call.
+func (b *HostPathProvisionerSpecApplyConfiguration) WithIntegrations(value *IntegrationsConfigApplyConfiguration) *HostPathProvisionerSpecApplyConfiguration {
+	b.Integrations = value
+	return b
+}
+
+// WithVerifyProvisioning sets the VerifyProvisioning field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the VerifyProvisioning field is set to the value of the last call.
+func (b *HostPathProvisionerSpecApplyConfiguration) WithVerifyProvisioning(value bool) *HostPathProvisionerSpecApplyConfiguration {
+	b.VerifyProvisioning = &value
+	return b
+}
@@ -20,16 +20,27 @@ package v1beta1
 
 import (
 	v1 "github.com/openshift/custom-resource-status/conditions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // HostPathProvisionerStatusApplyConfiguration represents an declarative configuration of the HostPathProvisionerStatus type for use
 // with apply.
 type HostPathProvisionerStatusApplyConfiguration struct {
-	Conditions          []v1.Condition                        `json:"conditions,omitempty"`
-	OperatorVersion     *string                               `json:"operatorVersion,omitempty"`
-	TargetVersion       *string                               `json:"targetVersion,omitempty"`
-	ObservedVersion     *string                               `json:"observedVersion,omitempty"`
-	StoragePoolStatuses []StoragePoolStatusApplyConfiguration `json:"storagePoolStatuses,omitempty"`
+	Conditions          []v1.Condition                          `json:"conditions,omitempty"`
+	OperatorVersion     *string                                 `json:"operatorVersion,omitempty"`
+	OperatorBuild       *OperatorBuildApplyConfiguration        `json:"operatorBuild,omitempty"`
+	TargetVersion       *string                                 `json:"targetVersion,omitempty"`
+	ObservedVersion     *string                                 `json:"observedVersion,omitempty"`
+	CSIDriverVersion    *string                                 `json:"csiDriverVersion,omitempty"`
+	CSIDriverName       *string                                 `json:"csiDriverName,omitempty"`
+	ObservedGeneration  *int64                                  `json:"observedGeneration,omitempty"`
+	LastReconcileTime   *metav1.Time                            `json:"lastReconcileTime,omitempty"`
+	OperatorNamespace   *string                                 `json:"operatorNamespace,omitempty"`
+	StoragePoolStatuses []StoragePoolStatusApplyConfiguration   `json:"storagePoolStatuses,omitempty"`
+	EnabledFeatureGates []string                                `json:"enabledFeatureGates,omitempty"`
+	Summary             *string                                 `json:"summary,omitempty"`
+	ConditionHistory    []ConditionTransitionApplyConfiguration `json:"conditionHistory,omitempty"`
+	UnschedulableNodes  []UnschedulableNodeApplyConfiguration   `json:"unschedulableNodes,omitempty"`
 }
 
 // HostPathProvisionerStatusApplyConfiguration constructs an declarative configuration of the HostPathProvisionerStatus type for use with
@@ -56,6 +67,14 @@ func (b *HostPathProvisionerStatusApplyConfiguration) WithOperatorVersion(value
 	return b
 }
 
+// WithOperatorBuild sets the OperatorBuild field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OperatorBuild field is set to the value of the last call.
+func (b *HostPathProvisionerStatusApplyConfiguration) WithOperatorBuild(value *OperatorBuildApplyConfiguration) *HostPathProvisionerStatusApplyConfiguration {
+	b.OperatorBuild = value
+	return b
+}
+
 // WithTargetVersion sets the TargetVersion field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the TargetVersion field is set to the value of the last call.
@@ -72,6 +91,46 @@ func (b *HostPathProvisionerStatusApplyConfiguration) WithObservedVersion(value
 	return b
 }
 
+// WithCSIDriverVersion sets the CSIDriverVersion field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CSIDriverVersion field is set to the value of the last call.
+func (b *HostPathProvisionerStatusApplyConfiguration) WithCSIDriverVersion(value string) *HostPathProvisionerStatusApplyConfiguration {
+	b.CSIDriverVersion = &value
+	return b
+}
+
+// WithCSIDriverName sets the CSIDriverName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CSIDriverName field is set to the value of the last call.
+func (b *HostPathProvisionerStatusApplyConfiguration) WithCSIDriverName(value string) *HostPathProvisionerStatusApplyConfiguration {
+	b.CSIDriverName = &value
+	return b
+}
+
+// WithObservedGeneration sets the ObservedGeneration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ObservedGeneration field is set to the value of the last call.
+func (b *HostPathProvisionerStatusApplyConfiguration) WithObservedGeneration(value int64) *HostPathProvisionerStatusApplyConfiguration {
+	b.ObservedGeneration = &value
+	return b
+}
+
+// WithLastReconcileTime sets the LastReconcileTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastReconcileTime field is set to the value of the last call.
+func (b *HostPathProvisionerStatusApplyConfiguration) WithLastReconcileTime(value metav1.Time) *HostPathProvisionerStatusApplyConfiguration {
+	b.LastReconcileTime = &value
+	return b
+}
+
+// WithOperatorNamespace sets the OperatorNamespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OperatorNamespace field is set to the value of the last call.
+func (b *HostPathProvisionerStatusApplyConfiguration) WithOperatorNamespace(value string) *HostPathProvisionerStatusApplyConfiguration {
+	b.OperatorNamespace = &value
+	return b
+}
+
 // WithStoragePoolStatuses adds the given value to the StoragePoolStatuses field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the StoragePoolStatuses field.
@@ -84,3 +143,47 @@ func (b *HostPathProvisionerStatusApplyConfiguration) WithStoragePoolStatuses(va
 	}
 	return b
 }
+
+// WithEnabledFeatureGates adds the given value to the EnabledFeatureGates field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the EnabledFeatureGates field.
+func (b *HostPathProvisionerStatusApplyConfiguration) WithEnabledFeatureGates(values ...string) *HostPathProvisionerStatusApplyConfiguration {
+	for i := range values {
+		b.EnabledFeatureGates = append(b.EnabledFeatureGates, values[i])
+	}
+	return b
+}
+
+// WithSummary sets the Summary field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Summary field is set to the value of the last call.
+func (b *HostPathProvisionerStatusApplyConfiguration) WithSummary(value string) *HostPathProvisionerStatusApplyConfiguration {
+	b.Summary = &value
+	return b
+}
+
+// WithConditionHistory adds the given value to the ConditionHistory field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ConditionHistory field.
+func (b *HostPathProvisionerStatusApplyConfiguration) WithConditionHistory(values ...*ConditionTransitionApplyConfiguration) *HostPathProvisionerStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConditionHistory")
+		}
+		b.ConditionHistory = append(b.ConditionHistory, *values[i])
+	}
+	return b
+}
+
+// WithUnschedulableNodes adds the given value to the UnschedulableNodes field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the UnschedulableNodes field.
+func (b *HostPathProvisionerStatusApplyConfiguration) WithUnschedulableNodes(values ...*UnschedulableNodeApplyConfiguration) *HostPathProvisionerStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithUnschedulableNodes")
+		}
+		b.UnschedulableNodes = append(b.UnschedulableNodes, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2020 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// IntegrationsConfigApplyConfiguration represents an declarative configuration of the IntegrationsConfig type for use
+// with apply.
+type IntegrationsConfigApplyConfiguration struct {
+	CDI *bool `json:"cdi,omitempty"`
+}
+
+// IntegrationsConfigApplyConfiguration constructs an declarative configuration of the IntegrationsConfig type for use with
+// apply.
+func IntegrationsConfig() *IntegrationsConfigApplyConfiguration {
+	return &IntegrationsConfigApplyConfiguration{}
+}
+
+// WithCDI sets the CDI field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CDI field is set to the value of the last call.
+func (b *IntegrationsConfigApplyConfiguration) WithCDI(value bool) *IntegrationsConfigApplyConfiguration {
+	b.CDI = &value
+	return b
+}
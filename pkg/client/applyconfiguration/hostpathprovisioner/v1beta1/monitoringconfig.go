@@ -0,0 +1,79 @@
+/*
+Copyright 2020 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MonitoringConfigApplyConfiguration represents an declarative configuration of the MonitoringConfig type for use
+// with apply.
+type MonitoringConfigApplyConfiguration struct {
+	NotReadyGracePeriod *v1.Duration `json:"notReadyGracePeriod,omitempty"`
+	DegradedGracePeriod *v1.Duration `json:"degradedGracePeriod,omitempty"`
+	CreateAlerts        *bool        `json:"createAlerts,omitempty"`
+	MinReadyNodes       *int32       `json:"minReadyNodes,omitempty"`
+	EmitConditionEvents *bool        `json:"emitConditionEvents,omitempty"`
+}
+
+// MonitoringConfigApplyConfiguration constructs an declarative configuration of the MonitoringConfig type for use with
+// apply.
+func MonitoringConfig() *MonitoringConfigApplyConfiguration {
+	return &MonitoringConfigApplyConfiguration{}
+}
+
+// WithNotReadyGracePeriod sets the NotReadyGracePeriod field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NotReadyGracePeriod field is set to the value of the last call.
+func (b *MonitoringConfigApplyConfiguration) WithNotReadyGracePeriod(value v1.Duration) *MonitoringConfigApplyConfiguration {
+	b.NotReadyGracePeriod = &value
+	return b
+}
+
+// WithDegradedGracePeriod sets the DegradedGracePeriod field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DegradedGracePeriod field is set to the value of the last call.
+func (b *MonitoringConfigApplyConfiguration) WithDegradedGracePeriod(value v1.Duration) *MonitoringConfigApplyConfiguration {
+	b.DegradedGracePeriod = &value
+	return b
+}
+
+// WithCreateAlerts sets the CreateAlerts field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CreateAlerts field is set to the value of the last call.
+func (b *MonitoringConfigApplyConfiguration) WithCreateAlerts(value bool) *MonitoringConfigApplyConfiguration {
+	b.CreateAlerts = &value
+	return b
+}
+
+// WithMinReadyNodes sets the MinReadyNodes field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MinReadyNodes field is set to the value of the last call.
+func (b *MonitoringConfigApplyConfiguration) WithMinReadyNodes(value int32) *MonitoringConfigApplyConfiguration {
+	b.MinReadyNodes = &value
+	return b
+}
+
+// WithEmitConditionEvents sets the EmitConditionEvents field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EmitConditionEvents field is set to the value of the last call.
+func (b *MonitoringConfigApplyConfiguration) WithEmitConditionEvents(value bool) *MonitoringConfigApplyConfiguration {
+	b.EmitConditionEvents = &value
+	return b
+}
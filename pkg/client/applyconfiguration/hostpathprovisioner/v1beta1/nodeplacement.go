@@ -20,14 +20,32 @@ package v1beta1
 
 import (
 	v1 "k8s.io/api/core/v1"
+	v1beta1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
 )
 
 // NodePlacementApplyConfiguration represents an declarative configuration of the NodePlacement type for use
 // with apply.
 type NodePlacementApplyConfiguration struct {
-	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
-	Affinity     *v1.Affinity      `json:"affinity,omitempty"`
-	Tolerations  []v1.Toleration   `json:"tolerations,omitempty"`
+	NodeSelector                  map[string]string                        `json:"nodeSelector,omitempty"`
+	Affinity                      *v1.Affinity                             `json:"affinity,omitempty"`
+	Tolerations                   []v1.Toleration                          `json:"tolerations,omitempty"`
+	TolerateControlPlane          *bool                                    `json:"tolerateControlPlane,omitempty"`
+	CriticalAddon                 *bool                                    `json:"criticalAddon,omitempty"`
+	ImagePullSecrets              []v1.LocalObjectReference                `json:"imagePullSecrets,omitempty"`
+	TerminationGracePeriodSeconds *int64                                   `json:"terminationGracePeriodSeconds,omitempty"`
+	SingleNode                    *bool                                    `json:"singleNode,omitempty"`
+	ResourceProfile               *v1beta1.ResourceProfile                 `json:"resourceProfile,omitempty"`
+	DNSPolicy                     *v1.DNSPolicy                            `json:"dnsPolicy,omitempty"`
+	DNSConfig                     *v1.PodDNSConfig                         `json:"dnsConfig,omitempty"`
+	HostNetwork                   *bool                                    `json:"hostNetwork,omitempty"`
+	CleanupJob                    *CleanupJobConfigApplyConfiguration      `json:"cleanupJob,omitempty"`
+	Env                           []v1.EnvVar                              `json:"env,omitempty"`
+	InitContainers                []v1.Container                           `json:"initContainers,omitempty"`
+	ServiceAccountName            *string                                  `json:"serviceAccountName,omitempty"`
+	AutomountServiceAccountToken  *bool                                    `json:"automountServiceAccountToken,omitempty"`
+	FSGroup                       *int64                                   `json:"fsGroup,omitempty"`
+	RevisionHistoryLimit          *int32                                   `json:"revisionHistoryLimit,omitempty"`
+	TrustedCABundle               *TrustedCABundleConfigApplyConfiguration `json:"trustedCABundle,omitempty"`
 }
 
 // NodePlacementApplyConfiguration constructs an declarative configuration of the NodePlacement type for use with
@@ -67,3 +85,145 @@ func (b *NodePlacementApplyConfiguration) WithTolerations(values ...v1.Toleratio
 	}
 	return b
 }
+
+// WithTolerateControlPlane sets the TolerateControlPlane field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TolerateControlPlane field is set to the value of the last call.
+func (b *NodePlacementApplyConfiguration) WithTolerateControlPlane(value bool) *NodePlacementApplyConfiguration {
+	b.TolerateControlPlane = &value
+	return b
+}
+
+// WithCriticalAddon sets the CriticalAddon field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CriticalAddon field is set to the value of the last call.
+func (b *NodePlacementApplyConfiguration) WithCriticalAddon(value bool) *NodePlacementApplyConfiguration {
+	b.CriticalAddon = &value
+	return b
+}
+
+// WithImagePullSecrets adds the given value to the ImagePullSecrets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ImagePullSecrets field.
+func (b *NodePlacementApplyConfiguration) WithImagePullSecrets(values ...v1.LocalObjectReference) *NodePlacementApplyConfiguration {
+	for i := range values {
+		b.ImagePullSecrets = append(b.ImagePullSecrets, values[i])
+	}
+	return b
+}
+
+// WithTerminationGracePeriodSeconds sets the TerminationGracePeriodSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TerminationGracePeriodSeconds field is set to the value of the last call.
+func (b *NodePlacementApplyConfiguration) WithTerminationGracePeriodSeconds(value int64) *NodePlacementApplyConfiguration {
+	b.TerminationGracePeriodSeconds = &value
+	return b
+}
+
+// WithSingleNode sets the SingleNode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SingleNode field is set to the value of the last call.
+func (b *NodePlacementApplyConfiguration) WithSingleNode(value bool) *NodePlacementApplyConfiguration {
+	b.SingleNode = &value
+	return b
+}
+
+// WithResourceProfile sets the ResourceProfile field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ResourceProfile field is set to the value of the last call.
+func (b *NodePlacementApplyConfiguration) WithResourceProfile(value v1beta1.ResourceProfile) *NodePlacementApplyConfiguration {
+	b.ResourceProfile = &value
+	return b
+}
+
+// WithDNSPolicy sets the DNSPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DNSPolicy field is set to the value of the last call.
+func (b *NodePlacementApplyConfiguration) WithDNSPolicy(value v1.DNSPolicy) *NodePlacementApplyConfiguration {
+	b.DNSPolicy = &value
+	return b
+}
+
+// WithDNSConfig sets the DNSConfig field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DNSConfig field is set to the value of the last call.
+func (b *NodePlacementApplyConfiguration) WithDNSConfig(value v1.PodDNSConfig) *NodePlacementApplyConfiguration {
+	b.DNSConfig = &value
+	return b
+}
+
+// WithHostNetwork sets the HostNetwork field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HostNetwork field is set to the value of the last call.
+func (b *NodePlacementApplyConfiguration) WithHostNetwork(value bool) *NodePlacementApplyConfiguration {
+	b.HostNetwork = &value
+	return b
+}
+
+// WithCleanupJob sets the CleanupJob field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CleanupJob field is set to the value of the last call.
+func (b *NodePlacementApplyConfiguration) WithCleanupJob(value *CleanupJobConfigApplyConfiguration) *NodePlacementApplyConfiguration {
+	b.CleanupJob = value
+	return b
+}
+
+// WithEnv adds the given value to the Env field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Env field.
+func (b *NodePlacementApplyConfiguration) WithEnv(values ...v1.EnvVar) *NodePlacementApplyConfiguration {
+	for i := range values {
+		b.Env = append(b.Env, values[i])
+	}
+	return b
+}
+
+// WithInitContainers adds the given value to the InitContainers field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the InitContainers field.
+func (b *NodePlacementApplyConfiguration) WithInitContainers(values ...v1.Container) *NodePlacementApplyConfiguration {
+	for i := range values {
+		b.InitContainers = append(b.InitContainers, values[i])
+	}
+	return b
+}
+
+// WithServiceAccountName sets the ServiceAccountName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServiceAccountName field is set to the value of the last call.
+func (b *NodePlacementApplyConfiguration) WithServiceAccountName(value string) *NodePlacementApplyConfiguration {
+	b.ServiceAccountName = &value
+	return b
+}
+
+// WithAutomountServiceAccountToken sets the AutomountServiceAccountToken field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AutomountServiceAccountToken field is set to the value of the last call.
+func (b *NodePlacementApplyConfiguration) WithAutomountServiceAccountToken(value bool) *NodePlacementApplyConfiguration {
+	b.AutomountServiceAccountToken = &value
+	return b
+}
+
+// WithFSGroup sets the FSGroup field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FSGroup field is set to the value of the last call.
+func (b *NodePlacementApplyConfiguration) WithFSGroup(value int64) *NodePlacementApplyConfiguration {
+	b.FSGroup = &value
+	return b
+}
+
+// WithRevisionHistoryLimit sets the RevisionHistoryLimit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RevisionHistoryLimit field is set to the value of the last call.
+func (b *NodePlacementApplyConfiguration) WithRevisionHistoryLimit(value int32) *NodePlacementApplyConfiguration {
+	b.RevisionHistoryLimit = &value
+	return b
+}
+
+// WithTrustedCABundle sets the TrustedCABundle field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TrustedCABundle field is set to the value of the last call.
+func (b *NodePlacementApplyConfiguration) WithTrustedCABundle(value *TrustedCABundleConfigApplyConfiguration) *NodePlacementApplyConfiguration {
+	b.TrustedCABundle = value
+	return b
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2020 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// OperatorBuildApplyConfiguration represents an declarative configuration of the OperatorBuild type for use
+// with apply.
+type OperatorBuildApplyConfiguration struct {
+	GitCommit *string `json:"gitCommit,omitempty"`
+	BuildDate *string `json:"buildDate,omitempty"`
+}
+
+// OperatorBuildApplyConfiguration constructs an declarative configuration of the OperatorBuild type for use with
+// apply.
+func OperatorBuild() *OperatorBuildApplyConfiguration {
+	return &OperatorBuildApplyConfiguration{}
+}
+
+// WithGitCommit sets the GitCommit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GitCommit field is set to the value of the last call.
+func (b *OperatorBuildApplyConfiguration) WithGitCommit(value string) *OperatorBuildApplyConfiguration {
+	b.GitCommit = &value
+	return b
+}
+
+// WithBuildDate sets the BuildDate field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BuildDate field is set to the value of the last call.
+func (b *OperatorBuildApplyConfiguration) WithBuildDate(value string) *OperatorBuildApplyConfiguration {
+	b.BuildDate = &value
+	return b
+}
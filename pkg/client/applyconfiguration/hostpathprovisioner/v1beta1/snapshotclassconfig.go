@@ -0,0 +1,39 @@
+/*
+Copyright 2020 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// SnapshotClassConfigApplyConfiguration represents an declarative configuration of the SnapshotClassConfig type for use
+// with apply.
+type SnapshotClassConfigApplyConfiguration struct {
+	DeletionPolicy *string `json:"deletionPolicy,omitempty"`
+}
+
+// SnapshotClassConfigApplyConfiguration constructs an declarative configuration of the SnapshotClassConfig type for use with
+// apply.
+func SnapshotClassConfig() *SnapshotClassConfigApplyConfiguration {
+	return &SnapshotClassConfigApplyConfiguration{}
+}
+
+// WithDeletionPolicy sets the DeletionPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeletionPolicy field is set to the value of the last call.
+func (b *SnapshotClassConfigApplyConfiguration) WithDeletionPolicy(value string) *SnapshotClassConfigApplyConfiguration {
+	b.DeletionPolicy = &value
+	return b
+}
@@ -19,15 +19,21 @@ limitations under the License.
 package v1beta1
 
 import (
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 )
 
 // StoragePoolApplyConfiguration represents an declarative configuration of the StoragePool type for use
 // with apply.
 type StoragePoolApplyConfiguration struct {
-	Name        *string                       `json:"name,omitempty"`
-	PVCTemplate *v1.PersistentVolumeClaimSpec `json:"pvcTemplate,omitempty"`
-	Path        *string                       `json:"path,omitempty"`
+	Name                       *string                       `json:"name,omitempty"`
+	PVCTemplate                *v1.PersistentVolumeClaimSpec `json:"pvcTemplate,omitempty"`
+	Path                       *string                       `json:"path,omitempty"`
+	CreateStorageClass         *bool                         `json:"createStorageClass,omitempty"`
+	Namespace                  *string                       `json:"namespace,omitempty"`
+	AnnotateProvisionedVolumes *bool                         `json:"annotateProvisionedVolumes,omitempty"`
+	TopologySpreadConstraints  []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	DeploymentStrategy         *appsv1.DeploymentStrategy    `json:"deploymentStrategy,omitempty"`
 }
 
 // StoragePoolApplyConfiguration constructs an declarative configuration of the StoragePool type for use with
@@ -59,3 +65,45 @@ func (b *StoragePoolApplyConfiguration) WithPath(value string) *StoragePoolApply
 	b.Path = &value
 	return b
 }
+
+// WithCreateStorageClass sets the CreateStorageClass field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CreateStorageClass field is set to the value of the last call.
+func (b *StoragePoolApplyConfiguration) WithCreateStorageClass(value bool) *StoragePoolApplyConfiguration {
+	b.CreateStorageClass = &value
+	return b
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *StoragePoolApplyConfiguration) WithNamespace(value string) *StoragePoolApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
+
+// WithAnnotateProvisionedVolumes sets the AnnotateProvisionedVolumes field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AnnotateProvisionedVolumes field is set to the value of the last call.
+func (b *StoragePoolApplyConfiguration) WithAnnotateProvisionedVolumes(value bool) *StoragePoolApplyConfiguration {
+	b.AnnotateProvisionedVolumes = &value
+	return b
+}
+
+// WithTopologySpreadConstraints adds the given value to the TopologySpreadConstraints field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the TopologySpreadConstraints field.
+func (b *StoragePoolApplyConfiguration) WithTopologySpreadConstraints(values ...v1.TopologySpreadConstraint) *StoragePoolApplyConfiguration {
+	for i := range values {
+		b.TopologySpreadConstraints = append(b.TopologySpreadConstraints, values[i])
+	}
+	return b
+}
+
+// WithDeploymentStrategy sets the DeploymentStrategy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeploymentStrategy field is set to the value of the last call.
+func (b *StoragePoolApplyConfiguration) WithDeploymentStrategy(value appsv1.DeploymentStrategy) *StoragePoolApplyConfiguration {
+	b.DeploymentStrategy = &value
+	return b
+}
@@ -30,6 +30,7 @@ type StoragePoolStatusApplyConfiguration struct {
 	DesiredReady  *int                            `json:"desiredReady,omitempty"`
 	CurrentReady  *int                            `json:"currentReady,omitempty"`
 	ClaimStatuses []ClaimStatusApplyConfiguration `json:"claimStatuses,omitempty"`
+	Nodes         []string                        `json:"nodes,omitempty"`
 }
 
 // StoragePoolStatusApplyConfiguration constructs an declarative configuration of the StoragePoolStatus type for use with
@@ -82,3 +83,13 @@ func (b *StoragePoolStatusApplyConfiguration) WithClaimStatuses(values ...*Claim
 	}
 	return b
 }
+
+// WithNodes adds the given value to the Nodes field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Nodes field.
+func (b *StoragePoolStatusApplyConfiguration) WithNodes(values ...string) *StoragePoolStatusApplyConfiguration {
+	for i := range values {
+		b.Nodes = append(b.Nodes, values[i])
+	}
+	return b
+}
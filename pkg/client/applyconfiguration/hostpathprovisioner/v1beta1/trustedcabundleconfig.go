@@ -0,0 +1,57 @@
+/*
+Copyright 2020 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// TrustedCABundleConfigApplyConfiguration represents an declarative configuration of the TrustedCABundleConfig type for use
+// with apply.
+type TrustedCABundleConfigApplyConfiguration struct {
+	ConfigMapName            *string `json:"configMapName,omitempty"`
+	Key                      *string `json:"key,omitempty"`
+	InjectClusterTrustBundle *bool   `json:"injectClusterTrustBundle,omitempty"`
+}
+
+// TrustedCABundleConfigApplyConfiguration constructs an declarative configuration of the TrustedCABundleConfig type for use with
+// apply.
+func TrustedCABundleConfig() *TrustedCABundleConfigApplyConfiguration {
+	return &TrustedCABundleConfigApplyConfiguration{}
+}
+
+// WithConfigMapName sets the ConfigMapName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ConfigMapName field is set to the value of the last call.
+func (b *TrustedCABundleConfigApplyConfiguration) WithConfigMapName(value string) *TrustedCABundleConfigApplyConfiguration {
+	b.ConfigMapName = &value
+	return b
+}
+
+// WithKey sets the Key field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Key field is set to the value of the last call.
+func (b *TrustedCABundleConfigApplyConfiguration) WithKey(value string) *TrustedCABundleConfigApplyConfiguration {
+	b.Key = &value
+	return b
+}
+
+// WithInjectClusterTrustBundle sets the InjectClusterTrustBundle field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the InjectClusterTrustBundle field is set to the value of the last call.
+func (b *TrustedCABundleConfigApplyConfiguration) WithInjectClusterTrustBundle(value bool) *TrustedCABundleConfigApplyConfiguration {
+	b.InjectClusterTrustBundle = &value
+	return b
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2020 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// UnschedulableNodeApplyConfiguration represents an declarative configuration of the UnschedulableNode type for use
+// with apply.
+type UnschedulableNodeApplyConfiguration struct {
+	Node      *string `json:"node,omitempty"`
+	DaemonSet *string `json:"daemonSet,omitempty"`
+	Reason    *string `json:"reason,omitempty"`
+}
+
+// UnschedulableNodeApplyConfiguration constructs an declarative configuration of the UnschedulableNode type for use with
+// apply.
+func UnschedulableNode() *UnschedulableNodeApplyConfiguration {
+	return &UnschedulableNodeApplyConfiguration{}
+}
+
+// WithNode sets the Node field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Node field is set to the value of the last call.
+func (b *UnschedulableNodeApplyConfiguration) WithNode(value string) *UnschedulableNodeApplyConfiguration {
+	b.Node = &value
+	return b
+}
+
+// WithDaemonSet sets the DaemonSet field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DaemonSet field is set to the value of the last call.
+func (b *UnschedulableNodeApplyConfiguration) WithDaemonSet(value string) *UnschedulableNodeApplyConfiguration {
+	b.DaemonSet = &value
+	return b
+}
+
+// WithReason sets the Reason field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Reason field is set to the value of the last call.
+func (b *UnschedulableNodeApplyConfiguration) WithReason(value string) *UnschedulableNodeApplyConfiguration {
+	b.Reason = &value
+	return b
+}
@@ -30,22 +30,44 @@ import (
 func ForKind(kind schema.GroupVersionKind) interface{} {
 	switch kind {
 	// Group=hostpathprovisioner.kubevirt.io, Version=v1beta1
+	case v1beta1.SchemeGroupVersion.WithKind("AdditionalVolume"):
+		return &hostpathprovisionerv1beta1.AdditionalVolumeApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ClaimStatus"):
 		return &hostpathprovisionerv1beta1.ClaimStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("CleanupJobConfig"):
+		return &hostpathprovisionerv1beta1.CleanupJobConfigApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ConditionTransition"):
+		return &hostpathprovisionerv1beta1.ConditionTransitionApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("CSIDriverConfig"):
+		return &hostpathprovisionerv1beta1.CSIDriverConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("HostPathProvisioner"):
 		return &hostpathprovisionerv1beta1.HostPathProvisionerApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("HostPathProvisionerSpec"):
 		return &hostpathprovisionerv1beta1.HostPathProvisionerSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("HostPathProvisionerStatus"):
 		return &hostpathprovisionerv1beta1.HostPathProvisionerStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("IntegrationsConfig"):
+		return &hostpathprovisionerv1beta1.IntegrationsConfigApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("MonitoringConfig"):
+		return &hostpathprovisionerv1beta1.MonitoringConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("NodePlacement"):
 		return &hostpathprovisionerv1beta1.NodePlacementApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("OperatorBuild"):
+		return &hostpathprovisionerv1beta1.OperatorBuildApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PathConfig"):
 		return &hostpathprovisionerv1beta1.PathConfigApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("RBACConfig"):
+		return &hostpathprovisionerv1beta1.RBACConfigApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("SnapshotClassConfig"):
+		return &hostpathprovisionerv1beta1.SnapshotClassConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("StoragePool"):
 		return &hostpathprovisionerv1beta1.StoragePoolApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("StoragePoolStatus"):
 		return &hostpathprovisionerv1beta1.StoragePoolStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("TrustedCABundleConfig"):
+		return &hostpathprovisionerv1beta1.TrustedCABundleConfigApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("UnschedulableNode"):
+		return &hostpathprovisionerv1beta1.UnschedulableNodeApplyConfiguration{}
 
 	}
 	return nil
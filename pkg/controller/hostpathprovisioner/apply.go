@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/csaupgrade"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// fieldManager is the field manager of the server side apply patches of the operator. It is the binary name, which is
+// also the field manager the client side updates of previous versions of the operator were recorded under.
+const fieldManager = "hostpath-provisioner-operator"
+
+// csaFieldManagers are the field managers of the client side updates of previous versions of the operator.
+var csaFieldManagers = sets.New(fieldManager)
+
+// applyObject creates or updates the managed object with a server side apply patch, so the API server tracks which
+// fields the operator owns. Fields other managers, like GitOps controllers, set on the object are left alone. Current
+// is the object as found in the cluster, nil if it does not exist yet.
+func (r *ReconcileHostPathProvisioner) applyObject(ctx context.Context, desired, current client.Object) error {
+	if current != nil {
+		if err := r.upgradeManagedFields(ctx, current); err != nil {
+			return err
+		}
+	}
+	gvk, err := apiutil.GVKForObject(desired, r.scheme)
+	if err != nil {
+		return err
+	}
+	// An apply patch has to have the type, and cannot have the server set fields.
+	desired.GetObjectKind().SetGroupVersionKind(gvk)
+	desired.SetResourceVersion("")
+	desired.SetManagedFields(nil)
+	desired.SetCreationTimestamp(metav1.Time{})
+	return r.client.Patch(ctx, desired, client.Apply, client.FieldOwner(fieldManager), client.ForceOwnership)
+}
+
+// upgradeManagedFields moves the fields set by client side updates of previous versions of the operator to the apply
+// field manager, otherwise the fields the operator no longer sets would never be removed from the object.
+func (r *ReconcileHostPathProvisioner) upgradeManagedFields(ctx context.Context, current client.Object) error {
+	patch, err := csaupgrade.UpgradeManagedFieldsPatch(current, csaFieldManagers, fieldManager)
+	if err != nil || patch == nil {
+		return err
+	}
+	return r.client.Patch(ctx, current, client.RawPatch(types.JSONPatchType, patch))
+}
+
+// upToDateVersion is the resource version of a managed object a reconcile found matching the desired state, and the
+// number of the last reconcile that found it up to date.
+type upToDateVersion struct {
+	resourceVersion string
+	reconcile       uint64
+}
+
+// isUpToDate returns true if a previous reconcile found the current object matching the same desired state, and the
+// object did not change since, so the diff of the object can be skipped. The desired hash annotation changes with the
+// desired state, the resource version with any change made by others.
+func (r *ReconcileHostPathProvisioner) isUpToDate(desired, current client.Object) bool {
+	hash := desired.GetAnnotations()[desiredHashAnnotation]
+	if hash == "" || current.GetAnnotations()[desiredHashAnnotation] != hash {
+		return false
+	}
+	version, ok := r.upToDateVersions.Load(upToDateKey(current))
+	if !ok || version.(upToDateVersion).resourceVersion != current.GetResourceVersion() {
+		return false
+	}
+	r.markUpToDate(current)
+	return true
+}
+
+// markUpToDate records the resource version of the current object once it matches the desired state.
+func (r *ReconcileHostPathProvisioner) markUpToDate(current client.Object) {
+	r.upToDateVersions.Store(upToDateKey(current), upToDateVersion{
+		resourceVersion: current.GetResourceVersion(),
+		reconcile:       r.upToDateReconcile.Load(),
+	})
+}
+
+// pruneUpToDateVersions forgets the objects the current reconcile did not find up to date, like deleted objects and
+// the objects the CR no longer asks for, so their resource versions do not pile up. An object that still exists is
+// diffed again in the next reconcile.
+func (r *ReconcileHostPathProvisioner) pruneUpToDateVersions() {
+	current := r.upToDateReconcile.Load()
+	r.upToDateVersions.Range(func(key, version interface{}) bool {
+		if version.(upToDateVersion).reconcile != current {
+			r.upToDateVersions.Delete(key)
+		}
+		return true
+	})
+}
+
+func upToDateKey(obj client.Object) string {
+	return fmt.Sprintf("%T/%s/%s/%s", obj, obj.GetObjectKind().GroupVersionKind().Kind, obj.GetNamespace(), obj.GetName())
+}
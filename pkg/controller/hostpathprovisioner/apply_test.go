@@ -0,0 +1,340 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	ginkgo "github.com/onsi/ginkgo/v2"
+	gomega "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"kubevirt.io/hostpath-provisioner-operator/version"
+)
+
+var _ = ginkgo.Describe("Controller reconcile loop", func() {
+	ginkgo.Context("server side apply", func() {
+		var (
+			req = reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			dsNN = types.NamespacedName{
+				Name:      fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName),
+				Namespace: testNamespace,
+			}
+		)
+
+		ginkgo.BeforeEach(func() {
+			watchNamespaceFunc = func() (string, error) {
+				return testNamespace, nil
+			}
+			version.VersionStringFunc = func() (string, error) {
+				return versionString, nil
+			}
+		})
+
+		ginkgo.It("Should create the managed objects with the field manager of the operator", func() {
+			_, _, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			for _, obj := range []client.Object{
+				&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: dsNN.Name, Namespace: dsNN.Namespace}},
+				&storagev1.CSIDriver{ObjectMeta: metav1.ObjectMeta{Name: driverName}},
+				&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: ProvisionerServiceAccountNameCsi, Namespace: testNamespace}},
+			} {
+				err := cl.Get(context.TODO(), client.ObjectKeyFromObject(obj), obj)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(obj.GetManagedFields()).To(gomega.ContainElement(gomega.And(
+					gomega.HaveField("Manager", fieldManager),
+					gomega.HaveField("Operation", metav1.ManagedFieldsOperationApply),
+				)), "%T %s", obj, obj.GetName())
+			}
+		})
+
+		ginkgo.It("Should update the managed objects without removing the fields of other managers", func() {
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			ds := &appsv1.DaemonSet{}
+			err := cl.Get(context.TODO(), dsNN, ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			ginkgo.By("Adding a label and an annotation like a GitOps controller would")
+			ds.Labels["gitops.example.com/app"] = "storage"
+			ds.Annotations = map[string]string{"gitops.example.com/sync-wave": "1"}
+			err = cl.Update(context.TODO(), ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			ginkgo.By("Changing the CR, so the DaemonSet is updated")
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.ImagePullPolicy = corev1.PullNever
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			ds = &appsv1.DaemonSet{}
+			err = cl.Get(context.TODO(), dsNN, ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ds.Spec.Template.Spec.Containers[0].ImagePullPolicy).To(gomega.Equal(corev1.PullNever))
+			gomega.Expect(ds.Labels).To(gomega.HaveKeyWithValue("gitops.example.com/app", "storage"))
+			gomega.Expect(ds.Annotations).To(gomega.HaveKeyWithValue("gitops.example.com/sync-wave", "1"))
+			gomega.Expect(ds.Annotations).To(gomega.HaveKey(lastAppliedConfigAnnotation))
+		})
+
+		ginkgo.It("Should move the fields of client side updates to the apply field manager", func() {
+			_, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			sa := &corev1.ServiceAccount{}
+			err := cl.Get(context.TODO(), types.NamespacedName{Name: ProvisionerServiceAccountNameCsi, Namespace: testNamespace}, sa)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			ginkgo.By("Recording the fields as set by a client side update of a previous version")
+			sa.SetManagedFields([]metav1.ManagedFieldsEntry{
+				{
+					Manager:    fieldManager,
+					Operation:  metav1.ManagedFieldsOperationUpdate,
+					APIVersion: "v1",
+					FieldsType: "FieldsV1",
+					FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{"f:k8s-app":{}}}}`)},
+				},
+			})
+			err = cl.Update(context.TODO(), sa)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			err = r.upgradeManagedFields(context.TODO(), sa)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), client.ObjectKeyFromObject(sa), sa)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(sa.GetManagedFields()).To(gomega.HaveLen(1))
+			gomega.Expect(sa.GetManagedFields()[0].Manager).To(gomega.Equal(fieldManager))
+			gomega.Expect(sa.GetManagedFields()[0].Operation).To(gomega.Equal(metav1.ManagedFieldsOperationApply))
+
+			ginkgo.By("Not patching objects without client side update fields")
+			resourceVersion := sa.GetResourceVersion()
+			err = r.upgradeManagedFields(context.TODO(), sa)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(sa.GetResourceVersion()).To(gomega.Equal(resourceVersion))
+		})
+
+		ginkgo.It("Should skip the managed objects that did not change since the previous reconcile", func() {
+			_, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			_, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			ds := &appsv1.DaemonSet{}
+			err = cl.Get(context.TODO(), dsNN, ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ds.Annotations).To(gomega.HaveKey(desiredHashAnnotation))
+			version, ok := r.upToDateVersions.Load(upToDateKey(ds))
+			gomega.Expect(ok).To(gomega.BeTrue())
+			gomega.Expect(version.(upToDateVersion).resourceVersion).To(gomega.Equal(ds.GetResourceVersion()))
+
+			ginkgo.By("Not updating anything in a reconcile without changes")
+			countingClient := &countingUpdatesFakeCtrlRuntimeClient{
+				Client: cl,
+			}
+			r.client = countingClient
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(countingClient.calls).To(gomega.BeZero())
+
+			ginkgo.By("Still fixing an object changed by others")
+			ds.Spec.Template.Spec.Containers[0].ImagePullPolicy = corev1.PullNever
+			err = cl.Update(context.TODO(), ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(countingClient.calls).To(gomega.Equal(1))
+			err = cl.Get(context.TODO(), dsNN, ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ds.Spec.Template.Spec.Containers[0].ImagePullPolicy).ToNot(gomega.Equal(corev1.PullNever))
+		})
+
+		ginkgo.It("Should forget the managed objects that are no longer reconciled", func() {
+			cr := createStoragePoolWithTemplateCr()
+			cr.Spec.StoragePools[0].CreateStorageClass = true
+			cr, r, cl := createDeployedCr(cr)
+			storageClasses, err := r.currentStorageClasses(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(storageClasses).To(gomega.HaveLen(1))
+			sc := &storageClasses[0]
+			ds := &appsv1.DaemonSet{}
+			err = cl.Get(context.TODO(), dsNN, ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, ok := r.upToDateVersions.Load(upToDateKey(sc))
+			gomega.Expect(ok).To(gomega.BeTrue())
+
+			ginkgo.By("Removing the StorageClass of the storage pool")
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.StoragePools[0].CreateStorageClass = false
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, ok = r.upToDateVersions.Load(upToDateKey(sc))
+			gomega.Expect(ok).To(gomega.BeFalse())
+			_, ok = r.upToDateVersions.Load(upToDateKey(ds))
+			gomega.Expect(ok).To(gomega.BeTrue())
+
+			ginkgo.By("Deleting the CR")
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Delete(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			r.upToDateVersions.Range(func(key, _ interface{}) bool {
+				ginkgo.Fail(fmt.Sprintf("%v is still recorded", key))
+				return true
+			})
+		})
+	})
+})
+
+// countingUpdatesFakeCtrlRuntimeClient counts the calls that update or patch an object, status updates are not counted.
+type countingUpdatesFakeCtrlRuntimeClient struct {
+	client.Client
+	calls int
+}
+
+func (p *countingUpdatesFakeCtrlRuntimeClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	p.calls++
+	return p.Client.Update(ctx, obj, opts...)
+}
+
+func (p *countingUpdatesFakeCtrlRuntimeClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	p.calls++
+	return p.Client.Patch(ctx, obj, patch, opts...)
+}
+
+// fakeApplyPatch emulates server side apply, which the fake client does not support. Ownership is only tracked for the
+// labels and annotations: the ones the field manager applied before and no longer applies are removed, the ones of
+// other managers are kept. The rest of the object is replaced by the applied object, except for the status.
+func fakeApplyPatch(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if patch.Type() != types.ApplyPatchType {
+		return c.Patch(ctx, obj, patch, opts...)
+	}
+	patchOptions := &client.PatchOptions{}
+	patchOptions.ApplyOptions(opts)
+	manager := patchOptions.FieldManager
+	if manager == "" {
+		return fmt.Errorf("apply patch of %s without a field manager", obj.GetName())
+	}
+	applied := obj.DeepCopyObject().(client.Object)
+	current := obj.DeepCopyObject().(client.Object)
+	err := c.Get(ctx, client.ObjectKeyFromObject(obj), current)
+	if errors.IsNotFound(err) {
+		obj.SetManagedFields(fakeApplyManagedFields(nil, manager, applied))
+		return c.Create(ctx, obj)
+	} else if err != nil {
+		return err
+	}
+	ownedLabels, ownedAnnotations := fakeOwnedKeys(current, manager)
+	obj.SetLabels(mergeOwnedKeys(current.GetLabels(), applied.GetLabels(), ownedLabels))
+	obj.SetAnnotations(mergeOwnedKeys(current.GetAnnotations(), applied.GetAnnotations(), ownedAnnotations))
+	obj.SetManagedFields(fakeApplyManagedFields(current.GetManagedFields(), manager, applied))
+	obj.SetResourceVersion(current.GetResourceVersion())
+	obj.SetUID(current.GetUID())
+	obj.SetCreationTimestamp(current.GetCreationTimestamp())
+	// The status is not part of an apply patch of the object.
+	if status := reflect.ValueOf(obj).Elem().FieldByName("Status"); status.IsValid() {
+		status.Set(reflect.ValueOf(current).Elem().FieldByName("Status"))
+	}
+	return c.Update(ctx, obj)
+}
+
+// mergeOwnedKeys returns the current values, without the ones that were owned before, with the applied values on top.
+func mergeOwnedKeys(current, applied map[string]string, owned []string) map[string]string {
+	res := make(map[string]string)
+	for k, v := range current {
+		res[k] = v
+	}
+	for _, k := range owned {
+		delete(res, k)
+	}
+	for k, v := range applied {
+		res[k] = v
+	}
+	if len(res) == 0 {
+		return nil
+	}
+	return res
+}
+
+type fakeFieldsV1 struct {
+	Metadata struct {
+		Labels      map[string]struct{} `json:"f:labels,omitempty"`
+		Annotations map[string]struct{} `json:"f:annotations,omitempty"`
+	} `json:"f:metadata"`
+}
+
+// fakeOwnedKeys returns the labels and annotations the manager applied before.
+func fakeOwnedKeys(obj client.Object, manager string) ([]string, []string) {
+	var labels, annotations []string
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Manager != manager || entry.Operation != metav1.ManagedFieldsOperationApply || entry.FieldsV1 == nil {
+			continue
+		}
+		fields := &fakeFieldsV1{}
+		gomega.Expect(json.Unmarshal(entry.FieldsV1.Raw, fields)).To(gomega.Succeed())
+		for k := range fields.Metadata.Labels {
+			labels = append(labels, strings.TrimPrefix(k, "f:"))
+		}
+		for k := range fields.Metadata.Annotations {
+			annotations = append(annotations, strings.TrimPrefix(k, "f:"))
+		}
+	}
+	return labels, annotations
+}
+
+// fakeApplyManagedFields replaces the apply entry of the manager with the labels and annotations of the applied object.
+func fakeApplyManagedFields(entries []metav1.ManagedFieldsEntry, manager string, applied client.Object) []metav1.ManagedFieldsEntry {
+	fields := &fakeFieldsV1{}
+	fields.Metadata.Labels = make(map[string]struct{})
+	for k := range applied.GetLabels() {
+		fields.Metadata.Labels["f:"+k] = struct{}{}
+	}
+	fields.Metadata.Annotations = make(map[string]struct{})
+	for k := range applied.GetAnnotations() {
+		fields.Metadata.Annotations["f:"+k] = struct{}{}
+	}
+	raw, err := json.Marshal(fields)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	res := []metav1.ManagedFieldsEntry{}
+	for _, entry := range entries {
+		if entry.Manager != manager || entry.Operation != metav1.ManagedFieldsOperationApply {
+			res = append(res, entry)
+		}
+	}
+	return append(res, metav1.ManagedFieldsEntry{
+		Manager:    manager,
+		Operation:  metav1.ManagedFieldsOperationApply,
+		APIVersion: applied.GetObjectKind().GroupVersionKind().GroupVersion().String(),
+		FieldsType: "FieldsV1",
+		FieldsV1:   &metav1.FieldsV1{Raw: raw},
+	})
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+const (
+	auditLogVerbosityEnvVarName = "AUDIT_LOG_VERBOSITY"
+	defaultAuditLogVerbosity    = 2
+)
+
+// auditClient logs a structured record of every create, update, patch and delete the operator does, which gives an
+// audit trail of the changes made to the managed resources in each reconcile.
+type auditClient struct {
+	client.Client
+	logger    logr.Logger
+	verbosity int
+}
+
+func newAuditClient(c client.Client, logger logr.Logger) client.Client {
+	return &auditClient{
+		Client:    c,
+		logger:    logger.WithName("audit"),
+		verbosity: getAuditLogVerbosity(),
+	}
+}
+
+// getAuditLogVerbosity returns the log level of the audit records, so they don't flood the logs at the default level.
+func getAuditLogVerbosity() int {
+	if value := os.Getenv(auditLogVerbosityEnvVarName); value != "" {
+		if v, err := strconv.Atoi(value); err == nil && v >= 0 {
+			return v
+		}
+	}
+	return defaultAuditLogVerbosity
+}
+
+func (c *auditClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	err := c.Client.Create(ctx, obj, opts...)
+	c.audit("create", obj, err)
+	return err
+}
+
+func (c *auditClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	err := c.Client.Update(ctx, obj, opts...)
+	c.audit("update", obj, err)
+	return err
+}
+
+func (c *auditClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	err := c.Client.Patch(ctx, obj, patch, opts...)
+	c.audit("patch", obj, err)
+	return err
+}
+
+func (c *auditClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	err := c.Client.Delete(ctx, obj, opts...)
+	c.audit("delete", obj, err)
+	return err
+}
+
+func (c *auditClient) audit(operation string, obj client.Object, err error) {
+	logger := c.logger.V(c.verbosity)
+	if !logger.Enabled() {
+		return
+	}
+	kind := fmt.Sprintf("%T", obj)
+	if gvk, gvkErr := apiutil.GVKForObject(obj, c.Scheme()); gvkErr == nil {
+		kind = gvk.Kind
+	}
+	keysAndValues := []interface{}{
+		"operation", operation,
+		"kind", kind,
+		"name", obj.GetName(),
+		"namespace", obj.GetNamespace(),
+		"generation", obj.GetGeneration(),
+	}
+	if err != nil {
+		keysAndValues = append(keysAndValues, "error", err.Error())
+	}
+	logger.Info("Managed resource changed", keysAndValues...)
+}
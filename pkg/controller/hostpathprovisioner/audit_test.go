@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-logr/logr/funcr"
+	ginkgo "github.com/onsi/ginkgo/v2"
+	gomega "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = ginkgo.Describe("Audit client", func() {
+	var (
+		records []map[string]interface{}
+		cl      client.Client
+	)
+
+	createAuditClient := func(verbosity int) {
+		records = nil
+		logger := funcr.NewJSON(func(obj string) {
+			record := map[string]interface{}{}
+			gomega.Expect(json.Unmarshal([]byte(obj), &record)).To(gomega.Succeed())
+			records = append(records, record)
+		}, funcr.Options{Verbosity: verbosity})
+		cl = newAuditClient(fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(), logger)
+	}
+
+	ginkgo.It("Should log the changes to the managed resources", func() {
+		createAuditClient(defaultAuditLogVerbosity)
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: testNamespace,
+			},
+		}
+		gomega.Expect(cl.Create(context.TODO(), cm)).To(gomega.Succeed())
+		gomega.Expect(cl.Update(context.TODO(), cm)).To(gomega.Succeed())
+		gomega.Expect(cl.Delete(context.TODO(), cm)).To(gomega.Succeed())
+		gomega.Expect(cl.Delete(context.TODO(), cm)).ToNot(gomega.Succeed())
+
+		gomega.Expect(records).To(gomega.HaveLen(4))
+		for i, operation := range []string{"create", "update", "delete", "delete"} {
+			gomega.Expect(records[i]).To(gomega.HaveKeyWithValue("operation", operation))
+			gomega.Expect(records[i]).To(gomega.HaveKeyWithValue("kind", "ConfigMap"))
+			gomega.Expect(records[i]).To(gomega.HaveKeyWithValue("name", "test"))
+			gomega.Expect(records[i]).To(gomega.HaveKeyWithValue("namespace", testNamespace))
+			gomega.Expect(records[i]).To(gomega.HaveKey("generation"))
+		}
+		gomega.Expect(records[2]).ToNot(gomega.HaveKey("error"))
+		gomega.Expect(records[3]).To(gomega.HaveKey("error"))
+	})
+
+	ginkgo.It("Should not log below the audit verbosity", func() {
+		createAuditClient(defaultAuditLogVerbosity - 1)
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: testNamespace,
+			},
+		}
+		gomega.Expect(cl.Create(context.TODO(), cm)).To(gomega.Succeed())
+		gomega.Expect(records).To(gomega.BeEmpty())
+	})
+})
@@ -29,6 +29,8 @@ const (
 	LivenessProbeImageDefault = "registry.k8s.io/sig-storage/livenessprobe:v2.3.0"
 	// SnapshotterImageDefault is the default value of the csi snapshotter side car container image name.
 	SnapshotterImageDefault = "registry.k8s.io/sig-storage/csi-snapshotter:v4.2.1"
+	// ResizerImageDefault is the default value of the csi resizer side car container image name.
+	ResizerImageDefault = "registry.k8s.io/sig-storage/csi-resizer:v1.7.0"
 	// CsiSigStorageProvisionerImageDefault is the default value of the sig storage csi provisioner side car container image name.
 	CsiSigStorageProvisionerImageDefault = "registry.k8s.io/sig-storage/csi-provisioner:v3.4.1"
 
@@ -38,8 +40,18 @@ const (
 	nodeDriverRegistrarImageEnvVarName      = "NODE_DRIVER_REG_IMAGE"
 	livenessProbeImageEnvVarName            = "LIVENESS_PROBE_IMAGE"
 	snapshotterImageEnvVarName              = "CSI_SNAPSHOT_IMAGE"
+	resizerImageEnvVarName                  = "CSI_RESIZER_IMAGE"
 	csiSigStorageProvisionerImageEnvVarName = "CSI_SIG_STORAGE_PROVISIONER_IMAGE"
 	verbosityEnvVarName                     = "VERBOSITY"
+	maxConcurrentReconcilesEnvVarName       = "MAX_CONCURRENT_RECONCILES"
+	diagnosticsEnvVarName                   = "ENABLE_DIAGNOSTICS"
+	reconcilePeriodEnvVarName               = "RECONCILE_PERIOD"
+	metricsBindAddressEnvVarName            = "METRICS_BIND_ADDRESS"
+	metricsTLSCertFileEnvVarName            = "METRICS_TLS_CERT_FILE"
+	metricsTLSKeyFileEnvVarName             = "METRICS_TLS_KEY_FILE"
+	leaseDurationEnvVarName                 = "LEADER_ELECTION_LEASE_DURATION"
+	renewDeadlineEnvVarName                 = "LEADER_ELECTION_RENEW_DEADLINE"
+	retryPeriodEnvVarName                   = "LEADER_ELECTION_RETRY_PERIOD"
 
 	// OperatorServiceAccountName is the name of Service Account used to run the operator.
 	OperatorServiceAccountName = "hostpath-provisioner-operator"
@@ -77,21 +89,124 @@ const (
 	updateResourceFailed  = "UpdateResourceFailed"
 	updateResourceSuccess = "UpdateResourceSuccess"
 
+	deleteResourceFailed  = "DeleteResourceFailed"
+	deleteResourceSuccess = "DeleteResourceSuccess"
+
 	createMessageFailed    = "Failed to create resource %s, %v"
 	createMessageSucceeded = "Successfully created resource %T %s"
 
 	updateMessageFailed    = "Failed to update resource %s, %v"
 	updateMessageSucceeded = "Successfully updated resource %T %s"
 
+	deleteMessageFailed    = "Failed to delete resource %s, %v"
+	deleteMessageSucceeded = "Successfully deleted resource %T %s"
+
 	provisionerHealthy        = "ProvisionerHealthy"
 	provisionerHealthyMessage = "Provisioner Healthy"
 
 	watchNameSpace = "WatchNameSpace"
 
+	operatorMisconfigured      = "OperatorMisconfigured"
+	emptyWatchNamespaceMessage = "%s is empty, set it to the namespace the operator is deployed in"
+
 	deployStarted        = "DeployStarted"
 	deployStartedMessage = "Started Deployment"
 
-	upgradeStarted = "UpgradeStarted"
+	rolloutProgressMessage = "Rolling out: %d/%d nodes updated"
+	// unschedulablePodsMessage is the Progressing condition message of a deployment with pods the scheduler cannot place.
+	unschedulablePodsMessage = "%s, pods cannot be scheduled: %s"
+
+	upgradeStarted         = "UpgradeStarted"
+	unsupportedUpgradePath = "UnsupportedUpgradePath"
+
+	reconcileFailed         = "Reconcile Failed"
+	reconcileTransientError = "ReconcileTransientError"
+	reconcileInvalidSpec    = "ReconcileInvalidSpec"
+
+	conflictingStorageConfig        = "ConflictingStorageConfig"
+	conflictingStorageConfigMessage = "pathConfig and storage pools cannot be both set"
+	missingStorageConfig            = "MissingStorageConfig"
+	missingStorageConfigMessage     = "either pathConfig or storage pools must be set"
+	overlappingStoragePaths         = "OverlappingStoragePaths"
+	overlappingStoragePathsMessage  = "storage pools %s and %s have overlapping paths %s and %s"
+
+	unknownFeatureGates        = "UnknownFeatureGates"
+	unknownFeatureGatesMessage = "Unknown feature gates: %s"
+
+	volumeNodeAffinityConflict                 = "VolumeNodeAffinityConflict"
+	noVolumeNodeAffinityConflict               = "NoVolumeNodeAffinityConflict"
+	volumeNodeAffinityConflictConditionMessage = "%d pods cannot be scheduled because their volumes are bound to other nodes, %d scheduling failures"
+
+	provisioningVerified                   = "ProvisioningVerified"
+	provisioningVerifiedMessage            = "A volume was provisioned, written and read"
+	provisioningVerificationRunning        = "ProvisioningVerificationRunning"
+	provisioningVerificationRunningMessage = "Verifying provisioning from StorageClass %s"
+	provisioningVerificationFailed         = "ProvisioningVerificationFailed"
+	provisioningVerificationFailedMessage  = "The provisioning verification Job failed: %s %s"
+	noVerificationStorageClass             = "NoVerificationStorageClass"
+	noVerificationStorageClassMessage      = "No storage pool has createStorageClass set, there is no StorageClass to verify the provisioning with"
+
+	csiDriverUnmanaged               = "CSIDriverUnmanaged"
+	csiDriverUnmanagedMessage        = "CSIDriver %s is managed outside of the operator, not reconciling it"
+	csiDriverUnmanagedMissingMessage = "CSIDriver %s is managed outside of the operator and does not exist"
+
+	notReadyWithinGracePeriod        = "NotReady"
+	notReadyWithinGracePeriodMessage = "DaemonSets are not ready, marking degraded if they are not ready within %s"
+
+	clockSkewDetected        = "ClockSkewDetected"
+	clockSkewDetectedMessage = "Condition heartbeats are %s ahead of the operator clock, check the clock of the nodes"
+
+	// sccPending and sccDenied are the Degraded reasons of a SecurityContextConstraints that cannot be created or
+	// updated, the pods of the DaemonSets cannot start without it. Denied means the operator lacks the permissions.
+	sccPending       = "SCCPending"
+	sccDenied        = "SCCDenied"
+	sccFailedMessage = "Unable to reconcile SecurityContextConstraints %s: %v"
+
+	// insufficientPermissions is the Degraded reason of an operator that lacks some of the permissions it needs.
+	insufficientPermissions        = "InsufficientPermissions"
+	insufficientPermissionsMessage = "The operator is missing permissions: %s"
+
+	versionSkew                = "VersionSkew"
+	versionSkewMessage         = "Not all pods run the image of their DaemonSet, check the rollout of the DaemonSets: %s"
+	versionSkewWorkloadMessage = "%s runs %s instead of %s"
+
+	noSchedulableNodes        = "NoSchedulableNodes"
+	noSchedulableNodesMessage = "DaemonSets %s match no nodes, check the workload node selector and affinity"
+
+	storageClassConflict        = "StorageClassConflict"
+	storageClassConflictMessage = "StorageClass %s already exists and is not managed by the operator"
+
+	snapshotClassConflict           = "SnapshotClassConflict"
+	snapshotClassConflictMessage    = "VolumeSnapshotClass %s already exists and is not managed by the operator"
+	snapshotClassUnavailable        = "SnapshotClassUnavailable"
+	snapshotClassUnavailableMessage = "Snapshotting is enabled but the VolumeSnapshotClass CRD is not installed"
+
+	storageProfileUnavailable        = "StorageProfileUnavailable"
+	storageProfileUnavailableMessage = "The CDI integration is enabled but the StorageProfile CRD is not installed"
+
+	adoptResourceFailed   = "AdoptResourceFailed"
+	adoptResourceSuccess  = "AdoptResourceSuccess"
+	adoptMessageFailed    = "Refusing to adopt resource %s, it is labeled as %s"
+	adoptMessageSucceeded = "Successfully adopted resource %T %s"
+
+	ownerReferenceRepaired        = "OwnerReferenceRepaired"
+	ownerReferenceRepairedMessage = "Restored the controller owner reference of resource %T %s"
+
+	conditionChanged        = "ConditionChanged"
+	conditionChangedMessage = "Condition %s changed to %s, reason %s: %s"
+
+	legacyProvisionerRemoved        = "LegacyProvisionerRemoved"
+	legacyProvisionerRemovedMessage = "Removed legacy provisioner DaemonSet %s, pathConfig is no longer set"
+
+	legacyResourceRemoved        = "LegacyResourceRemoved"
+	legacyResourceRemovedMessage = "Removed resource %T %s left over from version %s"
+
+	serviceAccountNotFound        = "ServiceAccountNotFound"
+	serviceAccountNotFoundMessage = "ServiceAccount %s does not exist in namespace %s"
+
+	daemonSetRecreatedForSelectorChange        = "DaemonSetRecreatedForSelectorChange"
+	daemonSetRecreatedForSelectorChangeMessage = "Recreated DaemonSet %s, the selector of the existing DaemonSet does not match"
 
-	reconcileFailed = "Reconcile Failed"
+	rbacDriftCorrected        = "RBACDriftCorrected"
+	rbacDriftCorrectedMessage = "Corrected the rules of %T %s, they were changed outside of the operator"
 )
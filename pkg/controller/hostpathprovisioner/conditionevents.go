@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"fmt"
+
+	conditions "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	hostpathprovisionerv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
+)
+
+// unhealthyConditionStatus is the status of the conditions that reports a problem, the transitions to it are emitted
+// as warning events.
+var unhealthyConditionStatus = map[conditions.ConditionType]corev1.ConditionStatus{
+	conditions.ConditionAvailable:       corev1.ConditionFalse,
+	conditions.ConditionDegraded:        corev1.ConditionTrue,
+	ConditionUnknownFeatureGates:        corev1.ConditionTrue,
+	ConditionVolumeNodeAffinityConflict: corev1.ConditionTrue,
+	ConditionProvisioningVerified:       corev1.ConditionFalse,
+}
+
+func shouldEmitConditionEvents(cr *hostpathprovisionerv1.HostPathProvisioner) bool {
+	return cr.Spec.Monitoring != nil && cr.Spec.Monitoring.EmitConditionEvents
+}
+
+// emitConditionEvents emits an event for every condition of the CR that was added, or whose status differs from the
+// previous conditions. Changes of only the reason or message are not emitted.
+func (r *ReconcileHostPathProvisioner) emitConditionEvents(cr *hostpathprovisionerv1.HostPathProvisioner, previous []conditions.Condition) {
+	if !shouldEmitConditionEvents(cr) {
+		return
+	}
+	for _, condition := range cr.Status.Conditions {
+		if current := conditions.FindStatusCondition(previous, condition.Type); current != nil && current.Status == condition.Status {
+			continue
+		}
+		eventType := corev1.EventTypeNormal
+		if status, ok := unhealthyConditionStatus[condition.Type]; ok && status == condition.Status {
+			eventType = corev1.EventTypeWarning
+		}
+		r.recorder.Event(cr, eventType, conditionChanged, fmt.Sprintf(conditionChangedMessage, condition.Type, condition.Status, condition.Reason, condition.Message))
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ginkgo "github.com/onsi/ginkgo/v2"
+	gomega "github.com/onsi/gomega"
+	conditions "github.com/openshift/custom-resource-status/conditions/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hppv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
+	"kubevirt.io/hostpath-provisioner-operator/version"
+)
+
+var _ = ginkgo.Describe("Controller reconcile loop", func() {
+	ginkgo.Context("condition events", func() {
+		var (
+			req = reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+		)
+
+		ginkgo.BeforeEach(func() {
+			watchNamespaceFunc = func() (string, error) {
+				return testNamespace, nil
+			}
+			version.VersionStringFunc = func() (string, error) {
+				return versionString, nil
+			}
+		})
+
+		conditionEvents := func(r *ReconcileHostPathProvisioner) []string {
+			recorder, ok := r.recorder.(*record.FakeRecorder)
+			gomega.Expect(ok).To(gomega.BeTrue())
+			events := make([]string, 0)
+			for len(recorder.Events) > 0 {
+				if event := <-recorder.Events; strings.Contains(event, conditionChanged) {
+					events = append(events, event)
+				}
+			}
+			return events
+		}
+
+		setEmitConditionEvents := func(cl client.Client, emit bool) {
+			cr := &hppv1.HostPathProvisioner{}
+			err := cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.Monitoring = &hppv1.MonitoringConfig{
+				EmitConditionEvents: emit,
+			}
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		}
+
+		setCsiPodsReady := func(cl client.Client, ready int32) {
+			ds := &appsv1.DaemonSet{}
+			err := cl.Get(context.TODO(), types.NamespacedName{Name: fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName), Namespace: testNamespace}, ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			ds.Status.NumberReady = ready
+			err = cl.Status().Update(context.TODO(), ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		}
+
+		getCondition := func(cl client.Client, conditionType conditions.ConditionType) *conditions.Condition {
+			cr := &hppv1.HostPathProvisioner{}
+			err := cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			return conditions.FindStatusCondition(cr.Status.Conditions, conditionType)
+		}
+
+		ginkgo.It("Should emit an event only when the status of a condition changes", func() {
+			_, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			setEmitConditionEvents(cl, true)
+			conditionEvents(r)
+
+			ginkgo.By("Not emitting events while the conditions don't change")
+			_, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(conditionEvents(r)).To(gomega.BeEmpty())
+
+			ginkgo.By("Emitting warnings when the DaemonSet is not ready anymore")
+			setCsiPodsReady(cl, 1)
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			available := getCondition(cl, conditions.ConditionAvailable)
+			degraded := getCondition(cl, conditions.ConditionDegraded)
+			gomega.Expect(conditionEvents(r)).To(gomega.ConsistOf(
+				fmt.Sprintf("%s %s %s", corev1.EventTypeWarning, conditionChanged, fmt.Sprintf(conditionChangedMessage, available.Type, available.Status, available.Reason, available.Message)),
+				fmt.Sprintf("%s %s %s", corev1.EventTypeWarning, conditionChanged, fmt.Sprintf(conditionChangedMessage, degraded.Type, degraded.Status, degraded.Reason, degraded.Message)),
+			))
+
+			ginkgo.By("Not emitting the events again while the DaemonSet stays not ready")
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(conditionEvents(r)).To(gomega.BeEmpty())
+
+			ginkgo.By("Emitting normal events when the DaemonSet is ready again")
+			setCsiPodsReady(cl, 2)
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			events := conditionEvents(r)
+			gomega.Expect(events).To(gomega.HaveLen(2))
+			for _, event := range events {
+				gomega.Expect(event).To(gomega.HavePrefix(corev1.EventTypeNormal))
+			}
+		})
+
+		ginkgo.It("Should not emit condition events unless emitConditionEvents is set", func() {
+			_, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			setEmitConditionEvents(cl, false)
+			conditionEvents(r)
+			setCsiPodsReady(cl, 1)
+			_, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(getCondition(cl, conditions.ConditionAvailable).Status).To(gomega.Equal(corev1.ConditionFalse))
+			gomega.Expect(conditionEvents(r)).To(gomega.BeEmpty())
+		})
+	})
+})
@@ -18,10 +18,16 @@ package hostpathprovisioner
 
 import (
 	"context"
+	goerrors "errors"
 	"fmt"
 	"os"
 	"reflect"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -31,6 +37,7 @@ import (
 	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
 	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	storagev1 "k8s.io/api/storage/v1"
@@ -51,6 +58,7 @@ import (
 
 	hostpathprovisionerv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
 	"kubevirt.io/hostpath-provisioner-operator/pkg/monitoring/metrics"
+	"kubevirt.io/hostpath-provisioner-operator/pkg/util"
 	"kubevirt.io/hostpath-provisioner-operator/pkg/util/cryptopolicy"
 	"kubevirt.io/hostpath-provisioner-operator/version"
 )
@@ -65,15 +73,25 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
-	// 0 is our 'something bad is going on' value for alert to start firing, so can't default to that
-	metrics.SetReadyGaugeValue(-1)
 }
 
 const (
-	snapshotFeatureGate = "Snapshotting"
-	hppFinalizer        = "finalizer.delete.hostpath-provisioner"
+	snapshotFeatureGate        = "Snapshotting"
+	volumeExpansionFeatureGate = "VolumeExpansion"
+	hppFinalizer               = "finalizer.delete.hostpath-provisioner"
+	lastReconcileTimeInterval  = time.Minute
+	// Heartbeats are only refreshed every reconcile, anything further ahead than this is not normal drift.
+	maxClockSkew = 5 * time.Minute
+	// The operator reconciles at least this often if RECONCILE_PERIOD is not set.
+	defaultReconcilePeriod = 10 * time.Minute
+	// volumeNodeAffinityConflictFeatureGate reports the pods that cannot be scheduled because their volumes are bound
+	// to other nodes in the VolumeNodeAffinityConflict condition.
+	volumeNodeAffinityConflictFeatureGate = "VolumeNodeAffinityConflictDetection"
 )
 
+// knownFeatureGates are the feature gates the operator acts on.
+var knownFeatureGates = []string{snapshotFeatureGate, volumeExpansionFeatureGate, volumeNodeAffinityConflictFeatureGate}
+
 func isErrCacheNotStarted(err error) bool {
 	if err == nil {
 		return false
@@ -100,44 +118,58 @@ func newReconciler(mgr manager.Manager) reconcile.Reconciler {
 	}
 
 	return &ReconcileHostPathProvisioner{
-		client:   mgr.GetClient(),
-		scheme:   mgrScheme,
-		recorder: mgr.GetEventRecorderFor("operator-controller"),
-		Log:      log,
+		client:          newAuditClient(mgr.GetClient(), log),
+		scheme:          mgrScheme,
+		recorder:        mgr.GetEventRecorderFor("operator-controller"),
+		Log:             log,
+		reconcilePeriod: getReconcilePeriod(),
+		accessReviewer:  &selfSubjectAccessReviewer{client: mgr.GetClient()},
+		apiReader:       mgr.GetAPIReader(),
+	}
+}
+
+func getControllerOptions(r reconcile.Reconciler) controller.Options {
+	return controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: getMaxConcurrentReconciles(),
+	}
+}
+
+// getMaxConcurrentReconciles returns the number of concurrent reconciles from the environment, defaults to 1.
+// The work queue never reconciles the same request concurrently, and all the owned resources map to the single HPP CR,
+// so this doesn't break the single HPP invariant.
+func getMaxConcurrentReconciles() int {
+	if value := os.Getenv(maxConcurrentReconcilesEnvVarName); value != "" {
+		if v, err := strconv.Atoi(value); err == nil && v > 0 {
+			return v
+		}
+		log.Info("Invalid max concurrent reconciles, using 1", maxConcurrentReconcilesEnvVarName, value)
+	}
+	return 1
+}
+
+// getReconcilePeriod returns the period after which the HPP is reconciled again without an event, as a safety net for
+// missed events. It defaults to 10 minutes, 0 turns the periodic reconcile off.
+func getReconcilePeriod() time.Duration {
+	if value := os.Getenv(reconcilePeriodEnvVarName); value != "" {
+		if v, err := time.ParseDuration(value); err == nil && v >= 0 {
+			return v
+		}
+		log.Info("Invalid reconcile period, using the default", reconcilePeriodEnvVarName, value, "default", defaultReconcilePeriod)
 	}
+	return defaultReconcilePeriod
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	// Create a new controller
-	c, err := controller.New("hostpathprovisioner-controller", mgr, controller.Options{Reconciler: r})
+	c, err := controller.New("hostpathprovisioner-controller", mgr, getControllerOptions(r))
 	if err != nil {
 		return err
 	}
 
 	// mapFn will be used to map reconcile requests to the HPP for resources that don't have an ownerRef
-	mapFn := handler.MapFunc(func(_ context.Context, o client.Object) []reconcile.Request {
-		if val, ok := o.GetLabels()["k8s-app"]; ok && val == MultiPurposeHostPathProvisionerName {
-			hppList, err := getHppList(mgr.GetClient())
-			if err != nil {
-				log.Error(err, "Error getting HPPs")
-				return nil
-			}
-			if size := len(hppList.Items); size != 1 {
-				log.Info("There should be exactly one HPP instance")
-				return nil
-			}
-
-			return []reconcile.Request{
-				{
-					NamespacedName: types.NamespacedName{
-						Name: hppList.Items[0].Name,
-					},
-				},
-			}
-		}
-		return nil
-	})
+	mapFn := hppMapFunc(mgr.GetClient())
 
 	// handleAPIServer will be used to handle APIServer Watch triggering
 	handleAPIServer := handler.MapFunc(handleAPIServerFunc)
@@ -173,6 +205,11 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	err = c.Watch(source.Kind(mgr.GetCache(), &storagev1.StorageClass{}), handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &hostpathprovisionerv1.HostPathProvisioner{}, handler.OnlyControllerOwner()))
+	if err != nil {
+		return err
+	}
+
 	if err := c.Watch(source.Kind(mgr.GetCache(), &storagev1.CSIDriver{}), handler.EnqueueRequestsFromMapFunc(mapFn)); err != nil {
 		return err
 	}
@@ -191,28 +228,52 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	if err := c.Watch(source.Kind(mgr.GetCache(), &rbacv1.RoleBinding{}), handler.EnqueueRequestsFromMapFunc(mapFn)); err != nil {
 		return err
 	}
+	if err := c.Watch(source.Kind(mgr.GetCache(), &corev1.Namespace{}), handler.EnqueueRequestsFromMapFunc(hppNamespaceMapFunc(mgr.GetClient()))); err != nil {
+		return err
+	}
+	if err := c.Watch(source.Kind(mgr.GetCache(), &corev1.ServiceAccount{}), handler.EnqueueRequestsFromMapFunc(hppServiceAccountMapFunc(mgr.GetClient()))); err != nil {
+		return err
+	}
 	if err := c.Watch(source.Kind(mgr.GetCache(), &corev1.Service{}), handler.EnqueueRequestsFromMapFunc(mapFn)); err != nil {
 		return err
 	}
+	// The storage pool PVCs and cleanup jobs don't have an ownerRef, but are labeled like the other managed resources.
+	if err := c.Watch(source.Kind(mgr.GetCache(), &corev1.PersistentVolumeClaim{}), handler.EnqueueRequestsFromMapFunc(mapFn)); err != nil {
+		return err
+	}
+	if err := c.Watch(source.Kind(mgr.GetCache(), &batchv1.Job{}), handler.EnqueueRequestsFromMapFunc(mapFn)); err != nil {
+		return err
+	}
+	if err := c.Watch(source.Kind(mgr.GetCache(), &corev1.ConfigMap{}), handler.EnqueueRequestsFromMapFunc(hppTrustedCABundleMapFunc(mgr.GetClient()))); err != nil {
+		return err
+	}
+	// Events are not labeled, only the scheduling failures of pods with a volume node affinity conflict are mapped.
+	if err := c.Watch(source.Kind(mgr.GetCache(), &corev1.Event{}), handler.EnqueueRequestsFromMapFunc(hppVolumeNodeAffinityConflictMapFunc(mgr.GetClient()))); err != nil {
+		return err
+	}
 
-	if used, err := r.(*ReconcileHostPathProvisioner).checkSCCUsed(); used || isErrCacheNotStarted(err) {
+	// A missing SCC, APIServer or Proxy kind should not stop the prometheus resources from being watched.
+	if used, err := r.(*ReconcileHostPathProvisioner).checkSCCUsed(context.TODO()); used || isErrCacheNotStarted(err) {
 		if err := c.Watch(source.Kind(mgr.GetCache(), &secv1.SecurityContextConstraints{}), handler.EnqueueRequestsFromMapFunc(mapFn)); err != nil {
-			if meta.IsNoMatchError(err) {
-				log.Info("Not watching SecurityContextConstraints")
-				return nil
+			if !meta.IsNoMatchError(err) {
+				return err
 			}
-			return err
+			log.Info("Not watching SecurityContextConstraints")
+		} else if err := c.Watch(source.Kind(mgr.GetCache(), &ocpconfigv1.APIServer{}), handler.EnqueueRequestsFromMapFunc(handleAPIServer)); err != nil {
+			if !meta.IsNoMatchError(err) {
+				return err
+			}
+			log.Info("Not watching APIServer")
 		}
-		if err := c.Watch(source.Kind(mgr.GetCache(), &ocpconfigv1.APIServer{}), handler.EnqueueRequestsFromMapFunc(handleAPIServer)); err != nil {
-			if meta.IsNoMatchError(err) {
-				log.Info("Not watching APIServer")
-				return nil
+		if err := c.Watch(source.Kind(mgr.GetCache(), &ocpconfigv1.Proxy{}), handler.EnqueueRequestsFromMapFunc(hppProxyMapFunc(mgr.GetClient()))); err != nil {
+			if !meta.IsNoMatchError(err) {
+				return err
 			}
-			return err
+			log.Info("Not watching Proxy")
 		}
 	}
 
-	if used, err := r.(*ReconcileHostPathProvisioner).checkPrometheusUsed(); used || isErrCacheNotStarted(err) {
+	if used, err := r.(*ReconcileHostPathProvisioner).checkPrometheusUsed(context.TODO()); used || isErrCacheNotStarted(err) {
 		if err := c.Watch(source.Kind(mgr.GetCache(), &promv1.PrometheusRule{}), handler.EnqueueRequestsFromMapFunc(mapFn)); err != nil {
 			if meta.IsNoMatchError(err) {
 				log.Info("Not watching PrometheusRules")
@@ -232,6 +293,134 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	return nil
 }
 
+// hppMapFunc returns a map function that maps the managed resources, recognized by the k8s-app label, to a reconcile
+// request of the HPP. There should be exactly one HPP in the cluster.
+func hppMapFunc(c client.Client) handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []reconcile.Request {
+		if val, ok := o.GetLabels()["k8s-app"]; ok && val == MultiPurposeHostPathProvisionerName {
+			hppList, err := getHppList(ctx, c)
+			if err != nil {
+				log.Error(err, "Error getting HPPs")
+				return nil
+			}
+			if size := len(hppList.Items); size != 1 {
+				log.Info("There should be exactly one HPP instance")
+				return nil
+			}
+
+			return []reconcile.Request{
+				{
+					NamespacedName: types.NamespacedName{
+						Name: hppList.Items[0].Name,
+					},
+				},
+			}
+		}
+		return nil
+	}
+}
+
+// hppNamespaceMapFunc returns a map function that maps namespaces to a reconcile request of the HPP, if the HPP selects
+// namespaces for its RBAC resources. Namespaces are not labeled by the operator, so they can't be recognized by the k8s-app label.
+func hppNamespaceMapFunc(c client.Client) handler.MapFunc {
+	return func(ctx context.Context, _ client.Object) []reconcile.Request {
+		hppList, err := getHppList(ctx, c)
+		if err != nil {
+			log.Error(err, "Error getting HPPs")
+			return nil
+		}
+		if size := len(hppList.Items); size != 1 {
+			return nil
+		}
+		if hppList.Items[0].Spec.RBAC == nil || hppList.Items[0].Spec.RBAC.NamespaceSelector == nil {
+			return nil
+		}
+		return []reconcile.Request{
+			{
+				NamespacedName: types.NamespacedName{
+					Name: hppList.Items[0].Name,
+				},
+			},
+		}
+	}
+}
+
+// hppServiceAccountMapFunc returns a map function that maps the external ServiceAccount of the HPP to a reconcile request
+// of the HPP, so creating a missing ServiceAccount clears the Degraded condition. It is not labeled by the operator either.
+func hppServiceAccountMapFunc(c client.Client) handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []reconcile.Request {
+		hppList, err := getHppList(ctx, c)
+		if err != nil {
+			log.Error(err, "Error getting HPPs")
+			return nil
+		}
+		if size := len(hppList.Items); size != 1 {
+			return nil
+		}
+		if hppList.Items[0].Spec.Workload.ServiceAccountName != o.GetName() {
+			return nil
+		}
+		return []reconcile.Request{
+			{
+				NamespacedName: types.NamespacedName{
+					Name: hppList.Items[0].Name,
+				},
+			},
+		}
+	}
+}
+
+// hppProxyMapFunc returns a map function that maps the cluster wide Proxy of OpenShift to a reconcile request of the HPP,
+// so proxy changes reach the provisioner pods. The Proxy is not labeled by the operator either.
+func hppProxyMapFunc(c client.Client) handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []reconcile.Request {
+		if o.GetName() != clusterProxyName {
+			return nil
+		}
+		hppList, err := getHppList(ctx, c)
+		if err != nil {
+			log.Error(err, "Error getting HPPs")
+			return nil
+		}
+		if size := len(hppList.Items); size != 1 {
+			return nil
+		}
+		return []reconcile.Request{
+			{
+				NamespacedName: types.NamespacedName{
+					Name: hppList.Items[0].Name,
+				},
+			},
+		}
+	}
+}
+
+// hppTrustedCABundleMapFunc reconciles the HostPathProvisioner when the ConfigMap of its trusted CA bundle changes, so
+// the pods are restarted with the new bundle.
+func hppTrustedCABundleMapFunc(c client.Client) handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []reconcile.Request {
+		hppList, err := getHppList(ctx, c)
+		if err != nil {
+			log.Error(err, "Error getting HPPs")
+			return nil
+		}
+		if size := len(hppList.Items); size != 1 {
+			return nil
+		}
+		hpp := &hppList.Items[0]
+		if hpp.Spec.Workload.TrustedCABundle == nil || o.GetName() != getTrustedCABundleConfigMapName(hpp) {
+			return nil
+		}
+		return []reconcile.Request{
+			{
+				NamespacedName: types.NamespacedName{
+					Name: hpp.Name,
+				},
+			},
+		}
+	}
+}
+
 // blank assignment to verify that ReconcileHostPathProvisioner implements reconcile.Reconciler
 var _ reconcile.Reconciler = &ReconcileHostPathProvisioner{}
 
@@ -243,18 +432,33 @@ type ReconcileHostPathProvisioner struct {
 	scheme   *runtime.Scheme
 	recorder record.EventRecorder
 	Log      logr.Logger
+	// reconcilePeriod is the longest time between two reconciles of the HPP, 0 only reconciles on events.
+	reconcilePeriod time.Duration
+	// upToDateVersions are the resource versions of the managed objects a reconcile found matching the desired state.
+	upToDateVersions sync.Map
+	// upToDateReconcile is the number of the current reconcile of the managed objects, the objects upToDateVersions has
+	// from earlier reconciles are pruned.
+	upToDateReconcile atomic.Uint64
+	// apiReader reads the objects the cache does not hold, like the pods of other workloads.
+	apiReader client.Reader
+	// accessReviewer checks the permissions of the operator, the check is skipped without one.
+	accessReviewer accessReviewer
+	// permissionsGrantedAt is the time in unix nanoseconds the operator was last found to have all its permissions.
+	permissionsGrantedAt atomic.Int64
+	// reportedEvents are the messages of the events only emitted when the state they report starts, by reason.
+	reportedEvents sync.Map
 }
 
 // Reconcile reads that state of the cluster for a HostPathProvisioner object and makes changes based on the state read
 // and what is in the HostPathProvisioner.Spec
 // The Controller will requeue the Request to be processed again if the returned error is non-nil or
 // Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
-func (r *ReconcileHostPathProvisioner) Reconcile(context context.Context, request reconcile.Request) (reconcile.Result, error) {
+func (r *ReconcileHostPathProvisioner) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	reqLogger := r.Log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	reqLogger.V(3).Info("Reconciling HostPathProvisioner")
 
 	// Checks that only a single HPP instance exists
-	hppList, err := getHppList(r.client)
+	hppList, err := getHppList(ctx, r.client)
 	if err != nil {
 		reqLogger.Error(err, "Error getting HPPs")
 		return reconcile.Result{}, err
@@ -269,10 +473,12 @@ func (r *ReconcileHostPathProvisioner) Reconcile(context context.Context, reques
 	if err != nil {
 		return reconcile.Result{}, err
 	}
+	buildInfo := version.BuildInfoFunc()
+	metrics.SetBuildInfo(buildInfo.GitCommit, buildInfo.BuildDate)
 
 	// Fetch the HostPathProvisioner instance
 	cr := &hostpathprovisionerv1.HostPathProvisioner{}
-	err = r.client.Get(context, request.NamespacedName, cr)
+	err = r.client.Get(ctx, request.NamespacedName, cr)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
@@ -296,55 +502,80 @@ func (r *ReconcileHostPathProvisioner) Reconcile(context context.Context, reques
 		// Not an issue if progress is still ongoing
 		metrics.SetReadyGaugeValue(0)
 	}
+	// Check before the heartbeats are refreshed by this reconcile.
+	r.checkClockSkew(reqLogger, cr)
 
 	namespace, err := watchNamespaceFunc()
+	reason := watchNameSpace
+	if err == nil && namespace == "" {
+		// The managed namespaced objects would be created without a namespace, and fail obscurely.
+		err = fmt.Errorf(emptyWatchNamespaceMessage, k8sutil.WatchNamespaceEnvVar)
+		reason = operatorMisconfigured
+	}
 	if err != nil {
-		MarkCrFailed(cr, watchNameSpace, err.Error())
-		r.recorder.Event(cr, corev1.EventTypeWarning, watchNameSpace, err.Error())
-		err2 := r.client.Update(context, cr)
+		statusBase := cr.DeepCopy()
+		MarkCrFailed(cr, reason, err.Error())
+		r.recorder.Event(cr, corev1.EventTypeWarning, reason, err.Error())
+		err2 := r.updateCrStatus(ctx, statusBase, cr)
 		if err2 != nil {
 			reqLogger.Error(err2, "Unable to update CR to failed state")
 		}
 		// Error reading the object - requeue the request.
 		return reconcile.Result{}, err
 	}
+	metrics.SetOperatorNamespace(namespace)
 
 	if cr.GetDeletionTimestamp() != nil {
-		if err := r.cleanDeployments(reqLogger, cr, namespace); err != nil {
+		// The verification Job is labeled like the cleanup Jobs, remove it before waiting for those.
+		if err := r.deleteProvisioningVerification(ctx, reqLogger, cr, namespace); err != nil {
 			return reconcile.Result{}, err
 		}
-		if res, err := r.reconcileCleanup(reqLogger, cr, namespace, 0); err != nil || res.RequeueAfter == time.Second {
+		if err := r.cleanDeployments(ctx, reqLogger, cr); err != nil {
+			return reconcile.Result{}, err
+		}
+		if res, err := r.reconcileCleanup(ctx, reqLogger, cr, namespace, 0); err != nil || res.RequeueAfter == time.Second {
 			return res, err
 		}
 		reqLogger.Info("Deleting SecurityContextConstraint", "SecurityContextConstraints", MultiPurposeHostPathProvisionerName)
-		if err := r.deleteSCC(MultiPurposeHostPathProvisionerName); err != nil {
+		if err := r.deleteSCC(ctx, MultiPurposeHostPathProvisionerName); err != nil {
 			reqLogger.Error(err, "Unable to delete SecurityContextConstraints")
 			// TODO, should we return and in essence keep retrying, and thus never be able to delete the CR if deleting the SCC fails, or
 			// should be not return and allow the CR to be deleted but without deleting the SCC if that fails.
 			return reconcile.Result{}, err
 		}
-		if err := r.deleteSCC(fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)); err != nil {
+		if err := r.deleteSCC(ctx, fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)); err != nil {
 			reqLogger.Error(err, "Unable to delete CSI SecurityContextConstraints")
 			// TODO, should we return and in essence keep retrying, and thus never be able to delete the CR if deleting the SCC fails, or
 			// should be not return and allow the CR to be deleted but without deleting the SCC if that fails.
 			return reconcile.Result{}, err
 		}
-		if err := r.deletePrometheusResources(namespace); err != nil {
+		if err := r.deletePrometheusResources(ctx, namespace); err != nil {
 			reqLogger.Error(err, "Unable to delete Prometheus Infra (PrometheusRule, ServiceMonitor, RBAC)")
 			return reconcile.Result{}, err
 		}
-		if res, err := r.deleteAllRbac(reqLogger, namespace); err != nil {
+		if res, err := r.deleteAllRbac(ctx, reqLogger, cr, namespace); err != nil {
 			return res, err
 		}
 		reqLogger.Info("Deleting CSIDriver", "CSIDriver", MultiPurposeHostPathProvisionerName)
-		if err := r.deleteCSIDriver(); err != nil {
+		if err := r.deleteCSIDriver(ctx, cr); err != nil {
 			reqLogger.Error(err, "Unable to delete CSIDriver")
 			return reconcile.Result{}, err
 		}
+		if err := r.deleteSnapshotClass(ctx, reqLogger, cr); err != nil {
+			reqLogger.Error(err, "Unable to delete VolumeSnapshotClass")
+			return reconcile.Result{}, err
+		}
+		if err := r.deleteStorageProfiles(ctx, reqLogger, cr); err != nil {
+			reqLogger.Error(err, "Unable to delete StorageProfiles")
+			return reconcile.Result{}, err
+		}
+		// None of the managed objects are left.
+		r.upToDateReconcile.Add(1)
+		r.pruneUpToDateVersions()
 		RemoveFinalizer(cr, hppFinalizer)
 
 		// Update CR
-		err = r.client.Update(context, cr)
+		err = r.client.Update(ctx, cr)
 		if err != nil {
 			reqLogger.Error(err, "Unable to remove finalizer from CR")
 			return reconcile.Result{}, err
@@ -354,22 +585,47 @@ func (r *ReconcileHostPathProvisioner) Reconcile(context context.Context, reques
 
 	currentCopy := cr.DeepCopy()
 	// Add finalizer for this CR
-	if err := r.addFinalizer(reqLogger, cr); err != nil {
+	if err := r.addFinalizer(ctx, reqLogger, cr); err != nil {
 		return reconcile.Result{}, err
 	}
+	// The finalizer is written with an update of the CR, the status is patched separately from here on.
+	statusBase := cr.DeepCopy()
 
+	// Make a misconfigured WATCH_NAMESPACE visible.
+	cr.Status.OperatorNamespace = namespace
 	cr.Status.OperatorVersion = versionString
+	cr.Status.OperatorBuild = getOperatorBuild(buildInfo)
 	cr.Status.TargetVersion = versionString
 	canUpgrade, err := canUpgrade(cr.Status.ObservedVersion, versionString)
 	if err != nil {
 		// Downgrading not supported
 		return reconcile.Result{}, err
 	}
+	// An upgrade from a pre-CSI version skips versions, so the leftovers are removed before the upgrade path is checked.
+	if err := r.removeLegacyResources(ctx, reqLogger, cr); err != nil {
+		reqLogger.Error(err, "unable to remove the resources left over from a pre-CSI version")
+		return reconcile.Result{}, err
+	}
+	if canUpgrade {
+		if err := validateUpgradePath(cr.Status.ObservedVersion, versionString); err != nil {
+			// Nothing is upgraded until the operator is replaced with a supported version.
+			reqLogger.Error(err, "Unsupported upgrade path")
+			MarkCrFailed(cr, unsupportedUpgradePath, err.Error())
+			r.recorder.Event(cr, corev1.EventTypeWarning, unsupportedUpgradePath, err.Error())
+			r.ignoreHeartBeatTimestamp(currentCopy, cr)
+			if !reflect.DeepEqual(currentCopy, cr) {
+				if updateErr := r.updateCrStatus(ctx, statusBase, cr); updateErr != nil {
+					return reconcile.Result{}, updateErr
+				}
+			}
+			return reconcile.Result{}, nil
+		}
+	}
 	if r.isDeploying(cr) {
 		//New install, mark deploying.
 		MarkCrDeploying(cr, deployStarted, deployStartedMessage)
 		r.recorder.Event(cr, corev1.EventTypeNormal, deployStarted, deployStartedMessage)
-		err = r.client.Update(context, cr)
+		err = r.updateCrStatus(ctx, statusBase, cr)
 		if err != nil {
 			reqLogger.Info("Marked deploying failed", "Error", err.Error())
 			// Error updating the object - requeue the request.
@@ -382,7 +638,7 @@ func (r *ReconcileHostPathProvisioner) Reconcile(context context.Context, reques
 		MarkCrUpgradeHealingDegraded(cr, upgradeStarted, fmt.Sprintf("Started upgrade to version %s", cr.Status.TargetVersion))
 		r.recorder.Event(cr, corev1.EventTypeWarning, upgradeStarted, fmt.Sprintf("Started upgrade to version %s", cr.Status.TargetVersion))
 		// Mark Observed version to blank, so we get to the reconcile upgrade section.
-		err = r.client.Update(context, cr)
+		err = r.updateCrStatus(ctx, statusBase, cr)
 		if err != nil {
 			// Error updating the object - requeue the request.
 			return reconcile.Result{}, err
@@ -390,38 +646,83 @@ func (r *ReconcileHostPathProvisioner) Reconcile(context context.Context, reques
 		reqLogger.Info("Started upgrading")
 	}
 
-	res, err := r.reconcileUpdate(reqLogger, cr, namespace)
-	if err == nil {
-		res, err = r.reconcileStatus(context, reqLogger, cr, namespace, versionString)
+	var res reconcile.Result
+	reason, message := validateStorageConfig(cr)
+	if reason == "" {
+		if reason, message, err = r.validateExternalServiceAccount(ctx, cr, namespace); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	if reason == "" {
+		if reason, message, err = r.validatePermissions(ctx, namespace); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	if reason == overlappingStoragePaths && !r.isDeploying(cr) {
+		// The deployed workloads keep running with the storage pools they have, the overlapping paths are not applied.
+		MarkCrDegraded(cr, reason, message)
+		r.recorder.Event(cr, corev1.EventTypeWarning, reason, message)
+	} else if reason != "" {
+		// Nothing is deployed until the CR is fixed or the ServiceAccount is created, both trigger a new reconcile.
+		MarkCrFailed(cr, reason, message)
+		r.recorder.Event(cr, corev1.EventTypeWarning, reason, message)
+		if reason == insufficientPermissions {
+			// Granting the permissions does not trigger a reconcile.
+			res.RequeueAfter = permissionsRecheckInterval
+		}
+	} else if res, err = r.reconcileUpdate(ctx, reqLogger, cr, namespace); err == nil {
+		MarkCrReconciled(cr)
+		metrics.SetLastSuccessfulReconcile(time.Now())
+		res, err = r.reconcileStatus(ctx, reqLogger, cr, namespace, versionString)
 	} else {
-		MarkCrFailedHealing(cr, reconcileFailed, fmt.Sprintf("Unable to successfully reconcile: %v", err))
-		r.recorder.Event(cr, corev1.EventTypeWarning, reconcileFailed, fmt.Sprintf("Unable to successfully reconcile: %v", err))
+		var retry bool
+		reason, retry = classifyReconcileError(err)
+		message = fmt.Sprintf("Unable to successfully reconcile: %v", err)
+		var sccErr *sccError
+		if goerrors.As(err, &sccErr) {
+			reason, message, retry = sccErr.reason, sccErr.message, true
+		}
+		if retry {
+			MarkCrFailedHealing(cr, reason, message)
+		} else {
+			// Retrying does not help, the CR stays failed until it is fixed.
+			reqLogger.Error(err, "Not retrying the reconcile", "reason", reason)
+			MarkCrFailed(cr, reason, message)
+			err = nil
+			if reason == insufficientPermissions {
+				res.RequeueAfter = permissionsRecheckInterval
+			}
+		}
+		r.recorder.Event(cr, corev1.EventTypeWarning, reason, message)
 	}
-
 	r.ignoreHeartBeatTimestamp(currentCopy, cr)
 	if !reflect.DeepEqual(currentCopy, cr) {
 		logJSONDiff(reqLogger, currentCopy, cr)
-		updateErr := r.client.Update(context, cr)
+		updateErr := r.updateCrStatus(ctx, statusBase, cr)
 		if updateErr != nil {
 			r.Log.Error(err, "Unable to successfully reconcile")
 			err = updateErr
 		}
 	}
+	// Reconcile again after the reconcile period in case an event is missed, an earlier requeue is kept.
+	if err == nil && !res.Requeue && r.reconcilePeriod > 0 && (res.RequeueAfter == 0 || res.RequeueAfter > r.reconcilePeriod) {
+		res.RequeueAfter = r.reconcilePeriod
+	}
 	return res, err
 }
 
-func (r *ReconcileHostPathProvisioner) reconcileCleanup(reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string, deploymentCount int) (reconcile.Result, error) {
-	spDeployments, err := r.currentStoragePoolDeployments(cr, namespace)
+func (r *ReconcileHostPathProvisioner) reconcileCleanup(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string, deploymentCount int) (reconcile.Result, error) {
+	spDeployments, err := r.currentStoragePoolDeployments(ctx, cr)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 	reqLogger.Info("Number of storage pool deployments still active", "count", len(spDeployments))
-	cleanupFinished, err := r.hasCleanUpFinished(namespace)
+	cleanupFinished, err := r.hasCleanUpFinished(ctx)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 	if len(spDeployments) == deploymentCount && cleanupFinished {
-		if err := r.removeCleanUpJobs(reqLogger, namespace); err != nil {
+		if err := r.removeCleanUpJobs(ctx, reqLogger); err != nil {
 			return reconcile.Result{}, err
 		}
 	} else {
@@ -433,58 +734,164 @@ func (r *ReconcileHostPathProvisioner) reconcileCleanup(reqLogger logr.Logger, c
 func (r *ReconcileHostPathProvisioner) ignoreHeartBeatTimestamp(currentCopy, cr *hostpathprovisionerv1.HostPathProvisioner) {
 	for i, condition := range currentCopy.Status.Conditions {
 		crCond := conditions.FindStatusCondition(cr.Status.Conditions, condition.Type)
-		if crCond.Message == condition.Message && crCond.Reason == condition.Reason && crCond.Status == condition.Status {
+		if crCond != nil && crCond.Message == condition.Message && crCond.Reason == condition.Reason && crCond.Status == condition.Status {
 			currentCopy.Status.Conditions[i].LastHeartbeatTime = crCond.LastHeartbeatTime
 		}
 	}
 }
 
+// checkClockSkew compares the condition heartbeats, written by this or another operator replica, against the clock of
+// the operator. Heartbeats far in the future point at clock skew between the nodes, which makes the heartbeats flap.
+// This is diagnostic only, nothing is changed.
+func (r *ReconcileHostPathProvisioner) checkClockSkew(reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner) {
+	now := time.Now()
+	var skew time.Duration
+	for _, condition := range cr.Status.Conditions {
+		if ahead := condition.LastHeartbeatTime.Sub(now); ahead > skew {
+			skew = ahead
+		}
+	}
+	metrics.SetClockSkew(skew)
+	if skew > maxClockSkew {
+		message := fmt.Sprintf(clockSkewDetectedMessage, skew.Round(time.Second))
+		reqLogger.Info("Detected clock skew", "skew", skew)
+		r.recorder.Event(cr, corev1.EventTypeWarning, clockSkewDetected, message)
+	}
+}
+
 func (r *ReconcileHostPathProvisioner) isLegacy(cr *hostpathprovisionerv1.HostPathProvisioner) bool {
 	return cr.Spec.PathConfig != nil
 }
 
-func (r *ReconcileHostPathProvisioner) reconcileStatus(_ context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace, versionString string) (reconcile.Result, error) {
+// validateStorageConfig returns the degraded reason and message if the CR doesn't have exactly one of pathConfig and storage
+// pools set. The webhook rejects these CRs, but it might not be running.
+func validateStorageConfig(cr *hostpathprovisionerv1.HostPathProvisioner) (string, string) {
+	if cr.Spec.PathConfig != nil && len(cr.Spec.StoragePools) > 0 {
+		return conflictingStorageConfig, conflictingStorageConfigMessage
+	} else if cr.Spec.PathConfig == nil && len(cr.Spec.StoragePools) == 0 {
+		return missingStorageConfig, missingStorageConfigMessage
+	}
+	// The webhook rejects overlapping paths, but CRs created before it or without it can have them.
+	if first, second, ok := findOverlappingStoragePools(cr); ok {
+		return overlappingStoragePaths, fmt.Sprintf(overlappingStoragePathsMessage, first.Name, second.Name, first.Path, second.Path)
+	}
+	return "", ""
+}
+
+func (r *ReconcileHostPathProvisioner) reconcileStatus(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace, versionString string) (reconcile.Result, error) {
 	// Check if all requested pods are available.
-	degraded, err := r.checkDegraded(reqLogger, cr, namespace)
+	degraded, requeueAfter, err := r.checkDegraded(ctx, reqLogger, cr, namespace)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
-	if err := r.reconcileStoragePoolStatus(reqLogger, cr, namespace); err != nil {
+	if degraded && IsHppProgressing(cr) && requeueAfter == 0 {
+		if err := r.reconcileRolloutProgress(ctx, cr, namespace); err != nil {
+			return reconcile.Result{}, err
+		}
+		if r.isDeploying(cr) {
+			if err := r.reconcileUnschedulablePods(ctx, cr, namespace); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+	}
+	if err := r.reconcileUnschedulableNodes(ctx, cr, namespace); err != nil {
+		return reconcile.Result{}, err
+	}
+	if !degraded && !r.isDeploying(cr) && !r.isUpgrading(cr) {
+		// While deploying or upgrading the workloads are expected to run different versions for a while.
+		if degraded, err = r.reconcileVersionSkew(ctx, cr, namespace); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	if err := r.reconcileStoragePoolStatus(ctx, reqLogger, cr, namespace); err != nil {
 		MarkCrFailedHealing(cr, "StoragePoolNotReady", err.Error())
 		return reconcile.Result{}, err
 	}
+	if err := r.reconcileProvisionedVolumesMetric(ctx, cr); err != nil {
+		return reconcile.Result{}, err
+	}
+	r.reconcileFeatureGateStatus(cr)
+	if err := r.reconcileVolumeNodeAffinityConflicts(ctx, cr); err != nil {
+		return reconcile.Result{}, err
+	}
+	if err := r.reconcileProvisioningVerification(ctx, reqLogger, cr, namespace, degraded); err != nil {
+		return reconcile.Result{}, err
+	}
+	pruneUnknownConditions(cr)
+	if err := r.reconcileCSIDriverVersion(ctx, cr, namespace); err != nil {
+		return reconcile.Result{}, err
+	}
 	if !degraded && cr.Status.ObservedVersion != versionString {
 		cr.Status.ObservedVersion = versionString
 	}
-	return reconcile.Result{}, nil
+	cr.Status.Summary = getStatusSummary(cr)
+	cr.Status.ObservedGeneration = cr.GetGeneration()
+	// Check again once the degraded grace period is over.
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// getStatusSummary returns the readiness of the storage pools and the observed version, as shown by kubectl get. A
+// storage pool is ready once all its deployments are ready, storage pools without a PVC template have no deployments.
+func getStatusSummary(cr *hostpathprovisionerv1.HostPathProvisioner) string {
+	ready := 0
+	for _, status := range cr.Status.StoragePoolStatuses {
+		if status.Phase == hostpathprovisionerv1.StoragePoolReady && status.CurrentReady == status.DesiredReady {
+			ready++
+		}
+	}
+	summary := fmt.Sprintf("%d/%d pools ready", ready, len(cr.Status.StoragePoolStatuses))
+	if cr.Status.ObservedVersion != "" {
+		summary = fmt.Sprintf("%s, %s", summary, cr.Status.ObservedVersion)
+	}
+	return summary
 }
 
-func (r *ReconcileHostPathProvisioner) deleteAllRbac(reqLogger logr.Logger, namespace string) (reconcile.Result, error) {
+func (r *ReconcileHostPathProvisioner) deleteAllRbac(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) (reconcile.Result, error) {
 	for _, name := range []string{ProvisionerServiceAccountName, ProvisionerServiceAccountNameCsi, MultiPurposeHostPathProvisionerName} {
 		reqLogger.Info("Deleting ClusterRoleBinding", "ClusterRoleBinding", name)
-		if err := r.deleteClusterRoleBindingObject(name); err != nil {
+		if err := r.deleteClusterRoleBindingObject(ctx, name); err != nil {
 			reqLogger.Error(err, "Unable to delete ClusterRoleBinding")
 			return reconcile.Result{}, err
 		}
 		reqLogger.Info("Deleting ClusterRole", "ClusterRole", name)
-		if err := r.deleteClusterRoleObject(name); err != nil {
+		if err := r.deleteClusterRoleObject(ctx, name); err != nil {
 			reqLogger.Error(err, "Unable to delete ClusterRole")
 			return reconcile.Result{}, err
 		}
 		reqLogger.Info("Deleting RoleBinding", "ClusterRoleBinding", name)
-		if err := r.deleteRoleBindingObject(name, namespace); err != nil {
+		if err := r.deleteRoleBindingObject(ctx, name, namespace); err != nil {
 			reqLogger.Error(err, "Unable to delete RoleBinding")
 			return reconcile.Result{}, err
 		}
 		reqLogger.Info("Deleting Role", "ClusterRole", name)
-		if err := r.deleteRoleObject(name, namespace); err != nil {
+		if err := r.deleteRoleObject(ctx, name, namespace); err != nil {
 			reqLogger.Error(err, "Unable to delete Role")
 			return reconcile.Result{}, err
 		}
 	}
+	// The Roles and RoleBindings in the namespaces selected by spec.rbac.namespaceSelector.
+	if err := r.deleteUnselectedRoleBindings(ctx, reqLogger, cr, nil); err != nil {
+		reqLogger.Error(err, "Unable to delete RoleBindings")
+		return reconcile.Result{}, err
+	}
+	if err := r.deleteUnselectedRoles(ctx, reqLogger, cr, nil); err != nil {
+		reqLogger.Error(err, "Unable to delete Roles")
+		return reconcile.Result{}, err
+	}
 	return reconcile.Result{}, nil
 }
 
+// getOperatorBuild returns the build of the operator for the status, nil if the build is unknown.
+func getOperatorBuild(buildInfo version.BuildInfo) *hostpathprovisionerv1.OperatorBuild {
+	if buildInfo.GitCommit == "" && buildInfo.BuildDate == "" {
+		return nil
+	}
+	return &hostpathprovisionerv1.OperatorBuild{
+		GitCommit: buildInfo.GitCommit,
+		BuildDate: buildInfo.BuildDate,
+	}
+}
+
 func canUpgrade(current, target string) (bool, error) {
 	if current == "" {
 		// Can't upgrade if no current is set
@@ -511,127 +918,580 @@ func canUpgrade(current, target string) (bool, error) {
 	return result, nil
 }
 
-func (r *ReconcileHostPathProvisioner) reconcileUpdate(reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) (reconcile.Result, error) {
+// validateUpgradePath returns an error if the upgrade from current to target skips a minor version. Patch upgrades and
+// upgrades to the next minor or major version are allowed, as are versions that are not valid semver.
+func validateUpgradePath(current, target string) error {
+	targetSemver, errTarget := version.GetVersionFromString(target)
+	currentSemver, errCurrent := version.GetVersionFromString(current)
+	if errTarget != nil || errCurrent != nil {
+		return nil
+	}
+	if targetSemver.Major > currentSemver.Major+1 {
+		return fmt.Errorf("upgrade from %s to %s skips a major version, upgrade to %d.0 first", currentSemver.String(), targetSemver.String(), currentSemver.Major+1)
+	}
+	if targetSemver.Major == currentSemver.Major && targetSemver.Minor > currentSemver.Minor+1 {
+		return fmt.Errorf("upgrade from %s to %s skips more than one minor version, upgrade to %d.%d first", currentSemver.String(), targetSemver.String(), currentSemver.Major, currentSemver.Minor+1)
+	}
+	return nil
+}
+
+func (r *ReconcileHostPathProvisioner) reconcileUpdate(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) (reconcile.Result, error) {
 	// Reconcile the objects this operator manages.
-	res, err := r.reconcileDaemonSet(reqLogger, cr, namespace)
+	r.upToDateReconcile.Add(1)
+	if err := r.reconcileTrustedCABundle(ctx, reqLogger, cr, namespace); err != nil {
+		reqLogger.Error(err, "unable to create the trusted CA bundle ConfigMap")
+		return reconcile.Result{}, err
+	}
+	res, err := r.reconcileDaemonSet(ctx, reqLogger, cr, namespace)
 	if err != nil {
 		reqLogger.Error(err, "unable to create DaemonSet")
 		return res, err
 	}
 	// Reconcile storage pools
-	res, err = r.reconcileStoragePools(reqLogger, cr, namespace)
+	res, err = r.reconcileStoragePools(ctx, reqLogger, cr, namespace)
 	if err != nil {
 		reqLogger.Error(err, "unable to configure storage pools")
 		return res, err
 	}
-	res, err = r.reconcileServiceAccount(reqLogger, cr, namespace)
+	res, err = r.reconcileStorageClasses(ctx, reqLogger, cr)
+	if err != nil {
+		reqLogger.Error(err, "unable to create StorageClasses")
+		return res, err
+	}
+	res, err = r.reconcileStorageProfiles(ctx, reqLogger, cr)
+	if err != nil {
+		reqLogger.Error(err, "unable to reconcile StorageProfiles")
+		return res, err
+	}
+	res, err = r.reconcileServiceAccount(ctx, reqLogger, cr, namespace)
 	if err != nil {
 		reqLogger.Error(err, "unable to create ServiceAccount")
 		return res, err
 	}
-	res, err = r.reconcileClusterRole(reqLogger, cr)
+	res, err = r.reconcileClusterRole(ctx, reqLogger, cr)
 	if err != nil {
 		reqLogger.Error(err, "unable to create ClusterRole")
 		return res, err
 	}
-	res, err = r.reconcileClusterRoleBinding(reqLogger, cr, namespace)
+	res, err = r.reconcileClusterRoleBinding(ctx, reqLogger, cr, namespace)
 	if err != nil {
 		reqLogger.Error(err, "unable to create ClusterRoleBinding")
 		return res, err
 	}
-	res, err = r.reconcileRole(reqLogger, cr, namespace)
+	res, err = r.reconcileRole(ctx, reqLogger, cr, namespace)
 	if err != nil {
 		reqLogger.Error(err, "unable to create Role")
 		return res, err
 	}
-	res, err = r.reconcileRoleBinding(reqLogger, cr, namespace)
+	res, err = r.reconcileRoleBinding(ctx, reqLogger, cr, namespace)
 	if err != nil {
 		reqLogger.Error(err, "unable to create RoleBinding")
 		return res, err
 	}
-	res, err = r.reconcileCSIDriver(reqLogger, cr)
+	res, err = r.reconcileCSIDriver(ctx, reqLogger, cr)
 	if err != nil {
 		reqLogger.Error(err, "unable to create CSIDriver")
 		return res, err
 	}
-	res, err = r.reconcileSecurityContextConstraints(reqLogger, cr, namespace)
+	res, err = r.reconcileSnapshotClass(ctx, reqLogger, cr)
+	if err != nil {
+		reqLogger.Error(err, "unable to create VolumeSnapshotClass")
+		return res, err
+	}
+	res, err = r.reconcileSecurityContextConstraints(ctx, reqLogger, cr, namespace)
 	if err != nil {
 		reqLogger.Error(err, "unable to create SecurityContextConstraints")
 		return res, err
 	}
-	res, err = r.reconcilePrometheusInfra(reqLogger, cr, namespace)
+	res, err = r.reconcilePrometheusInfra(ctx, reqLogger, cr, namespace)
 	if err != nil {
 		reqLogger.Error(err, "unable to create Prometheus Infra (PrometheusRule, ServiceMonitor, RBAC)")
 		return res, err
 	}
-	daemonSet := &appsv1.DaemonSet{}
+	r.pruneUpToDateVersions()
+	ready := true
 	if r.isLegacy(cr) {
-		if err := r.client.Get(context.TODO(), types.NamespacedName{Name: MultiPurposeHostPathProvisionerName, Namespace: namespace}, daemonSet); err != nil {
+		if ready, _, err = r.checkWorkloadReady(ctx, cr, MultiPurposeHostPathProvisionerName, namespace); err != nil {
 			return reconcile.Result{}, err
 		}
 	}
-	daemonSetCsi := &appsv1.DaemonSet{}
-	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName), Namespace: namespace}, daemonSetCsi); err != nil {
+	csiReady, csiDesired, err := r.checkWorkloadReady(ctx, cr, fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName), namespace)
+	if err != nil {
 		return reconcile.Result{}, err
 	}
-	if (!r.isLegacy(cr) || checkDaemonSetReady(daemonSet)) && checkDaemonSetReady(daemonSetCsi) {
+	if ready && csiReady {
 		MarkCrHealthyMessage(cr, "Complete", "Application Available")
 		r.recorder.Event(cr, corev1.EventTypeNormal, provisionerHealthy, provisionerHealthyMessage)
 	}
-	if res, err := r.reconcileCleanup(reqLogger, cr, namespace, int(daemonSetCsi.Status.DesiredNumberScheduled)); err != nil || res.RequeueAfter == time.Second {
+	if res, err := r.reconcileCleanup(ctx, reqLogger, cr, namespace, csiDesired); err != nil || res.RequeueAfter == time.Second {
 		return res, err
 	}
 
 	return res, nil
 }
 
-func (r *ReconcileHostPathProvisioner) checkDegraded(logger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) (bool, error) {
+func (r *ReconcileHostPathProvisioner) checkDegraded(ctx context.Context, logger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) (bool, time.Duration, error) {
 	degraded := false
+	var requeueAfter time.Duration
 
-	daemonSet := &appsv1.DaemonSet{}
+	ready := true
 	if r.isLegacy(cr) {
-		err := r.client.Get(context.TODO(), types.NamespacedName{Name: MultiPurposeHostPathProvisionerName, Namespace: namespace}, daemonSet)
+		var err error
+		ready, _, err = r.checkWorkloadReady(ctx, cr, MultiPurposeHostPathProvisionerName, namespace)
 		if err != nil {
-			return true, err
+			return true, 0, err
 		}
 	}
-	daemonSetCsi := &appsv1.DaemonSet{}
-	err := r.client.Get(context.TODO(), types.NamespacedName{Name: fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName), Namespace: namespace}, daemonSetCsi)
+	csiReady, _, err := r.checkWorkloadReady(ctx, cr, fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName), namespace)
 	if err != nil {
-		return true, err
+		return true, 0, err
 	}
 
-	if !((!r.isLegacy(cr) || checkDaemonSetReady(daemonSet)) && checkDaemonSetReady(daemonSetCsi)) {
+	if !(ready && csiReady) {
 		degraded = true
 	}
 
 	logger.V(3).Info("Degraded check", "Degraded", degraded)
 
-	if degraded && !r.isDeploying(cr) {
-		MarkCrFailed(cr, "Degraded", "CR is deployed but DaemonSets are not ready")
+	unschedulable, err := r.getUnschedulableDaemonSets(ctx, cr, namespace)
+	if err != nil {
+		return true, 0, err
+	}
+	if len(unschedulable) > 0 {
+		// Also while deploying, otherwise the CR keeps progressing without anything to wait for.
+		message := fmt.Sprintf(noSchedulableNodesMessage, strings.Join(unschedulable, ", "))
+		if current := conditions.FindStatusCondition(cr.Status.Conditions, conditions.ConditionDegraded); current == nil || current.Reason != noSchedulableNodes || current.Message != message {
+			r.recorder.Event(cr, corev1.EventTypeWarning, noSchedulableNodes, message)
+		}
+		MarkCrFailed(cr, noSchedulableNodes, message)
+	} else if degraded && !r.isDeploying(cr) {
+		if requeueAfter = getDegradedGracePeriodRemaining(cr); requeueAfter > 0 {
+			// Not ready for a short time, for instance while a node reboots, report it as progressing.
+			MarkCrDeploying(cr, notReadyWithinGracePeriod, fmt.Sprintf(notReadyWithinGracePeriodMessage, cr.Spec.Monitoring.DegradedGracePeriod.Duration))
+		} else {
+			MarkCrFailed(cr, "Degraded", "CR is deployed but DaemonSets are not ready")
+		}
 	}
 
 	logger.V(3).Info("Finished degraded check", "conditions", cr.Status.Conditions)
-	return degraded, nil
+	return degraded, requeueAfter, nil
+}
+
+// getDegradedGracePeriodRemaining returns how much longer a not ready CR is reported as progressing before it is marked
+// degraded. The not ready period starts when the Available condition becomes false.
+func getDegradedGracePeriodRemaining(cr *hostpathprovisionerv1.HostPathProvisioner) time.Duration {
+	if cr.Spec.Monitoring == nil || cr.Spec.Monitoring.DegradedGracePeriod == nil {
+		return 0
+	}
+	gracePeriod := cr.Spec.Monitoring.DegradedGracePeriod.Duration
+	available := conditions.FindStatusCondition(cr.Status.Conditions, conditions.ConditionAvailable)
+	if available == nil {
+		return 0
+	}
+	if available.Status != corev1.ConditionFalse {
+		return gracePeriod
+	}
+	if remaining := gracePeriod - time.Since(available.LastTransitionTime.Time); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// checkWorkloadReady returns if the DaemonSet, or the Deployment in single node mode, with the passed in name is ready
+// and the number of pods it should be running.
+func (r *ReconcileHostPathProvisioner) checkWorkloadReady(ctx context.Context, cr *hostpathprovisionerv1.HostPathProvisioner, name, namespace string) (bool, int, error) {
+	if isSingleNode(cr) {
+		deployment := &appsv1.Deployment{}
+		if err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, deployment); err != nil {
+			return false, 0, err
+		}
+		return checkDeploymentReady(deployment), int(deployment.Status.Replicas), nil
+	}
+	daemonSet := &appsv1.DaemonSet{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, daemonSet); err != nil {
+		return false, 0, err
+	}
+	return checkDaemonSetReady(daemonSet, getMinReadyNodes(cr)), int(daemonSet.Status.DesiredNumberScheduled), nil
+}
+
+// getMinReadyNodes returns the number of ready provisioner pods a DaemonSet needs before it is considered ready.
+func getMinReadyNodes(cr *hostpathprovisionerv1.HostPathProvisioner) int32 {
+	if cr.Spec.Monitoring == nil || cr.Spec.Monitoring.MinReadyNodes == nil {
+		return 1
+	}
+	return *cr.Spec.Monitoring.MinReadyNodes
+}
+
+// getUnschedulableDaemonSets returns the names of the DaemonSets that don't match any node, because of the node selector or
+// affinity of the workload. DaemonSets the DaemonSet controller hasn't observed yet are skipped.
+func (r *ReconcileHostPathProvisioner) getUnschedulableDaemonSets(ctx context.Context, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) ([]string, error) {
+	if isSingleNode(cr) {
+		return nil, nil
+	}
+	names := []string{fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)}
+	if r.isLegacy(cr) {
+		names = append([]string{MultiPurposeHostPathProvisionerName}, names...)
+	}
+	var unschedulable []string
+	for _, name := range names {
+		daemonSet := &appsv1.DaemonSet{}
+		if err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, daemonSet); err != nil {
+			return nil, err
+		}
+		observed := daemonSet.Status.ObservedGeneration > 0 && daemonSet.Status.ObservedGeneration >= daemonSet.GetGeneration()
+		if observed && daemonSet.Status.DesiredNumberScheduled == 0 {
+			unschedulable = append(unschedulable, name)
+		}
+	}
+	return unschedulable, nil
+}
+
+// reconcileUnschedulablePods adds the reasons the scheduler gives for the pending DaemonSet pods to the Progressing
+// condition message, so a deployment that never finishes explains what it is waiting for.
+func (r *ReconcileHostPathProvisioner) reconcileUnschedulablePods(ctx context.Context, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) error {
+	if isSingleNode(cr) {
+		return nil
+	}
+	names := []string{fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)}
+	if r.isLegacy(cr) {
+		names = append([]string{MultiPurposeHostPathProvisionerName}, names...)
+	}
+	var reasons []string
+	for _, name := range names {
+		workloadReasons, err := r.getUnschedulablePodReasons(ctx, name, namespace)
+		if err != nil {
+			return err
+		}
+		for _, reason := range workloadReasons {
+			if !slices.Contains(reasons, reason) {
+				reasons = append(reasons, reason)
+			}
+		}
+	}
+	if len(reasons) == 0 {
+		return nil
+	}
+	message := deployStartedMessage
+	if progressing := conditions.FindStatusCondition(cr.Status.Conditions, conditions.ConditionProgressing); progressing != nil && progressing.Message != "" {
+		message = progressing.Message
+	}
+	MarkCrProgressingMessage(cr, fmt.Sprintf(unschedulablePodsMessage, message, strings.Join(reasons, "; ")))
+	return nil
+}
+
+// getUnschedulablePodReasons returns the messages of the PodScheduled condition of the pods of the DaemonSet that the
+// scheduler could not place.
+func (r *ReconcileHostPathProvisioner) getUnschedulablePodReasons(ctx context.Context, name, namespace string) ([]string, error) {
+	unschedulable, err := r.getUnschedulableNodes(ctx, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+	var reasons []string
+	for _, node := range unschedulable {
+		if !slices.Contains(reasons, node.Reason) {
+			reasons = append(reasons, node.Reason)
+		}
+	}
+	return reasons, nil
+}
+
+// reconcileUnschedulableNodes sets the nodes the pods of the DaemonSets cannot be scheduled on in the status, sorted by
+// node.
+func (r *ReconcileHostPathProvisioner) reconcileUnschedulableNodes(ctx context.Context, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) error {
+	cr.Status.UnschedulableNodes = nil
+	if isSingleNode(cr) {
+		return nil
+	}
+	names := []string{fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)}
+	if r.isLegacy(cr) {
+		names = append([]string{MultiPurposeHostPathProvisionerName}, names...)
+	}
+	for _, name := range names {
+		unschedulable, err := r.getUnschedulableNodes(ctx, name, namespace)
+		if err != nil {
+			return err
+		}
+		cr.Status.UnschedulableNodes = append(cr.Status.UnschedulableNodes, unschedulable...)
+	}
+	sort.SliceStable(cr.Status.UnschedulableNodes, func(i, j int) bool {
+		return cr.Status.UnschedulableNodes[i].Node < cr.Status.UnschedulableNodes[j].Node
+	})
+	return nil
 }
 
-func checkDaemonSetReady(daemonSet *appsv1.DaemonSet) bool {
-	return checkApplicationAvailable(daemonSet) && daemonSet.Status.NumberReady >= daemonSet.Status.DesiredNumberScheduled
+// getUnschedulableNodes returns the nodes of the pending pods of the DaemonSet that the scheduler could not place, with
+// the message of their PodScheduled condition.
+func (r *ReconcileHostPathProvisioner) getUnschedulableNodes(ctx context.Context, name, namespace string) ([]hostpathprovisionerv1.UnschedulableNode, error) {
+	daemonSet := &appsv1.DaemonSet{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, daemonSet); err != nil {
+		return nil, err
+	}
+	if daemonSet.Spec.Selector == nil {
+		return nil, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(daemonSet.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	podList := &corev1.PodList{}
+	if err := r.client.List(ctx, podList, &client.ListOptions{
+		LabelSelector: selector,
+		Namespace:     namespace,
+	}); err != nil {
+		return nil, err
+	}
+	var unschedulable []hostpathprovisionerv1.UnschedulableNode
+	for _, pod := range podList.Items {
+		if !isPodOwnedByWorkload(&pod, daemonSet) || pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodPending {
+			continue
+		}
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse &&
+				condition.Reason == corev1.PodReasonUnschedulable && condition.Message != "" {
+				unschedulable = append(unschedulable, hostpathprovisionerv1.UnschedulableNode{
+					Node:      getDaemonSetPodNodeName(&pod),
+					DaemonSet: name,
+					Reason:    condition.Message,
+				})
+			}
+		}
+	}
+	return unschedulable, nil
 }
 
+// getDaemonSetPodNodeName returns the node a DaemonSet pod should run on. The DaemonSet controller doesn't set the node
+// name of its pods, but a required node affinity on the metadata.name field of the node.
+func getDaemonSetPodNodeName(pod *corev1.Pod) string {
+	if pod.Spec.NodeName != "" {
+		return pod.Spec.NodeName
+	}
+	if pod.Spec.Affinity == nil || pod.Spec.Affinity.NodeAffinity == nil || pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return ""
+	}
+	for _, term := range pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		for _, field := range term.MatchFields {
+			if field.Key == metav1.ObjectNameField && field.Operator == corev1.NodeSelectorOpIn && len(field.Values) == 1 {
+				return field.Values[0]
+			}
+		}
+	}
+	return ""
+}
+
+// reconcileVersionSkew marks the CR degraded if pods of the legacy or the CSI workload run another provisioner image
+// than their workload, for instance because the rollout of one of them is stuck. Each workload is compared with its own
+// pod template, the images of the two workloads are configured independently. It returns true if the versions are
+// skewed.
+func (r *ReconcileHostPathProvisioner) reconcileVersionSkew(ctx context.Context, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) (bool, error) {
+	if !r.isLegacy(cr) {
+		return false, nil
+	}
+	var skewed []string
+	for _, name := range []string{fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName), MultiPurposeHostPathProvisionerName} {
+		desired, running, err := r.getWorkloadImages(ctx, cr, name, namespace)
+		if err != nil {
+			return false, err
+		}
+		if desired == "" || len(running) == 0 || slices.Equal(running, []string{desired}) {
+			continue
+		}
+		skewed = append(skewed, fmt.Sprintf(versionSkewWorkloadMessage, name, strings.Join(running, ", "), desired))
+	}
+	if len(skewed) == 0 {
+		return false, nil
+	}
+	message := fmt.Sprintf(versionSkewMessage, strings.Join(skewed, ", "))
+	if current := conditions.FindStatusCondition(cr.Status.Conditions, conditions.ConditionDegraded); current == nil || current.Reason != versionSkew || current.Message != message {
+		r.recorder.Event(cr, corev1.EventTypeWarning, versionSkew, message)
+	}
+	MarkCrFailed(cr, versionSkew, message)
+	return true, nil
+}
+
+// getWorkloadImages returns the provisioner image of the pod template of the DaemonSet, or the Deployment in single
+// node mode, and the sorted provisioner images its pods run. Images pinned by digest are compared as is.
+func (r *ReconcileHostPathProvisioner) getWorkloadImages(ctx context.Context, cr *hostpathprovisionerv1.HostPathProvisioner, name, namespace string) (string, []string, error) {
+	var workload client.Object = &appsv1.DaemonSet{}
+	if isSingleNode(cr) {
+		workload = &appsv1.Deployment{}
+	}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, workload); err != nil {
+		if errors.IsNotFound(err) {
+			return "", nil, nil
+		}
+		return "", nil, err
+	}
+	var labelSelector *metav1.LabelSelector
+	var template corev1.PodTemplateSpec
+	switch w := workload.(type) {
+	case *appsv1.DaemonSet:
+		labelSelector, template = w.Spec.Selector, w.Spec.Template
+	case *appsv1.Deployment:
+		labelSelector, template = w.Spec.Selector, w.Spec.Template
+	}
+	desired := getProvisionerImage(&template.Spec)
+	if labelSelector == nil || desired == "" {
+		return "", nil, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return "", nil, err
+	}
+	podList := &corev1.PodList{}
+	if err := r.client.List(ctx, podList, &client.ListOptions{
+		LabelSelector: selector,
+		Namespace:     namespace,
+	}); err != nil {
+		return "", nil, err
+	}
+	var running []string
+	for _, pod := range podList.Items {
+		if !isPodOwnedByWorkload(&pod, workload) || pod.DeletionTimestamp != nil {
+			continue
+		}
+		if image := getProvisionerImage(&pod.Spec); image != "" && !slices.Contains(running, image) {
+			running = append(running, image)
+		}
+	}
+	slices.Sort(running)
+	return desired, running, nil
+}
+
+// getProvisionerImage returns the image of the provisioner container of the pod spec.
+func getProvisionerImage(podSpec *corev1.PodSpec) string {
+	for _, container := range podSpec.Containers {
+		if container.Name == MultiPurposeHostPathProvisionerName {
+			return container.Image
+		}
+	}
+	return ""
+}
+
+// reconcileRolloutProgress sets the number of updated nodes in the Progressing condition message. The workloads run on the
+// same nodes, so a node is only updated once all the workloads on it are.
+func (r *ReconcileHostPathProvisioner) reconcileRolloutProgress(ctx context.Context, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) error {
+	names := []string{fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)}
+	if r.isLegacy(cr) {
+		names = append(names, MultiPurposeHostPathProvisionerName)
+	}
+	updated, desired := -1, 0
+	for _, name := range names {
+		workloadUpdated, workloadDesired, err := r.getWorkloadRolloutStatus(ctx, cr, name, namespace)
+		if err != nil {
+			return err
+		}
+		if updated < 0 || workloadUpdated < updated {
+			updated = workloadUpdated
+		}
+		if workloadDesired > desired {
+			desired = workloadDesired
+		}
+	}
+	if desired > 0 {
+		MarkCrProgressingMessage(cr, fmt.Sprintf(rolloutProgressMessage, updated, desired))
+	}
+	return nil
+}
+
+// reconcileCSIDriverVersion sets the version of the CSI driver image once all the CSI driver pods are updated, so a stuck
+// rollout keeps reporting the previous version. The version is the image tag, or the version label if the image has no tag.
+func (r *ReconcileHostPathProvisioner) reconcileCSIDriverVersion(ctx context.Context, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) error {
+	name := fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)
+	updated, desired, err := r.getWorkloadRolloutStatus(ctx, cr, name, namespace)
+	if err != nil {
+		return err
+	}
+	if updated < desired {
+		return nil
+	}
+	var template *corev1.PodTemplateSpec
+	if isSingleNode(cr) {
+		deployment := &appsv1.Deployment{}
+		if err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, deployment); err != nil {
+			return err
+		}
+		template = &deployment.Spec.Template
+	} else {
+		daemonSet := &appsv1.DaemonSet{}
+		if err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, daemonSet); err != nil {
+			return err
+		}
+		if daemonSet.Status.ObservedGeneration < daemonSet.GetGeneration() {
+			return nil
+		}
+		template = &daemonSet.Spec.Template
+	}
+	for _, container := range template.Spec.Containers {
+		if container.Name != MultiPurposeHostPathProvisionerName {
+			continue
+		}
+		if tag := getImageTag(container.Image); tag != "" {
+			cr.Status.CSIDriverVersion = tag
+		} else {
+			cr.Status.CSIDriverVersion = template.GetLabels()[util.AppKubernetesVersionLabel]
+		}
+	}
+	return nil
+}
+
+// getImageTag returns the tag of the image, or an empty string if the image has no tag.
+func getImageTag(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[i+1:]
+	}
+	return ""
+}
+
+// getWorkloadRolloutStatus returns the number of updated and desired pods of the DaemonSet, or the Deployment in single node
+// mode, with the passed in name.
+func (r *ReconcileHostPathProvisioner) getWorkloadRolloutStatus(ctx context.Context, cr *hostpathprovisionerv1.HostPathProvisioner, name, namespace string) (int, int, error) {
+	if isSingleNode(cr) {
+		deployment := &appsv1.Deployment{}
+		if err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, deployment); err != nil {
+			return 0, 0, err
+		}
+		replicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+		return int(deployment.Status.UpdatedReplicas), int(replicas), nil
+	}
+	daemonSet := &appsv1.DaemonSet{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, daemonSet); err != nil {
+		return 0, 0, err
+	}
+	return int(daemonSet.Status.UpdatedNumberScheduled), int(daemonSet.Status.DesiredNumberScheduled), nil
+}
+
+// checkDaemonSetReady returns if all the pods of the DaemonSet are ready, and at least minReadyNodes of them. While nodes are
+// added the DaemonSet can have all its pods ready before it is scheduled on the new nodes.
+func checkDaemonSetReady(daemonSet *appsv1.DaemonSet, minReadyNodes int32) bool {
+	return checkApplicationAvailable(daemonSet) && daemonSet.Status.NumberReady >= daemonSet.Status.DesiredNumberScheduled &&
+		daemonSet.Status.NumberReady >= minReadyNodes
+}
+
+// checkApplicationAvailable returns false if the DaemonSet should not run any pods, a DaemonSet that matches no nodes is
+// not available.
 func checkApplicationAvailable(daemonSet *appsv1.DaemonSet) bool {
-	return daemonSet.Status.NumberReady > 0
+	return daemonSet.Status.DesiredNumberScheduled > 0 && daemonSet.Status.NumberReady > 0
 }
 
-func (r *ReconcileHostPathProvisioner) addFinalizer(reqLogger logr.Logger, obj client.Object) error {
-	if obj.GetDeletionTimestamp() == nil {
-		currentFinalizers := obj.GetFinalizers()
-		reqLogger.V(3).Info("Adding deletion Finalizer")
-		AddFinalizer(obj, hppFinalizer)
+// addFinalizer adds the deletion finalizer to the CR, or removes it if the CR opted out with spec.skipFinalizer.
+func (r *ReconcileHostPathProvisioner) addFinalizer(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner) error {
+	if cr.GetDeletionTimestamp() == nil {
+		currentFinalizers := cr.GetFinalizers()
+		if cr.Spec.SkipFinalizer {
+			reqLogger.V(3).Info("Skipping deletion Finalizer")
+			RemoveFinalizer(cr, hppFinalizer)
+		} else {
+			reqLogger.V(3).Info("Adding deletion Finalizer")
+			AddFinalizer(cr, hppFinalizer)
+		}
 		// Only update if we modified the finalizers.
-		if !reflect.DeepEqual(currentFinalizers, obj.GetFinalizers()) {
+		if !reflect.DeepEqual(currentFinalizers, cr.GetFinalizers()) {
 			// Update CR
-			err := r.client.Update(context.TODO(), obj)
+			err := r.client.Update(ctx, cr)
 			if err != nil {
 				reqLogger.Error(err, "Failed to update cr with finalizer")
 				return err
@@ -641,6 +1501,34 @@ func (r *ReconcileHostPathProvisioner) addFinalizer(reqLogger logr.Logger, obj c
 	return nil
 }
 
+// updateCrStatus patches the status of the CR with the changes since base. The patch includes the resourceVersion of
+// base, so a concurrent writer causes a conflict instead of being overwritten with stale data. The conflict is
+// returned, so the reconcile is requeued and computes the status again from the latest CR. base is updated to the
+// written CR for the next patch. If enabled, an Event is emitted for every condition whose status changed compared to
+// base.
+func (r *ReconcileHostPathProvisioner) updateCrStatus(ctx context.Context, base, cr *hostpathprovisionerv1.HostPathProvisioner) error {
+	if err := r.client.Status().Patch(ctx, cr, client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{})); err != nil {
+		return err
+	}
+	r.emitConditionEvents(cr, base.Status.Conditions)
+	cr.DeepCopyInto(base)
+	return nil
+}
+
+// recordEventOnce emits an event about a state that holds over many reconciles, like a missing CRD, only when the state
+// starts or its message changes, instead of on every reconcile. forgetEvent is called once the state ends, so the
+// event is emitted again when it comes back.
+func (r *ReconcileHostPathProvisioner) recordEventOnce(cr *hostpathprovisionerv1.HostPathProvisioner, eventType, reason, message string) {
+	if previous, reported := r.reportedEvents.Swap(reason, message); reported && previous == message {
+		return
+	}
+	r.recorder.Event(cr, eventType, reason, message)
+}
+
+func (r *ReconcileHostPathProvisioner) forgetEvent(reason string) {
+	r.reportedEvents.Delete(reason)
+}
+
 func (r *ReconcileHostPathProvisioner) isFeatureGateEnabled(feature string, cr *hostpathprovisionerv1.HostPathProvisioner) bool {
 	for _, featuregate := range cr.Spec.FeatureGates {
 		if featuregate == feature {
@@ -650,11 +1538,37 @@ func (r *ReconcileHostPathProvisioner) isFeatureGateEnabled(feature string, cr *
 	return false
 }
 
+// reconcileFeatureGateStatus lists the enabled feature gates in the status, and warns about the unknown ones.
+func (r *ReconcileHostPathProvisioner) reconcileFeatureGateStatus(cr *hostpathprovisionerv1.HostPathProvisioner) {
+	var enabled []string
+	for _, feature := range knownFeatureGates {
+		if r.isFeatureGateEnabled(feature, cr) {
+			enabled = append(enabled, feature)
+		}
+	}
+	cr.Status.EnabledFeatureGates = enabled
+
+	var unknown []string
+	for _, featuregate := range cr.Spec.FeatureGates {
+		if !slices.Contains(knownFeatureGates, featuregate) {
+			unknown = append(unknown, featuregate)
+		}
+	}
+	if len(unknown) == 0 {
+		conditions.RemoveStatusCondition(&cr.Status.Conditions, ConditionUnknownFeatureGates)
+		return
+	}
+	message := fmt.Sprintf(unknownFeatureGatesMessage, strings.Join(unknown, ", "))
+	if MarkCrUnknownFeatureGates(cr, message) {
+		r.recorder.Event(cr, corev1.EventTypeWarning, unknownFeatureGates, message)
+	}
+}
+
 // This function returns the list of HPP instances in the cluster and an error otherwise
-func getHppList(c client.Client) (*hostpathprovisionerv1.HostPathProvisionerList, error) {
+func getHppList(ctx context.Context, c client.Client) (*hostpathprovisionerv1.HostPathProvisionerList, error) {
 	hppList := &hostpathprovisionerv1.HostPathProvisionerList{}
 
-	if err := c.List(context.TODO(), hppList, &client.ListOptions{}); err != nil {
+	if err := c.List(ctx, hppList, &client.ListOptions{}); err != nil {
 		return nil, err
 	}
 
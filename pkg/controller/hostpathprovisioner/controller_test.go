@@ -19,7 +19,9 @@ import (
 	"context"
 	"fmt"
 	"k8s.io/utils/ptr"
+	"os"
 	"strings"
+	"time"
 
 	ginkgo "github.com/onsi/ginkgo/v2"
 	gomega "github.com/onsi/gomega"
@@ -28,9 +30,12 @@ import (
 	conditions "github.com/openshift/custom-resource-status/conditions/v1"
 	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -41,10 +46,13 @@ import (
 	"kubevirt.io/hostpath-provisioner-operator/version"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	runtimemetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	hppv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
+	"kubevirt.io/hostpath-provisioner-operator/pkg/monitoring/metrics"
 	"kubevirt.io/hostpath-provisioner-operator/pkg/monitoring/rules/alerts"
 )
 
@@ -72,8 +80,7 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 	ginkgo.DescribeTable("Should respect snapshot feature gate", func(cr *hppv1.HostPathProvisioner, scName string) {
 		req := reconcile.Request{
 			NamespacedName: types.NamespacedName{
-				Name:      "test-name",
-				Namespace: testNamespace,
+				Name: "test-name",
 			},
 		}
 		args := getDaemonSetArgs(logf.Log.WithName("hostpath-provisioner-operator-controller-test"), testNamespace, false)
@@ -149,6 +156,230 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 		ginkgo.Entry("storagePoolCr", createStoragePoolWithTemplateCr(), "local"),
 	)
 
+	ginkgo.DescribeTable("Should respect volume expansion feature gate", func(cr *hppv1.HostPathProvisioner) {
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name: "test-name",
+			},
+		}
+		args := getDaemonSetArgs(logf.Log.WithName("hostpath-provisioner-operator-controller-test"), testNamespace, false)
+		cr, r, cl := createDeployedCr(cr)
+		verifyResizer := func(enabled bool) {
+			ds := &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      args.name,
+					Namespace: testNamespace,
+				},
+			}
+			err := cl.Get(context.TODO(), client.ObjectKeyFromObject(ds), ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			sidecarImages := make([]string, 0)
+			for _, container := range ds.Spec.Template.Spec.Containers {
+				sidecarImages = append(sidecarImages, container.Image)
+			}
+			crole := &rbacv1.ClusterRole{}
+			err = cl.Get(context.TODO(), types.NamespacedName{Name: ProvisionerServiceAccountNameCsi}, crole)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			if enabled {
+				gomega.Expect(sidecarImages).To(gomega.ContainElement(ResizerImageDefault))
+				gomega.Expect(crole.Rules).To(gomega.ContainElements(createResizerCsiClusterRoles()))
+			} else {
+				gomega.Expect(sidecarImages).ToNot(gomega.ContainElement(ResizerImageDefault))
+				gomega.Expect(crole.Rules).ToNot(gomega.ContainElement(createResizerCsiClusterRoles()[1]))
+			}
+		}
+		setFeatureGates := func(featureGates []string) {
+			cr = &hppv1.HostPathProvisioner{}
+			err := cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.FeatureGates = featureGates
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			res, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(res.Requeue).To(gomega.BeFalse())
+		}
+		verifyResizer(false)
+
+		ginkgo.By("Enabling the feature gate, the resizer should be added")
+		setFeatureGates([]string{volumeExpansionFeatureGate})
+		verifyResizer(true)
+
+		ginkgo.By("Disabling the feature gate, the resizer should be removed")
+		setFeatureGates(nil)
+		verifyResizer(false)
+	},
+		ginkgo.Entry("legacyCr", createLegacyCr()),
+		ginkgo.Entry("legacyStoragePoolCr", createLegacyStoragePoolCr()),
+		ginkgo.Entry("storagePoolCr", createStoragePoolWithTemplateCr()),
+	)
+
+	ginkgo.DescribeTable("Should report the feature gates in effect", func(featureGates, expectedEnabled, expectedUnknown []string) {
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name: "test-name",
+			},
+		}
+		_, r, cl := createDeployedCr(createLegacyCr())
+		recorder, ok := r.recorder.(*record.FakeRecorder)
+		gomega.Expect(ok).To(gomega.BeTrue())
+		for len(recorder.Events) > 0 {
+			<-recorder.Events
+		}
+		cr := &hppv1.HostPathProvisioner{}
+		err := cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		cr.Spec.FeatureGates = featureGates
+		err = cl.Update(context.TODO(), cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		_, err = r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		err = cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(cr.Status.EnabledFeatureGates).To(gomega.Equal(expectedEnabled))
+		gomega.Expect(IsCrHealthy(cr)).To(gomega.BeTrue())
+		events := make([]string, 0)
+		for len(recorder.Events) > 0 {
+			events = append(events, <-recorder.Events)
+		}
+		unknownCondition := conditions.FindStatusCondition(cr.Status.Conditions, ConditionUnknownFeatureGates)
+		if len(expectedUnknown) == 0 {
+			gomega.Expect(unknownCondition).To(gomega.BeNil())
+			for _, event := range events {
+				gomega.Expect(event).ToNot(gomega.ContainSubstring(unknownFeatureGates))
+			}
+			return
+		}
+		message := fmt.Sprintf(unknownFeatureGatesMessage, strings.Join(expectedUnknown, ", "))
+		gomega.Expect(unknownCondition).ToNot(gomega.BeNil())
+		gomega.Expect(unknownCondition.Status).To(gomega.Equal(corev1.ConditionTrue))
+		gomega.Expect(unknownCondition.Reason).To(gomega.Equal(unknownFeatureGates))
+		gomega.Expect(unknownCondition.Message).To(gomega.Equal(message))
+		gomega.Expect(events).To(gomega.ContainElement(fmt.Sprintf("%s %s %s", corev1.EventTypeWarning, unknownFeatureGates, message)))
+
+		ginkgo.By("Not warning again for the same unknown feature gates")
+		_, err = r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		for len(recorder.Events) > 0 {
+			gomega.Expect(<-recorder.Events).ToNot(gomega.ContainSubstring(unknownFeatureGates))
+		}
+
+		ginkgo.By("Removing the condition once the unknown feature gates are removed")
+		err = cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		cr.Spec.FeatureGates = expectedEnabled
+		err = cl.Update(context.TODO(), cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		_, err = r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		err = cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(conditions.FindStatusCondition(cr.Status.Conditions, ConditionUnknownFeatureGates)).To(gomega.BeNil())
+		gomega.Expect(cr.Status.EnabledFeatureGates).To(gomega.Equal(expectedEnabled))
+	},
+		ginkgo.Entry("no feature gates", nil, nil, nil),
+		ginkgo.Entry("known feature gates", []string{volumeExpansionFeatureGate, snapshotFeatureGate}, []string{snapshotFeatureGate, volumeExpansionFeatureGate}, nil),
+		ginkgo.Entry("unknown feature gate", []string{"Snapshoting"}, nil, []string{"Snapshoting"}),
+		ginkgo.Entry("known and unknown feature gates", []string{snapshotFeatureGate, "Resize", "Other"}, []string{snapshotFeatureGate}, []string{"Resize", "Other"}),
+	)
+
+	ginkgo.DescribeTable("Should trigger a reconcile and recreate deleted managed resources", func(cr *hppv1.HostPathProvisioner, storagePoolNodes int) {
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name: "test-name",
+			},
+		}
+		cr, r, cl := createDeployedCr(cr)
+		if storagePoolNodes > 0 {
+			scaleClusterNodesAndDsUp(1, storagePoolNodes, cr, r, cl)
+		}
+		mapFn := hppMapFunc(cl)
+		managedLists := []client.ObjectList{
+			&appsv1.DaemonSetList{},
+			&appsv1.DeploymentList{},
+			&corev1.ServiceAccountList{},
+			&corev1.ServiceList{},
+			&corev1.PersistentVolumeClaimList{},
+			&rbacv1.ClusterRoleList{},
+			&rbacv1.ClusterRoleBindingList{},
+			&rbacv1.RoleList{},
+			&rbacv1.RoleBindingList{},
+			&storagev1.CSIDriverList{},
+			&secv1.SecurityContextConstraintsList{},
+			&promv1.PrometheusRuleList{},
+			&promv1.ServiceMonitorList{},
+		}
+		for _, list := range managedLists {
+			err := cl.List(context.TODO(), list, client.MatchingLabels{"k8s-app": MultiPurposeHostPathProvisionerName})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			objs, err := meta.ExtractList(list)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			if storagePoolNodes == 0 {
+				if _, ok := list.(*corev1.PersistentVolumeClaimList); ok {
+					gomega.Expect(objs).To(gomega.BeEmpty())
+					continue
+				}
+				if _, ok := list.(*appsv1.DeploymentList); ok {
+					gomega.Expect(objs).To(gomega.BeEmpty())
+					continue
+				}
+			}
+			gomega.Expect(objs).ToNot(gomega.BeEmpty(), "%T", list)
+			names := make([]string, 0)
+			for _, o := range objs {
+				obj := o.(client.Object)
+				names = append(names, client.ObjectKeyFromObject(obj).String())
+				// Either the owner reference or the map function enqueues the HPP when the object is deleted.
+				if owner := metav1.GetControllerOf(obj); owner != nil {
+					gomega.Expect(owner.Kind).To(gomega.Equal("HostPathProvisioner"), "%T %s", obj, obj.GetName())
+					gomega.Expect(owner.Name).To(gomega.Equal(cr.Name), "%T %s", obj, obj.GetName())
+				} else {
+					gomega.Expect(mapFn(context.TODO(), obj)).To(gomega.Equal([]reconcile.Request{{NamespacedName: types.NamespacedName{Name: cr.Name}}}), "%T %s", obj, obj.GetName())
+				}
+				err = cl.Delete(context.TODO(), obj)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			}
+			// The status of the recreated objects is not ready, only the recreation is checked.
+			_, _ = r.Reconcile(context.TODO(), req)
+
+			err = cl.List(context.TODO(), list, client.MatchingLabels{"k8s-app": MultiPurposeHostPathProvisionerName})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			objs, err = meta.ExtractList(list)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			recreated := make([]string, 0)
+			for _, o := range objs {
+				recreated = append(recreated, client.ObjectKeyFromObject(o.(client.Object)).String())
+			}
+			gomega.Expect(recreated).To(gomega.ConsistOf(names), "%T", list)
+		}
+	},
+		ginkgo.Entry("legacyCr", createLegacyCr(), 0),
+		ginkgo.Entry("storagePoolCr", createStoragePoolWithTemplateCr(), 2),
+	)
+
+	ginkgo.It("Should only map labeled resources to the HPP", func() {
+		_, cl := createReconciler(createStoragePoolWithTemplateCr())
+		mapFn := hppMapFunc(cl)
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cleanup-pool-local-node1",
+				Namespace: testNamespace,
+				Labels: map[string]string{
+					"k8s-app": MultiPurposeHostPathProvisionerName,
+				},
+			},
+		}
+		gomega.Expect(mapFn(context.TODO(), job)).To(gomega.Equal([]reconcile.Request{{NamespacedName: types.NamespacedName{Name: "test-name"}}}))
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "user-pvc",
+				Namespace: testNamespace,
+			},
+		}
+		gomega.Expect(mapFn(context.TODO(), pvc)).To(gomega.BeEmpty())
+	})
+
 	ginkgo.It("Should requeue if watch namespaces returns error", func() {
 		watchNamespaceFunc = func() (string, error) {
 			return "", fmt.Errorf("Something is not right, no watch namespace")
@@ -175,8 +406,7 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 
 		req := reconcile.Request{
 			NamespacedName: types.NamespacedName{
-				Name:      "test-name",
-				Namespace: testNamespace,
+				Name: "test-name",
 			},
 		}
 		res, err := r.Reconcile(context.TODO(), req)
@@ -184,6 +414,49 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 		gomega.Expect(res.Requeue).To(gomega.BeFalse())
 	})
 
+	ginkgo.It("Should mark the CR degraded if the watch namespace is empty", func() {
+		watchNamespaceFunc = func() (string, error) {
+			return "", nil
+		}
+		cr := createLegacyCr()
+		objs := []runtime.Object{cr}
+		// Register operator types with the runtime scheme.
+		s := scheme.Scheme
+		s.AddKnownTypes(hppv1.SchemeGroupVersion, cr)
+		s.AddKnownTypes(hppv1.SchemeGroupVersion, &hppv1.HostPathProvisionerList{})
+		promv1.AddToScheme(s)
+		secv1.Install(s)
+
+		// Create a fake client to mock API calls.
+		cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).WithStatusSubresource(cr).Build()
+
+		r := &ReconcileHostPathProvisioner{
+			client:   cl,
+			scheme:   s,
+			recorder: record.NewFakeRecorder(250),
+			Log:      logf.Log.WithName("hostpath-provisioner-operator-controller-test"),
+		}
+
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name: "test-name",
+			},
+		}
+		_, err := r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).To(gomega.HaveOccurred())
+		gomega.Expect(err.Error()).To(gomega.ContainSubstring("WATCH_NAMESPACE is empty"))
+		err = cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		degraded := conditions.FindStatusCondition(cr.Status.Conditions, conditions.ConditionDegraded)
+		gomega.Expect(degraded).ToNot(gomega.BeNil())
+		gomega.Expect(degraded.Status).To(gomega.Equal(corev1.ConditionTrue))
+		gomega.Expect(degraded.Reason).To(gomega.Equal(operatorMisconfigured))
+		dsList := &appsv1.DaemonSetList{}
+		err = cl.List(context.TODO(), dsList)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(dsList.Items).To(gomega.BeEmpty())
+	})
+
 	ginkgo.It("Should requeue if cr cannot be located", func() {
 		cr := createLegacyCr()
 		objs := []runtime.Object{cr}
@@ -225,8 +498,7 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 		}
 		req := reconcile.Request{
 			NamespacedName: types.NamespacedName{
-				Name:      "test-name",
-				Namespace: testNamespace,
+				Name: "test-name",
 			},
 		}
 		res, err := r.Reconcile(context.TODO(), req)
@@ -235,11 +507,123 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 		gomega.Expect(strings.Contains(err.Error(), "downgraded")).To(gomega.BeTrue())
 	})
 
+	ginkgo.DescribeTable("Should validate the upgrade path", func(current, target string, expectedErr string) {
+		err := validateUpgradePath(current, target)
+		if expectedErr == "" {
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		} else {
+			gomega.Expect(err).To(gomega.MatchError(expectedErr))
+		}
+	},
+		ginkgo.Entry("same version", "1.2.3", "1.2.3", ""),
+		ginkgo.Entry("patch upgrade", "1.2.3", "1.2.4", ""),
+		ginkgo.Entry("patch upgrade with v prefix", "v1.2.3", "v1.2.5", ""),
+		ginkgo.Entry("next minor version", "1.2.3", "1.3.0", ""),
+		ginkgo.Entry("next major version", "1.2.3", "2.0.0", ""),
+		ginkgo.Entry("next major version with a later minor", "1.2.3", "2.1.0", ""),
+		ginkgo.Entry("skipping a minor version", "1.2.3", "1.4.0", "upgrade from 1.2.3 to 1.4.0 skips more than one minor version, upgrade to 1.3 first"),
+		ginkgo.Entry("skipping several minor versions", "v1.2.3", "v1.7.1", "upgrade from 1.2.3 to 1.7.1 skips more than one minor version, upgrade to 1.3 first"),
+		ginkgo.Entry("skipping a major version", "1.2.3", "3.0.0", "upgrade from 1.2.3 to 3.0.0 skips a major version, upgrade to 2.0 first"),
+		ginkgo.Entry("invalid current version", "latest", "1.4.0", ""),
+		ginkgo.Entry("invalid target version", "1.2.3", "latest", ""),
+	)
+
+	ginkgo.It("Should mark the CR degraded when the upgrade skips a minor version", func() {
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name: "test-name",
+			},
+		}
+		_, r, cl := createDeployedCr(createLegacyCr())
+		version.VersionStringFunc = func() (string, error) {
+			return "1.2.0", nil
+		}
+		res, err := r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(res.Requeue).To(gomega.BeFalse())
+
+		updatedCr := &hppv1.HostPathProvisioner{}
+		err = cl.Get(context.TODO(), req.NamespacedName, updatedCr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(updatedCr.Status.ObservedVersion).To(gomega.Equal(versionString))
+		gomega.Expect(updatedCr.Status.TargetVersion).To(gomega.Equal("1.2.0"))
+		degraded := conditions.FindStatusCondition(updatedCr.Status.Conditions, conditions.ConditionDegraded)
+		gomega.Expect(degraded).ToNot(gomega.BeNil())
+		gomega.Expect(degraded.Status).To(gomega.Equal(corev1.ConditionTrue))
+		gomega.Expect(degraded.Reason).To(gomega.Equal(unsupportedUpgradePath))
+		gomega.Expect(degraded.Message).To(gomega.Equal("upgrade from 1.0.1 to 1.2.0 skips more than one minor version, upgrade to 1.1 first"))
+		gomega.Expect(conditions.IsStatusConditionTrue(updatedCr.Status.Conditions, conditions.ConditionProgressing)).To(gomega.BeFalse())
+	})
+
+	ginkgo.DescribeTable("Should get the image tag", func(image, expected string) {
+		gomega.Expect(getImageTag(image)).To(gomega.Equal(expected))
+	},
+		ginkgo.Entry("tag", "quay.io/kubevirt/hostpath-csi-driver:v0.20.0", "v0.20.0"),
+		ginkgo.Entry("no tag", "hostpath-provisioner-csi", ""),
+		ginkgo.Entry("registry port without tag", "registry:5000/hostpath-csi-driver", ""),
+		ginkgo.Entry("registry port with tag", "registry:5000/hostpath-csi-driver:v1", "v1"),
+		ginkgo.Entry("digest", "quay.io/kubevirt/hostpath-csi-driver@sha256:1234", ""),
+		ginkgo.Entry("tag and digest", "quay.io/kubevirt/hostpath-csi-driver:v1@sha256:1234", "v1"),
+	)
+
+	ginkgo.It("Should report the CSI driver version separately from the operator version", func() {
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name: "test-name",
+			},
+		}
+		_, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+		os.Setenv(csiProvisionerImageEnvVarName, "quay.io/kubevirt/hostpath-csi-driver:v0.9.0")
+		defer os.Unsetenv(csiProvisionerImageEnvVarName)
+		dsCsi := &appsv1.DaemonSet{}
+		dsNNCsi := types.NamespacedName{
+			Name:      fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName),
+			Namespace: testNamespace,
+		}
+		setUpdatedPods := func(updated int32) {
+			err := cl.Get(context.TODO(), dsNNCsi, dsCsi)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			dsCsi.Status.UpdatedNumberScheduled = updated
+			err = cl.Status().Update(context.TODO(), dsCsi)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		}
+		reconcileAndGetCr := func() *hppv1.HostPathProvisioner {
+			_, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr := &hppv1.HostPathProvisioner{}
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			return cr
+		}
+		setUpdatedPods(2)
+		cr := reconcileAndGetCr()
+		gomega.Expect(cr.Status.ObservedVersion).To(gomega.Equal(versionString))
+		gomega.Expect(cr.Status.CSIDriverVersion).To(gomega.Equal("v0.9.0"))
+
+		ginkgo.By("Keeping the previous version while the driver pods are rolling out")
+		os.Setenv(csiProvisionerImageEnvVarName, "quay.io/kubevirt/hostpath-csi-driver:v1.0.1")
+		setUpdatedPods(1)
+		cr = reconcileAndGetCr()
+		err := cl.Get(context.TODO(), dsNNCsi, dsCsi)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(dsCsi.Spec.Template.Spec.Containers[0].Image).To(gomega.Equal("quay.io/kubevirt/hostpath-csi-driver:v1.0.1"))
+		gomega.Expect(cr.Status.CSIDriverVersion).To(gomega.Equal("v0.9.0"))
+
+		ginkgo.By("Updating the version once all the driver pods are updated")
+		setUpdatedPods(2)
+		cr = reconcileAndGetCr()
+		gomega.Expect(cr.Status.CSIDriverVersion).To(gomega.Equal("v1.0.1"))
+
+		ginkgo.By("Using the version label if the image has no tag")
+		os.Unsetenv(csiProvisionerImageEnvVarName)
+		cr = reconcileAndGetCr()
+		gomega.Expect(cr.Status.CSIDriverVersion).To(gomega.Equal("v0.0.0-tests"))
+	})
+
 	ginkgo.It("Should update CR status when upgrading", func() {
 		req := reconcile.Request{
 			NamespacedName: types.NamespacedName{
-				Name:      "test-name",
-				Namespace: testNamespace,
+				Name: "test-name",
 			},
 		}
 		_, r, cl := createDeployedCr(createLegacyCr())
@@ -348,148 +732,1131 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 		gomega.Expect(conditions.IsStatusConditionTrue(updatedCr.Status.Conditions, conditions.ConditionDegraded)).To(gomega.BeFalse())
 	})
 
-	ginkgo.It("Should delete CR name dependent resource when upgrading", func() {
+	ginkgo.It("Should delete CR name dependent resource when upgrading", func() {
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name: "test-name",
+			},
+		}
+		_, r, _ := createDeployedCr(createLegacyCr())
+		// Mimic a service account from a previous version whose name depends on the CR's
+		err := r.client.Create(context.TODO(), createServiceAccountWithNameThatDependsOnCr())
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		saList := &corev1.ServiceAccountList{}
+		err = r.client.List(context.TODO(), saList, &client.ListOptions{Namespace: testNamespace})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(len(saList.Items)).To(gomega.Equal(3))
+		gomega.Expect(saList.Items[1].Name).To(gomega.Equal(ProvisionerServiceAccountNameCsi))
+
+		version.VersionStringFunc = func() (string, error) {
+			return "1.0.2", nil
+		}
+		res, err := r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(res.Requeue).To(gomega.BeFalse())
+
+		updatedCr := &hppv1.HostPathProvisioner{}
+		err = r.client.Get(context.TODO(), req.NamespacedName, updatedCr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(updatedCr.Status.OperatorVersion).To(gomega.Equal("1.0.2"))
+		gomega.Expect(updatedCr.Status.ObservedVersion).To(gomega.Equal("1.0.2"))
+		gomega.Expect(updatedCr.Status.TargetVersion).To(gomega.Equal("1.0.2"))
+
+		gomega.Expect(conditions.IsStatusConditionTrue(updatedCr.Status.Conditions, conditions.ConditionAvailable)).To(gomega.BeTrue())
+		gomega.Expect(conditions.IsStatusConditionTrue(updatedCr.Status.Conditions, conditions.ConditionProgressing)).To(gomega.BeFalse())
+		gomega.Expect(conditions.IsStatusConditionTrue(updatedCr.Status.Conditions, conditions.ConditionDegraded)).To(gomega.BeFalse())
+
+		saList = &corev1.ServiceAccountList{}
+		err = r.client.List(context.TODO(), saList, &client.ListOptions{Namespace: testNamespace})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(len(saList.Items)).To(gomega.Equal(2))
+		gomega.Expect(saList.Items[0].Name).To(gomega.Equal(ProvisionerServiceAccountName))
+	})
+
+	ginkgo.It("Should err when more than one CR", func() {
+		secondCr := &hppv1.HostPathProvisioner{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-name-second",
+				Namespace: testNamespace,
+			},
+			Spec: hppv1.HostPathProvisionerSpec{
+				ImagePullPolicy: corev1.PullAlways,
+				PathConfig: &hppv1.PathConfig{
+					Path:            "/tmp/test",
+					UseNamingPrefix: false,
+				},
+			},
+		}
+		cr := createLegacyCr()
+		objs := []runtime.Object{cr, secondCr}
+		// Register operator types with the runtime scheme.
+		s := scheme.Scheme
+		s.AddKnownTypes(hppv1.SchemeGroupVersion, cr)
+		s.AddKnownTypes(hppv1.SchemeGroupVersion, &hppv1.HostPathProvisionerList{})
+		promv1.AddToScheme(s)
+		secv1.Install(s)
+
+		// Create a fake client to mock API calls.
+		cl := fake.NewFakeClient(objs...)
+
+		// Create a ReconcileMemcached object with the scheme and fake client.
+		r := &ReconcileHostPathProvisioner{
+			client:   cl,
+			scheme:   s,
+			recorder: record.NewFakeRecorder(250),
+			Log:      logf.Log.WithName("hostpath-provisioner-operator-controller-test"),
+		}
+
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name: "test-name",
+			},
+		}
+		res, err := r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).To(gomega.HaveOccurred())
+		gomega.Expect(res.Requeue).To(gomega.BeFalse())
+		gomega.Expect(err.Error()).To(gomega.Equal("there should be a single hostpath provisioner, 2 items found"))
+	})
+
+	ginkgo.It("Should not requeue when CR is deleted", func() {
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name: "test-name",
+			},
+		}
+		cr, r, cl := createDeployedCr(createLegacyCr())
+		err := cl.Delete(context.TODO(), cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		res, err := r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(res.Requeue).To(gomega.BeFalse())
+	})
+
+	ginkgo.It("Should not add the finalizer if skipFinalizer is set", func() {
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name: "test-name",
+			},
+		}
+		cr := createStoragePoolWithTemplateCr()
+		cr.Spec.SkipFinalizer = true
+		cr, _, cl := createDeployedCr(cr)
+		err := cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(cr.GetFinalizers()).ToNot(gomega.ContainElement(hppFinalizer))
+
+		ginkgo.By("Setting skipFinalizer on a CR with the finalizer, the finalizer should be removed")
+		cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+		err = cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(cr.GetFinalizers()).To(gomega.ContainElement(hppFinalizer))
+		cr.Spec.SkipFinalizer = true
+		err = cl.Update(context.TODO(), cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		_, err = r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		err = cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(cr.GetFinalizers()).ToNot(gomega.ContainElement(hppFinalizer))
+	})
+
+	ginkgo.It("Should update CR with FailedHealing", func() {
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name: "test-name",
+			},
+		}
+		_, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+		ds := &appsv1.DaemonSet{}
+		dsNN := types.NamespacedName{
+			Name:      fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName),
+			Namespace: testNamespace,
+		}
+		err := cl.Get(context.TODO(), dsNN, ds)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		err = cl.Delete(context.TODO(), ds, &client.DeleteOptions{})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		newCl := erroringFakeCtrlRuntimeClient{
+			Client: cl,
+			errMsg: "create failed",
+		}
+		r.client = newCl
+		_, err = r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).To(gomega.HaveOccurred())
+		updatedCr := &hppv1.HostPathProvisioner{}
+		err = r.client.Get(context.TODO(), req.NamespacedName, updatedCr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(conditions.IsStatusConditionTrue(updatedCr.Status.Conditions, conditions.ConditionAvailable)).To(gomega.BeFalse())
+		gomega.Expect(conditions.IsStatusConditionTrue(updatedCr.Status.Conditions, conditions.ConditionProgressing)).To(gomega.BeTrue())
+		gomega.Expect(conditions.IsStatusConditionTrue(updatedCr.Status.Conditions, conditions.ConditionDegraded)).To(gomega.BeTrue())
+		gomega.Expect(conditions.FindStatusCondition(updatedCr.Status.Conditions, conditions.ConditionDegraded).Message).To(gomega.Equal("Unable to successfully reconcile: create failed"))
+	})
+
+	ginkgo.It("Should only update observedGeneration after a successful reconcile", func() {
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name: "test-name",
+			},
+		}
+		cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+		err := cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(cr.Status.ObservedGeneration).To(gomega.Equal(cr.GetGeneration()))
+
+		cr.SetGeneration(cr.GetGeneration() + 1)
+		err = cl.Update(context.TODO(), cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		_, err = r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		err = cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		observedGeneration := cr.GetGeneration()
+		gomega.Expect(cr.Status.ObservedGeneration).To(gomega.Equal(observedGeneration))
+
+		ginkgo.By("Failing the reconcile, observedGeneration should not change")
+		ds := &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName),
+				Namespace: testNamespace,
+			},
+		}
+		err = cl.Delete(context.TODO(), ds, &client.DeleteOptions{})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		cr.SetGeneration(cr.GetGeneration() + 1)
+		err = cl.Update(context.TODO(), cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		r.client = erroringFakeCtrlRuntimeClient{
+			Client: cl,
+			errMsg: "create failed",
+		}
+		_, err = r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).To(gomega.HaveOccurred())
+		err = cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(cr.GetGeneration()).To(gomega.BeNumerically(">", observedGeneration))
+		gomega.Expect(cr.Status.ObservedGeneration).To(gomega.Equal(observedGeneration))
+	})
+
+	ginkgo.It("Should only advance lastReconcileTime after a successful reconcile", func() {
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name: "test-name",
+			},
+		}
+		cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+		err := cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(cr.Status.LastReconcileTime).ToNot(gomega.BeNil())
+
+		lastReconcileTime := metav1.NewTime(time.Now().Add(-time.Hour))
+		cr.Status.LastReconcileTime = &lastReconcileTime
+		err = cl.Status().Update(context.TODO(), cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		_, err = r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		err = cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(cr.Status.LastReconcileTime.Time).To(gomega.BeTemporally("~", time.Now(), lastReconcileTimeInterval))
+		currentReconcileTime := *cr.Status.LastReconcileTime
+
+		ginkgo.By("Reconciling again right away, lastReconcileTime should not be refreshed yet")
+		_, err = r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		err = cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(cr.Status.LastReconcileTime.Unix()).To(gomega.Equal(currentReconcileTime.Unix()))
+
+		ginkgo.By("Failing the reconcile, lastReconcileTime should not change")
+		cr.Status.LastReconcileTime = &lastReconcileTime
+		err = cl.Status().Update(context.TODO(), cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		ds := &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName),
+				Namespace: testNamespace,
+			},
+		}
+		err = cl.Delete(context.TODO(), ds, &client.DeleteOptions{})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		r.client = erroringFakeCtrlRuntimeClient{
+			Client: cl,
+			errMsg: "create failed",
+		}
+		_, err = r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).To(gomega.HaveOccurred())
+		err = cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(cr.Status.LastReconcileTime.Unix()).To(gomega.Equal(lastReconcileTime.Unix()))
+	})
+
+	ginkgo.It("Should record the condition transitions in the status", func() {
+		cr, _, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+		err := cl.Get(context.TODO(), client.ObjectKeyFromObject(cr), cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(cr.Status.ConditionHistory).To(gomega.ContainElement(gomega.And(
+			gomega.HaveField("Type", conditions.ConditionAvailable),
+			gomega.HaveField("Status", corev1.ConditionTrue),
+			gomega.HaveField("Reason", "Complete"),
+			gomega.HaveField("PreviousStatus", corev1.ConditionFalse),
+		)))
+		history := len(cr.Status.ConditionHistory)
+
+		ginkgo.By("Not recording conditions set to the same status and reason")
+		MarkCrHealthyMessage(cr, "Complete", "Still available")
+		gomega.Expect(cr.Status.ConditionHistory).To(gomega.HaveLen(history))
+
+		ginkgo.By("Recording a change of the reason")
+		MarkCrFailed(cr, "Degraded", "CR is deployed but DaemonSets are not ready")
+		MarkCrFailed(cr, noSchedulableNodes, "no nodes")
+		last := cr.Status.ConditionHistory[len(cr.Status.ConditionHistory)-1]
+		gomega.Expect(last.Type).To(gomega.Equal(conditions.ConditionDegraded))
+		gomega.Expect(last.Status).To(gomega.Equal(corev1.ConditionTrue))
+		gomega.Expect(last.Reason).To(gomega.Equal(noSchedulableNodes))
+		gomega.Expect(last.PreviousStatus).To(gomega.Equal(corev1.ConditionTrue))
+		gomega.Expect(last.PreviousReason).To(gomega.Equal("Degraded"))
+		gomega.Expect(last.Time.IsZero()).To(gomega.BeFalse())
+
+		ginkgo.By("Keeping only the most recent transitions")
+		for i := 0; i < maxConditionHistory; i++ {
+			MarkCrHealthyMessage(cr, "Complete", "Application Available")
+			MarkCrFailed(cr, fmt.Sprintf("Flapping%d", i), "flapping")
+		}
+		gomega.Expect(cr.Status.ConditionHistory).To(gomega.HaveLen(maxConditionHistory))
+		last = cr.Status.ConditionHistory[maxConditionHistory-1]
+		gomega.Expect(last.Type).To(gomega.Equal(conditions.ConditionDegraded))
+		gomega.Expect(last.Reason).To(gomega.Equal(fmt.Sprintf("Flapping%d", maxConditionHistory-1)))
+	})
+
+	ginkgo.It("Should remove the conditions of previous operator versions", func() {
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name: "test-name",
+			},
+		}
+		cr, r, cl := createDeployedCr(createLegacyCr())
+		err := cl.Get(context.TODO(), client.ObjectKeyFromObject(cr), cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		conditions.SetStatusCondition(&cr.Status.Conditions, conditions.Condition{
+			Type:   "LegacyUpgradeable",
+			Status: corev1.ConditionTrue,
+		})
+		err = cl.Status().Update(context.TODO(), cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		_, err = r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		err = cl.Get(context.TODO(), client.ObjectKeyFromObject(cr), cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(conditions.FindStatusCondition(cr.Status.Conditions, "LegacyUpgradeable")).To(gomega.BeNil())
+		for _, conditionType := range []conditions.ConditionType{conditions.ConditionAvailable, conditions.ConditionProgressing, conditions.ConditionDegraded} {
+			gomega.Expect(conditions.FindStatusCondition(cr.Status.Conditions, conditionType)).ToNot(gomega.BeNil(), string(conditionType))
+		}
+	})
+
+	ginkgo.DescribeTable("Should be degraded if the DaemonSet matches no nodes", func(desired int32, expectedHealthy bool) {
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name: "test-name",
+			},
+		}
+		cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+		recorder, ok := r.recorder.(*record.FakeRecorder)
+		gomega.Expect(ok).To(gomega.BeTrue())
+		for len(recorder.Events) > 0 {
+			<-recorder.Events
+		}
+		ds := &appsv1.DaemonSet{}
+		err := cl.Get(context.TODO(), types.NamespacedName{Name: fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName), Namespace: testNamespace}, ds)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		ds.Status.ObservedGeneration = ds.GetGeneration() + 1
+		ds.Status.DesiredNumberScheduled = desired
+		ds.Status.NumberAvailable = desired
+		ds.Status.NumberReady = desired
+		err = cl.Status().Update(context.TODO(), ds)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		noSchedulableNodesEvent := fmt.Sprintf("%s %s %s", corev1.EventTypeWarning, noSchedulableNodes, fmt.Sprintf(noSchedulableNodesMessage, ds.GetName()))
+		for i := 0; i < 2; i++ {
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(IsCrHealthy(cr)).To(gomega.Equal(expectedHealthy))
+			events := make([]string, 0)
+			for len(recorder.Events) > 0 {
+				events = append(events, <-recorder.Events)
+			}
+			if expectedHealthy {
+				gomega.Expect(events).ToNot(gomega.ContainElement(noSchedulableNodesEvent))
+				continue
+			}
+			degraded := conditions.FindStatusCondition(cr.Status.Conditions, conditions.ConditionDegraded)
+			gomega.Expect(degraded).ToNot(gomega.BeNil())
+			gomega.Expect(degraded.Status).To(gomega.Equal(corev1.ConditionTrue))
+			gomega.Expect(degraded.Reason).To(gomega.Equal(noSchedulableNodes))
+			gomega.Expect(conditions.IsStatusConditionFalse(cr.Status.Conditions, conditions.ConditionAvailable)).To(gomega.BeTrue())
+			if i == 0 {
+				gomega.Expect(events).To(gomega.ContainElement(noSchedulableNodesEvent))
+			} else {
+				// The event is only recorded when the condition changes.
+				gomega.Expect(events).ToNot(gomega.ContainElement(noSchedulableNodesEvent))
+			}
+		}
+	},
+		ginkgo.Entry("no desired pods", int32(0), false),
+		ginkgo.Entry("ready pods", int32(2), true),
+	)
+
+	ginkgo.DescribeTable("Should wait for the degraded grace period before marking the CR degraded", func(gracePeriod *metav1.Duration, sustained bool) {
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name: "test-name",
+			},
+		}
+		cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+		err := cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		cr.Spec.Monitoring = &hppv1.MonitoringConfig{
+			DegradedGracePeriod: gracePeriod,
+		}
+		err = cl.Update(context.TODO(), cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		setCsiDaemonSetReady := func(ready int32) {
+			ds := &appsv1.DaemonSet{}
+			err := cl.Get(context.TODO(), types.NamespacedName{Name: fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName), Namespace: testNamespace}, ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			ds.Status.NumberReady = ready
+			err = cl.Status().Update(context.TODO(), ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		}
+
+		ginkgo.By("Making a node not ready")
+		setCsiDaemonSetReady(1)
+		res, err := r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		err = cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(conditions.IsStatusConditionFalse(cr.Status.Conditions, conditions.ConditionAvailable)).To(gomega.BeTrue())
+		if gracePeriod == nil {
+			gomega.Expect(res.RequeueAfter).To(gomega.BeZero())
+			gomega.Expect(conditions.IsStatusConditionTrue(cr.Status.Conditions, conditions.ConditionDegraded)).To(gomega.BeTrue())
+			return
+		}
+		gomega.Expect(res.RequeueAfter).To(gomega.BeNumerically(">", 0))
+		gomega.Expect(res.RequeueAfter).To(gomega.BeNumerically("<=", gracePeriod.Duration))
+		gomega.Expect(conditions.IsStatusConditionFalse(cr.Status.Conditions, conditions.ConditionDegraded)).To(gomega.BeTrue())
+		progressing := conditions.FindStatusCondition(cr.Status.Conditions, conditions.ConditionProgressing)
+		gomega.Expect(progressing).ToNot(gomega.BeNil())
+		gomega.Expect(progressing.Status).To(gomega.Equal(corev1.ConditionTrue))
+		gomega.Expect(progressing.Reason).To(gomega.Equal(notReadyWithinGracePeriod))
+		gomega.Expect(progressing.Message).To(gomega.Equal(fmt.Sprintf(notReadyWithinGracePeriodMessage, gracePeriod.Duration)))
+
+		if !sustained {
+			ginkgo.By("Making the node ready again within the grace period")
+			setCsiDaemonSetReady(2)
+			res, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(res.RequeueAfter).To(gomega.BeZero())
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(IsCrHealthy(cr)).To(gomega.BeTrue())
+			return
+		}
+
+		ginkgo.By("Keeping the node not ready past the grace period")
+		available := conditions.FindStatusCondition(cr.Status.Conditions, conditions.ConditionAvailable)
+		available.LastTransitionTime = metav1.NewTime(time.Now().Add(-gracePeriod.Duration - time.Minute))
+		err = cl.Status().Update(context.TODO(), cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		res, err = r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(res.RequeueAfter).To(gomega.BeZero())
+		err = cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		degraded := conditions.FindStatusCondition(cr.Status.Conditions, conditions.ConditionDegraded)
+		gomega.Expect(degraded).ToNot(gomega.BeNil())
+		gomega.Expect(degraded.Status).To(gomega.Equal(corev1.ConditionTrue))
+		gomega.Expect(degraded.Reason).To(gomega.Equal("Degraded"))
+		gomega.Expect(conditions.IsStatusConditionFalse(cr.Status.Conditions, conditions.ConditionProgressing)).To(gomega.BeTrue())
+	},
+		ginkgo.Entry("without a grace period", nil, false),
+		ginkgo.Entry("brief not ready period", &metav1.Duration{Duration: 10 * time.Minute}, false),
+		ginkgo.Entry("sustained not ready period", &metav1.Duration{Duration: 10 * time.Minute}, true),
+	)
+
+	ginkgo.DescribeTable("Should not consider a DaemonSet without desired pods ready", func(desired, ready int32, expected bool) {
+		ds := &appsv1.DaemonSet{
+			Status: appsv1.DaemonSetStatus{
+				DesiredNumberScheduled: desired,
+				NumberReady:            ready,
+			},
+		}
+		gomega.Expect(checkDaemonSetReady(ds, 1)).To(gomega.Equal(expected))
+	},
+		ginkgo.Entry("no desired pods", int32(0), int32(0), false),
+		ginkgo.Entry("not all pods ready", int32(2), int32(1), false),
+		ginkgo.Entry("all pods ready", int32(2), int32(2), true),
+	)
+
+	ginkgo.DescribeTable("Should only consider a DaemonSet ready with at least minReadyNodes ready pods", func(minReadyNodes *int32, desired, ready int32, expected bool) {
+		cr := &hppv1.HostPathProvisioner{}
+		if minReadyNodes != nil {
+			cr.Spec.Monitoring = &hppv1.MonitoringConfig{
+				MinReadyNodes: minReadyNodes,
+			}
+		}
+		ds := &appsv1.DaemonSet{
+			Status: appsv1.DaemonSetStatus{
+				DesiredNumberScheduled: desired,
+				NumberReady:            ready,
+			},
+		}
+		gomega.Expect(checkDaemonSetReady(ds, getMinReadyNodes(cr))).To(gomega.Equal(expected))
+	},
+		ginkgo.Entry("default with one node", nil, int32(1), int32(1), true),
+		ginkgo.Entry("below the threshold", ptr.To[int32](3), int32(1), int32(1), false),
+		ginkgo.Entry("scaling up below the threshold", ptr.To[int32](3), int32(2), int32(2), false),
+		ginkgo.Entry("at the threshold", ptr.To[int32](3), int32(3), int32(3), true),
+		ginkgo.Entry("above the threshold", ptr.To[int32](3), int32(5), int32(5), true),
+		ginkgo.Entry("above the threshold but not all pods ready", ptr.To[int32](3), int32(5), int32(4), false),
+	)
+
+	ginkgo.It("Should not mark the CR available before minReadyNodes provisioner pods are ready", func() {
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name: "test-name",
+			},
+		}
+		cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+		err := cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		cr.Spec.Monitoring = &hppv1.MonitoringConfig{
+			MinReadyNodes: ptr.To[int32](3),
+		}
+		err = cl.Update(context.TODO(), cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		_, err = r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		err = cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(conditions.IsStatusConditionTrue(cr.Status.Conditions, conditions.ConditionAvailable)).To(gomega.BeFalse())
+
+		ginkgo.By("Scaling up to the minimum number of nodes")
+		ds := &appsv1.DaemonSet{}
+		err = cl.Get(context.TODO(), types.NamespacedName{Name: fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName), Namespace: testNamespace}, ds)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		ds.Status.DesiredNumberScheduled = 3
+		ds.Status.NumberReady = 3
+		err = cl.Status().Update(context.TODO(), ds)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		_, err = r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		err = cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(conditions.IsStatusConditionTrue(cr.Status.Conditions, conditions.ConditionAvailable)).To(gomega.BeTrue())
+	})
+
+	ginkgo.It("Should export the time of the last successful reconcile", func() {
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name: "test-name",
+			},
+		}
+		metrics.SetLeader(true)
+		defer metrics.SetLeader(false)
+		_, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+		gomega.Expect(lastSuccessfulReconcileValue()).To(gomega.BeNumerically("~", time.Now().Unix(), 5))
+
+		ginkgo.By("Reconciling again right away, the time should be refreshed even though lastReconcileTime is not")
+		metrics.SetLastSuccessfulReconcile(time.Now().Add(-time.Hour))
+		_, err := r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(lastSuccessfulReconcileValue()).To(gomega.BeNumerically("~", time.Now().Unix(), 5))
+
+		ginkgo.By("Failing the reconcile, the time should not change")
+		lastReconciled := time.Now().Add(-time.Hour)
+		metrics.SetLastSuccessfulReconcile(lastReconciled)
+		err = cl.Delete(context.TODO(), &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName),
+				Namespace: testNamespace,
+			},
+		})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		r.client = erroringFakeCtrlRuntimeClient{
+			Client: cl,
+			errMsg: "create failed",
+		}
+		_, err = r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).To(gomega.HaveOccurred())
+		gomega.Expect(lastSuccessfulReconcileValue()).To(gomega.Equal(float64(lastReconciled.Unix())))
+	})
+
+	ginkgo.It("Should warn about condition heartbeats from the future", func() {
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name: "test-name",
+			},
+		}
+		metrics.SetLeader(true)
+		defer metrics.SetLeader(false)
+		cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+		recorder, ok := r.recorder.(*record.FakeRecorder)
+		gomega.Expect(ok).To(gomega.BeTrue())
+		for len(recorder.Events) > 0 {
+			<-recorder.Events
+		}
+		readEvents := func() []string {
+			events := make([]string, 0)
+			for len(recorder.Events) > 0 {
+				events = append(events, <-recorder.Events)
+			}
+			return events
+		}
+
+		_, err := r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(readEvents()).ToNot(gomega.ContainElement(gomega.ContainSubstring(clockSkewDetected)))
+		gomega.Expect(clockSkewValue()).To(gomega.BeNumerically("<", maxClockSkew.Seconds()))
+
+		ginkgo.By("Writing a heartbeat an hour in the future, like an operator with a skewed clock would")
+		err = cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		cr.Status.Conditions[0].LastHeartbeatTime = metav1.NewTime(time.Now().Add(time.Hour))
+		err = cl.Status().Update(context.TODO(), cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		_, err = r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(readEvents()).To(gomega.ContainElement(gomega.HavePrefix(fmt.Sprintf("%s %s ", corev1.EventTypeWarning, clockSkewDetected))))
+		gomega.Expect(clockSkewValue()).To(gomega.BeNumerically("~", time.Hour.Seconds(), lastReconcileTimeInterval.Seconds()))
+		err = cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(IsCrHealthy(cr)).To(gomega.BeTrue())
+	})
+
+	ginkgo.It("Should report the watched namespace in the status and metrics", func() {
+		cr, _, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+		err := cl.Get(context.TODO(), client.ObjectKeyFromObject(cr), cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(cr.Status.OperatorNamespace).To(gomega.Equal(testNamespace))
+		gomega.Expect(operatorInfoNamespaces()).To(gomega.Equal([]string{testNamespace}))
+
+		ginkgo.By("Changing the watched namespace, the new namespace is reported")
+		watchNamespaceFunc = func() (string, error) {
+			return "other-namespace", nil
+		}
+		r, cl := createReconciler(createStoragePoolWithTemplateCr())
+		_, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cr)})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		err = cl.Get(context.TODO(), client.ObjectKeyFromObject(cr), cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(cr.Status.OperatorNamespace).To(gomega.Equal("other-namespace"))
+		gomega.Expect(operatorInfoNamespaces()).To(gomega.Equal([]string{"other-namespace"}))
+	})
+
+	ginkgo.It("Should report the operator build in the status and metrics", func() {
+		orgBuildInfoFunc := version.BuildInfoFunc
+		defer func() {
+			version.BuildInfoFunc = orgBuildInfoFunc
+		}()
+		version.BuildInfoFunc = func() version.BuildInfo {
+			return version.BuildInfo{
+				GitCommit: "0123456789abcdef",
+				BuildDate: "2024-05-01T10:00:00Z",
+			}
+		}
+		cr, _, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+		err := cl.Get(context.TODO(), client.ObjectKeyFromObject(cr), cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(cr.Status.OperatorBuild).To(gomega.Equal(&hppv1.OperatorBuild{
+			GitCommit: "0123456789abcdef",
+			BuildDate: "2024-05-01T10:00:00Z",
+		}))
+		gomega.Expect(buildInfoLabels()).To(gomega.Equal([]map[string]string{
+			{
+				"git_commit": version.BuildInfoFunc().GitCommit,
+				"build_date": version.BuildInfoFunc().BuildDate,
+			},
+		}))
+	})
+
+	ginkgo.It("Should return a conflict of the status update, without overwriting the concurrent change", func() {
+		cr := createStoragePoolWithTemplateCr()
+		r, cl := createReconciler(cr)
+		conflictingClient := &conflictingFakeCtrlRuntimeClient{Client: cl, conflicts: 1}
+		r.client = conflictingClient
+		_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cr)})
+		gomega.Expect(errors.IsConflict(err)).To(gomega.BeTrue())
+		gomega.Expect(conflictingClient.statusPatches).To(gomega.Equal(1))
+		err = cl.Get(context.TODO(), client.ObjectKeyFromObject(cr), cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(cr.GetLabels()).To(gomega.HaveKeyWithValue("concurrent", "1"))
+		gomega.Expect(cr.Status.Conditions).To(gomega.BeEmpty())
+
+		ginkgo.By("Reconciling again, the status should be computed from the latest CR")
+		_, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cr)})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		err = cl.Get(context.TODO(), client.ObjectKeyFromObject(cr), cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(cr.GetLabels()).To(gomega.HaveKeyWithValue("concurrent", "1"))
+		gomega.Expect(cr.GetFinalizers()).To(gomega.ContainElement(hppFinalizer))
+		gomega.Expect(cr.Status.OperatorVersion).To(gomega.Equal(versionString))
+		gomega.Expect(conditions.IsStatusConditionTrue(cr.Status.Conditions, conditions.ConditionProgressing)).To(gomega.BeTrue())
+	})
+
+	ginkgo.It("Should abort the reconcile once the context is cancelled", func() {
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name: "test-name",
+			},
+		}
+		_, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+
+		ginkgo.By("Reconciling with an already cancelled context")
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		cancellingClient := &cancellingFakeCtrlRuntimeClient{Client: cl}
+		r.client = cancellingClient
+		_, err := r.Reconcile(ctx, req)
+		gomega.Expect(err).To(gomega.MatchError(context.Canceled))
+		gomega.Expect(cancellingClient.callsAfterCancel).To(gomega.Equal(1))
+
+		ginkgo.By("Cancelling the context in the middle of the reconcile")
+		ctx, cancel = context.WithCancel(context.Background())
+		defer cancel()
+		cancellingClient = &cancellingFakeCtrlRuntimeClient{Client: cl, cancelAfter: 5, cancel: cancel}
+		r.client = cancellingClient
+		_, err = r.Reconcile(ctx, req)
+		gomega.Expect(err).To(gomega.MatchError(context.Canceled))
+		// The failing call, and the final update of the CR status.
+		gomega.Expect(cancellingClient.callsAfterCancel).To(gomega.Equal(2))
+	})
+
+	ginkgo.It("Should report the rollout progress in the Progressing condition while deploying", func() {
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name: "test-name",
+			},
+		}
+		cr, r, cl := createDeployedCr(createLegacyCr())
+		err := cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		cr.Status.ObservedVersion = ""
+		err = cl.Status().Update(context.TODO(), cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		setDaemonSetRolloutStatus(cl, MultiPurposeHostPathProvisionerName, 3, 2, 3)
+		setDaemonSetRolloutStatus(cl, fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName), 2, 1, 3)
+
+		_, err = r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		err = cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		progressing := conditions.FindStatusCondition(cr.Status.Conditions, conditions.ConditionProgressing)
+		gomega.Expect(progressing).ToNot(gomega.BeNil())
+		gomega.Expect(progressing.Status).To(gomega.Equal(corev1.ConditionTrue))
+		gomega.Expect(progressing.Reason).To(gomega.Equal(deployStarted))
+		gomega.Expect(progressing.Message).To(gomega.Equal("Rolling out: 1/3 nodes updated"))
+
+		ginkgo.By("Finishing the rollout, the message should be cleared")
+		setDaemonSetRolloutStatus(cl, MultiPurposeHostPathProvisionerName, 3, 3, 3)
+		setDaemonSetRolloutStatus(cl, fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName), 3, 3, 3)
+		_, err = r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		err = cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		progressing = conditions.FindStatusCondition(cr.Status.Conditions, conditions.ConditionProgressing)
+		gomega.Expect(progressing.Status).To(gomega.Equal(corev1.ConditionFalse))
+		gomega.Expect(progressing.Message).To(gomega.BeEmpty())
+		gomega.Expect(IsCrHealthy(cr)).To(gomega.BeTrue())
+	})
+
+	ginkgo.It("Should be degraded if the pods of a DaemonSet do not run its image", func() {
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name: "test-name",
+			},
+		}
+		cr, r, cl := createDeployedCr(createLegacyCr())
+		createVersionPod := func(name, dsName, image string) {
+			ds := &appsv1.DaemonSet{}
+			err := cl.Get(context.TODO(), types.NamespacedName{Name: dsName, Namespace: testNamespace}, ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			// The fake client does not set the UID the pods are matched on.
+			if ds.GetUID() == "" {
+				ds.SetUID(types.UID(dsName))
+				err = cl.Update(context.TODO(), ds)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			}
+			controller := true
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: testNamespace,
+					Labels:    ds.Spec.Selector.MatchLabels,
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							Controller: &controller,
+							UID:        ds.GetUID(),
+						},
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  MultiPurposeHostPathProvisionerName,
+							Image: image,
+						},
+					},
+				},
+			}
+			err = cl.Create(context.TODO(), pod)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		}
+		getTemplateImage := func(dsName string) string {
+			ds := &appsv1.DaemonSet{}
+			err := cl.Get(context.TODO(), types.NamespacedName{Name: dsName, Namespace: testNamespace}, ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			return getProvisionerImage(&ds.Spec.Template.Spec)
+		}
+		csiName := fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)
+		legacyImage := getTemplateImage(MultiPurposeHostPathProvisionerName)
+		// The images of the two DaemonSets are configured independently, different tags are not a skew.
+		createVersionPod("csi-pod", csiName, getTemplateImage(csiName))
+		createVersionPod("legacy-pod", MultiPurposeHostPathProvisionerName, "quay.io/kubevirt/hostpath-provisioner@sha256:1234")
+		expectedMessage := fmt.Sprintf(versionSkewMessage, fmt.Sprintf(versionSkewWorkloadMessage, MultiPurposeHostPathProvisionerName, "quay.io/kubevirt/hostpath-provisioner@sha256:1234", legacyImage))
+
+		_, err := r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		err = cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		degraded := conditions.FindStatusCondition(cr.Status.Conditions, conditions.ConditionDegraded)
+		gomega.Expect(degraded).ToNot(gomega.BeNil())
+		gomega.Expect(degraded.Status).To(gomega.Equal(corev1.ConditionTrue))
+		gomega.Expect(degraded.Reason).To(gomega.Equal(versionSkew))
+		gomega.Expect(degraded.Message).To(gomega.Equal(expectedMessage))
+		recorder, ok := r.recorder.(*record.FakeRecorder)
+		gomega.Expect(ok).To(gomega.BeTrue())
+		var events []string
+		for len(recorder.Events) > 0 {
+			events = append(events, <-recorder.Events)
+		}
+		gomega.Expect(events).To(gomega.ContainElement(fmt.Sprintf("%s %s %s", corev1.EventTypeWarning, versionSkew, expectedMessage)))
+
+		ginkgo.By("Rolling out the same version to the legacy DaemonSet")
+		pod := &corev1.Pod{}
+		err = cl.Get(context.TODO(), types.NamespacedName{Name: "legacy-pod", Namespace: testNamespace}, pod)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		err = cl.Delete(context.TODO(), pod)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		createVersionPod("legacy-pod-updated", MultiPurposeHostPathProvisionerName, legacyImage)
+		_, err = r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		err = cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(IsCrHealthy(cr)).To(gomega.BeTrue())
+	})
+
+	ginkgo.It("Should report why the pods cannot be scheduled in the Progressing condition while deploying", func() {
 		req := reconcile.Request{
 			NamespacedName: types.NamespacedName{
-				Name:      "test-name",
-				Namespace: testNamespace,
+				Name: "test-name",
 			},
 		}
-		_, r, _ := createDeployedCr(createLegacyCr())
-		// Mimic a service account from a previous version whose name depends on the CR's
-		err := r.client.Create(context.TODO(), createServiceAccountWithNameThatDependsOnCr())
-		gomega.Expect(err).NotTo(gomega.HaveOccurred())
-		saList := &corev1.ServiceAccountList{}
-		err = r.client.List(context.TODO(), saList, &client.ListOptions{Namespace: testNamespace})
-		gomega.Expect(err).NotTo(gomega.HaveOccurred())
-		gomega.Expect(len(saList.Items)).To(gomega.Equal(3))
-		gomega.Expect(saList.Items[1].Name).To(gomega.Equal(ProvisionerServiceAccountNameCsi))
-
-		version.VersionStringFunc = func() (string, error) {
-			return "1.0.2", nil
-		}
-		res, err := r.Reconcile(context.TODO(), req)
+		cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+		err := cl.Get(context.TODO(), req.NamespacedName, cr)
 		gomega.Expect(err).NotTo(gomega.HaveOccurred())
-		gomega.Expect(res.Requeue).To(gomega.BeFalse())
-
-		updatedCr := &hppv1.HostPathProvisioner{}
-		err = r.client.Get(context.TODO(), req.NamespacedName, updatedCr)
+		cr.Status.ObservedVersion = ""
+		err = cl.Status().Update(context.TODO(), cr)
 		gomega.Expect(err).NotTo(gomega.HaveOccurred())
-		gomega.Expect(updatedCr.Status.OperatorVersion).To(gomega.Equal("1.0.2"))
-		gomega.Expect(updatedCr.Status.ObservedVersion).To(gomega.Equal("1.0.2"))
-		gomega.Expect(updatedCr.Status.TargetVersion).To(gomega.Equal("1.0.2"))
-
-		gomega.Expect(conditions.IsStatusConditionTrue(updatedCr.Status.Conditions, conditions.ConditionAvailable)).To(gomega.BeTrue())
-		gomega.Expect(conditions.IsStatusConditionTrue(updatedCr.Status.Conditions, conditions.ConditionProgressing)).To(gomega.BeFalse())
-		gomega.Expect(conditions.IsStatusConditionTrue(updatedCr.Status.Conditions, conditions.ConditionDegraded)).To(gomega.BeFalse())
+		csiName := fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)
+		setDaemonSetRolloutStatus(cl, csiName, 2, 3, 3)
 
-		saList = &corev1.ServiceAccountList{}
-		err = r.client.List(context.TODO(), saList, &client.ListOptions{Namespace: testNamespace})
+		ginkgo.By("Creating a pod that is pending because of an unsatisfiable node selector")
+		ds := &appsv1.DaemonSet{}
+		err = cl.Get(context.TODO(), types.NamespacedName{Name: csiName, Namespace: testNamespace}, ds)
 		gomega.Expect(err).NotTo(gomega.HaveOccurred())
-		gomega.Expect(len(saList.Items)).To(gomega.Equal(2))
-		gomega.Expect(saList.Items[0].Name).To(gomega.Equal(ProvisionerServiceAccountName))
-	})
-
-	ginkgo.It("Should err when more than one CR", func() {
-		secondCr := &hppv1.HostPathProvisioner{
+		schedulerMessage := "0/3 nodes are available: 3 node(s) didn't match Pod's node affinity/selector."
+		controller := true
+		pod := &corev1.Pod{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      "test-name-second",
+				Name:      "pending-pod",
 				Namespace: testNamespace,
+				Labels:    ds.Spec.Selector.MatchLabels,
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Controller: &controller,
+						UID:        ds.GetUID(),
+					},
+				},
 			},
-			Spec: hppv1.HostPathProvisionerSpec{
-				ImagePullPolicy: corev1.PullAlways,
-				PathConfig: &hppv1.PathConfig{
-					Path:            "/tmp/test",
-					UseNamingPrefix: false,
+			Spec: corev1.PodSpec{
+				NodeSelector: map[string]string{
+					"missing": "label",
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+				Conditions: []corev1.PodCondition{
+					{
+						Type:    corev1.PodScheduled,
+						Status:  corev1.ConditionFalse,
+						Reason:  corev1.PodReasonUnschedulable,
+						Message: schedulerMessage,
+					},
 				},
 			},
 		}
-		cr := createLegacyCr()
-		objs := []runtime.Object{cr, secondCr}
-		// Register operator types with the runtime scheme.
-		s := scheme.Scheme
-		s.AddKnownTypes(hppv1.SchemeGroupVersion, cr)
-		s.AddKnownTypes(hppv1.SchemeGroupVersion, &hppv1.HostPathProvisionerList{})
-		promv1.AddToScheme(s)
-		secv1.Install(s)
-
-		// Create a fake client to mock API calls.
-		cl := fake.NewFakeClient(objs...)
+		err = cl.Create(context.TODO(), pod)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
 
-		// Create a ReconcileMemcached object with the scheme and fake client.
-		r := &ReconcileHostPathProvisioner{
-			client:   cl,
-			scheme:   s,
-			recorder: record.NewFakeRecorder(250),
-			Log:      logf.Log.WithName("hostpath-provisioner-operator-controller-test"),
-		}
+		_, err = r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		err = cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		progressing := conditions.FindStatusCondition(cr.Status.Conditions, conditions.ConditionProgressing)
+		gomega.Expect(progressing).ToNot(gomega.BeNil())
+		gomega.Expect(progressing.Status).To(gomega.Equal(corev1.ConditionTrue))
+		gomega.Expect(progressing.Reason).To(gomega.Equal(deployStarted))
+		gomega.Expect(progressing.Message).To(gomega.Equal(fmt.Sprintf(unschedulablePodsMessage, "Rolling out: 3/3 nodes updated", schedulerMessage)))
+
+		ginkgo.By("Scheduling the pod, the reason should be removed")
+		pod.Status.Conditions[0].Status = corev1.ConditionTrue
+		pod.Status.Conditions[0].Reason = ""
+		pod.Status.Conditions[0].Message = ""
+		err = cl.Status().Update(context.TODO(), pod)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		_, err = r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		err = cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		progressing = conditions.FindStatusCondition(cr.Status.Conditions, conditions.ConditionProgressing)
+		gomega.Expect(progressing.Message).To(gomega.Equal("Rolling out: 3/3 nodes updated"))
+	})
 
+	ginkgo.It("Should list the nodes the pods cannot be scheduled on in the status", func() {
 		req := reconcile.Request{
 			NamespacedName: types.NamespacedName{
-				Name:      "test-name",
-				Namespace: testNamespace,
+				Name: "test-name",
 			},
 		}
-		res, err := r.Reconcile(context.TODO(), req)
-		gomega.Expect(err).To(gomega.HaveOccurred())
-		gomega.Expect(res.Requeue).To(gomega.BeFalse())
-		gomega.Expect(err.Error()).To(gomega.Equal("there should be a single hostpath provisioner, 2 items found"))
+		cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+		gomega.Expect(cr.Status.UnschedulableNodes).To(gomega.BeEmpty())
+		csiName := fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)
+		ds := &appsv1.DaemonSet{}
+		err := cl.Get(context.TODO(), types.NamespacedName{Name: csiName, Namespace: testNamespace}, ds)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		createPendingPod := func(node, message string) *corev1.Pod {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("pending-pod-%s", node),
+					Namespace: testNamespace,
+					Labels:    ds.Spec.Selector.MatchLabels,
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							Controller: ptr.To(true),
+							UID:        ds.GetUID(),
+						},
+					},
+				},
+				Spec: corev1.PodSpec{
+					Affinity: &corev1.Affinity{
+						NodeAffinity: &corev1.NodeAffinity{
+							RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+								NodeSelectorTerms: []corev1.NodeSelectorTerm{
+									{
+										MatchFields: []corev1.NodeSelectorRequirement{
+											{
+												Key:      metav1.ObjectNameField,
+												Operator: corev1.NodeSelectorOpIn,
+												Values:   []string{node},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodPending,
+					Conditions: []corev1.PodCondition{
+						{
+							Type:    corev1.PodScheduled,
+							Status:  corev1.ConditionFalse,
+							Reason:  corev1.PodReasonUnschedulable,
+							Message: message,
+						},
+					},
+				},
+			}
+			err := cl.Create(context.TODO(), pod)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			return pod
+		}
+		insufficientCPU := "0/3 nodes are available: 1 Insufficient cpu."
+		untolerated := "0/3 nodes are available: 1 node(s) had untolerated taint {storage: maintenance}."
+		pendingPod := createPendingPod("node-b", insufficientCPU)
+		createPendingPod("node-a", untolerated)
+
+		_, err = r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		err = cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(cr.Status.UnschedulableNodes).To(gomega.Equal([]hppv1.UnschedulableNode{
+			{Node: "node-a", DaemonSet: csiName, Reason: untolerated},
+			{Node: "node-b", DaemonSet: csiName, Reason: insufficientCPU},
+		}))
+
+		ginkgo.By("Scheduling a pod, its node should be removed")
+		pendingPod.Status.Phase = corev1.PodRunning
+		pendingPod.Status.Conditions[0].Status = corev1.ConditionTrue
+		pendingPod.Status.Conditions[0].Reason = ""
+		pendingPod.Status.Conditions[0].Message = ""
+		err = cl.Status().Update(context.TODO(), pendingPod)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		_, err = r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		err = cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(cr.Status.UnschedulableNodes).To(gomega.Equal([]hppv1.UnschedulableNode{
+			{Node: "node-a", DaemonSet: csiName, Reason: untolerated},
+		}))
 	})
 
-	ginkgo.It("Should not requeue when CR is deleted", func() {
+	ginkgo.DescribeTable("Should mark the CR degraded if the storage config is invalid", func(pathConfig *hppv1.PathConfig, storagePools []hppv1.StoragePool, reason, message string) {
 		req := reconcile.Request{
 			NamespacedName: types.NamespacedName{
-				Name:      "test-name",
-				Namespace: testNamespace,
+				Name: "test-name",
 			},
 		}
-		cr, r, cl := createDeployedCr(createLegacyCr())
-		err := cl.Delete(context.TODO(), cr)
+		cr := createLegacyCr()
+		cr.Spec.PathConfig = pathConfig
+		cr.Spec.StoragePools = storagePools
+		r, cl := createReconciler(cr)
+		_, err := r.Reconcile(context.TODO(), req)
 		gomega.Expect(err).NotTo(gomega.HaveOccurred())
-		res, err := r.Reconcile(context.TODO(), req)
+		err = cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(conditions.IsStatusConditionTrue(cr.Status.Conditions, conditions.ConditionAvailable)).To(gomega.BeFalse())
+		degraded := conditions.FindStatusCondition(cr.Status.Conditions, conditions.ConditionDegraded)
+		gomega.Expect(degraded).ToNot(gomega.BeNil())
+		gomega.Expect(degraded.Status).To(gomega.Equal(corev1.ConditionTrue))
+		gomega.Expect(degraded.Reason).To(gomega.Equal(reason))
+		gomega.Expect(degraded.Message).To(gomega.Equal(message))
+		for _, name := range []string{MultiPurposeHostPathProvisionerName, fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)} {
+			err = cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: testNamespace}, &appsv1.DaemonSet{})
+			gomega.Expect(errors.IsNotFound(err)).To(gomega.BeTrue())
+		}
+	},
+		ginkgo.Entry("both set", &hppv1.PathConfig{Path: "/tmp/test"}, []hppv1.StoragePool{{Name: "local", Path: "/tmp/test2"}}, conflictingStorageConfig, conflictingStorageConfigMessage),
+		ginkgo.Entry("neither set", nil, nil, missingStorageConfig, missingStorageConfigMessage),
+		ginkgo.Entry("nested storage pool paths", nil, []hppv1.StoragePool{{Name: "local", Path: "/tmp/test"}, {Name: "fast", Path: "/tmp/test/fast"}},
+			overlappingStoragePaths, fmt.Sprintf(overlappingStoragePathsMessage, "local", "fast", "/tmp/test", "/tmp/test/fast")),
+		ginkgo.Entry("identical storage pool paths", nil, []hppv1.StoragePool{{Name: "local", Path: "/tmp/test/"}, {Name: "other", Path: "/tmp/test"}},
+			overlappingStoragePaths, fmt.Sprintf(overlappingStoragePathsMessage, "local", "other", "/tmp/test/", "/tmp/test")),
+	)
+
+	ginkgo.It("Should keep a deployed CR available if storage pool paths that overlap are added", func() {
+		cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cr)}
+		err := cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		existing := cr.Spec.StoragePools[0]
+		cr.Spec.StoragePools = append(cr.Spec.StoragePools, hppv1.StoragePool{Name: "nested", Path: existing.Path + "/nested"})
+		err = cl.Update(context.TODO(), cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		_, err = r.Reconcile(context.TODO(), req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		err = cl.Get(context.TODO(), req.NamespacedName, cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(conditions.IsStatusConditionTrue(cr.Status.Conditions, conditions.ConditionAvailable)).To(gomega.BeTrue())
+		degraded := conditions.FindStatusCondition(cr.Status.Conditions, conditions.ConditionDegraded)
+		gomega.Expect(degraded).ToNot(gomega.BeNil())
+		gomega.Expect(degraded.Status).To(gomega.Equal(corev1.ConditionTrue))
+		gomega.Expect(degraded.Reason).To(gomega.Equal(overlappingStoragePaths))
+		gomega.Expect(degraded.Message).To(gomega.Equal(fmt.Sprintf(overlappingStoragePathsMessage, existing.Name, "nested", existing.Path, existing.Path+"/nested")))
+		err = cl.Get(context.TODO(), types.NamespacedName{Name: fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName), Namespace: testNamespace}, &appsv1.DaemonSet{})
 		gomega.Expect(err).NotTo(gomega.HaveOccurred())
-		gomega.Expect(res.Requeue).To(gomega.BeFalse())
 	})
 
-	ginkgo.It("Should update CR with FailedHealing", func() {
+	ginkgo.DescribeTable("Should deploy with a single storage config", func(cr *hppv1.HostPathProvisioner) {
+		cr, _, cl := createDeployedCr(cr)
+		err := cl.Get(context.TODO(), client.ObjectKeyFromObject(cr), cr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(IsCrHealthy(cr)).To(gomega.BeTrue())
+	},
+		ginkgo.Entry("pathConfig", createLegacyCr()),
+		ginkgo.Entry("storagePools", createStoragePoolWithTemplateCr()),
+		ginkgo.Entry("storagePools with disjoint paths", func() *hppv1.HostPathProvisioner {
+			cr := createStoragePoolWithTemplateCr()
+			cr.Spec.StoragePools = append(cr.Spec.StoragePools, hppv1.StoragePool{Name: "other", Path: cr.Spec.StoragePools[0].Path + "2"})
+			return cr
+		}()),
+	)
+
+	ginkgo.DescribeTable("Should set max concurrent reconciles from the environment", func(value string, expected int) {
+		if value != "" {
+			os.Setenv(maxConcurrentReconcilesEnvVarName, value)
+			defer os.Unsetenv(maxConcurrentReconcilesEnvVarName)
+		}
+		r := &ReconcileHostPathProvisioner{}
+		options := getControllerOptions(r)
+		gomega.Expect(options.Reconciler).To(gomega.Equal(r))
+		gomega.Expect(options.MaxConcurrentReconciles).To(gomega.Equal(expected))
+	},
+		ginkgo.Entry("not set", "", 1),
+		ginkgo.Entry("set", "4", 4),
+		ginkgo.Entry("zero", "0", 1),
+		ginkgo.Entry("invalid", "abc", 1),
+	)
+
+	ginkgo.DescribeTable("Should set the reconcile period from the environment", func(value string, expected time.Duration) {
+		if value != "" {
+			os.Setenv(reconcilePeriodEnvVarName, value)
+			defer os.Unsetenv(reconcilePeriodEnvVarName)
+		}
+		gomega.Expect(getReconcilePeriod()).To(gomega.Equal(expected))
+	},
+		ginkgo.Entry("not set", "", defaultReconcilePeriod),
+		ginkgo.Entry("set", "30m", 30*time.Minute),
+		ginkgo.Entry("zero", "0", time.Duration(0)),
+		ginkgo.Entry("negative", "-1m", defaultReconcilePeriod),
+		ginkgo.Entry("invalid", "abc", defaultReconcilePeriod),
+	)
+
+	ginkgo.It("Should requeue after the reconcile period", func() {
 		req := reconcile.Request{
 			NamespacedName: types.NamespacedName{
-				Name:      "test-name",
-				Namespace: testNamespace,
+				Name: "test-name",
 			},
 		}
-		_, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
-		ds := &appsv1.DaemonSet{}
-		dsNN := types.NamespacedName{
-			Name:      fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName),
-			Namespace: testNamespace,
-		}
-		err := cl.Get(context.TODO(), dsNN, ds)
-		gomega.Expect(err).NotTo(gomega.HaveOccurred())
-		err = cl.Delete(context.TODO(), ds, &client.DeleteOptions{})
+		_, r, _ := createDeployedCr(createStoragePoolWithTemplateCr())
+		res, err := r.Reconcile(context.TODO(), req)
 		gomega.Expect(err).NotTo(gomega.HaveOccurred())
-		newCl := erroringFakeCtrlRuntimeClient{
-			Client: cl,
-			errMsg: "create failed",
-		}
-		r.client = newCl
-		_, err = r.Reconcile(context.TODO(), req)
-		gomega.Expect(err).To(gomega.HaveOccurred())
-		updatedCr := &hppv1.HostPathProvisioner{}
-		err = r.client.Get(context.TODO(), req.NamespacedName, updatedCr)
+		gomega.Expect(res.RequeueAfter).To(gomega.BeZero())
+
+		r.reconcilePeriod = 5 * time.Minute
+		res, err = r.Reconcile(context.TODO(), req)
 		gomega.Expect(err).NotTo(gomega.HaveOccurred())
-		gomega.Expect(conditions.IsStatusConditionTrue(updatedCr.Status.Conditions, conditions.ConditionAvailable)).To(gomega.BeFalse())
-		gomega.Expect(conditions.IsStatusConditionTrue(updatedCr.Status.Conditions, conditions.ConditionProgressing)).To(gomega.BeTrue())
-		gomega.Expect(conditions.IsStatusConditionTrue(updatedCr.Status.Conditions, conditions.ConditionDegraded)).To(gomega.BeTrue())
-		gomega.Expect(conditions.FindStatusCondition(updatedCr.Status.Conditions, conditions.ConditionDegraded).Message).To(gomega.Equal("Unable to successfully reconcile: create failed"))
+		gomega.Expect(res.RequeueAfter).To(gomega.Equal(5 * time.Minute))
 	})
 })
 
+func setDaemonSetRolloutStatus(cl client.Client, name string, ready, updated, desired int32) {
+	ds := &appsv1.DaemonSet{}
+	err := cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: testNamespace}, ds)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	ds.Status.NumberReady = ready
+	ds.Status.UpdatedNumberScheduled = updated
+	ds.Status.DesiredNumberScheduled = desired
+	err = cl.Status().Update(context.TODO(), ds)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+}
+
 func createLegacyCr() *hppv1.HostPathProvisioner {
 	return &hppv1.HostPathProvisioner{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "test-name",
-			Namespace: testNamespace,
+			Name: "test-name",
 		},
 		Spec: hppv1.HostPathProvisionerSpec{
 			ImagePullPolicy: corev1.PullAlways,
@@ -504,8 +1871,7 @@ func createLegacyCr() *hppv1.HostPathProvisioner {
 func createLegacyStoragePoolCr() *hppv1.HostPathProvisioner {
 	return &hppv1.HostPathProvisioner{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "test-name",
-			Namespace: testNamespace,
+			Name: "test-name",
 		},
 		Spec: hppv1.HostPathProvisionerSpec{
 			ImagePullPolicy: corev1.PullAlways,
@@ -540,8 +1906,7 @@ func createStoragePoolWithTemplateVolumeModeCr(name string, volumeMode *corev1.P
 	scName := "test"
 	return &hppv1.HostPathProvisioner{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "test-name",
-			Namespace: testNamespace,
+			Name: "test-name",
 		},
 		Spec: hppv1.HostPathProvisionerSpec{
 			ImagePullPolicy: corev1.PullAlways,
@@ -579,8 +1944,8 @@ func createStoragePoolWithTemplateVolumeModeAndBasicCr(name string, volumeMode *
 	return cr
 }
 
-// After this has run, the returned cr state should be available, not progressing and not degraded.
-func createDeployedCr(cr *hppv1.HostPathProvisioner) (*hppv1.HostPathProvisioner, *ReconcileHostPathProvisioner, client.Client) {
+// createReconciler returns a reconciler with a fake client that contains the passed in CR.
+func createReconciler(cr *hppv1.HostPathProvisioner) (*ReconcileHostPathProvisioner, erroringFakeCtrlRuntimeClient) {
 	objs := []runtime.Object{cr}
 	// Register operator types with the runtime scheme.
 	s := scheme.Scheme
@@ -592,25 +1957,30 @@ func createDeployedCr(cr *hppv1.HostPathProvisioner) (*hppv1.HostPathProvisioner
 
 	// Create a fake client to mock API calls.
 	cl := erroringFakeCtrlRuntimeClient{
-		Client: fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).Build(),
+		Client: fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).WithStatusSubresource(cr).
+			WithInterceptorFuncs(interceptor.Funcs{Patch: fakeApplyPatch}).Build(),
 		errMsg: "",
 	}
 
 	// Create a ReconcileMemcached object with the scheme and fake client.
 	r := &ReconcileHostPathProvisioner{
-		client:   cl,
-		scheme:   s,
-		recorder: record.NewFakeRecorder(250),
-		Log:      logf.Log.WithName("hostpath-provisioner-operator-controller-test"),
+		client:    cl,
+		scheme:    s,
+		recorder:  record.NewFakeRecorder(250),
+		Log:       logf.Log.WithName("hostpath-provisioner-operator-controller-test"),
+		apiReader: cl,
 	}
+	return r, cl
+}
+
+// After this has run, the returned cr state should be available, not progressing and not degraded.
+func createDeployedCr(cr *hppv1.HostPathProvisioner) (*hppv1.HostPathProvisioner, *ReconcileHostPathProvisioner, client.Client) {
+	r, cl := createReconciler(cr)
 
 	// Mock request to simulate Reconcile() being called on an event for a
 	// watched resource .
 	req := reconcile.Request{
-		NamespacedName: types.NamespacedName{
-			Name:      "test-name",
-			Namespace: testNamespace,
-		},
+		NamespacedName: client.ObjectKeyFromObject(cr),
 	}
 	res, err := r.Reconcile(context.TODO(), req)
 	gomega.Expect(err).NotTo(gomega.HaveOccurred())
@@ -1204,14 +2574,203 @@ func createServiceAccountWithNameThatDependsOnCr() *corev1.ServiceAccount {
 	}
 }
 
+// erroringFakeCtrlRuntimeClient fails the creates with err, or with errMsg if err is not set.
 type erroringFakeCtrlRuntimeClient struct {
 	client.Client
 	errMsg string
+	err    error
 }
 
-func (p erroringFakeCtrlRuntimeClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+func (p erroringFakeCtrlRuntimeClient) createError() error {
+	if p.err != nil {
+		return p.err
+	}
 	if len(p.errMsg) > 0 {
 		return fmt.Errorf(p.errMsg)
 	}
+	return nil
+}
+
+func (p erroringFakeCtrlRuntimeClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := p.createError(); err != nil {
+		return err
+	}
+	return p.Client.Create(ctx, obj, opts...)
+}
+
+// Patch fails apply patches that would create the object, the operator creates the managed objects with apply patches.
+func (p erroringFakeCtrlRuntimeClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if createErr := p.createError(); createErr != nil && patch.Type() == types.ApplyPatchType {
+		current := obj.DeepCopyObject().(client.Object)
+		if err := p.Client.Get(ctx, client.ObjectKeyFromObject(obj), current); errors.IsNotFound(err) {
+			return createErr
+		}
+	}
+	return p.Client.Patch(ctx, obj, patch, opts...)
+}
+
+// cancellingFakeCtrlRuntimeClient honors context cancellation like a real client does, and cancels the context after
+// cancelAfter calls if set.
+type cancellingFakeCtrlRuntimeClient struct {
+	client.Client
+	cancelAfter      int
+	cancel           context.CancelFunc
+	calls            int
+	callsAfterCancel int
+}
+
+func (p *cancellingFakeCtrlRuntimeClient) checkContext(ctx context.Context) error {
+	p.calls++
+	if p.cancel != nil && p.calls > p.cancelAfter {
+		p.cancel()
+	}
+	if err := ctx.Err(); err != nil {
+		p.callsAfterCancel++
+		return err
+	}
+	return nil
+}
+
+func (p *cancellingFakeCtrlRuntimeClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if err := p.checkContext(ctx); err != nil {
+		return err
+	}
+	return p.Client.Get(ctx, key, obj, opts...)
+}
+
+func (p *cancellingFakeCtrlRuntimeClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if err := p.checkContext(ctx); err != nil {
+		return err
+	}
+	return p.Client.List(ctx, list, opts...)
+}
+
+func (p *cancellingFakeCtrlRuntimeClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := p.checkContext(ctx); err != nil {
+		return err
+	}
 	return p.Client.Create(ctx, obj, opts...)
 }
+
+func (p *cancellingFakeCtrlRuntimeClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := p.checkContext(ctx); err != nil {
+		return err
+	}
+	return p.Client.Update(ctx, obj, opts...)
+}
+
+func (p *cancellingFakeCtrlRuntimeClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if err := p.checkContext(ctx); err != nil {
+		return err
+	}
+	return p.Client.Delete(ctx, obj, opts...)
+}
+
+func (p *cancellingFakeCtrlRuntimeClient) Status() client.SubResourceWriter {
+	return &cancellingFakeStatusWriter{SubResourceWriter: p.Client.Status(), client: p}
+}
+
+type cancellingFakeStatusWriter struct {
+	client.SubResourceWriter
+	client *cancellingFakeCtrlRuntimeClient
+}
+
+func (w *cancellingFakeStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	if err := w.client.checkContext(ctx); err != nil {
+		return err
+	}
+	return w.SubResourceWriter.Patch(ctx, obj, patch, opts...)
+}
+
+// conflictingFakeCtrlRuntimeClient changes the CR before the first conflicts status patches, and then fails them
+// with a conflict, like a concurrent writer would.
+type conflictingFakeCtrlRuntimeClient struct {
+	client.Client
+	conflicts     int
+	statusPatches int
+}
+
+func (p *conflictingFakeCtrlRuntimeClient) Status() client.SubResourceWriter {
+	return &conflictingFakeStatusWriter{SubResourceWriter: p.Client.Status(), client: p}
+}
+
+type conflictingFakeStatusWriter struct {
+	client.SubResourceWriter
+	client *conflictingFakeCtrlRuntimeClient
+}
+
+func (w *conflictingFakeStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	w.client.statusPatches++
+	if w.client.statusPatches <= w.client.conflicts {
+		cr := &hppv1.HostPathProvisioner{}
+		if err := w.client.Get(ctx, client.ObjectKeyFromObject(obj), cr); err != nil {
+			return err
+		}
+		cr.SetLabels(map[string]string{"concurrent": fmt.Sprintf("%d", w.client.statusPatches)})
+		if err := w.client.Update(ctx, cr); err != nil {
+			return err
+		}
+	}
+	return w.SubResourceWriter.Patch(ctx, obj, patch, opts...)
+}
+
+func lastSuccessfulReconcileValue() float64 {
+	return gaugeValue("kubevirt_hpp_last_successful_reconcile_timestamp_seconds")
+}
+
+func clockSkewValue() float64 {
+	return gaugeValue("kubevirt_hpp_clock_skew_seconds")
+}
+
+func gaugeValue(name string) float64 {
+	families, err := runtimemetrics.Registry.Gather()
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	for _, family := range families {
+		if family.GetName() == name {
+			gomega.Expect(family.GetMetric()).To(gomega.HaveLen(1))
+			return family.GetMetric()[0].GetGauge().GetValue()
+		}
+	}
+	ginkgo.Fail(fmt.Sprintf("%s is not registered", name))
+	return 0
+}
+
+func buildInfoLabels() []map[string]string {
+	families, err := runtimemetrics.Registry.Gather()
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	labels := make([]map[string]string, 0)
+	for _, family := range families {
+		if family.GetName() != "kubevirt_hpp_build_info" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			gomega.Expect(metric.GetGauge().GetValue()).To(gomega.Equal(float64(1)))
+			metricLabels := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				metricLabels[label.GetName()] = label.GetValue()
+			}
+			labels = append(labels, metricLabels)
+		}
+	}
+	return labels
+}
+
+func operatorInfoNamespaces() []string {
+	families, err := runtimemetrics.Registry.Gather()
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	namespaces := make([]string, 0)
+	for _, family := range families {
+		if family.GetName() != "kubevirt_hpp_operator_info" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			gomega.Expect(metric.GetGauge().GetValue()).To(gomega.Equal(float64(1)))
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "namespace" {
+					namespaces = append(namespaces, label.GetValue())
+				}
+			}
+		}
+	}
+	return namespaces
+}
@@ -19,10 +19,67 @@ package hostpathprovisioner
 import (
 	conditions "github.com/openshift/custom-resource-status/conditions/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	hostpathprovisionerv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
 )
 
+// ConditionUnknownFeatureGates is true if the CR lists feature gates the operator doesn't know about.
+const ConditionUnknownFeatureGates conditions.ConditionType = "UnknownFeatureGates"
+
+// ConditionVolumeNodeAffinityConflict is true if pods cannot be scheduled because their volumes are bound to other
+// nodes. It is only set if the VolumeNodeAffinityConflictDetection feature gate is enabled.
+const ConditionVolumeNodeAffinityConflict conditions.ConditionType = "VolumeNodeAffinityConflict"
+
+// ConditionProvisioningVerified is true if the verification Job provisioned a volume, and wrote and read a file. It is
+// only set if spec.verifyProvisioning is set.
+const ConditionProvisioningVerified conditions.ConditionType = "ProvisioningVerified"
+
+// maxConditionHistory is the number of condition transitions kept in the status.
+const maxConditionHistory = 20
+
+// knownConditionTypes are the condition types the operator sets. Conditions of other types were set by previous
+// versions of the operator, and are removed from the status.
+var knownConditionTypes = sets.New(
+	conditions.ConditionAvailable,
+	conditions.ConditionProgressing,
+	conditions.ConditionDegraded,
+	ConditionUnknownFeatureGates,
+	ConditionVolumeNodeAffinityConflict,
+	ConditionProvisioningVerified,
+)
+
+// pruneUnknownConditions removes the conditions the operator doesn't set anymore. The CR object needs to be updated by
+// the caller afterwards.
+func pruneUnknownConditions(cr *hostpathprovisionerv1.HostPathProvisioner) {
+	for _, condition := range cr.Status.Conditions {
+		if !knownConditionTypes.Has(condition.Type) {
+			conditions.RemoveStatusCondition(&cr.Status.Conditions, condition.Type)
+		}
+	}
+}
+
+// setCrCondition sets the condition, and records the change in the condition history if the status or reason of the
+// condition changes. The oldest transitions are dropped once the history is full.
+func setCrCondition(cr *hostpathprovisionerv1.HostPathProvisioner, condition conditions.Condition) {
+	if current := conditions.FindStatusCondition(cr.Status.Conditions, condition.Type); current != nil &&
+		(current.Status != condition.Status || current.Reason != condition.Reason) {
+		cr.Status.ConditionHistory = append(cr.Status.ConditionHistory, hostpathprovisionerv1.ConditionTransition{
+			Type:           condition.Type,
+			Status:         condition.Status,
+			Reason:         condition.Reason,
+			PreviousStatus: current.Status,
+			PreviousReason: current.Reason,
+			Time:           metav1.Now(),
+		})
+		if extra := len(cr.Status.ConditionHistory) - maxConditionHistory; extra > 0 {
+			cr.Status.ConditionHistory = cr.Status.ConditionHistory[extra:]
+		}
+	}
+	conditions.SetStatusCondition(&cr.Status.Conditions, condition)
+}
+
 func (r *ReconcileHostPathProvisioner) isDeploying(cr *hostpathprovisionerv1.HostPathProvisioner) bool {
 	return cr.Status.ObservedVersion == ""
 }
@@ -31,23 +88,33 @@ func (r *ReconcileHostPathProvisioner) isUpgrading(cr *hostpathprovisionerv1.Hos
 	return cr.Status.ObservedVersion != "" && cr.Status.ObservedVersion != cr.Status.TargetVersion
 }
 
+// MarkCrReconciled records the time of a successful reconcile. The CR object needs to be updated by the caller afterwards.
+// The time is only refreshed after lastReconcileTimeInterval, otherwise every reconcile would update the CR and trigger
+// another reconcile.
+func MarkCrReconciled(cr *hostpathprovisionerv1.HostPathProvisioner) {
+	now := metav1.Now()
+	if cr.Status.LastReconcileTime == nil || now.Sub(cr.Status.LastReconcileTime.Time) >= lastReconcileTimeInterval {
+		cr.Status.LastReconcileTime = &now
+	}
+}
+
 // MarkCrHealthyMessage marks the passed in CR as healthy. The CR object needs to be updated by the caller afterwards.
 // Healthy means the following status conditions are set:
 // ApplicationAvailable: true
 // Progressing: false
 // Degraded: false
 func MarkCrHealthyMessage(cr *hostpathprovisionerv1.HostPathProvisioner, reason, message string) {
-	conditions.SetStatusCondition(&cr.Status.Conditions, conditions.Condition{
+	setCrCondition(cr, conditions.Condition{
 		Type:    conditions.ConditionAvailable,
 		Status:  corev1.ConditionTrue,
 		Reason:  reason,
 		Message: message,
 	})
-	conditions.SetStatusCondition(&cr.Status.Conditions, conditions.Condition{
+	setCrCondition(cr, conditions.Condition{
 		Type:   conditions.ConditionProgressing,
 		Status: corev1.ConditionFalse,
 	})
-	conditions.SetStatusCondition(&cr.Status.Conditions, conditions.Condition{
+	setCrCondition(cr, conditions.Condition{
 		Type:   conditions.ConditionDegraded,
 		Status: corev1.ConditionFalse,
 	})
@@ -70,15 +137,15 @@ func IsCrHealthy(cr *hostpathprovisionerv1.HostPathProvisioner) bool {
 // Progressing: true
 // Degraded: true
 func MarkCrUpgradeHealingDegraded(cr *hostpathprovisionerv1.HostPathProvisioner, reason, message string) {
-	conditions.SetStatusCondition(&cr.Status.Conditions, conditions.Condition{
+	setCrCondition(cr, conditions.Condition{
 		Type:   conditions.ConditionAvailable,
 		Status: corev1.ConditionTrue,
 	})
-	conditions.SetStatusCondition(&cr.Status.Conditions, conditions.Condition{
+	setCrCondition(cr, conditions.Condition{
 		Type:   conditions.ConditionProgressing,
 		Status: corev1.ConditionTrue,
 	})
-	conditions.SetStatusCondition(&cr.Status.Conditions, conditions.Condition{
+	setCrCondition(cr, conditions.Condition{
 		Type:    conditions.ConditionDegraded,
 		Status:  corev1.ConditionTrue,
 		Reason:  reason,
@@ -92,15 +159,28 @@ func MarkCrUpgradeHealingDegraded(cr *hostpathprovisionerv1.HostPathProvisioner,
 // Progressing: false
 // Degraded: true
 func MarkCrFailed(cr *hostpathprovisionerv1.HostPathProvisioner, reason, message string) {
-	conditions.SetStatusCondition(&cr.Status.Conditions, conditions.Condition{
+	setCrCondition(cr, conditions.Condition{
 		Type:   conditions.ConditionAvailable,
 		Status: corev1.ConditionFalse,
 	})
-	conditions.SetStatusCondition(&cr.Status.Conditions, conditions.Condition{
+	setCrCondition(cr, conditions.Condition{
 		Type:   conditions.ConditionProgressing,
 		Status: corev1.ConditionFalse,
 	})
-	conditions.SetStatusCondition(&cr.Status.Conditions, conditions.Condition{
+	setCrCondition(cr, conditions.Condition{
+		Type:    conditions.ConditionDegraded,
+		Status:  corev1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+// MarkCrDegraded marks the passed CR as degraded, without changing the state of the deployed workloads. The CR object
+// needs to be updated by the caller afterwards.
+// Degraded means the following status condition is set:
+// Degraded: true
+func MarkCrDegraded(cr *hostpathprovisionerv1.HostPathProvisioner, reason, message string) {
+	setCrCondition(cr, conditions.Condition{
 		Type:    conditions.ConditionDegraded,
 		Status:  corev1.ConditionTrue,
 		Reason:  reason,
@@ -114,15 +194,15 @@ func MarkCrFailed(cr *hostpathprovisionerv1.HostPathProvisioner, reason, message
 // Progressing: true
 // Degraded: true
 func MarkCrFailedHealing(cr *hostpathprovisionerv1.HostPathProvisioner, reason, message string) {
-	conditions.SetStatusCondition(&cr.Status.Conditions, conditions.Condition{
+	setCrCondition(cr, conditions.Condition{
 		Type:   conditions.ConditionAvailable,
 		Status: corev1.ConditionFalse,
 	})
-	conditions.SetStatusCondition(&cr.Status.Conditions, conditions.Condition{
+	setCrCondition(cr, conditions.Condition{
 		Type:   conditions.ConditionProgressing,
 		Status: corev1.ConditionTrue,
 	})
-	conditions.SetStatusCondition(&cr.Status.Conditions, conditions.Condition{
+	setCrCondition(cr, conditions.Condition{
 		Type:    conditions.ConditionDegraded,
 		Status:  corev1.ConditionTrue,
 		Reason:  reason,
@@ -136,22 +216,51 @@ func MarkCrFailedHealing(cr *hostpathprovisionerv1.HostPathProvisioner, reason,
 // Progressing: true
 // Degraded: false
 func MarkCrDeploying(cr *hostpathprovisionerv1.HostPathProvisioner, reason, message string) {
-	conditions.SetStatusCondition(&cr.Status.Conditions, conditions.Condition{
+	setCrCondition(cr, conditions.Condition{
 		Type:   conditions.ConditionAvailable,
 		Status: corev1.ConditionFalse,
 	})
-	conditions.SetStatusCondition(&cr.Status.Conditions, conditions.Condition{
+	setCrCondition(cr, conditions.Condition{
 		Type:    conditions.ConditionProgressing,
 		Status:  corev1.ConditionTrue,
 		Reason:  reason,
 		Message: message,
 	})
-	conditions.SetStatusCondition(&cr.Status.Conditions, conditions.Condition{
+	setCrCondition(cr, conditions.Condition{
 		Type:   conditions.ConditionDegraded,
 		Status: corev1.ConditionFalse,
 	})
 }
 
+// MarkCrProgressingMessage updates the message of the Progressing condition of the passed in CR, the other conditions are
+// left untouched. The CR object needs to be updated by the caller afterwards.
+func MarkCrProgressingMessage(cr *hostpathprovisionerv1.HostPathProvisioner, message string) {
+	progressing := conditions.FindStatusCondition(cr.Status.Conditions, conditions.ConditionProgressing)
+	if progressing == nil {
+		return
+	}
+	setCrCondition(cr, conditions.Condition{
+		Type:    conditions.ConditionProgressing,
+		Status:  progressing.Status,
+		Reason:  progressing.Reason,
+		Message: message,
+	})
+}
+
+// MarkCrUnknownFeatureGates sets the UnknownFeatureGates condition with the passed in message, and returns true if
+// the message changed. The other conditions are left untouched. The CR object needs to be updated by the caller afterwards.
+func MarkCrUnknownFeatureGates(cr *hostpathprovisionerv1.HostPathProvisioner, message string) bool {
+	current := conditions.FindStatusCondition(cr.Status.Conditions, ConditionUnknownFeatureGates)
+	changed := current == nil || current.Message != message
+	setCrCondition(cr, conditions.Condition{
+		Type:    ConditionUnknownFeatureGates,
+		Status:  corev1.ConditionTrue,
+		Reason:  unknownFeatureGates,
+		Message: message,
+	})
+	return changed
+}
+
 // IsHppAvailable returns whether the HPP installation is available for use
 func IsHppAvailable(cr *hostpathprovisionerv1.HostPathProvisioner) bool {
 	for _, condition := range cr.Status.Conditions {
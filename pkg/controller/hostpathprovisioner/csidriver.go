@@ -27,6 +27,8 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	hostpathprovisionerv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
@@ -34,21 +36,38 @@ import (
 )
 
 const (
+	// driverName is the default name of the CSI driver
 	driverName = "kubevirt.io.hostpath-provisioner"
 )
 
-func (r *ReconcileHostPathProvisioner) reconcileCSIDriver(reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner) (reconcile.Result, error) {
+func (r *ReconcileHostPathProvisioner) reconcileCSIDriver(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner) (reconcile.Result, error) {
+	if isCSIDriverUnmanaged(cr) {
+		return reconcile.Result{}, r.verifyUnmanagedCSIDriver(ctx, reqLogger, cr)
+	}
+	r.forgetEvent(csiDriverUnmanaged)
+	res, err := r.reconcileManagedCSIDriver(ctx, reqLogger, cr)
+	if err != nil {
+		return res, err
+	}
+	return res, r.deletePreviousCSIDriver(ctx, reqLogger, cr)
+}
+
+func (r *ReconcileHostPathProvisioner) reconcileManagedCSIDriver(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner) (reconcile.Result, error) {
 	// Define a new CSIDriver object
-	desired := createCSIDriverObject()
+	desired := createCSIDriverObject(cr)
 
+	// Set HostPathProvisioner instance as the owner and controller
+	if err := controllerutil.SetControllerReference(cr, desired, r.scheme); err != nil {
+		return reconcile.Result{}, err
+	}
 	setLastAppliedConfiguration(desired)
 
 	// Check if this CSIDriver already exists
 	found := &storagev1.CSIDriver{}
-	err := r.client.Get(context.TODO(), types.NamespacedName{Name: driverName}, found)
+	err := r.client.Get(ctx, types.NamespacedName{Name: desired.Name}, found)
 	if err != nil && errors.IsNotFound(err) {
 		reqLogger.Info("Creating a new CSI Driver", "CSIDriver.Name", desired.Name)
-		err = r.client.Create(context.TODO(), desired)
+		err = r.applyObject(ctx, desired, nil)
 		if err != nil {
 			r.recorder.Event(cr, corev1.EventTypeWarning, createResourceFailed, fmt.Sprintf(createMessageFailed, desired.Name, err))
 			return reconcile.Result{}, err
@@ -60,6 +79,18 @@ func (r *ReconcileHostPathProvisioner) reconcileCSIDriver(reqLogger logr.Logger,
 		return reconcile.Result{}, err
 	}
 
+	if err := r.repairControllerReference(ctx, reqLogger, cr, found); err != nil {
+		return reconcile.Result{}, err
+	}
+	if changed := changedImmutableCSIDriverFields(cr, found); len(changed) > 0 {
+		return r.recreateCSIDriver(ctx, reqLogger, cr, copyUnspecifiedImmutableFields(cr, desired, found), found, changed)
+	}
+
+	if r.isUpToDate(desired, found) {
+		reqLogger.V(3).Info("Skip reconcile: CSIDriver is up to date", "CSIDriver.Name", found.Name)
+		return reconcile.Result{}, nil
+	}
+
 	// Keep a copy of the original for comparison later.
 	currentRuntimeObjCopy := found.DeepCopyObject()
 
@@ -80,32 +111,166 @@ func (r *ReconcileHostPathProvisioner) reconcileCSIDriver(reqLogger logr.Logger,
 		logJSONDiff(reqLogger, currentRuntimeObjCopy, merged)
 		// Current is different from desired, update.
 		reqLogger.Info("Updating CSIDriver", "CSIDriver.Name", desired.Name)
-		err = r.client.Update(context.TODO(), merged)
+		err = r.applyObject(ctx, desired, found)
 		if err != nil {
 			return reconcile.Result{}, err
 		}
 		return reconcile.Result{}, nil
 	}
 	// CSIDriver already exists and matches the desired state - don't requeue
+	r.markUpToDate(found)
 	reqLogger.V(3).Info("Skip reconcile: CSIDriver already exists", "CSIDriver.Name", found.Name)
 	return reconcile.Result{}, nil
 }
 
-func (r *ReconcileHostPathProvisioner) deleteCSIDriver() error {
-	// Check if this CSIDriver already exists
-	csiDriver := &storagev1.CSIDriver{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: driverName,
-		},
+// deletePreviousCSIDriver removes the CSIDriver of the previous driver name once spec.csiDriver.driverName changes, and
+// records the name of the current CSIDriver in the status. The CSIDriver name is immutable, so a new one is created for
+// the new name.
+func (r *ReconcileHostPathProvisioner) deletePreviousCSIDriver(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner) error {
+	name := getDriverName(cr)
+	if previous := cr.Status.CSIDriverName; previous != "" && previous != name {
+		reqLogger.Info("Deleting CSIDriver of the previous driver name", "CSIDriver.Name", previous)
+		csiDriver := &storagev1.CSIDriver{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: previous,
+			},
+		}
+		if err := r.client.Delete(ctx, csiDriver); err != nil && !errors.IsNotFound(err) {
+			r.recorder.Event(cr, corev1.EventTypeWarning, deleteResourceFailed, fmt.Sprintf(deleteMessageFailed, previous, err))
+			return err
+		}
+		r.recorder.Event(cr, corev1.EventTypeNormal, deleteResourceSuccess, fmt.Sprintf(deleteMessageSucceeded, csiDriver, previous))
+	}
+	cr.Status.CSIDriverName = name
+	return nil
+}
+
+// changedImmutableCSIDriverFields returns the immutable fields the CR specifies with a value different from the one of
+// the current CSIDriver.
+func changedImmutableCSIDriverFields(cr *hostpathprovisionerv1.HostPathProvisioner, current *storagev1.CSIDriver) []string {
+	var changed []string
+	if fsGroupPolicyChanged(cr, current) {
+		changed = append(changed, "fsGroupPolicy")
+	}
+	if podInfoOnMountChanged(cr, current) {
+		changed = append(changed, "podInfoOnMount")
+	}
+	if attachRequiredChanged(cr, current) {
+		changed = append(changed, "attachRequired")
+	}
+	return changed
+}
+
+// copyUnspecifiedImmutableFields keeps the immutable fields the CR does not specify when the CSIDriver is recreated to
+// change another one.
+func copyUnspecifiedImmutableFields(cr *hostpathprovisionerv1.HostPathProvisioner, desired, current *storagev1.CSIDriver) *storagev1.CSIDriver {
+	if cr.Spec.CSIDriver == nil || cr.Spec.CSIDriver.FSGroupPolicy == nil {
+		desired.Spec.FSGroupPolicy = current.Spec.FSGroupPolicy
+	}
+	if cr.Spec.CSIDriver == nil || cr.Spec.CSIDriver.PodInfoOnMount == nil {
+		desired.Spec.PodInfoOnMount = current.Spec.PodInfoOnMount
+	}
+	if cr.Spec.CSIDriver == nil || cr.Spec.CSIDriver.AttachRequired == nil {
+		desired.Spec.AttachRequired = current.Spec.AttachRequired
+	}
+	return desired
+}
+
+// podInfoOnMountChanged returns true if the CR specifies a podInfoOnMount different from the one of the current CSIDriver.
+func podInfoOnMountChanged(cr *hostpathprovisionerv1.HostPathProvisioner, current *storagev1.CSIDriver) bool {
+	if cr.Spec.CSIDriver == nil || cr.Spec.CSIDriver.PodInfoOnMount == nil {
+		return false
 	}
+	return current.Spec.PodInfoOnMount == nil || *current.Spec.PodInfoOnMount != *cr.Spec.CSIDriver.PodInfoOnMount
+}
+
+// attachRequiredChanged returns true if the CR specifies an attachRequired different from the one of the current CSIDriver.
+func attachRequiredChanged(cr *hostpathprovisionerv1.HostPathProvisioner, current *storagev1.CSIDriver) bool {
+	if cr.Spec.CSIDriver == nil || cr.Spec.CSIDriver.AttachRequired == nil {
+		return false
+	}
+	return current.Spec.AttachRequired == nil || *current.Spec.AttachRequired != *cr.Spec.CSIDriver.AttachRequired
+}
+
+// fsGroupPolicyChanged returns true if the CR specifies a fsGroupPolicy different from the one of the current CSIDriver.
+func fsGroupPolicyChanged(cr *hostpathprovisionerv1.HostPathProvisioner, current *storagev1.CSIDriver) bool {
+	if cr.Spec.CSIDriver == nil || cr.Spec.CSIDriver.FSGroupPolicy == nil {
+		return false
+	}
+	return current.Spec.FSGroupPolicy == nil || *current.Spec.FSGroupPolicy != *cr.Spec.CSIDriver.FSGroupPolicy
+}
+
+// recreateCSIDriver deletes the current CSIDriver and creates the desired one, the immutable fields cannot be updated.
+func (r *ReconcileHostPathProvisioner) recreateCSIDriver(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, desired, current *storagev1.CSIDriver, changed []string) (reconcile.Result, error) {
+	reqLogger.Info("Recreating CSIDriver to change immutable fields", "CSIDriver.Name", current.Name, "fields", changed)
+	if err := r.client.Delete(ctx, current); err != nil && !errors.IsNotFound(err) {
+		r.recorder.Event(cr, corev1.EventTypeWarning, deleteResourceFailed, fmt.Sprintf(deleteMessageFailed, current.Name, err))
+		return reconcile.Result{}, err
+	}
+	r.recorder.Event(cr, corev1.EventTypeNormal, deleteResourceSuccess, fmt.Sprintf(deleteMessageSucceeded, current, current.Name))
+	if err := r.applyObject(ctx, desired, nil); err != nil {
+		r.recorder.Event(cr, corev1.EventTypeWarning, createResourceFailed, fmt.Sprintf(createMessageFailed, desired.Name, err))
+		return reconcile.Result{}, err
+	}
+	r.recorder.Event(cr, corev1.EventTypeNormal, createResourceSuccess, fmt.Sprintf(createMessageSucceeded, desired, desired.Name))
+	return reconcile.Result{}, nil
+}
 
-	if err := r.client.Delete(context.TODO(), csiDriver); err != nil && !errors.IsNotFound(err) {
+// isCSIDriverUnmanaged returns true if another operator manages the CSIDriver object.
+func isCSIDriverUnmanaged(cr *hostpathprovisionerv1.HostPathProvisioner) bool {
+	return cr.Spec.CSIDriver != nil && cr.Spec.CSIDriver.Unmanaged
+}
+
+// verifyUnmanagedCSIDriver only checks the CSIDriver managed outside of the operator exists, it is never changed. The
+// event is only emitted when the CSIDriver becomes unmanaged, and when it goes missing or comes back.
+func (r *ReconcileHostPathProvisioner) verifyUnmanagedCSIDriver(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner) error {
+	name := getDriverName(cr)
+	found := &storagev1.CSIDriver{}
+	err := r.client.Get(ctx, types.NamespacedName{Name: name}, found)
+	if errors.IsNotFound(err) {
+		reqLogger.V(3).Info("Unmanaged CSIDriver does not exist", "CSIDriver.Name", name)
+		r.recordEventOnce(cr, corev1.EventTypeWarning, csiDriverUnmanaged, fmt.Sprintf(csiDriverUnmanagedMissingMessage, name))
+		return nil
+	} else if err != nil {
 		return err
 	}
+	reqLogger.V(3).Info("Skip reconcile: CSIDriver is unmanaged", "CSIDriver.Name", name)
+	r.recordEventOnce(cr, corev1.EventTypeNormal, csiDriverUnmanaged, fmt.Sprintf(csiDriverUnmanagedMessage, name))
+	return nil
+}
+
+func (r *ReconcileHostPathProvisioner) deleteCSIDriver(ctx context.Context, cr *hostpathprovisionerv1.HostPathProvisioner) error {
+	if isCSIDriverUnmanaged(cr) {
+		// Left to the operator that manages it.
+		return nil
+	}
+	// The CSIDriver of the previous driver name is still there if the driver name changed after the last reconcile.
+	for _, name := range sets.List(sets.New(getDriverName(cr), cr.Status.CSIDriverName)) {
+		if name == "" {
+			continue
+		}
+		csiDriver := &storagev1.CSIDriver{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+		}
+
+		if err := r.client.Delete(ctx, csiDriver); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
 
 	return nil
 }
 
+// getDriverName returns the name of the CSI driver, spec.csiDriver.driverName if set.
+func getDriverName(cr *hostpathprovisionerv1.HostPathProvisioner) string {
+	if cr.Spec.CSIDriver != nil && cr.Spec.CSIDriver.DriverName != "" {
+		return cr.Spec.CSIDriver.DriverName
+	}
+	return driverName
+}
+
 func copyImmutableFields(desired, current *storagev1.CSIDriver) *storagev1.CSIDriver {
 	desired.Spec.AttachRequired = current.Spec.AttachRequired
 	desired.Spec.PodInfoOnMount = current.Spec.PodInfoOnMount
@@ -116,13 +281,25 @@ func copyImmutableFields(desired, current *storagev1.CSIDriver) *storagev1.CSIDr
 	return desired
 }
 
-func createCSIDriverObject() *storagev1.CSIDriver {
+func createCSIDriverObject(cr *hostpathprovisionerv1.HostPathProvisioner) *storagev1.CSIDriver {
 	labels := util.GetRecommendedLabels()
 	podInfoOnMount := true
 	attachRequired := false
 	storageCapacity := true
 	requiresRepublish := false
 	fsGroupPolicy := storagev1.ReadWriteOnceWithFSTypeFSGroupPolicy
+	if cr.Spec.CSIDriver != nil && cr.Spec.CSIDriver.FSGroupPolicy != nil {
+		fsGroupPolicy = *cr.Spec.CSIDriver.FSGroupPolicy
+	}
+	if cr.Spec.CSIDriver != nil && cr.Spec.CSIDriver.PodInfoOnMount != nil {
+		podInfoOnMount = *cr.Spec.CSIDriver.PodInfoOnMount
+	}
+	if cr.Spec.CSIDriver != nil && cr.Spec.CSIDriver.RequiresRepublish != nil {
+		requiresRepublish = *cr.Spec.CSIDriver.RequiresRepublish
+	}
+	if cr.Spec.CSIDriver != nil && cr.Spec.CSIDriver.AttachRequired != nil {
+		attachRequired = *cr.Spec.CSIDriver.AttachRequired
+	}
 
 	return &storagev1.CSIDriver{
 		TypeMeta: metav1.TypeMeta{
@@ -130,7 +307,7 @@ func createCSIDriverObject() *storagev1.CSIDriver {
 			Kind:       "CSIDriver",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:   driverName,
+			Name:   getDriverName(cr),
 			Labels: labels,
 		},
 		Spec: storagev1.CSIDriverSpec{
@@ -17,11 +17,19 @@ package hostpathprovisioner
 
 import (
 	"context"
+	"fmt"
 
 	ginkgo "github.com/onsi/ginkgo/v2"
 	gomega "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	hppv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
@@ -42,8 +50,7 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 		ginkgo.DescribeTable("Should not reconcile over immutable csidriver fields", func(cr *hppv1.HostPathProvisioner) {
 			req := reconcile.Request{
 				NamespacedName: types.NamespacedName{
-					Name:      "test-name",
-					Namespace: testNamespace,
+					Name: "test-name",
 				},
 			}
 			csiDriverNN := types.NamespacedName{
@@ -78,11 +85,214 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 			ginkgo.Entry("storagePoolCr", createStoragePoolWithTemplateCr()),
 		)
 
+		ginkgo.DescribeTable("Should recreate the CSIDriver when the fsGroupPolicy changes", func(cr *hppv1.HostPathProvisioner) {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			csiDriverNN := types.NamespacedName{
+				Name: "kubevirt.io.hostpath-provisioner",
+			}
+			_, r, cl := createDeployedCr(cr)
+			csiDriver := &storagev1.CSIDriver{}
+			err := cl.Get(context.TODO(), csiDriverNN, csiDriver)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(*csiDriver.Spec.FSGroupPolicy).To(gomega.Equal(storagev1.ReadWriteOnceWithFSTypeFSGroupPolicy))
+			// Annotations added by users are kept on updates, so they are only lost when the CSIDriver is recreated.
+			csiDriver.Annotations["user-annotation"] = "original"
+			err = cl.Update(context.TODO(), csiDriver)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			cr = &hppv1.HostPathProvisioner{}
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			fsGroupPolicy := storagev1.FileFSGroupPolicy
+			cr.Spec.CSIDriver = &hppv1.CSIDriverConfig{
+				FSGroupPolicy: &fsGroupPolicy,
+			}
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			res, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(res.Requeue).To(gomega.BeFalse())
+
+			csiDriver = &storagev1.CSIDriver{}
+			err = cl.Get(context.TODO(), csiDriverNN, csiDriver)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(*csiDriver.Spec.FSGroupPolicy).To(gomega.Equal(storagev1.FileFSGroupPolicy))
+			gomega.Expect(csiDriver.Annotations).ToNot(gomega.HaveKey("user-annotation"))
+			csiDriver.Annotations["user-annotation"] = "recreated"
+			err = cl.Update(context.TODO(), csiDriver)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			ginkgo.By("Not recreating the CSIDriver again if the fsGroupPolicy matches")
+			res, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(res.Requeue).To(gomega.BeFalse())
+			csiDriver = &storagev1.CSIDriver{}
+			err = cl.Get(context.TODO(), csiDriverNN, csiDriver)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(csiDriver.Annotations).To(gomega.HaveKeyWithValue("user-annotation", "recreated"))
+			gomega.Expect(*csiDriver.Spec.FSGroupPolicy).To(gomega.Equal(storagev1.FileFSGroupPolicy))
+		},
+			ginkgo.Entry("legacyCr", createLegacyCr()),
+			ginkgo.Entry("legacyStoragePoolCr", createLegacyStoragePoolCr()),
+			ginkgo.Entry("storagePoolCr", createStoragePoolWithTemplateCr()),
+		)
+
+		ginkgo.It("Should recreate the CSIDriver when podInfoOnMount changes, keeping the current fsGroupPolicy", func() {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			csiDriverNN := types.NamespacedName{
+				Name: "kubevirt.io.hostpath-provisioner",
+			}
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			csiDriver := &storagev1.CSIDriver{}
+			err := cl.Get(context.TODO(), csiDriverNN, csiDriver)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(*csiDriver.Spec.PodInfoOnMount).To(gomega.BeTrue())
+			// The fsGroupPolicy is not in the CR, the current one is kept.
+			currentFSGroupPolicy := storagev1.NoneFSGroupPolicy
+			csiDriver.Spec.FSGroupPolicy = &currentFSGroupPolicy
+			csiDriver.Annotations["user-annotation"] = "original"
+			err = cl.Update(context.TODO(), csiDriver)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			podInfoOnMount := false
+			cr.Spec.CSIDriver = &hppv1.CSIDriverConfig{
+				PodInfoOnMount: &podInfoOnMount,
+			}
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			csiDriver = &storagev1.CSIDriver{}
+			err = cl.Get(context.TODO(), csiDriverNN, csiDriver)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(*csiDriver.Spec.PodInfoOnMount).To(gomega.BeFalse())
+			gomega.Expect(*csiDriver.Spec.FSGroupPolicy).To(gomega.Equal(storagev1.NoneFSGroupPolicy))
+			gomega.Expect(csiDriver.Annotations).ToNot(gomega.HaveKey("user-annotation"))
+			csiDriver.Annotations["user-annotation"] = "recreated"
+			err = cl.Update(context.TODO(), csiDriver)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			ginkgo.By("Not recreating the CSIDriver again if podInfoOnMount matches")
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			csiDriver = &storagev1.CSIDriver{}
+			err = cl.Get(context.TODO(), csiDriverNN, csiDriver)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(csiDriver.Annotations).To(gomega.HaveKeyWithValue("user-annotation", "recreated"))
+			gomega.Expect(*csiDriver.Spec.PodInfoOnMount).To(gomega.BeFalse())
+		})
+
+		ginkgo.It("Should recreate the CSIDriver when attachRequired changes", func() {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			csiDriverNN := types.NamespacedName{
+				Name: "kubevirt.io.hostpath-provisioner",
+			}
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			setAttachRequired := func(attachRequired *bool) {
+				err := cl.Get(context.TODO(), req.NamespacedName, cr)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				cr.Spec.CSIDriver = &hppv1.CSIDriverConfig{
+					AttachRequired: attachRequired,
+				}
+				err = cl.Update(context.TODO(), cr)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			}
+			csiDriver := &storagev1.CSIDriver{}
+			err := cl.Get(context.TODO(), csiDriverNN, csiDriver)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(*csiDriver.Spec.AttachRequired).To(gomega.BeFalse())
+			csiDriver.Annotations["user-annotation"] = "original"
+			err = cl.Update(context.TODO(), csiDriver)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			ginkgo.By("Requiring attach")
+			setAttachRequired(pointer.Bool(true))
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			csiDriver = &storagev1.CSIDriver{}
+			err = cl.Get(context.TODO(), csiDriverNN, csiDriver)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(*csiDriver.Spec.AttachRequired).To(gomega.BeTrue())
+			gomega.Expect(csiDriver.Annotations).ToNot(gomega.HaveKey("user-annotation"))
+			csiDriver.Annotations["user-annotation"] = "recreated"
+			err = cl.Update(context.TODO(), csiDriver)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			ginkgo.By("Keeping the current attachRequired once it is no longer set")
+			setAttachRequired(nil)
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			csiDriver = &storagev1.CSIDriver{}
+			err = cl.Get(context.TODO(), csiDriverNN, csiDriver)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(*csiDriver.Spec.AttachRequired).To(gomega.BeTrue())
+			gomega.Expect(csiDriver.Annotations).To(gomega.HaveKeyWithValue("user-annotation", "recreated"))
+
+			ginkgo.By("Not requiring attach again")
+			setAttachRequired(pointer.Bool(false))
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			csiDriver = &storagev1.CSIDriver{}
+			err = cl.Get(context.TODO(), csiDriverNN, csiDriver)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(*csiDriver.Spec.AttachRequired).To(gomega.BeFalse())
+			gomega.Expect(csiDriver.Annotations).ToNot(gomega.HaveKey("user-annotation"))
+		})
+
+		ginkgo.It("Should update requiresRepublish on the CSIDriver without recreating it", func() {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			csiDriverNN := types.NamespacedName{
+				Name: "kubevirt.io.hostpath-provisioner",
+			}
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			csiDriver := &storagev1.CSIDriver{}
+			err := cl.Get(context.TODO(), csiDriverNN, csiDriver)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(*csiDriver.Spec.RequiresRepublish).To(gomega.BeFalse())
+			csiDriver.Annotations["user-annotation"] = "original"
+			err = cl.Update(context.TODO(), csiDriver)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			for _, requiresRepublish := range []bool{true, false} {
+				err = cl.Get(context.TODO(), req.NamespacedName, cr)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				cr.Spec.CSIDriver = &hppv1.CSIDriverConfig{
+					RequiresRepublish: &requiresRepublish,
+				}
+				err = cl.Update(context.TODO(), cr)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				_, err = r.Reconcile(context.TODO(), req)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				csiDriver = &storagev1.CSIDriver{}
+				err = cl.Get(context.TODO(), csiDriverNN, csiDriver)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(*csiDriver.Spec.RequiresRepublish).To(gomega.Equal(requiresRepublish))
+				gomega.Expect(csiDriver.Annotations).To(gomega.HaveKeyWithValue("user-annotation", "original"))
+			}
+		})
+
 		ginkgo.DescribeTable("Should fix a changed CSIDriver", func(cr *hppv1.HostPathProvisioner) {
 			req := reconcile.Request{
 				NamespacedName: types.NamespacedName{
-					Name:      "test-name",
-					Namespace: testNamespace,
+					Name: "test-name",
 				},
 			}
 			csiDriverNN := types.NamespacedName{
@@ -116,5 +326,161 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 			ginkgo.Entry("legacyStoragePoolCr", createLegacyStoragePoolCr()),
 			ginkgo.Entry("storagePoolCr", createStoragePoolWithTemplateCr()),
 		)
+
+		ginkgo.It("Should use the configured driver name, and recreate the CSIDriver when it changes", func() {
+			// The StorageClasses are cluster scoped, so their owner is too.
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			dsNN := types.NamespacedName{
+				Name:      fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName),
+				Namespace: testNamespace,
+			}
+			customDriverName := "hostpath.csi.example.com"
+			cr, r, cl := createDeployedCr(createStorageClassCr(true))
+			err := cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(cr.Status.CSIDriverName).To(gomega.Equal(driverName))
+
+			ginkgo.By("Changing the driver name")
+			cr.Spec.CSIDriver = &hppv1.CSIDriverConfig{
+				DriverName: customDriverName,
+			}
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), types.NamespacedName{Name: customDriverName}, &storagev1.CSIDriver{})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), types.NamespacedName{Name: driverName}, &storagev1.CSIDriver{})
+			gomega.Expect(errors.IsNotFound(err)).To(gomega.BeTrue())
+			ds := &appsv1.DaemonSet{}
+			err = cl.Get(context.TODO(), dsNN, ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ds.Spec.Template.Spec.Containers[0].Args).To(gomega.ContainElement(fmt.Sprintf("--drivername=%s", customDriverName)))
+			sc := &storagev1.StorageClass{}
+			err = cl.Get(context.TODO(), types.NamespacedName{Name: cr.Spec.StoragePools[0].Name}, sc)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(sc.Provisioner).To(gomega.Equal(customDriverName))
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(cr.Status.CSIDriverName).To(gomega.Equal(customDriverName))
+
+			ginkgo.By("Deleting the CSIDriver with the CR")
+			err = cl.Delete(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), types.NamespacedName{Name: customDriverName}, &storagev1.CSIDriver{})
+			gomega.Expect(errors.IsNotFound(err)).To(gomega.BeTrue())
+		})
+
+		ginkgo.It("Should not create, change or delete the CSIDriver if it is unmanaged", func() {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			csiDriverNN := types.NamespacedName{
+				Name: driverName,
+			}
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			recorder, ok := r.recorder.(*record.FakeRecorder)
+			gomega.Expect(ok).To(gomega.BeTrue())
+			drainEvents := func() []string {
+				events := make([]string, 0)
+				for len(recorder.Events) > 0 {
+					events = append(events, <-recorder.Events)
+				}
+				return events
+			}
+			err := cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.CSIDriver = &hppv1.CSIDriverConfig{
+				Unmanaged: true,
+			}
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			countingClient := &countingCSIDriverFakeCtrlRuntimeClient{
+				Client: cl,
+			}
+			r.client = countingClient
+
+			ginkgo.By("Changing the CSIDriver like the operator that manages it would")
+			csiDriver := &storagev1.CSIDriver{}
+			err = cl.Get(context.TODO(), csiDriverNN, csiDriver)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			csiDriver.Labels = map[string]string{"managed-by": "platform"}
+			err = cl.Update(context.TODO(), csiDriver)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			drainEvents()
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(drainEvents()).To(gomega.ContainElement(fmt.Sprintf("%s %s %s", corev1.EventTypeNormal, csiDriverUnmanaged, fmt.Sprintf(csiDriverUnmanagedMessage, driverName))))
+			err = cl.Get(context.TODO(), csiDriverNN, csiDriver)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(csiDriver.Labels).To(gomega.Equal(map[string]string{"managed-by": "platform"}))
+
+			ginkgo.By("Not emitting the event again on the next reconcile")
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(drainEvents()).ToNot(gomega.ContainElement(gomega.ContainSubstring(csiDriverUnmanaged)))
+
+			ginkgo.By("Not recreating a deleted CSIDriver")
+			err = cl.Delete(context.TODO(), csiDriver)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			drainEvents()
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(drainEvents()).To(gomega.ContainElement(fmt.Sprintf("%s %s %s", corev1.EventTypeWarning, csiDriverUnmanaged, fmt.Sprintf(csiDriverUnmanagedMissingMessage, driverName))))
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(drainEvents()).ToNot(gomega.ContainElement(gomega.ContainSubstring(csiDriverUnmanaged)))
+
+			ginkgo.By("Not deleting the CSIDriver with the CR")
+			err = cl.Create(context.TODO(), &storagev1.CSIDriver{ObjectMeta: metav1.ObjectMeta{Name: driverName}})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Delete(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), csiDriverNN, csiDriver)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(countingClient.calls).To(gomega.BeZero())
+		})
 	})
 })
+
+// countingCSIDriverFakeCtrlRuntimeClient counts the calls that create, change or delete the CSIDriver.
+type countingCSIDriverFakeCtrlRuntimeClient struct {
+	client.Client
+	calls int
+}
+
+func (p *countingCSIDriverFakeCtrlRuntimeClient) count(obj client.Object) {
+	if _, ok := obj.(*storagev1.CSIDriver); ok {
+		p.calls++
+	}
+}
+
+func (p *countingCSIDriverFakeCtrlRuntimeClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	p.count(obj)
+	return p.Client.Create(ctx, obj, opts...)
+}
+
+func (p *countingCSIDriverFakeCtrlRuntimeClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	p.count(obj)
+	return p.Client.Update(ctx, obj, opts...)
+}
+
+func (p *countingCSIDriverFakeCtrlRuntimeClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	p.count(obj)
+	return p.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (p *countingCSIDriverFakeCtrlRuntimeClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	p.count(obj)
+	return p.Client.Delete(ctx, obj, opts...)
+}
@@ -26,9 +26,11 @@ import (
 	"strconv"
 
 	"github.com/go-logr/logr"
+	ocpconfigv1 "github.com/openshift/api/config/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8slabels "k8s.io/apimachinery/pkg/labels"
@@ -46,8 +48,18 @@ import (
 const (
 	csiSocket               = "/csi/csi.sock"
 	nodeDriverRegistrarName = "node-driver-registrar"
+	livenessProbeName       = "liveness-probe"
 	legacyStoragePoolName   = "legacy"
 	maxMountNameLength      = 63
+	additionalVolumePrefix  = "additional"
+	// clusterProxyName is the name of the cluster wide Proxy of OpenShift
+	clusterProxyName = "cluster"
+	// defaultRevisionHistoryLimit is the revision history limit of the workloads if the CR does not set one
+	defaultRevisionHistoryLimit = 2
+	// safeToEvictAnnotation tells the cluster autoscaler whether it can evict the pod when scaling down a node
+	safeToEvictAnnotation = "cluster-autoscaler.kubernetes.io/safe-to-evict"
+	// criticalAddonPriorityClassName is the priority class of the pods of a critical addon workload
+	criticalAddonPriorityClassName = "system-node-critical"
 )
 
 var (
@@ -55,6 +67,33 @@ var (
 	selectorLabels       = map[string]string{
 		"k8s-app": MultiPurposeHostPathProvisionerName,
 	}
+	// resourceProfiles are the resources applied to every provisioner container for each workload resource profile.
+	resourceProfiles = map[hostpathprovisionerv1.ResourceProfile]corev1.ResourceRequirements{
+		hostpathprovisionerv1.ResourceProfileMinimal: {
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("5m"),
+				corev1.ResourceMemory: resource.MustParse("32Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("128Mi"),
+			},
+		},
+		hostpathprovisionerv1.ResourceProfileDefault: {
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("10m"),
+				corev1.ResourceMemory: resource.MustParse("150Mi"),
+			},
+		},
+		hostpathprovisionerv1.ResourceProfileHighThroughput: {
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("100m"),
+				corev1.ResourceMemory: resource.MustParse("300Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+		},
+	}
 )
 
 type daemonSetArgs struct {
@@ -63,46 +102,64 @@ type daemonSetArgs struct {
 	nodeDriverRegistrarImage string
 	livenessProbeImage       string
 	snapshotterImage         string
+	resizerImage             string
 	csiProvisionerImage      string
 	namespace                string
 	name                     string
 	verbosity                int
 	version                  string
+	proxyEnv                 []corev1.EnvVar
+	trustedCABundleHash      string
 }
 
 // reconcileDaemonSet Reconciles the daemon set.
-func (r *ReconcileHostPathProvisioner) reconcileDaemonSet(reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) (reconcile.Result, error) {
+func (r *ReconcileHostPathProvisioner) reconcileDaemonSet(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) (reconcile.Result, error) {
 	// Previous versions created resources with names that depend on the CR, whereas now, we have fixed names for those.
 	// We will remove those and have the next loop create the resources with fixed names so we don't end up with two sets of hpp resources.
-	dups, err := r.getDuplicateDaemonSet(cr.Name, namespace)
+	dups, err := r.getDuplicateDaemonSet(ctx, cr.Name, namespace)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 	for _, dup := range dups {
-		if err := r.deleteDaemonSet(dup.Name, namespace); err != nil {
+		if err := r.deleteDaemonSet(ctx, dup.Name, namespace); err != nil {
 			return reconcile.Result{}, err
 		}
 	}
+	proxyEnv, err := r.getClusterProxyEnv(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	trustedCABundleHash, err := r.getTrustedCABundleHash(ctx, cr, namespace)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
 	args := getDaemonSetArgs(reqLogger.WithName("daemonset args"), namespace, true)
+	args.proxyEnv = proxyEnv
+	args.trustedCABundleHash = trustedCABundleHash
 	if r.isLegacy(cr) {
 		// provisioner
 		args.version = cr.Status.TargetVersion
-		if res, err := r.reconcileDaemonSetForSa(reqLogger, createDaemonSetObject(cr, reqLogger, args), cr); err != nil {
+		if res, err := r.reconcileWorkload(ctx, reqLogger, createDaemonSetObject(cr, reqLogger, args), cr); err != nil {
 			return res, err
 		}
 	} else {
 		// remove legacy ds if it exists.
-		if err := r.deleteDaemonSet(args.name, args.namespace); err != nil {
+		if err := r.removeLegacyDaemonSet(ctx, reqLogger, cr, args.name, args.namespace); err != nil {
+			return reconcile.Result{}, err
+		}
+		if err := r.deleteSingleNodeDeployment(ctx, args.name, args.namespace); err != nil {
 			return reconcile.Result{}, err
 		}
 	}
 	// csi driver
 	args = getDaemonSetArgs(reqLogger.WithName("daemonset args"), namespace, false)
 	args.version = cr.Status.TargetVersion
-	return r.reconcileDaemonSetForSa(reqLogger, r.createCSIDaemonSetObject(cr, reqLogger, args), cr)
+	args.proxyEnv = proxyEnv
+	args.trustedCABundleHash = trustedCABundleHash
+	return r.reconcileWorkload(ctx, reqLogger, r.createCSIDaemonSetObject(cr, reqLogger, args), cr)
 }
 
-func (r *ReconcileHostPathProvisioner) reconcileDaemonSetForSa(reqLogger logr.Logger, desired *appsv1.DaemonSet, cr *hostpathprovisionerv1.HostPathProvisioner) (reconcile.Result, error) {
+func (r *ReconcileHostPathProvisioner) reconcileDaemonSetForSa(ctx context.Context, reqLogger logr.Logger, desired *appsv1.DaemonSet, cr *hostpathprovisionerv1.HostPathProvisioner) (reconcile.Result, error) {
 	// Define a new DaemonSet object
 	setLastAppliedConfiguration(desired)
 
@@ -113,10 +170,10 @@ func (r *ReconcileHostPathProvisioner) reconcileDaemonSetForSa(reqLogger logr.Lo
 
 	// Check if this DaemonSet already exists
 	found := &appsv1.DaemonSet{}
-	err := r.client.Get(context.TODO(), types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, found)
+	err := r.client.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, found)
 	if err != nil && errors.IsNotFound(err) {
 		reqLogger.Info("Creating a new DaemonSet", "DaemonSet.Namespace", desired.Namespace, "Daemonset.Name", desired.Name)
-		err = r.client.Create(context.TODO(), desired)
+		err = r.applyObject(ctx, desired, nil)
 		if err != nil {
 			r.recorder.Event(cr, corev1.EventTypeWarning, createResourceFailed, fmt.Sprintf(createMessageFailed, desired.Name, err))
 			return reconcile.Result{}, err
@@ -129,17 +186,26 @@ func (r *ReconcileHostPathProvisioner) reconcileDaemonSetForSa(reqLogger logr.Lo
 		return reconcile.Result{}, err
 	}
 
-	// Cleanup daemonsets from previous versions where .spec.selector contains junk
-	// We will remove those and have the next loop create them
-	if !reflect.DeepEqual(found.Spec.Selector.MatchLabels, desired.Spec.Selector.MatchLabels) {
-		if err := r.deleteDaemonSet(desired.Name, desired.Namespace); err != nil {
-			return reconcile.Result{}, err
-		}
-		return reconcile.Result{}, fmt.Errorf("DaemonSet with extra selector labels spotted, cleaning up and requeueing")
+	if err := r.repairControllerReference(ctx, reqLogger, cr, found); err != nil {
+		return reconcile.Result{}, err
 	}
-
 	// Keep a copy of the original for comparison later.
 	currentRuntimeObjCopy := found.DeepCopyObject()
+	if err := r.adoptDaemonSet(reqLogger, cr, desired, found); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// The selector is immutable, daemonsets from previous versions with different selector labels have to be recreated.
+	if !reflect.DeepEqual(found.Spec.Selector, desired.Spec.Selector) {
+		return r.recreateDaemonSet(ctx, reqLogger, cr, desired, found)
+	}
+	// A DaemonSet that still has to be adopted is never up to date.
+	if metav1.IsControlledBy(found, cr) && r.isUpToDate(desired, found) {
+		reqLogger.V(3).Info("Skip reconcile: DaemonSet is up to date", "DaemonSet.Namespace", found.Namespace, "Daemonset.Name", found.Name)
+		return reconcile.Result{}, nil
+	}
+	// Only take over the owners of the found DaemonSet, so a DaemonSet that is not adopted doesn't get the CR as owner.
+	desired.OwnerReferences = found.OwnerReferences
 	// Copy found status fields, so the compare won't fail on desired/scheduled/ready pods being different. Updating will ignore them anyway.
 	desired = copyIgnoredFields(desired, found)
 
@@ -152,7 +218,7 @@ func (r *ReconcileHostPathProvisioner) reconcileDaemonSetForSa(reqLogger logr.Lo
 		logJSONDiff(reqLogger, currentRuntimeObjCopy, found)
 		// Current is different from desired, update.
 		reqLogger.Info("Updating DaemonSet", "DaemonSet.Name", desired.Name)
-		err = r.client.Update(context.TODO(), found)
+		err = r.applyObject(ctx, desired, found)
 		if err != nil {
 			r.recorder.Event(cr, corev1.EventTypeWarning, updateResourceFailed, fmt.Sprintf(updateMessageFailed, desired.Name, err))
 			return reconcile.Result{}, err
@@ -162,10 +228,45 @@ func (r *ReconcileHostPathProvisioner) reconcileDaemonSetForSa(reqLogger logr.Lo
 	}
 
 	// DaemonSet already exists and matches the desired state - don't requeue
+	r.markUpToDate(found)
 	reqLogger.V(3).Info("Skip reconcile: DaemonSet already exists", "DaemonSet.Namespace", found.Namespace, "Daemonset.Name", found.Name)
 	return reconcile.Result{}, nil
 }
 
+// recreateDaemonSet deletes the current DaemonSet and creates the desired one, the selector cannot be updated.
+func (r *ReconcileHostPathProvisioner) recreateDaemonSet(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, desired, current *appsv1.DaemonSet) (reconcile.Result, error) {
+	reqLogger.Info("Recreating DaemonSet to change the selector", "DaemonSet.Namespace", current.Namespace, "Daemonset.Name", current.Name,
+		"current", current.Spec.Selector, "desired", desired.Spec.Selector)
+	if err := r.client.Delete(ctx, current); err != nil && !errors.IsNotFound(err) {
+		r.recorder.Event(cr, corev1.EventTypeWarning, deleteResourceFailed, fmt.Sprintf(deleteMessageFailed, current.Name, err))
+		return reconcile.Result{}, err
+	}
+	if err := r.applyObject(ctx, desired, nil); err != nil {
+		r.recorder.Event(cr, corev1.EventTypeWarning, createResourceFailed, fmt.Sprintf(createMessageFailed, desired.Name, err))
+		return reconcile.Result{}, err
+	}
+	r.recorder.Event(cr, corev1.EventTypeNormal, daemonSetRecreatedForSelectorChange, fmt.Sprintf(daemonSetRecreatedForSelectorChangeMessage, desired.Name))
+	return reconcile.Result{}, nil
+}
+
+// adoptDaemonSet makes the HostPathProvisioner the controller of an existing DaemonSet without a controller, if adoptExisting
+// is set. A DaemonSet with a k8s-app label of a different application is not adopted, the reconcile fails instead.
+func (r *ReconcileHostPathProvisioner) adoptDaemonSet(reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, desired, found *appsv1.DaemonSet) error {
+	if !cr.Spec.AdoptExisting || metav1.GetControllerOf(found) != nil {
+		return nil
+	}
+	if app, ok := found.GetLabels()["k8s-app"]; ok && app != desired.GetLabels()["k8s-app"] {
+		r.recorder.Event(cr, corev1.EventTypeWarning, adoptResourceFailed, fmt.Sprintf(adoptMessageFailed, found.Name, app))
+		return fmt.Errorf(adoptMessageFailed, found.Name, app)
+	}
+	reqLogger.Info("Adopting existing DaemonSet", "DaemonSet.Namespace", found.Namespace, "Daemonset.Name", found.Name)
+	if err := controllerutil.SetControllerReference(cr, found, r.scheme); err != nil {
+		return err
+	}
+	r.recorder.Event(cr, corev1.EventTypeNormal, adoptResourceSuccess, fmt.Sprintf(adoptMessageSucceeded, found, found.Name))
+	return nil
+}
+
 func getDaemonSetArgs(reqLogger logr.Logger, namespace string, legacyProvisioner bool) *daemonSetArgs {
 	res := &daemonSetArgs{}
 
@@ -200,6 +301,11 @@ func getDaemonSetArgs(reqLogger logr.Logger, namespace string, legacyProvisioner
 			reqLogger.V(3).Info(fmt.Sprintf("%s not set, defaulting to %s", snapshotterImageEnvVarName, SnapshotterImageDefault))
 			res.snapshotterImage = SnapshotterImageDefault
 		}
+		res.resizerImage = os.Getenv(resizerImageEnvVarName)
+		if res.resizerImage == "" {
+			reqLogger.V(3).Info(fmt.Sprintf("%s not set, defaulting to %s", resizerImageEnvVarName, ResizerImageDefault))
+			res.resizerImage = ResizerImageDefault
+		}
 
 		res.csiProvisionerImage = os.Getenv(csiSigStorageProvisionerImageEnvVarName)
 		if res.csiProvisionerImage == "" {
@@ -224,7 +330,7 @@ func getDaemonSetArgs(reqLogger logr.Logger, namespace string, legacyProvisioner
 	return res
 }
 
-func (r *ReconcileHostPathProvisioner) deleteDaemonSet(name, namespace string) error {
+func (r *ReconcileHostPathProvisioner) deleteDaemonSet(ctx context.Context, name, namespace string) error {
 	// Check if this DaemonSet already exists
 	ds := &appsv1.DaemonSet{
 		ObjectMeta: metav1.ObjectMeta{
@@ -233,13 +339,32 @@ func (r *ReconcileHostPathProvisioner) deleteDaemonSet(name, namespace string) e
 		},
 	}
 
-	if err := r.client.Delete(context.TODO(), ds); err != nil && !errors.IsNotFound(err) {
+	if err := r.client.Delete(ctx, ds); err != nil && !errors.IsNotFound(err) {
 		return err
 	}
 
 	return nil
 }
 
+// removeLegacyDaemonSet deletes the legacy provisioner DaemonSet left behind when pathConfig is removed from the CR to
+// migrate to the CSI driver only.
+func (r *ReconcileHostPathProvisioner) removeLegacyDaemonSet(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, name, namespace string) error {
+	ds := &appsv1.DaemonSet{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, ds); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	reqLogger.Info("Removing legacy provisioner DaemonSet", "DaemonSet.Namespace", namespace, "DaemonSet.Name", name)
+	if err := r.client.Delete(ctx, ds); err != nil && !errors.IsNotFound(err) {
+		r.recorder.Event(cr, corev1.EventTypeWarning, deleteResourceFailed, fmt.Sprintf(deleteMessageFailed, name, err))
+		return err
+	}
+	r.recorder.Event(cr, corev1.EventTypeNormal, legacyProvisionerRemoved, fmt.Sprintf(legacyProvisionerRemovedMessage, name))
+	return nil
+}
+
 func copyIgnoredFields(desired, current *appsv1.DaemonSet) *appsv1.DaemonSet {
 	desired = copyStatusFields(desired, current)
 	desired.Spec.Template.Spec.DeprecatedServiceAccount = current.Spec.Template.Spec.DeprecatedServiceAccount
@@ -261,7 +386,7 @@ func createDaemonSetObject(cr *hostpathprovisionerv1.HostPathProvisioner, reqLog
 	usePrefix := getUsePrefix(cr)
 	path := getPath(cr)
 	labels := util.GetRecommendedLabels()
-	return &appsv1.DaemonSet{
+	ds := &appsv1.DaemonSet{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "apps/v1",
 			Kind:       "DaemonSet",
@@ -280,11 +405,11 @@ func createDaemonSetObject(cr *hostpathprovisionerv1.HostPathProvisioner, reqLog
 					Labels: labels,
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName:            ProvisionerServiceAccountName,
+					ServiceAccountName:            getServiceAccountName(cr),
 					RestartPolicy:                 corev1.RestartPolicyAlways,
 					DNSPolicy:                     corev1.DNSClusterFirst,
-					TerminationGracePeriodSeconds: pointer.Int64Ptr(30),
-					SecurityContext:               &corev1.PodSecurityContext{},
+					TerminationGracePeriodSeconds: getTerminationGracePeriodSeconds(cr),
+					SecurityContext:               getPodSecurityContext(cr),
 					Containers: []corev1.Container{
 						{
 							Resources: corev1.ResourceRequirements{
@@ -355,11 +480,11 @@ func createDaemonSetObject(cr *hostpathprovisionerv1.HostPathProvisioner, reqLog
 						},
 					},
 					NodeSelector: cr.Spec.Workload.NodeSelector,
-					Tolerations:  cr.Spec.Workload.Tolerations,
+					Tolerations:  getWorkloadTolerations(cr),
 					Affinity:     cr.Spec.Workload.Affinity,
 				},
 			},
-			RevisionHistoryLimit: pointer.Int32Ptr(10),
+			RevisionHistoryLimit: getRevisionHistoryLimit(cr),
 			UpdateStrategy: appsv1.DaemonSetUpdateStrategy{
 				Type: appsv1.RollingUpdateDaemonSetStrategyType,
 				RollingUpdate: &appsv1.RollingUpdateDaemonSet{
@@ -372,6 +497,16 @@ func createDaemonSetObject(cr *hostpathprovisionerv1.HostPathProvisioner, reqLog
 			},
 		},
 	}
+	addAdditionalVolumes(cr, &ds.Spec.Template.Spec)
+	applyResourceProfile(cr, &ds.Spec.Template.Spec)
+	applyNetworkSettings(cr, &ds.Spec.Template.Spec)
+	applyAutomountServiceAccountToken(cr, &ds.Spec.Template.Spec)
+	addCriticalAddon(cr, &ds.Spec.Template)
+	addWorkloadEnv(cr, &ds.Spec.Template.Spec)
+	addProxyEnv(args.proxyEnv, &ds.Spec.Template.Spec)
+	addTrustedCABundle(cr, args.trustedCABundleHash, &ds.Spec.Template)
+	addWorkloadInitContainers(cr, &ds.Spec.Template.Spec)
+	return ds
 }
 
 func getUsePrefix(cr *hostpathprovisionerv1.HostPathProvisioner) bool {
@@ -381,6 +516,43 @@ func getUsePrefix(cr *hostpathprovisionerv1.HostPathProvisioner) bool {
 	return false
 }
 
+func getTerminationGracePeriodSeconds(cr *hostpathprovisionerv1.HostPathProvisioner) *int64 {
+	if cr.Spec.Workload.TerminationGracePeriodSeconds != nil {
+		return pointer.Int64Ptr(*cr.Spec.Workload.TerminationGracePeriodSeconds)
+	}
+	return pointer.Int64Ptr(30)
+}
+
+// getWorkloadTolerations returns the tolerations of the workload, with the control plane tolerations if the workload
+// tolerates the control plane.
+func getWorkloadTolerations(cr *hostpathprovisionerv1.HostPathProvisioner) []corev1.Toleration {
+	var tolerations []corev1.Toleration
+	tolerations = append(tolerations, cr.Spec.Workload.Tolerations...)
+	if cr.Spec.Workload.TolerateControlPlane {
+		tolerations = appendToleration(tolerations, controlPlaneToleration)
+		tolerations = appendToleration(tolerations, masterToleration)
+	}
+	return tolerations
+}
+
+// appendToleration appends the toleration, unless the same toleration is already in the list.
+func appendToleration(tolerations []corev1.Toleration, toleration corev1.Toleration) []corev1.Toleration {
+	for _, t := range tolerations {
+		if t.MatchToleration(&toleration) {
+			return tolerations
+		}
+	}
+	return append(tolerations, toleration)
+}
+
+// getRevisionHistoryLimit returns the number of old revisions the workloads keep, a few are enough to roll back.
+func getRevisionHistoryLimit(cr *hostpathprovisionerv1.HostPathProvisioner) *int32 {
+	if cr.Spec.Workload.RevisionHistoryLimit != nil {
+		return pointer.Int32(*cr.Spec.Workload.RevisionHistoryLimit)
+	}
+	return pointer.Int32(defaultRevisionHistoryLimit)
+}
+
 func getPath(cr *hostpathprovisionerv1.HostPathProvisioner) string {
 	if cr.Spec.PathConfig != nil {
 		return cr.Spec.PathConfig.Path
@@ -401,10 +573,16 @@ func getStoragePoolPaths(cr *hostpathprovisionerv1.HostPathProvisioner) []Storag
 		})
 	} else if len(cr.Spec.StoragePools) > 0 {
 		for _, storagePool := range cr.Spec.StoragePools {
-			storagePoolPaths = append(storagePoolPaths, StoragePoolInfo{
+			info := StoragePoolInfo{
 				Name: storagePool.Name,
 				Path: storagePool.Path,
-			})
+			}
+			if storagePool.AnnotateProvisionedVolumes {
+				info.VolumeAnnotations = map[string]string{
+					storagePoolAnnotationKey: storagePool.Name,
+				}
+			}
+			storagePoolPaths = append(storagePoolPaths, info)
 		}
 	}
 	return storagePoolPaths
@@ -498,14 +676,14 @@ func (r *ReconcileHostPathProvisioner) createCSIDaemonSetObject(cr *hostpathprov
 					MaxSurge: &intstr.IntOrString{},
 				},
 			},
-			RevisionHistoryLimit: pointer.Int32Ptr(10),
+			RevisionHistoryLimit: getRevisionHistoryLimit(cr),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: labels,
 				},
 				Spec: corev1.PodSpec{
 
-					ServiceAccountName: ProvisionerServiceAccountNameCsi,
+					ServiceAccountName: getCsiServiceAccountName(cr),
 					RestartPolicy:      corev1.RestartPolicyAlways,
 					Containers: []corev1.Container{
 						{
@@ -545,7 +723,7 @@ func (r *ReconcileHostPathProvisioner) createCSIDaemonSetObject(cr *hostpathprov
 								Privileged: pointer.BoolPtr(true),
 							},
 							Args: []string{
-								fmt.Sprintf("--drivername=%s", driverName),
+								fmt.Sprintf("--drivername=%s", getDriverName(cr)),
 								fmt.Sprintf("--v=%d", args.verbosity),
 								"--endpoint=$(CSI_ENDPOINT)",
 								"--nodeid=$(NODE_NAME)",
@@ -642,7 +820,7 @@ func (r *ReconcileHostPathProvisioner) createCSIDaemonSetObject(cr *hostpathprov
 									corev1.ResourceMemory: resource.MustParse("150Mi"),
 								},
 							},
-							Name:            "liveness-probe",
+							Name:            livenessProbeName,
 							Image:           args.livenessProbeImage,
 							ImagePullPolicy: cr.Spec.ImagePullPolicy,
 							Args: []string{
@@ -716,9 +894,9 @@ func (r *ReconcileHostPathProvisioner) createCSIDaemonSetObject(cr *hostpathprov
 							TerminationMessagePolicy: corev1.TerminationMessageReadFile,
 						},
 					},
-					SecurityContext:               &corev1.PodSecurityContext{},
+					SecurityContext:               getPodSecurityContext(cr),
 					DNSPolicy:                     corev1.DNSClusterFirst,
-					TerminationGracePeriodSeconds: pointer.Int64Ptr(30),
+					TerminationGracePeriodSeconds: getTerminationGracePeriodSeconds(cr),
 					Volumes: []corev1.Volume{
 						{
 							Name: "socket-dir",
@@ -758,7 +936,7 @@ func (r *ReconcileHostPathProvisioner) createCSIDaemonSetObject(cr *hostpathprov
 						},
 					},
 					NodeSelector: cr.Spec.Workload.NodeSelector,
-					Tolerations:  cr.Spec.Workload.Tolerations,
+					Tolerations:  getWorkloadTolerations(cr),
 					Affinity:     cr.Spec.Workload.Affinity,
 				},
 			},
@@ -768,15 +946,271 @@ func (r *ReconcileHostPathProvisioner) createCSIDaemonSetObject(cr *hostpathprov
 	if r.isFeatureGateEnabled(snapshotFeatureGate, cr) {
 		ds.Spec.Template.Spec.Containers = append(ds.Spec.Template.Spec.Containers, *createSnapshotSideCarContainer(args.snapshotterImage, cr.Spec.ImagePullPolicy, args.verbosity))
 	}
+	if r.isFeatureGateEnabled(volumeExpansionFeatureGate, cr) {
+		ds.Spec.Template.Spec.Containers = append(ds.Spec.Template.Spec.Containers, *createResizerSideCarContainer(args.resizerImage, cr.Spec.ImagePullPolicy, args.verbosity))
+	}
 	for i, container := range ds.Spec.Template.Spec.Containers {
 		if container.Name == MultiPurposeHostPathProvisionerName || container.Name == nodeDriverRegistrarName {
 			ds.Spec.Template.Spec.Containers[i].VolumeMounts = append(ds.Spec.Template.Spec.Containers[i].VolumeMounts, pathMounts...)
 		}
 	}
+	addAdditionalVolumes(cr, &ds.Spec.Template.Spec)
+	applyResourceProfile(cr, &ds.Spec.Template.Spec)
+	applyNetworkSettings(cr, &ds.Spec.Template.Spec)
+	applyAutomountServiceAccountToken(cr, &ds.Spec.Template.Spec)
+	addCriticalAddon(cr, &ds.Spec.Template)
+	if !isLivenessProbeEnabled(cr) {
+		removeLivenessProbe(&ds.Spec.Template.Spec)
+	}
+	addWorkerThreads(cr, &ds.Spec.Template.Spec)
+	addExtraArgs(cr, &ds.Spec.Template.Spec)
+	addWorkloadEnv(cr, &ds.Spec.Template.Spec)
+	addProxyEnv(args.proxyEnv, &ds.Spec.Template.Spec)
+	addTrustedCABundle(cr, args.trustedCABundleHash, &ds.Spec.Template)
+	addWorkloadInitContainers(cr, &ds.Spec.Template.Spec)
 
 	return ds
 }
 
+// isLivenessProbeEnabled returns true unless the liveness-probe sidecar is disabled in the CR.
+func isLivenessProbeEnabled(cr *hostpathprovisionerv1.HostPathProvisioner) bool {
+	return cr.Spec.CSIDriver == nil || cr.Spec.CSIDriver.EnableLivenessProbe == nil || *cr.Spec.CSIDriver.EnableLivenessProbe
+}
+
+// removeLivenessProbe removes the liveness-probe sidecar from the pod spec. The healthz port and the liveness probe of the
+// provisioner container are removed as well, the sidecar is what serves them.
+func removeLivenessProbe(podSpec *corev1.PodSpec) {
+	containers := make([]corev1.Container, 0, len(podSpec.Containers))
+	for _, container := range podSpec.Containers {
+		if container.Name == livenessProbeName {
+			continue
+		}
+		if container.Name == MultiPurposeHostPathProvisionerName {
+			container.LivenessProbe = nil
+			ports := make([]corev1.ContainerPort, 0, len(container.Ports))
+			for _, port := range container.Ports {
+				if port.Name != "healthz" {
+					ports = append(ports, port)
+				}
+			}
+			container.Ports = ports
+		}
+		containers = append(containers, container)
+	}
+	podSpec.Containers = containers
+}
+
+// addWorkerThreads sets the number of workers of the csi-provisioner sidecar, the default of the sidecar is used if the
+// CR does not set it.
+func addWorkerThreads(cr *hostpathprovisionerv1.HostPathProvisioner, podSpec *corev1.PodSpec) {
+	if cr.Spec.CSIDriver == nil || cr.Spec.CSIDriver.WorkerThreads == nil {
+		return
+	}
+	for i, container := range podSpec.Containers {
+		if container.Name == "csi-provisioner" {
+			podSpec.Containers[i].Args = append(podSpec.Containers[i].Args, fmt.Sprintf("--worker-threads=%d", *cr.Spec.CSIDriver.WorkerThreads))
+		}
+	}
+}
+
+// addExtraArgs appends the extra arguments of the CR to the provisioner container. The flags the operator sets win, the
+// webhook rejects extra arguments for them, but they are skipped here as well.
+func addExtraArgs(cr *hostpathprovisionerv1.HostPathProvisioner, podSpec *corev1.PodSpec) {
+	if cr.Spec.CSIDriver == nil || len(cr.Spec.CSIDriver.ExtraArgs) == 0 {
+		return
+	}
+	for i, container := range podSpec.Containers {
+		if container.Name != MultiPurposeHostPathProvisionerName {
+			continue
+		}
+		managed := make(map[string]bool, len(container.Args))
+		for _, arg := range container.Args {
+			managed[util.GetFlagName(arg)] = true
+		}
+		for _, arg := range cr.Spec.CSIDriver.ExtraArgs {
+			if !managed[util.GetFlagName(arg)] {
+				podSpec.Containers[i].Args = append(podSpec.Containers[i].Args, arg)
+			}
+		}
+	}
+}
+
+// addWorkloadEnv adds the extra environment variables of the workload to the provisioner container. The variables the
+// operator manages win, extra variables with the same name are skipped.
+func addWorkloadEnv(cr *hostpathprovisionerv1.HostPathProvisioner, podSpec *corev1.PodSpec) {
+	for i, container := range podSpec.Containers {
+		if container.Name != MultiPurposeHostPathProvisionerName {
+			continue
+		}
+		managed := make(map[string]bool, len(container.Env))
+		for _, env := range container.Env {
+			managed[env.Name] = true
+		}
+		for _, env := range cr.Spec.Workload.Env {
+			if !managed[env.Name] {
+				podSpec.Containers[i].Env = append(podSpec.Containers[i].Env, env)
+			}
+		}
+	}
+}
+
+// addWorkloadInitContainers prepends the extra init containers of the workload to the init containers of the pod spec.
+// The webhook rejects extra init containers named like the containers the operator manages, if it is not running the
+// managed containers win and the extra init containers with the same name are skipped.
+func addWorkloadInitContainers(cr *hostpathprovisionerv1.HostPathProvisioner, podSpec *corev1.PodSpec) {
+	if len(cr.Spec.Workload.InitContainers) == 0 {
+		return
+	}
+	managed := make(map[string]bool, len(podSpec.Containers)+len(podSpec.InitContainers))
+	for _, container := range podSpec.Containers {
+		managed[container.Name] = true
+	}
+	for _, container := range podSpec.InitContainers {
+		managed[container.Name] = true
+	}
+	initContainers := make([]corev1.Container, 0, len(cr.Spec.Workload.InitContainers)+len(podSpec.InitContainers))
+	for _, container := range cr.Spec.Workload.InitContainers {
+		if !managed[container.Name] {
+			initContainers = append(initContainers, *container.DeepCopy())
+		}
+	}
+	podSpec.InitContainers = append(initContainers, podSpec.InitContainers...)
+}
+
+// getClusterProxyEnv returns the proxy environment variables of the cluster wide Proxy of OpenShift. Without the Proxy kind,
+// or the cluster Proxy, there are none, the proxy can be set with the workload env instead.
+func (r *ReconcileHostPathProvisioner) getClusterProxyEnv(ctx context.Context) ([]corev1.EnvVar, error) {
+	proxy := &ocpconfigv1.Proxy{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: clusterProxyName}, proxy); err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var env []corev1.EnvVar
+	// The status has the values OpenShift resolved, the noProxy includes the cluster networks.
+	for _, proxyEnv := range []corev1.EnvVar{
+		{Name: "HTTP_PROXY", Value: proxy.Status.HTTPProxy},
+		{Name: "HTTPS_PROXY", Value: proxy.Status.HTTPSProxy},
+		{Name: "NO_PROXY", Value: proxy.Status.NoProxy},
+	} {
+		if proxyEnv.Value != "" {
+			env = append(env, proxyEnv)
+		}
+	}
+	return env, nil
+}
+
+// addProxyEnv adds the cluster proxy environment variables to the provisioner container. Variables set in the workload
+// env take precedence, so the proxy of the provisioner can still be changed.
+func addProxyEnv(proxyEnv []corev1.EnvVar, podSpec *corev1.PodSpec) {
+	for i, container := range podSpec.Containers {
+		if container.Name != MultiPurposeHostPathProvisionerName {
+			continue
+		}
+		set := make(map[string]bool, len(container.Env))
+		for _, env := range container.Env {
+			set[env.Name] = true
+		}
+		for _, env := range proxyEnv {
+			if !set[env.Name] {
+				podSpec.Containers[i].Env = append(podSpec.Containers[i].Env, env)
+			}
+		}
+	}
+}
+
+// addAdditionalVolumes adds the additional volumes from the CR to the pod spec, and mounts them in the provisioner container.
+func addAdditionalVolumes(cr *hostpathprovisionerv1.HostPathProvisioner, podSpec *corev1.PodSpec) {
+	directoryOrCreate := corev1.HostPathDirectoryOrCreate
+	hostToContainer := corev1.MountPropagationHostToContainer
+	for _, additionalVolume := range cr.Spec.AdditionalVolumes {
+		volumeName := getAdditionalVolumeName(additionalVolume.Name)
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: additionalVolume.HostPath,
+					Type: &directoryOrCreate,
+				},
+			},
+		})
+		for i, container := range podSpec.Containers {
+			if container.Name == MultiPurposeHostPathProvisionerName {
+				podSpec.Containers[i].VolumeMounts = append(podSpec.Containers[i].VolumeMounts, corev1.VolumeMount{
+					Name:             volumeName,
+					MountPath:        additionalVolume.MountPath,
+					ReadOnly:         additionalVolume.ReadOnly,
+					MountPropagation: &hostToContainer,
+				})
+			}
+		}
+	}
+}
+
+// applyResourceProfile sets the resources of the workload resource profile on all the containers of the pod spec.
+// Without a resource profile the containers keep their built in resources.
+func applyResourceProfile(cr *hostpathprovisionerv1.HostPathProvisioner, podSpec *corev1.PodSpec) {
+	resources, ok := resourceProfiles[cr.Spec.Workload.ResourceProfile]
+	if !ok {
+		return
+	}
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].Resources = *resources.DeepCopy()
+	}
+}
+
+// applyNetworkSettings sets the host network, DNS policy and DNS configuration of the workload on the pod spec.
+// Without a DNS policy the pod spec keeps ClusterFirst, or uses ClusterFirstWithHostNet with host networking.
+func applyNetworkSettings(cr *hostpathprovisionerv1.HostPathProvisioner, podSpec *corev1.PodSpec) {
+	podSpec.HostNetwork = cr.Spec.Workload.HostNetwork
+	if podSpec.HostNetwork {
+		podSpec.DNSPolicy = corev1.DNSClusterFirstWithHostNet
+	}
+	if cr.Spec.Workload.DNSPolicy != "" {
+		podSpec.DNSPolicy = cr.Spec.Workload.DNSPolicy
+	}
+	if cr.Spec.Workload.DNSConfig != nil {
+		podSpec.DNSConfig = cr.Spec.Workload.DNSConfig.DeepCopy()
+	}
+}
+
+// applyAutomountServiceAccountToken sets automountServiceAccountToken of the workload on the pod spec, if it is not set
+// the default of the ServiceAccount applies.
+func applyAutomountServiceAccountToken(cr *hostpathprovisionerv1.HostPathProvisioner, podSpec *corev1.PodSpec) {
+	if cr.Spec.Workload.AutomountServiceAccountToken != nil {
+		podSpec.AutomountServiceAccountToken = pointer.Bool(*cr.Spec.Workload.AutomountServiceAccountToken)
+	}
+}
+
+// getPodSecurityContext returns the security context of the provisioner pods, with the fsGroup of the workload if it is
+// set.
+func getPodSecurityContext(cr *hostpathprovisionerv1.HostPathProvisioner) *corev1.PodSecurityContext {
+	securityContext := &corev1.PodSecurityContext{}
+	if cr.Spec.Workload.FSGroup != nil {
+		securityContext.FSGroup = pointer.Int64(*cr.Spec.Workload.FSGroup)
+	}
+	return securityContext
+}
+
+// addCriticalAddon sets the system-node-critical priority class on the pod template and tells the cluster autoscaler
+// not to evict the pods, if the workload is declared as a critical addon.
+func addCriticalAddon(cr *hostpathprovisionerv1.HostPathProvisioner, template *corev1.PodTemplateSpec) {
+	if !cr.Spec.Workload.CriticalAddon {
+		return
+	}
+	if template.Annotations == nil {
+		template.Annotations = make(map[string]string)
+	}
+	template.Annotations[safeToEvictAnnotation] = "false"
+	template.Spec.PriorityClassName = criticalAddonPriorityClassName
+}
+
+// getAdditionalVolumeName prefixes the name so it cannot collide with the volumes managed by the operator.
+func getAdditionalVolumeName(name string) string {
+	return fmt.Sprintf("%s-%s", additionalVolumePrefix, name)
+}
+
 func createSnapshotSideCarContainer(image string, pullPolicy corev1.PullPolicy, verbosity int) *corev1.Container {
 	return &corev1.Container{
 		Name:            "csi-snapshotter",
@@ -796,9 +1230,28 @@ func createSnapshotSideCarContainer(image string, pullPolicy corev1.PullPolicy,
 	}
 }
 
+func createResizerSideCarContainer(image string, pullPolicy corev1.PullPolicy, verbosity int) *corev1.Container {
+	return &corev1.Container{
+		Name:            "csi-resizer",
+		Image:           image,
+		ImagePullPolicy: pullPolicy,
+		Args: []string{
+			fmt.Sprintf("--v=%d", verbosity),
+			fmt.Sprintf("--csi-address=%s", csiSocket),
+			"--leader-election",
+		},
+		SecurityContext: &corev1.SecurityContext{
+			Privileged: pointer.BoolPtr(true),
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			socketDirVolumeMount,
+		},
+	}
+}
+
 // getDuplicateDaemonSet will give us duplicate DaemonSets from a previous version if they exist.
 // This is possible from a previous HPP version where the resources (DaemonSet, RBAC) were named depending on the CR, whereas now, we have fixed names for those.
-func (r *ReconcileHostPathProvisioner) getDuplicateDaemonSet(customCrName, namespace string) ([]appsv1.DaemonSet, error) {
+func (r *ReconcileHostPathProvisioner) getDuplicateDaemonSet(ctx context.Context, customCrName, namespace string) ([]appsv1.DaemonSet, error) {
 	dsList := &appsv1.DaemonSetList{}
 	dups := make([]appsv1.DaemonSet, 0)
 
@@ -807,7 +1260,7 @@ func (r *ReconcileHostPathProvisioner) getDuplicateDaemonSet(customCrName, names
 		return dups, err
 	}
 	lo := &client.ListOptions{LabelSelector: ls, Namespace: namespace}
-	if err := r.client.List(context.TODO(), dsList, lo); err != nil {
+	if err := r.client.List(ctx, dsList, lo); err != nil {
 		return dups, err
 	}
 
@@ -17,15 +17,21 @@ package hostpathprovisioner
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	ginkgo "github.com/onsi/ginkgo/v2"
 	gomega "github.com/onsi/gomega"
+	ocpconfigv1 "github.com/openshift/api/config/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -90,6 +96,24 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 			gomega.Expect(foundVolume).To(gomega.BeTrue(), "did not find expected volume named csi-data-dir")
 		})
 
+		ginkgo.DescribeTable("Should pass useNamingPrefix to the legacy provisioner in lowercase", func(useNamingPrefix bool, expected string) {
+			cr, r, cl := createDeployedCr(createLegacyCr())
+			err := cl.Get(context.TODO(), client.ObjectKeyFromObject(cr), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.PathConfig.UseNamingPrefix = useNamingPrefix
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cr)})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			ds := &appsv1.DaemonSet{}
+			err = cl.Get(context.TODO(), types.NamespacedName{Name: MultiPurposeHostPathProvisionerName, Namespace: testNamespace}, ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ds.Spec.Template.Spec.Containers[0].Env).To(gomega.ContainElement(corev1.EnvVar{Name: "USE_NAMING_PREFIX", Value: expected}))
+		},
+			ginkgo.Entry("true", true, "true"),
+			ginkgo.Entry("false", false, "false"),
+		)
+
 		ginkgo.It("Should properly generate the datadir for volumesource CR", func() {
 			_, _, cl := createDeployedCr(createLegacyStoragePoolCr())
 			// Now modify the daemonSet to something not desired.
@@ -125,11 +149,54 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 			gomega.Expect(foundVolume).To(gomega.BeTrue(), "did not find expected volume path /tmp/test")
 		})
 
+		ginkgo.It("Should add the storage pool annotation to the driver config of annotated storage pools", func() {
+			cr := createLegacyStoragePoolCr()
+			cr.Spec.StoragePools[0].AnnotateProvisionedVolumes = true
+			cr.Spec.StoragePools = append(cr.Spec.StoragePools, hppv1.StoragePool{
+				Name: "other",
+				Path: "/tmp/other",
+			})
+			_, _, cl := createDeployedCr(cr)
+			ds := &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName),
+					Namespace: testNamespace,
+				},
+			}
+			err := cl.Get(context.TODO(), client.ObjectKeyFromObject(ds), ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			pvDir := ""
+			for _, container := range ds.Spec.Template.Spec.Containers {
+				if container.Name == MultiPurposeHostPathProvisionerName {
+					for _, env := range container.Env {
+						if env.Name == "PV_DIR" {
+							pvDir = env.Value
+						}
+					}
+				}
+			}
+			storagePools := make([]StoragePoolInfo, 0)
+			err = json.Unmarshal([]byte(pvDir), &storagePools)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(storagePools).To(gomega.Equal([]StoragePoolInfo{
+				{
+					Name: "legacy",
+					Path: "legacy-data-dir/csi",
+					VolumeAnnotations: map[string]string{
+						"hostpathprovisioner.kubevirt.io/pool": "legacy",
+					},
+				},
+				{
+					Name: "other",
+					Path: "other-data-dir/csi",
+				},
+			}))
+		})
+
 		ginkgo.It("Should fix a changed legacy daemonSet", func() {
 			req := reconcile.Request{
 				NamespacedName: types.NamespacedName{
-					Name:      "test-name",
-					Namespace: testNamespace,
+					Name: "test-name",
 				},
 			}
 			_, r, cl = createDeployedCr(createLegacyCr())
@@ -165,8 +232,7 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 		ginkgo.DescribeTable("Should fix a changed csi daemonSet", func(cr *hppv1.HostPathProvisioner, volumeMountName string) {
 			req := reconcile.Request{
 				NamespacedName: types.NamespacedName{
-					Name:      "test-name",
-					Namespace: testNamespace,
+					Name: "test-name",
 				},
 			}
 			cr, r, cl = createDeployedCr(cr)
@@ -243,8 +309,7 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 		ginkgo.DescribeTable("Should create daemonset with node placement", func(dsName string) {
 			req := reconcile.Request{
 				NamespacedName: types.NamespacedName{
-					Name:      "test-name",
-					Namespace: testNamespace,
+					Name: "test-name",
 				},
 			}
 			cr, r, cl := createDeployedCr(createLegacyCr())
@@ -309,35 +374,48 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 			ginkgo.Entry("csiDs", fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)),
 		)
 
-		ginkgo.DescribeTable("Should be able to remove node placement if CR doesn't have it anymore", func(dsName string) {
-			affinityTestValue := &corev1.Affinity{
-				NodeAffinity: &corev1.NodeAffinity{
-					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
-						NodeSelectorTerms: []corev1.NodeSelectorTerm{
-							{
-								MatchExpressions: []corev1.NodeSelectorRequirement{
-									{Key: "kubernetes.io/hostname", Operator: corev1.NodeSelectorOpIn, Values: []string{"somehostname"}},
-								},
-							},
-						},
-					},
+		ginkgo.DescribeTable("Should apply the termination grace period to the daemonset", func(dsName string) {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
 				},
 			}
-			nodeSelectorTestValue := map[string]string{"kubernetes.io/arch": "ppc64le"}
-			tolerationsTestValue := []corev1.Toleration{{Key: "test", Value: "123"}}
-			cr := createLegacyCr()
-			cr.Spec.Workload = hppv1.NodePlacement{
-				NodeSelector: nodeSelectorTestValue,
-				Affinity:     affinityTestValue,
-				Tolerations:  tolerationsTestValue,
+			cr, r, cl := createDeployedCr(createLegacyCr())
+			ds := &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      dsName,
+					Namespace: testNamespace,
+				},
 			}
-			cr, r, cl := createDeployedCr(cr)
+			err := cl.Get(context.TODO(), client.ObjectKeyFromObject(ds), ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ds.Spec.Template.Spec.TerminationGracePeriodSeconds).To(gomega.Equal(pointer.Int64Ptr(30)))
+
+			cr = &hppv1.HostPathProvisioner{}
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.Workload.TerminationGracePeriodSeconds = pointer.Int64Ptr(120)
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			res, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(res.Requeue).To(gomega.BeFalse())
+
+			err = cl.Get(context.TODO(), client.ObjectKeyFromObject(ds), ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ds.Spec.Template.Spec.TerminationGracePeriodSeconds).To(gomega.Equal(pointer.Int64Ptr(120)))
+		},
+			ginkgo.Entry("legacyDs", MultiPurposeHostPathProvisionerName),
+			ginkgo.Entry("csiDs", fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)),
+		)
+
+		ginkgo.DescribeTable("Should apply the revision history limit to the daemonset", func(dsName string) {
 			req := reconcile.Request{
 				NamespacedName: types.NamespacedName{
-					Name:      "test-name",
-					Namespace: testNamespace,
+					Name: "test-name",
 				},
 			}
+			cr, r, cl := createDeployedCr(createLegacyCr())
 			ds := &appsv1.DaemonSet{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      dsName,
@@ -346,47 +424,158 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 			}
 			err := cl.Get(context.TODO(), client.ObjectKeyFromObject(ds), ds)
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
-
-			gomega.Expect(ds.Spec.Template.Spec.Affinity).To(gomega.Equal(affinityTestValue))
-			gomega.Expect(ds.Spec.Template.Spec.NodeSelector).To(gomega.Equal(nodeSelectorTestValue))
-			gomega.Expect(ds.Spec.Template.Spec.Tolerations).To(gomega.Equal(tolerationsTestValue))
+			gomega.Expect(ds.Spec.RevisionHistoryLimit).To(gomega.Equal(pointer.Int32(defaultRevisionHistoryLimit)))
 
 			cr = &hppv1.HostPathProvisioner{}
 			err = cl.Get(context.TODO(), req.NamespacedName, cr)
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
-			cr.Spec.Workload = hppv1.NodePlacement{}
+			cr.Spec.Workload.RevisionHistoryLimit = pointer.Int32(0)
 			err = cl.Update(context.TODO(), cr)
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
-			res, err := r.Reconcile(context.TODO(), req)
+			_, err = r.Reconcile(context.TODO(), req)
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
-			gomega.Expect(res.Requeue).To(gomega.BeFalse())
 
-			ds = &appsv1.DaemonSet{
+			err = cl.Get(context.TODO(), client.ObjectKeyFromObject(ds), ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ds.Spec.RevisionHistoryLimit).To(gomega.Equal(pointer.Int32(0)))
+		},
+			ginkgo.Entry("legacyDs", MultiPurposeHostPathProvisionerName),
+			ginkgo.Entry("csiDs", fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)),
+		)
+
+		ginkgo.DescribeTable("Should add the control plane tolerations to the daemonset", func(dsName string) {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			toleration := corev1.Toleration{
+				Key:      "storage",
+				Operator: corev1.TolerationOpEqual,
+				Value:    "dedicated",
+				Effect:   corev1.TaintEffectNoSchedule,
+			}
+			cr := createLegacyCr()
+			cr.Spec.Workload.Tolerations = []corev1.Toleration{toleration, controlPlaneToleration}
+			cr, r, cl := createDeployedCr(cr)
+			ds := &appsv1.DaemonSet{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      dsName,
 					Namespace: testNamespace,
 				},
 			}
+			err := cl.Get(context.TODO(), client.ObjectKeyFromObject(ds), ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ds.Spec.Template.Spec.Tolerations).To(gomega.Equal([]corev1.Toleration{toleration, controlPlaneToleration}))
+
+			cr = &hppv1.HostPathProvisioner{}
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.Workload.TolerateControlPlane = true
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
 			err = cl.Get(context.TODO(), client.ObjectKeyFromObject(ds), ds)
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ds.Spec.Template.Spec.Tolerations).To(gomega.Equal([]corev1.Toleration{toleration, controlPlaneToleration, masterToleration}))
+		},
+			ginkgo.Entry("legacyDs", MultiPurposeHostPathProvisionerName),
+			ginkgo.Entry("csiDs", fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)),
+		)
 
-			gomega.Expect(ds.Spec.Template.Spec.Affinity).To(gomega.BeNil())
-			gomega.Expect(ds.Spec.Template.Spec.NodeSelector).To(gomega.BeEmpty())
-			gomega.Expect(ds.Spec.Template.Spec.Tolerations).To(gomega.BeEmpty())
+		ginkgo.DescribeTable("Should declare the pods as a critical addon", func(dsName string) {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			cr, r, cl := createDeployedCr(createLegacyCr())
+			ds := &appsv1.DaemonSet{}
+			dsNN := types.NamespacedName{Name: dsName, Namespace: testNamespace}
+			err := cl.Get(context.TODO(), dsNN, ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ds.Spec.Template.Annotations).ToNot(gomega.HaveKey(safeToEvictAnnotation))
+			gomega.Expect(ds.Spec.Template.Spec.PriorityClassName).To(gomega.BeEmpty())
+
+			cr = &hppv1.HostPathProvisioner{}
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.Workload.CriticalAddon = true
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			err = cl.Get(context.TODO(), dsNN, ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ds.Spec.Template.Annotations).To(gomega.HaveKeyWithValue(safeToEvictAnnotation, "false"))
+			gomega.Expect(ds.Spec.Template.Spec.PriorityClassName).To(gomega.Equal(criticalAddonPriorityClassName))
 		},
 			ginkgo.Entry("legacyDs", MultiPurposeHostPathProvisionerName),
 			ginkgo.Entry("csiDs", fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)),
 		)
 
-		ginkgo.DescribeTable("Should delete daemonsets from versions with junk in .spec.selector", func(dsName string) {
+		ginkgo.DescribeTable("Should apply the resource profile to all the containers", func(profile hppv1.ResourceProfile, expected corev1.ResourceRequirements) {
 			req := reconcile.Request{
 				NamespacedName: types.NamespacedName{
-					Name:      "test-name",
-					Namespace: testNamespace,
+					Name: "test-name",
 				},
 			}
-			_, r, cl := createDeployedCr(createLegacyCr())
-			// Now modify the daemonSet to something not desired.
+			cr, r, cl := createDeployedCr(createLegacyCr())
+			cr = &hppv1.HostPathProvisioner{}
+			err := cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.FeatureGates = []string{snapshotFeatureGate}
+			cr.Spec.Workload.ResourceProfile = profile
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			for _, dsName := range []string{MultiPurposeHostPathProvisionerName, fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)} {
+				ds := &appsv1.DaemonSet{}
+				err = cl.Get(context.TODO(), types.NamespacedName{Name: dsName, Namespace: testNamespace}, ds)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				for _, container := range ds.Spec.Template.Spec.Containers {
+					gomega.Expect(container.Resources).To(gomega.Equal(expected), "container %s in %s", container.Name, dsName)
+				}
+			}
+		},
+			ginkgo.Entry("minimal", hppv1.ResourceProfileMinimal, corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("5m"),
+					corev1.ResourceMemory: resource.MustParse("32Mi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("128Mi"),
+				},
+			}),
+			ginkgo.Entry("default", hppv1.ResourceProfileDefault, corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("10m"),
+					corev1.ResourceMemory: resource.MustParse("150Mi"),
+				},
+			}),
+			ginkgo.Entry("highThroughput", hppv1.ResourceProfileHighThroughput, corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("100m"),
+					corev1.ResourceMemory: resource.MustParse("300Mi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			}),
+		)
+
+		ginkgo.DescribeTable("Should apply a None dns policy with an explicit dns config to the daemonset", func(dsName string) {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			cr, r, cl := createDeployedCr(createLegacyCr())
 			ds := &appsv1.DaemonSet{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      dsName,
@@ -395,47 +584,891 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 			}
 			err := cl.Get(context.TODO(), client.ObjectKeyFromObject(ds), ds)
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
-			ds.Spec.Selector.MatchLabels = map[string]string{
-				"k8s-app": MultiPurposeHostPathProvisionerName,
-				"not":     "desired",
+			gomega.Expect(ds.Spec.Template.Spec.DNSPolicy).To(gomega.Equal(corev1.DNSClusterFirst))
+			gomega.Expect(ds.Spec.Template.Spec.DNSConfig).To(gomega.BeNil())
+
+			ndots := "2"
+			dnsConfig := &corev1.PodDNSConfig{
+				Nameservers: []string{"10.0.0.10"},
+				Searches:    []string{"storage.example.com"},
+				Options: []corev1.PodDNSConfigOption{
+					{
+						Name:  "ndots",
+						Value: &ndots,
+					},
+				},
 			}
-			err = cl.Update(context.TODO(), ds)
+			cr = &hppv1.HostPathProvisioner{}
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
-			ds = &appsv1.DaemonSet{
+			cr.Spec.Workload.DNSPolicy = corev1.DNSNone
+			cr.Spec.Workload.DNSConfig = dnsConfig
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			res, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(res.Requeue).To(gomega.BeFalse())
+
+			err = cl.Get(context.TODO(), client.ObjectKeyFromObject(ds), ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ds.Spec.Template.Spec.DNSPolicy).To(gomega.Equal(corev1.DNSNone))
+			gomega.Expect(ds.Spec.Template.Spec.DNSConfig).To(gomega.Equal(dnsConfig))
+
+			ginkgo.By("Removing the dns settings")
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.Workload.DNSPolicy = ""
+			cr.Spec.Workload.DNSConfig = nil
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			err = cl.Get(context.TODO(), client.ObjectKeyFromObject(ds), ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ds.Spec.Template.Spec.DNSPolicy).To(gomega.Equal(corev1.DNSClusterFirst))
+			gomega.Expect(ds.Spec.Template.Spec.DNSConfig).To(gomega.BeNil())
+		},
+			ginkgo.Entry("legacyDs", MultiPurposeHostPathProvisionerName),
+			ginkgo.Entry("csiDs", fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)),
+		)
+
+		ginkgo.DescribeTable("Should apply host networking to the daemonset", func(dsName string) {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			cr, r, cl := createDeployedCr(createLegacyCr())
+			ds := &appsv1.DaemonSet{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      dsName,
 					Namespace: testNamespace,
 				},
 			}
+			err := cl.Get(context.TODO(), client.ObjectKeyFromObject(ds), ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ds.Spec.Template.Spec.HostNetwork).To(gomega.BeFalse())
+
+			cr = &hppv1.HostPathProvisioner{}
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.Workload.HostNetwork = true
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
 			err = cl.Get(context.TODO(), client.ObjectKeyFromObject(ds), ds)
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
-			gomega.Expect(ds.Spec.Selector.MatchLabels).To(gomega.Equal(
-				map[string]string{
-					"k8s-app": MultiPurposeHostPathProvisionerName,
-					"not":     "desired",
-				},
-			))
+			gomega.Expect(ds.Spec.Template.Spec.HostNetwork).To(gomega.BeTrue())
+			gomega.Expect(ds.Spec.Template.Spec.DNSPolicy).To(gomega.Equal(corev1.DNSClusterFirstWithHostNet))
 
-			// Run the reconcile loop
+			ginkgo.By("Keeping an explicit dns policy")
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.Workload.DNSPolicy = corev1.DNSDefault
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			_, err = r.Reconcile(context.TODO(), req)
-			gomega.Expect(err).To(gomega.HaveOccurred())
-			gomega.Expect(err.Error()).To(gomega.Equal("DaemonSet with extra selector labels spotted, cleaning up and requeueing"))
-			// Artificial requeue (err occured implies requeue)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), client.ObjectKeyFromObject(ds), ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ds.Spec.Template.Spec.HostNetwork).To(gomega.BeTrue())
+			gomega.Expect(ds.Spec.Template.Spec.DNSPolicy).To(gomega.Equal(corev1.DNSDefault))
+
+			ginkgo.By("Disabling host networking")
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.Workload.HostNetwork = false
+			cr.Spec.Workload.DNSPolicy = ""
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			_, err = r.Reconcile(context.TODO(), req)
-			gomega.Expect(err).ToNot(gomega.HaveOccurred())
-			// Check the daemonSet value, make sure it changed back.
-			ds = &appsv1.DaemonSet{
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), client.ObjectKeyFromObject(ds), ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ds.Spec.Template.Spec.HostNetwork).To(gomega.BeFalse())
+			gomega.Expect(ds.Spec.Template.Spec.DNSPolicy).To(gomega.Equal(corev1.DNSClusterFirst))
+		},
+			ginkgo.Entry("legacyDs", MultiPurposeHostPathProvisionerName),
+			ginkgo.Entry("csiDs", fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)),
+		)
+
+		ginkgo.DescribeTable("Should apply automountServiceAccountToken to the daemonset", func(dsName string) {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			_, r, cl := createDeployedCr(createLegacyCr())
+			ds := &appsv1.DaemonSet{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      dsName,
 					Namespace: testNamespace,
 				},
 			}
-			err = cl.Get(context.TODO(), client.ObjectKeyFromObject(ds), ds)
+			err := cl.Get(context.TODO(), client.ObjectKeyFromObject(ds), ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ds.Spec.Template.Spec.AutomountServiceAccountToken).To(gomega.BeNil())
+
+			for _, automount := range []*bool{pointer.Bool(false), pointer.Bool(true), nil} {
+				ginkgo.By(fmt.Sprintf("Setting automountServiceAccountToken to %v", automount))
+				cr := &hppv1.HostPathProvisioner{}
+				err = cl.Get(context.TODO(), req.NamespacedName, cr)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				cr.Spec.Workload.AutomountServiceAccountToken = automount
+				err = cl.Update(context.TODO(), cr)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				_, err = r.Reconcile(context.TODO(), req)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				err = cl.Get(context.TODO(), client.ObjectKeyFromObject(ds), ds)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(ds.Spec.Template.Spec.AutomountServiceAccountToken).To(gomega.Equal(automount))
+			}
+		},
+			ginkgo.Entry("legacyDs", MultiPurposeHostPathProvisionerName),
+			ginkgo.Entry("csiDs", fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)),
+		)
+
+		ginkgo.DescribeTable("Should apply the fsGroup to the daemonset", func(dsName string) {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			_, r, cl := createDeployedCr(createLegacyCr())
+			ds := &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      dsName,
+					Namespace: testNamespace,
+				},
+			}
+			err := cl.Get(context.TODO(), client.ObjectKeyFromObject(ds), ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ds.Spec.Template.Spec.SecurityContext.FSGroup).To(gomega.BeNil())
+
+			for _, fsGroup := range []*int64{pointer.Int64(107), pointer.Int64(2000), nil} {
+				ginkgo.By(fmt.Sprintf("Setting fsGroup to %v", fsGroup))
+				cr := &hppv1.HostPathProvisioner{}
+				err = cl.Get(context.TODO(), req.NamespacedName, cr)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				cr.Spec.Workload.FSGroup = fsGroup
+				err = cl.Update(context.TODO(), cr)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				_, err = r.Reconcile(context.TODO(), req)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				err = cl.Get(context.TODO(), client.ObjectKeyFromObject(ds), ds)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(ds.Spec.Template.Spec.SecurityContext.FSGroup).To(gomega.Equal(fsGroup))
+			}
+		},
+			ginkgo.Entry("legacyDs", MultiPurposeHostPathProvisionerName),
+			ginkgo.Entry("csiDs", fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)),
+		)
+
+		ginkgo.It("Should add or remove the liveness-probe sidecar", func() {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			dsNN := types.NamespacedName{Name: fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName), Namespace: testNamespace}
+			verifyLivenessProbe := func(enabled bool) {
+				ds := &appsv1.DaemonSet{}
+				err := cl.Get(context.TODO(), dsNN, ds)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				foundSidecar := false
+				for _, container := range ds.Spec.Template.Spec.Containers {
+					if container.Name == livenessProbeName {
+						foundSidecar = true
+						gomega.Expect(container.Args).To(gomega.ContainElement("--health-port=9898"))
+					}
+					if container.Name != MultiPurposeHostPathProvisionerName {
+						continue
+					}
+					portNames := make([]string, 0)
+					for _, port := range container.Ports {
+						portNames = append(portNames, port.Name)
+					}
+					if enabled {
+						gomega.Expect(portNames).To(gomega.Equal([]string{"healthz", "metrics"}))
+						gomega.Expect(container.Ports[0].ContainerPort).To(gomega.Equal(int32(9898)))
+						gomega.Expect(container.LivenessProbe).ToNot(gomega.BeNil())
+						gomega.Expect(container.LivenessProbe.HTTPGet.Port.IntVal).To(gomega.Equal(container.Ports[0].ContainerPort))
+					} else {
+						gomega.Expect(portNames).To(gomega.Equal([]string{"metrics"}))
+						gomega.Expect(container.LivenessProbe).To(gomega.BeNil())
+					}
+				}
+				gomega.Expect(foundSidecar).To(gomega.Equal(enabled))
+			}
+			verifyLivenessProbe(true)
+
+			for _, enabled := range []bool{false, true} {
+				ginkgo.By(fmt.Sprintf("Setting enableLivenessProbe to %t", enabled))
+				err := cl.Get(context.TODO(), req.NamespacedName, cr)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				cr.Spec.CSIDriver = &hppv1.CSIDriverConfig{
+					EnableLivenessProbe: pointer.Bool(enabled),
+				}
+				err = cl.Update(context.TODO(), cr)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				_, err = r.Reconcile(context.TODO(), req)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				verifyLivenessProbe(enabled)
+			}
+		})
+
+		ginkgo.It("Should keep the built in resources without a resource profile", func() {
+			_, _, cl := createDeployedCr(createLegacyCr())
+			ds := &appsv1.DaemonSet{}
+			err := cl.Get(context.TODO(), types.NamespacedName{Name: MultiPurposeHostPathProvisionerName, Namespace: testNamespace}, ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ds.Spec.Template.Spec.Containers[0].Resources.Requests).To(gomega.Equal(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("10m"),
+				corev1.ResourceMemory: resource.MustParse("150Mi"),
+			}))
+			gomega.Expect(ds.Spec.Template.Spec.Containers[0].Resources.Limits).To(gomega.BeEmpty())
+		})
+
+		ginkgo.DescribeTable("Should add and remove additional volumes in the provisioner container", func(dsName string) {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			cr, r, cl := createDeployedCr(createLegacyCr())
+			ds := &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      dsName,
+					Namespace: testNamespace,
+				},
+			}
+			cr = &hppv1.HostPathProvisioner{}
+			err := cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.AdditionalVolumes = []hppv1.AdditionalVolume{
+				{
+					Name:      "extra",
+					HostPath:  "/mnt/extra",
+					MountPath: "/extra",
+					ReadOnly:  true,
+				},
+			}
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			directoryOrCreate := corev1.HostPathDirectoryOrCreate
+			hostToContainer := corev1.MountPropagationHostToContainer
+			expectedVolume := corev1.Volume{
+				Name: "additional-extra",
+				VolumeSource: corev1.VolumeSource{
+					HostPath: &corev1.HostPathVolumeSource{
+						Path: "/mnt/extra",
+						Type: &directoryOrCreate,
+					},
+				},
+			}
+			expectedMount := corev1.VolumeMount{
+				Name:             "additional-extra",
+				MountPath:        "/extra",
+				ReadOnly:         true,
+				MountPropagation: &hostToContainer,
+			}
+			err = cl.Get(context.TODO(), client.ObjectKeyFromObject(ds), ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ds.Spec.Template.Spec.Volumes).To(gomega.ContainElement(expectedVolume))
+			for _, container := range ds.Spec.Template.Spec.Containers {
+				if container.Name == MultiPurposeHostPathProvisionerName {
+					gomega.Expect(container.VolumeMounts).To(gomega.ContainElement(expectedMount))
+				} else {
+					gomega.Expect(container.VolumeMounts).ToNot(gomega.ContainElement(expectedMount))
+				}
+			}
+
+			ginkgo.By("Removing the additional volumes from the CR, they should be removed from the daemonset")
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.AdditionalVolumes = nil
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), client.ObjectKeyFromObject(ds), ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ds.Spec.Template.Spec.Volumes).ToNot(gomega.ContainElement(expectedVolume))
+			for _, container := range ds.Spec.Template.Spec.Containers {
+				gomega.Expect(container.VolumeMounts).ToNot(gomega.ContainElement(expectedMount))
+			}
+		},
+			ginkgo.Entry("legacyDs", MultiPurposeHostPathProvisionerName),
+			ginkgo.Entry("csiDs", fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)),
+		)
+
+		ginkgo.DescribeTable("Should be able to remove node placement if CR doesn't have it anymore", func(dsName string) {
+			affinityTestValue := &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{
+								MatchExpressions: []corev1.NodeSelectorRequirement{
+									{Key: "kubernetes.io/hostname", Operator: corev1.NodeSelectorOpIn, Values: []string{"somehostname"}},
+								},
+							},
+						},
+					},
+				},
+			}
+			nodeSelectorTestValue := map[string]string{"kubernetes.io/arch": "ppc64le"}
+			tolerationsTestValue := []corev1.Toleration{{Key: "test", Value: "123"}}
+			cr := createLegacyCr()
+			cr.Spec.Workload = hppv1.NodePlacement{
+				NodeSelector: nodeSelectorTestValue,
+				Affinity:     affinityTestValue,
+				Tolerations:  tolerationsTestValue,
+			}
+			cr, r, cl := createDeployedCr(cr)
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			ds := &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      dsName,
+					Namespace: testNamespace,
+				},
+			}
+			err := cl.Get(context.TODO(), client.ObjectKeyFromObject(ds), ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			gomega.Expect(ds.Spec.Template.Spec.Affinity).To(gomega.Equal(affinityTestValue))
+			gomega.Expect(ds.Spec.Template.Spec.NodeSelector).To(gomega.Equal(nodeSelectorTestValue))
+			gomega.Expect(ds.Spec.Template.Spec.Tolerations).To(gomega.Equal(tolerationsTestValue))
+
+			cr = &hppv1.HostPathProvisioner{}
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.Workload = hppv1.NodePlacement{}
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			res, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(res.Requeue).To(gomega.BeFalse())
+
+			ds = &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      dsName,
+					Namespace: testNamespace,
+				},
+			}
+			err = cl.Get(context.TODO(), client.ObjectKeyFromObject(ds), ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			gomega.Expect(ds.Spec.Template.Spec.Affinity).To(gomega.BeNil())
+			gomega.Expect(ds.Spec.Template.Spec.NodeSelector).To(gomega.BeEmpty())
+			gomega.Expect(ds.Spec.Template.Spec.Tolerations).To(gomega.BeEmpty())
+		},
+			ginkgo.Entry("legacyDs", MultiPurposeHostPathProvisionerName),
+			ginkgo.Entry("csiDs", fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)),
+		)
+
+		ginkgo.DescribeTable("Should recreate daemonsets from versions with a different .spec.selector", func(dsName string) {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			_, r, cl := createDeployedCr(createLegacyCr())
+			// Now modify the daemonSet to something not desired.
+			ds := &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      dsName,
+					Namespace: testNamespace,
+				},
+			}
+			err := cl.Get(context.TODO(), client.ObjectKeyFromObject(ds), ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			ds.Spec.Selector.MatchLabels = map[string]string{
+				"k8s-app": MultiPurposeHostPathProvisionerName,
+				"not":     "desired",
+			}
+			err = cl.Update(context.TODO(), ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			ds = &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      dsName,
+					Namespace: testNamespace,
+				},
+			}
+			err = cl.Get(context.TODO(), client.ObjectKeyFromObject(ds), ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ds.Spec.Selector.MatchLabels).To(gomega.Equal(
+				map[string]string{
+					"k8s-app": MultiPurposeHostPathProvisionerName,
+					"not":     "desired",
+				},
+			))
+
+			recorder, ok := r.recorder.(*record.FakeRecorder)
+			gomega.Expect(ok).To(gomega.BeTrue())
+			for len(recorder.Events) > 0 {
+				<-recorder.Events
+			}
+
+			// Run the reconcile loop, no requeue is needed to recreate the daemonSet
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			events := make([]string, 0)
+			for len(recorder.Events) > 0 {
+				events = append(events, <-recorder.Events)
+			}
+			gomega.Expect(events).To(gomega.ContainElement(fmt.Sprintf("%s %s %s", corev1.EventTypeNormal,
+				daemonSetRecreatedForSelectorChange, fmt.Sprintf(daemonSetRecreatedForSelectorChangeMessage, dsName))))
+			// Check the daemonSet value, make sure it changed back.
+			ds = &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      dsName,
+					Namespace: testNamespace,
+				},
+			}
+			err = cl.Get(context.TODO(), client.ObjectKeyFromObject(ds), ds)
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			gomega.Expect(ds.Spec.Selector.MatchLabels).To(gomega.Equal(selectorLabels))
 		},
 			ginkgo.Entry("legacyDs", MultiPurposeHostPathProvisionerName),
 			ginkgo.Entry("csiDs", fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)),
 		)
+
+		ginkgo.DescribeTable("Should adopt an existing daemonset without a controller", func(dsName string, adoptExisting bool) {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			cr, r, cl := createDeployedCr(createLegacyCr())
+			recorder, ok := r.recorder.(*record.FakeRecorder)
+			gomega.Expect(ok).To(gomega.BeTrue())
+			for len(recorder.Events) > 0 {
+				<-recorder.Events
+			}
+			ginkgo.By("Removing the owner of the daemonSet, like a helm installed daemonSet")
+			ds := &appsv1.DaemonSet{}
+			err := cl.Get(context.TODO(), types.NamespacedName{Name: dsName, Namespace: testNamespace}, ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			ds.OwnerReferences = nil
+			ds.Labels[AppKubernetesManagedByLabel] = "Helm"
+			err = cl.Update(context.TODO(), ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.AdoptExisting = adoptExisting
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			err = cl.Get(context.TODO(), types.NamespacedName{Name: dsName, Namespace: testNamespace}, ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(metav1.IsControlledBy(ds, cr)).To(gomega.Equal(adoptExisting))
+			gomega.Expect(ds.Labels[AppKubernetesManagedByLabel]).To(gomega.Equal("hostpath-provisioner-operator"))
+			events := make([]string, 0)
+			for len(recorder.Events) > 0 {
+				events = append(events, <-recorder.Events)
+			}
+			adoptEvent := fmt.Sprintf("%s %s %s", corev1.EventTypeNormal, adoptResourceSuccess, fmt.Sprintf(adoptMessageSucceeded, ds, dsName))
+			if adoptExisting {
+				gomega.Expect(events).To(gomega.ContainElement(adoptEvent))
+			} else {
+				gomega.Expect(events).ToNot(gomega.ContainElement(adoptEvent))
+			}
+		},
+			ginkgo.Entry("legacyDs", MultiPurposeHostPathProvisionerName, true),
+			ginkgo.Entry("csiDs", fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName), true),
+			ginkgo.Entry("legacyDs without adoptExisting", MultiPurposeHostPathProvisionerName, false),
+			ginkgo.Entry("csiDs without adoptExisting", fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName), false),
+		)
+
+		ginkgo.DescribeTable("Should add the workload env to the provisioner container", func(dsName string) {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			cr, r, cl := createDeployedCr(createLegacyCr())
+			getProvisionerEnv := func() []corev1.EnvVar {
+				ds := &appsv1.DaemonSet{}
+				err := cl.Get(context.TODO(), types.NamespacedName{Name: dsName, Namespace: testNamespace}, ds)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				for _, container := range ds.Spec.Template.Spec.Containers {
+					if container.Name == MultiPurposeHostPathProvisionerName {
+						return container.Env
+					}
+				}
+				ginkgo.Fail("provisioner container not found")
+				return nil
+			}
+			managedEnv := getProvisionerEnv()
+			updateEnv := func(env []corev1.EnvVar) {
+				err := cl.Get(context.TODO(), req.NamespacedName, cr)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				cr.Spec.Workload.Env = env
+				err = cl.Update(context.TODO(), cr)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				_, err = r.Reconcile(context.TODO(), req)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			}
+
+			updateEnv([]corev1.EnvVar{
+				{Name: "HTTP_PROXY", Value: "http://proxy.example.com:3128"},
+				{Name: "NODE_NAME", Value: "not-the-node"},
+			})
+			gomega.Expect(getProvisionerEnv()).To(gomega.Equal(append(managedEnv, corev1.EnvVar{Name: "HTTP_PROXY", Value: "http://proxy.example.com:3128"})))
+
+			ginkgo.By("Changing the value of the workload env")
+			updateEnv([]corev1.EnvVar{
+				{Name: "HTTP_PROXY", Value: "http://other-proxy.example.com:3128"},
+			})
+			gomega.Expect(getProvisionerEnv()).To(gomega.Equal(append(managedEnv, corev1.EnvVar{Name: "HTTP_PROXY", Value: "http://other-proxy.example.com:3128"})))
+
+			ginkgo.By("Removing the workload env")
+			updateEnv(nil)
+			gomega.Expect(getProvisionerEnv()).To(gomega.Equal(managedEnv))
+		},
+			ginkgo.Entry("legacyDs", MultiPurposeHostPathProvisionerName),
+			ginkgo.Entry("csiDs", fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)),
+		)
+
+		ginkgo.DescribeTable("Should add the cluster proxy to the provisioner container", func(dsName string) {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			cr, r, cl := createDeployedCr(createLegacyCr())
+			getProvisionerEnv := func() []corev1.EnvVar {
+				ds := &appsv1.DaemonSet{}
+				err := cl.Get(context.TODO(), types.NamespacedName{Name: dsName, Namespace: testNamespace}, ds)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				for _, container := range ds.Spec.Template.Spec.Containers {
+					if container.Name == MultiPurposeHostPathProvisionerName {
+						return container.Env
+					}
+				}
+				ginkgo.Fail("provisioner container not found")
+				return nil
+			}
+			managedEnv := getProvisionerEnv()
+
+			proxy := &ocpconfigv1.Proxy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: clusterProxyName,
+				},
+				Status: ocpconfigv1.ProxyStatus{
+					HTTPProxy:  "http://proxy.example.com:3128",
+					HTTPSProxy: "http://proxy.example.com:3129",
+					NoProxy:    ".cluster.local,10.0.0.0/16",
+				},
+			}
+			mapFn := hppProxyMapFunc(cl)
+			gomega.Expect(mapFn(context.TODO(), proxy)).To(gomega.HaveLen(1))
+			err := cl.Create(context.TODO(), proxy)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(getProvisionerEnv()).To(gomega.Equal(append(append([]corev1.EnvVar{}, managedEnv...),
+				corev1.EnvVar{Name: "HTTP_PROXY", Value: "http://proxy.example.com:3128"},
+				corev1.EnvVar{Name: "HTTPS_PROXY", Value: "http://proxy.example.com:3129"},
+				corev1.EnvVar{Name: "NO_PROXY", Value: ".cluster.local,10.0.0.0/16"},
+			)))
+
+			ginkgo.By("Overriding the proxy with the workload env")
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.Workload.Env = []corev1.EnvVar{
+				{Name: "HTTP_PROXY", Value: "http://other-proxy.example.com:3128"},
+			}
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(getProvisionerEnv()).To(gomega.Equal(append(append([]corev1.EnvVar{}, managedEnv...),
+				corev1.EnvVar{Name: "HTTP_PROXY", Value: "http://other-proxy.example.com:3128"},
+				corev1.EnvVar{Name: "HTTPS_PROXY", Value: "http://proxy.example.com:3129"},
+				corev1.EnvVar{Name: "NO_PROXY", Value: ".cluster.local,10.0.0.0/16"},
+			)))
+
+			ginkgo.By("Removing the cluster proxy")
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.Workload.Env = nil
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Delete(context.TODO(), proxy)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(getProvisionerEnv()).To(gomega.Equal(managedEnv))
+		},
+			ginkgo.Entry("legacyDs", MultiPurposeHostPathProvisionerName),
+			ginkgo.Entry("csiDs", fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)),
+		)
+
+		ginkgo.It("Should add the workload init containers to the provisioner pods", func() {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			getInitContainers := func() []corev1.Container {
+				ds := &appsv1.DaemonSet{}
+				err := cl.Get(context.TODO(), types.NamespacedName{Name: fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName), Namespace: testNamespace}, ds)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				return ds.Spec.Template.Spec.InitContainers
+			}
+			updateInitContainers := func(initContainers []corev1.Container) {
+				err := cl.Get(context.TODO(), req.NamespacedName, cr)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				cr.Spec.Workload.InitContainers = initContainers
+				err = cl.Update(context.TODO(), cr)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				_, err = r.Reconcile(context.TODO(), req)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			}
+			gomega.Expect(getInitContainers()).To(gomega.BeEmpty())
+			chmod := corev1.Container{
+				Name:    "chmod",
+				Image:   "registry.example.com/busybox",
+				Command: []string{"chmod", "0770", "/csi"},
+			}
+			updateInitContainers([]corev1.Container{chmod})
+			gomega.Expect(getInitContainers()).To(gomega.Equal([]corev1.Container{chmod}))
+
+			ginkgo.By("Updating the DaemonSet when the init containers change")
+			chmod.Command = []string{"chmod", "0755", "/csi"}
+			updateInitContainers([]corev1.Container{chmod})
+			gomega.Expect(getInitContainers()).To(gomega.Equal([]corev1.Container{chmod}))
+
+			ginkgo.By("Removing the init containers")
+			updateInitContainers(nil)
+			gomega.Expect(getInitContainers()).To(gomega.BeEmpty())
+		})
+
+		ginkgo.It("Should have the webhook reject init containers named like the containers of the DaemonSets", func() {
+			_, _, cl := createDeployedCr(createLegacyCr())
+			for _, name := range []string{MultiPurposeHostPathProvisionerName, fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)} {
+				ds := &appsv1.DaemonSet{}
+				err := cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: testNamespace}, ds)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				for _, container := range ds.Spec.Template.Spec.Containers {
+					cr := createLegacyCr()
+					cr.Spec.Workload.InitContainers = []corev1.Container{{Name: container.Name, Image: "registry.example.com/busybox"}}
+					_, err := cr.ValidateCreate()
+					gomega.Expect(err).To(gomega.HaveOccurred(), container.Name)
+				}
+			}
+		})
+
+		ginkgo.It("Should put the workload init containers before the init containers of the operator", func() {
+			cr := createStoragePoolWithTemplateCr()
+			cr.Spec.Workload.InitContainers = []corev1.Container{
+				{Name: "mkdir", Image: "registry.example.com/busybox"},
+				{Name: MultiPurposeHostPathProvisionerName, Image: "registry.example.com/busybox"},
+				{Name: "managed-init", Image: "registry.example.com/busybox"},
+			}
+			podSpec := &corev1.PodSpec{
+				Containers:     []corev1.Container{{Name: MultiPurposeHostPathProvisionerName}},
+				InitContainers: []corev1.Container{{Name: "managed-init"}},
+			}
+			addWorkloadInitContainers(cr, podSpec)
+			gomega.Expect(podSpec.InitContainers).To(gomega.Equal([]corev1.Container{
+				{Name: "mkdir", Image: "registry.example.com/busybox"},
+				{Name: "managed-init"},
+			}))
+			gomega.Expect(podSpec.Containers).To(gomega.Equal([]corev1.Container{{Name: MultiPurposeHostPathProvisionerName}}))
+		})
+
+		ginkgo.It("Should append the extra args to the csi driver container", func() {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			getProvisionerArgs := func() []string {
+				ds := &appsv1.DaemonSet{}
+				err := cl.Get(context.TODO(), types.NamespacedName{Name: fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName), Namespace: testNamespace}, ds)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				for _, container := range ds.Spec.Template.Spec.Containers {
+					if container.Name == MultiPurposeHostPathProvisionerName {
+						return container.Args
+					}
+				}
+				ginkgo.Fail("provisioner container not found")
+				return nil
+			}
+			managedArgs := getProvisionerArgs()
+			updateExtraArgs := func(extraArgs []string) {
+				err := cl.Get(context.TODO(), req.NamespacedName, cr)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				cr.Spec.CSIDriver = &hppv1.CSIDriverConfig{
+					ExtraArgs: extraArgs,
+				}
+				err = cl.Update(context.TODO(), cr)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				_, err = r.Reconcile(context.TODO(), req)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			}
+
+			updateExtraArgs([]string{"--log-format=json", "--datadir=/tmp", "-drivername=other"})
+			gomega.Expect(getProvisionerArgs()).To(gomega.Equal(append(append([]string{}, managedArgs...), "--log-format=json")))
+
+			ginkgo.By("Changing the extra args")
+			updateExtraArgs([]string{"--log-format=text", "--enable-feature"})
+			gomega.Expect(getProvisionerArgs()).To(gomega.Equal(append(append([]string{}, managedArgs...), "--log-format=text", "--enable-feature")))
+
+			ginkgo.By("Removing the extra args")
+			updateExtraArgs(nil)
+			gomega.Expect(getProvisionerArgs()).To(gomega.Equal(managedArgs))
+		})
+
+		ginkgo.It("Should pass the worker threads to the csi-provisioner sidecar", func() {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			getSidecarArgs := func() []string {
+				ds := &appsv1.DaemonSet{}
+				err := cl.Get(context.TODO(), types.NamespacedName{Name: fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName), Namespace: testNamespace}, ds)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				for _, container := range ds.Spec.Template.Spec.Containers {
+					if container.Name == "csi-provisioner" {
+						return container.Args
+					}
+				}
+				ginkgo.Fail("csi-provisioner container not found")
+				return nil
+			}
+			updateWorkerThreads := func(workerThreads *int32) {
+				err := cl.Get(context.TODO(), req.NamespacedName, cr)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				cr.Spec.CSIDriver = &hppv1.CSIDriverConfig{
+					WorkerThreads: workerThreads,
+				}
+				err = cl.Update(context.TODO(), cr)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				_, err = r.Reconcile(context.TODO(), req)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			}
+			gomega.Expect(getSidecarArgs()).ToNot(gomega.ContainElement(gomega.HavePrefix("--worker-threads")))
+
+			updateWorkerThreads(pointer.Int32(50))
+			gomega.Expect(getSidecarArgs()).To(gomega.ContainElement("--worker-threads=50"))
+
+			ginkgo.By("Changing the worker threads")
+			updateWorkerThreads(pointer.Int32(200))
+			gomega.Expect(getSidecarArgs()).To(gomega.ContainElement("--worker-threads=200"))
+			gomega.Expect(getSidecarArgs()).ToNot(gomega.ContainElement("--worker-threads=50"))
+
+			ginkgo.By("Removing the worker threads")
+			updateWorkerThreads(nil)
+			gomega.Expect(getSidecarArgs()).ToNot(gomega.ContainElement(gomega.HavePrefix("--worker-threads")))
+		})
+
+		ginkgo.It("Should remove the legacy daemonset when migrating to CSI only", func() {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			cr, r, cl := createDeployedCr(createLegacyCr())
+			recorder, ok := r.recorder.(*record.FakeRecorder)
+			gomega.Expect(ok).To(gomega.BeTrue())
+			for len(recorder.Events) > 0 {
+				<-recorder.Events
+			}
+			legacyNN := types.NamespacedName{Name: MultiPurposeHostPathProvisionerName, Namespace: testNamespace}
+			err := cl.Get(context.TODO(), legacyNN, &appsv1.DaemonSet{})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			ginkgo.By("Replacing the pathConfig with a storage pool")
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.StoragePools = []hppv1.StoragePool{
+				{
+					Name: legacyStoragePoolName,
+					Path: cr.Spec.PathConfig.Path,
+				},
+			}
+			cr.Spec.PathConfig = nil
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			err = cl.Get(context.TODO(), legacyNN, &appsv1.DaemonSet{})
+			gomega.Expect(errors.IsNotFound(err)).To(gomega.BeTrue())
+			err = cl.Get(context.TODO(), types.NamespacedName{Name: fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName), Namespace: testNamespace}, &appsv1.DaemonSet{})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			removedEvent := fmt.Sprintf("%s %s %s", corev1.EventTypeNormal, legacyProvisionerRemoved, fmt.Sprintf(legacyProvisionerRemovedMessage, MultiPurposeHostPathProvisionerName))
+			events := make([]string, 0)
+			for len(recorder.Events) > 0 {
+				events = append(events, <-recorder.Events)
+			}
+			gomega.Expect(events).To(gomega.ContainElement(removedEvent))
+
+			ginkgo.By("Not emitting the event again once the legacy daemonset is gone")
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			events = make([]string, 0)
+			for len(recorder.Events) > 0 {
+				events = append(events, <-recorder.Events)
+			}
+			gomega.Expect(events).ToNot(gomega.ContainElement(removedEvent))
+		})
+
+		ginkgo.DescribeTable("Should refuse to adopt a daemonset labeled as a different application", func(dsName string) {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			cr, r, cl := createDeployedCr(createLegacyCr())
+			ds := &appsv1.DaemonSet{}
+			err := cl.Get(context.TODO(), types.NamespacedName{Name: dsName, Namespace: testNamespace}, ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			ds.OwnerReferences = nil
+			ds.Labels["k8s-app"] = "other-app"
+			err = cl.Update(context.TODO(), ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.AdoptExisting = true
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			gomega.Expect(err.Error()).To(gomega.Equal(fmt.Sprintf(adoptMessageFailed, dsName, "other-app")))
+
+			err = cl.Get(context.TODO(), types.NamespacedName{Name: dsName, Namespace: testNamespace}, ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(metav1.GetControllerOf(ds)).To(gomega.BeNil())
+			gomega.Expect(ds.Labels["k8s-app"]).To(gomega.Equal("other-app"))
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(IsCrHealthy(cr)).To(gomega.BeFalse())
+		},
+			ginkgo.Entry("legacyDs", MultiPurposeHostPathProvisionerName),
+			ginkgo.Entry("csiDs", fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)),
+		)
 	})
 })
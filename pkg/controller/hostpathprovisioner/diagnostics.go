@@ -0,0 +1,156 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	conditions "github.com/openshift/custom-resource-status/conditions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hostpathprovisionerv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
+)
+
+const (
+	// DiagnosticsPath is the path of the diagnostics endpoint on the metrics server.
+	DiagnosticsPath = "/debug/hpp"
+)
+
+// Diagnostics is the state of the HostPathProvisioner returned by the diagnostics endpoint.
+type Diagnostics struct {
+	Name            string                                    `json:"name"`
+	Conditions      []conditions.Condition                    `json:"conditions,omitempty"`
+	OperatorVersion string                                    `json:"operatorVersion,omitempty"`
+	TargetVersion   string                                    `json:"targetVersion,omitempty"`
+	ObservedVersion string                                    `json:"observedVersion,omitempty"`
+	Workloads       []WorkloadDiagnostics                     `json:"workloads"`
+	StoragePools    []hostpathprovisionerv1.StoragePoolStatus `json:"storagePools,omitempty"`
+	Errors          []string                                  `json:"errors,omitempty"`
+}
+
+// WorkloadDiagnostics is the readiness of one of the DaemonSets, or the Deployment in single node mode.
+type WorkloadDiagnostics struct {
+	Name    string `json:"name"`
+	Ready   bool   `json:"ready"`
+	Desired int    `json:"desired"`
+	Updated int    `json:"updated"`
+}
+
+// IsDiagnosticsEnabled returns true if the diagnostics endpoint is enabled in the environment, it is off by default.
+func IsDiagnosticsEnabled() bool {
+	enabled, err := strconv.ParseBool(os.Getenv(diagnosticsEnvVarName))
+	return err == nil && enabled
+}
+
+// DiagnosticsHandler serves the diagnostics of the HostPathProvisioner. It only reads from the cluster.
+type DiagnosticsHandler struct {
+	client client.Client
+}
+
+// NewDiagnosticsHandler returns a diagnostics handler, the client has to be set before requests are served.
+func NewDiagnosticsHandler() *DiagnosticsHandler {
+	return &DiagnosticsHandler{}
+}
+
+// SetClient sets the client used to read the HostPathProvisioner and its managed objects.
+func (h *DiagnosticsHandler) SetClient(c client.Client) {
+	h.client = c
+}
+
+// ServeHTTP returns the diagnostics of the first HostPathProvisioner as JSON, there is only ever one.
+func (h *DiagnosticsHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if h.client == nil {
+		http.Error(w, "diagnostics not ready", http.StatusServiceUnavailable)
+		return
+	}
+	hppList := &hostpathprovisionerv1.HostPathProvisionerList{}
+	if err := h.client.List(req.Context(), hppList); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(hppList.Items) == 0 {
+		http.Error(w, "no HostPathProvisioner found", http.StatusNotFound)
+		return
+	}
+	namespace, err := watchNamespaceFunc()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(h.getDiagnostics(req.Context(), &hppList.Items[0], namespace)); err != nil {
+		log.Error(err, "Unable to write diagnostics")
+	}
+}
+
+// getDiagnostics uses the status helpers of the reconciler, on a copy of the CR, so nothing is written to the cluster.
+func (h *DiagnosticsHandler) getDiagnostics(ctx context.Context, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) *Diagnostics {
+	r := &ReconcileHostPathProvisioner{
+		client: h.client,
+		Log:    log,
+	}
+	res := &Diagnostics{
+		Name:            cr.Name,
+		Conditions:      cr.Status.Conditions,
+		OperatorVersion: cr.Status.OperatorVersion,
+		TargetVersion:   cr.Status.TargetVersion,
+		ObservedVersion: cr.Status.ObservedVersion,
+		Workloads:       make([]WorkloadDiagnostics, 0),
+	}
+
+	names := []string{fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)}
+	if r.isLegacy(cr) {
+		names = append(names, MultiPurposeHostPathProvisionerName)
+	}
+	for _, name := range names {
+		ready, desired, err := r.checkWorkloadReady(ctx, cr, name, namespace)
+		if err != nil {
+			res.Errors = append(res.Errors, fmt.Sprintf("workload %s: %v", name, err))
+			continue
+		}
+		updated, _, err := r.getWorkloadRolloutStatus(ctx, cr, name, namespace)
+		if err != nil {
+			res.Errors = append(res.Errors, fmt.Sprintf("workload %s: %v", name, err))
+			continue
+		}
+		res.Workloads = append(res.Workloads, WorkloadDiagnostics{
+			Name:    name,
+			Ready:   ready,
+			Desired: desired,
+			Updated: updated,
+		})
+	}
+
+	crCopy := cr.DeepCopy()
+	if err := r.reconcileStoragePoolStatus(ctx, log, crCopy, namespace); err != nil {
+		res.Errors = append(res.Errors, fmt.Sprintf("storage pools: %v", err))
+	}
+	res.StoragePools = crCopy.Status.StoragePoolStatuses
+	return res
+}
+
+// blank assignment to verify that DiagnosticsHandler implements http.Handler
+var _ http.Handler = &DiagnosticsHandler{}
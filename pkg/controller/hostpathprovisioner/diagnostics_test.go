@@ -0,0 +1,152 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	ginkgo "github.com/onsi/ginkgo/v2"
+	gomega "github.com/onsi/gomega"
+	conditions "github.com/openshift/custom-resource-status/conditions/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hppv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
+	"kubevirt.io/hostpath-provisioner-operator/version"
+)
+
+var _ = ginkgo.Describe("Diagnostics", func() {
+	ginkgo.BeforeEach(func() {
+		watchNamespaceFunc = func() (string, error) {
+			return testNamespace, nil
+		}
+		version.VersionStringFunc = func() (string, error) {
+			return versionString, nil
+		}
+	})
+
+	ginkgo.AfterEach(func() {
+		os.Unsetenv(diagnosticsEnvVarName)
+	})
+
+	ginkgo.DescribeTable("Should only be enabled by the environment variable", func(value string, expected bool) {
+		os.Setenv(diagnosticsEnvVarName, value)
+		gomega.Expect(IsDiagnosticsEnabled()).To(gomega.Equal(expected))
+	},
+		ginkgo.Entry("not set", "", false),
+		ginkgo.Entry("true", "true", true),
+		ginkgo.Entry("false", "false", false),
+		ginkgo.Entry("invalid", "yes please", false),
+	)
+
+	ginkgo.It("Should return the conditions, versions and workload state of the CR", func() {
+		cr, _, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+		err := cl.Get(context.TODO(), client.ObjectKeyFromObject(cr), cr)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		h := NewDiagnosticsHandler()
+		h.SetClient(cl)
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, DiagnosticsPath, nil))
+		gomega.Expect(rec.Code).To(gomega.Equal(http.StatusOK))
+		gomega.Expect(rec.Header().Get("Content-Type")).To(gomega.Equal("application/json"))
+		res := &Diagnostics{}
+		err = json.Unmarshal(rec.Body.Bytes(), res)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+		gomega.Expect(res.Name).To(gomega.Equal(cr.Name))
+		gomega.Expect(res.OperatorVersion).To(gomega.Equal(versionString))
+		gomega.Expect(res.TargetVersion).To(gomega.Equal(versionString))
+		gomega.Expect(res.ObservedVersion).To(gomega.Equal(versionString))
+		gomega.Expect(conditions.IsStatusConditionTrue(res.Conditions, conditions.ConditionAvailable)).To(gomega.BeTrue())
+		gomega.Expect(res.Workloads).To(gomega.Equal([]WorkloadDiagnostics{
+			{
+				Name:    fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName),
+				Ready:   true,
+				Desired: 2,
+			},
+		}))
+		gomega.Expect(res.StoragePools).To(gomega.HaveLen(1))
+		gomega.Expect(res.StoragePools[0].Name).To(gomega.Equal("local"))
+		gomega.Expect(res.Errors).To(gomega.BeEmpty())
+
+		ginkgo.By("Not modifying the CR")
+		current := &hppv1.HostPathProvisioner{}
+		err = cl.Get(context.TODO(), client.ObjectKeyFromObject(cr), current)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(current.GetResourceVersion()).To(gomega.Equal(cr.GetResourceVersion()))
+	})
+
+	ginkgo.It("Should report missing workloads as errors", func() {
+		_, _, cl := createDeployedCr(createLegacyCr())
+		err := cl.Delete(context.TODO(), &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      MultiPurposeHostPathProvisionerName,
+				Namespace: testNamespace,
+			},
+		})
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		h := NewDiagnosticsHandler()
+		h.SetClient(cl)
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, DiagnosticsPath, nil))
+		gomega.Expect(rec.Code).To(gomega.Equal(http.StatusOK))
+		res := &Diagnostics{}
+		err = json.Unmarshal(rec.Body.Bytes(), res)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(res.Workloads).To(gomega.HaveLen(1))
+		gomega.Expect(res.Workloads[0].Name).To(gomega.Equal(fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)))
+		gomega.Expect(res.Errors).To(gomega.HaveLen(1))
+		gomega.Expect(res.Errors[0]).To(gomega.HavePrefix(fmt.Sprintf("workload %s:", MultiPurposeHostPathProvisionerName)))
+	})
+
+	ginkgo.It("Should return not found without a CR", func() {
+		cr := createStoragePoolWithTemplateCr()
+		_, cl := createReconciler(cr)
+		err := cl.Delete(context.TODO(), cr)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		h := NewDiagnosticsHandler()
+		h.SetClient(cl)
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, DiagnosticsPath, nil))
+		gomega.Expect(rec.Code).To(gomega.Equal(http.StatusNotFound))
+	})
+
+	ginkgo.It("Should only allow GET requests", func() {
+		_, cl := createReconciler(createStoragePoolWithTemplateCr())
+		h := NewDiagnosticsHandler()
+		h.SetClient(cl)
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, DiagnosticsPath, nil))
+		gomega.Expect(rec.Code).To(gomega.Equal(http.StatusMethodNotAllowed))
+	})
+
+	ginkgo.It("Should not be ready without a client", func() {
+		rec := httptest.NewRecorder()
+		NewDiagnosticsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, DiagnosticsPath, nil))
+		gomega.Expect(rec.Code).To(gomega.Equal(http.StatusServiceUnavailable))
+	})
+})
@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// The defaults of the manager, used to validate the durations that are set against the ones that are not.
+const (
+	defaultLeaseDuration = 15 * time.Second
+	defaultRenewDeadline = 10 * time.Second
+	defaultRetryPeriod   = 2 * time.Second
+)
+
+// SetLeaderElectionOptions sets the lease duration, renew deadline and retry period of the leader election from the
+// LEADER_ELECTION_LEASE_DURATION, LEADER_ELECTION_RENEW_DEADLINE and LEADER_ELECTION_RETRY_PERIOD environment variables.
+// Durations that are not set keep the defaults of the manager. The renew deadline has to be shorter than the lease
+// duration, and the retry period shorter than the renew deadline.
+func SetLeaderElectionOptions(options *manager.Options) error {
+	leaseDuration, err := getLeaderElectionDuration(leaseDurationEnvVarName)
+	if err != nil {
+		return err
+	}
+	renewDeadline, err := getLeaderElectionDuration(renewDeadlineEnvVarName)
+	if err != nil {
+		return err
+	}
+	retryPeriod, err := getLeaderElectionDuration(retryPeriodEnvVarName)
+	if err != nil {
+		return err
+	}
+	if valueOrDefault(renewDeadline, defaultRenewDeadline) >= valueOrDefault(leaseDuration, defaultLeaseDuration) {
+		return fmt.Errorf("%s has to be shorter than %s", renewDeadlineEnvVarName, leaseDurationEnvVarName)
+	}
+	if valueOrDefault(retryPeriod, defaultRetryPeriod) >= valueOrDefault(renewDeadline, defaultRenewDeadline) {
+		return fmt.Errorf("%s has to be shorter than %s", retryPeriodEnvVarName, renewDeadlineEnvVarName)
+	}
+	options.LeaseDuration = leaseDuration
+	options.RenewDeadline = renewDeadline
+	options.RetryPeriod = retryPeriod
+	return nil
+}
+
+// getLeaderElectionDuration returns the positive duration in the environment variable, or nil if it is not set.
+func getLeaderElectionDuration(name string) (*time.Duration, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return nil, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	if d <= 0 {
+		return nil, fmt.Errorf("invalid %s: %s is not positive", name, value)
+	}
+	return &d, nil
+}
+
+func valueOrDefault(d *time.Duration, defaultValue time.Duration) time.Duration {
+	if d == nil {
+		return defaultValue
+	}
+	return *d
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"os"
+	"time"
+
+	ginkgo "github.com/onsi/ginkgo/v2"
+	gomega "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+var _ = ginkgo.Describe("Leader election options", func() {
+	setEnv := func(name, value string) {
+		if value != "" {
+			os.Setenv(name, value)
+			ginkgo.DeferCleanup(os.Unsetenv, name)
+		}
+	}
+
+	ginkgo.It("Should keep the defaults of the manager if nothing is set", func() {
+		options := manager.Options{}
+		err := SetLeaderElectionOptions(&options)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(options.LeaseDuration).To(gomega.BeNil())
+		gomega.Expect(options.RenewDeadline).To(gomega.BeNil())
+		gomega.Expect(options.RetryPeriod).To(gomega.BeNil())
+	})
+
+	ginkgo.It("Should set the durations from the environment", func() {
+		setEnv(leaseDurationEnvVarName, "60s")
+		setEnv(renewDeadlineEnvVarName, "40s")
+		setEnv(retryPeriodEnvVarName, "5s")
+		options := manager.Options{}
+		err := SetLeaderElectionOptions(&options)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(*options.LeaseDuration).To(gomega.Equal(time.Minute))
+		gomega.Expect(*options.RenewDeadline).To(gomega.Equal(40 * time.Second))
+		gomega.Expect(*options.RetryPeriod).To(gomega.Equal(5 * time.Second))
+	})
+
+	ginkgo.It("Should only set the durations that are in the environment", func() {
+		setEnv(leaseDurationEnvVarName, "1m")
+		options := manager.Options{}
+		err := SetLeaderElectionOptions(&options)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(*options.LeaseDuration).To(gomega.Equal(time.Minute))
+		gomega.Expect(options.RenewDeadline).To(gomega.BeNil())
+		gomega.Expect(options.RetryPeriod).To(gomega.BeNil())
+	})
+
+	ginkgo.DescribeTable("Should reject invalid durations", func(leaseDuration, renewDeadline, retryPeriod, expectedErr string) {
+		setEnv(leaseDurationEnvVarName, leaseDuration)
+		setEnv(renewDeadlineEnvVarName, renewDeadline)
+		setEnv(retryPeriodEnvVarName, retryPeriod)
+		options := manager.Options{}
+		err := SetLeaderElectionOptions(&options)
+		gomega.Expect(err).To(gomega.HaveOccurred())
+		gomega.Expect(err.Error()).To(gomega.ContainSubstring(expectedErr))
+		gomega.Expect(options.LeaseDuration).To(gomega.BeNil())
+	},
+		ginkgo.Entry("not a duration", "long", "", "", "invalid LEADER_ELECTION_LEASE_DURATION"),
+		ginkgo.Entry("not positive", "", "", "0s", "invalid LEADER_ELECTION_RETRY_PERIOD"),
+		ginkgo.Entry("renew deadline not shorter than the lease duration", "30s", "30s", "", "LEADER_ELECTION_RENEW_DEADLINE has to be shorter than LEADER_ELECTION_LEASE_DURATION"),
+		ginkgo.Entry("renew deadline not shorter than the default lease duration", "", "20s", "", "LEADER_ELECTION_RENEW_DEADLINE has to be shorter than LEADER_ELECTION_LEASE_DURATION"),
+		ginkgo.Entry("retry period not shorter than the renew deadline", "", "", "10s", "LEADER_ELECTION_RETRY_PERIOD has to be shorter than LEADER_ELECTION_RENEW_DEADLINE"),
+	)
+})
@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hostpathprovisionerv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
+	"kubevirt.io/hostpath-provisioner-operator/version"
+)
+
+// firstCSIVersion is the first version that deployed the CSI driver. Before it the cluster scoped RBAC of the
+// provisioner was named after the CR and labeled with k8s-app=<CR name>, those objects are not managed anymore.
+const firstCSIVersion = "v0.11.0"
+
+// isPreCSIVersion returns true if the observed version is a valid semver older than the first CSI version. A new
+// install or a version that is not valid semver is not considered pre-CSI.
+func isPreCSIVersion(observed string) bool {
+	observedSemver, err := version.GetVersionFromString(observed)
+	if err != nil {
+		return false
+	}
+	firstCSISemver, err := version.GetVersionFromString(firstCSIVersion)
+	if err != nil {
+		return false
+	}
+	return observedSemver.Compare(*firstCSISemver) < 0
+}
+
+// removeLegacyResources deletes the ClusterRoleBindings and ClusterRoles a pre-CSI version created for the CR. It runs
+// during the upgrade from such a version only, once the new version is observed it is skipped. Only objects with the
+// old k8s-app label that are owned by the CR are removed, and a ClusterRole is kept while a ClusterRoleBinding that is
+// not removed still references it. The operator may only delete its own ClusterRoles and ClusterRoleBindings, unless
+// the optional ClusterRole in deploy/legacy_rbac_cleanup.yaml is bound the leftovers are left to the garbage
+// collector, which removes them with the CR.
+func (r *ReconcileHostPathProvisioner) removeLegacyResources(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner) error {
+	// With the default CR name the old names and labels are the current ones.
+	if cr.Name == MultiPurposeHostPathProvisionerName || !isPreCSIVersion(cr.Status.ObservedVersion) {
+		return nil
+	}
+	crbList := &rbacv1.ClusterRoleBindingList{}
+	if err := r.client.List(ctx, crbList); err != nil {
+		return err
+	}
+	referenced := make(map[string]bool)
+	for i := range crbList.Items {
+		crb := &crbList.Items[i]
+		if !isLegacyObject(cr, crb) {
+			if crb.RoleRef.Kind == "ClusterRole" {
+				referenced[crb.RoleRef.Name] = true
+			}
+			continue
+		}
+		if err := r.removeLegacyResource(ctx, reqLogger, cr, crb); err != nil {
+			return err
+		}
+	}
+	crList := &rbacv1.ClusterRoleList{}
+	if err := r.client.List(ctx, crList, client.MatchingLabels{"k8s-app": cr.Name}); err != nil {
+		return err
+	}
+	for i := range crList.Items {
+		role := &crList.Items[i]
+		if !isLegacyObject(cr, role) || referenced[role.Name] {
+			continue
+		}
+		if err := r.removeLegacyResource(ctx, reqLogger, cr, role); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ReconcileHostPathProvisioner) removeLegacyResource(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, obj client.Object) error {
+	reqLogger.Info("Removing resource left over from a pre-CSI version", "kind", fmt.Sprintf("%T", obj), "name", obj.GetName())
+	if err := r.client.Delete(ctx, obj); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		if isRBACDenied(err) {
+			reqLogger.Info("Not allowed to remove resource left over from a pre-CSI version, it is removed with the CR", "kind", fmt.Sprintf("%T", obj), "name", obj.GetName())
+			return nil
+		}
+		r.recorder.Event(cr, corev1.EventTypeWarning, deleteResourceFailed, fmt.Sprintf(deleteMessageFailed, obj.GetName(), err))
+		return err
+	}
+	r.recorder.Event(cr, corev1.EventTypeNormal, legacyResourceRemoved, fmt.Sprintf(legacyResourceRemovedMessage, obj, obj.GetName(), cr.Status.ObservedVersion))
+	return nil
+}
+
+// isLegacyObject returns true if the object has the k8s-app label of a pre-CSI version and is owned by the CR. The UID
+// is compared as well, objects of an earlier CR with the same name are not the leftovers of this one.
+func isLegacyObject(cr *hostpathprovisionerv1.HostPathProvisioner, obj client.Object) bool {
+	if obj.GetLabels()["k8s-app"] != cr.Name {
+		return false
+	}
+	for _, ownerRef := range obj.GetOwnerReferences() {
+		if ownerRef.Kind == "HostPathProvisioner" && ownerRef.Name == cr.Name && ownerRef.UID == cr.UID {
+			return true
+		}
+	}
+	return false
+}
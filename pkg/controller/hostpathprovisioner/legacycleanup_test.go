@@ -0,0 +1,273 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ginkgo "github.com/onsi/ginkgo/v2"
+	gomega "github.com/onsi/gomega"
+	conditions "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hppv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
+	"kubevirt.io/hostpath-provisioner-operator/version"
+)
+
+var _ = ginkgo.Describe("Controller reconcile loop", func() {
+	ginkgo.Context("pre-CSI leftovers", func() {
+		var (
+			req = reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+		)
+
+		ginkgo.BeforeEach(func() {
+			watchNamespaceFunc = func() (string, error) {
+				return testNamespace, nil
+			}
+			version.VersionStringFunc = func() (string, error) {
+				return versionString, nil
+			}
+		})
+
+		removedEvents := func(r *ReconcileHostPathProvisioner) []string {
+			recorder, ok := r.recorder.(*record.FakeRecorder)
+			gomega.Expect(ok).To(gomega.BeTrue())
+			events := make([]string, 0)
+			for len(recorder.Events) > 0 {
+				if event := <-recorder.Events; strings.Contains(event, legacyResourceRemoved) {
+					events = append(events, event)
+				}
+			}
+			return events
+		}
+
+		createUpgradingCr := func() *hppv1.HostPathProvisioner {
+			cr := createStoragePoolWithTemplateCr()
+			cr.UID = "test-uid"
+			return cr
+		}
+
+		legacyMeta := func(name string, owned bool) metav1.ObjectMeta {
+			meta := metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{"k8s-app": req.Name},
+			}
+			if owned {
+				meta.OwnerReferences = []metav1.OwnerReference{{
+					APIVersion: hppv1.SchemeGroupVersion.String(),
+					Kind:       "HostPathProvisioner",
+					Name:       req.Name,
+					UID:        "test-uid",
+				}}
+			}
+			return meta
+		}
+
+		createLegacyClusterRoleBinding := func(cl client.Client, name, roleName string, owned bool) *rbacv1.ClusterRoleBinding {
+			crb := &rbacv1.ClusterRoleBinding{
+				ObjectMeta: legacyMeta(name, owned),
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: "rbac.authorization.k8s.io",
+					Kind:     "ClusterRole",
+					Name:     roleName,
+				},
+			}
+			err := cl.Create(context.TODO(), crb)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			return crb
+		}
+
+		createLegacyClusterRole := func(cl client.Client, name string, owned bool) *rbacv1.ClusterRole {
+			role := &rbacv1.ClusterRole{
+				ObjectMeta: legacyMeta(name, owned),
+			}
+			err := cl.Create(context.TODO(), role)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			return role
+		}
+
+		setObservedVersion := func(cl client.Client, observedVersion string) {
+			cr := &hppv1.HostPathProvisioner{}
+			err := cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Status.ObservedVersion = observedVersion
+			err = cl.Status().Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		}
+
+		expectExists := func(cl client.Client, obj client.Object, exists bool) {
+			err := cl.Get(context.TODO(), client.ObjectKeyFromObject(obj), obj)
+			if exists {
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			} else {
+				gomega.Expect(errors.IsNotFound(err)).To(gomega.BeTrue())
+			}
+		}
+
+		ginkgo.It("Should remove the unreferenced leftovers when upgrading from a pre-CSI version", func() {
+			_, r, cl := createDeployedCr(createUpgradingCr())
+			legacyCrb := createLegacyClusterRoleBinding(cl, req.Name, req.Name, true)
+			legacyRole := createLegacyClusterRole(cl, req.Name, true)
+			referencedRole := createLegacyClusterRole(cl, "test-name-admin", true)
+			otherCrb := createLegacyClusterRoleBinding(cl, "other-binding", referencedRole.Name, false)
+			unownedRole := createLegacyClusterRole(cl, "test-name-unowned", false)
+			setObservedVersion(cl, "v0.10.0")
+			version.VersionStringFunc = func() (string, error) {
+				return firstCSIVersion, nil
+			}
+			removedEvents(r)
+
+			_, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			expectExists(cl, legacyCrb, false)
+			expectExists(cl, legacyRole, false)
+			expectExists(cl, referencedRole, true)
+			expectExists(cl, otherCrb, true)
+			expectExists(cl, unownedRole, true)
+			gomega.Expect(removedEvents(r)).To(gomega.ConsistOf(
+				fmt.Sprintf("%s %s %s", corev1.EventTypeNormal, legacyResourceRemoved, fmt.Sprintf(legacyResourceRemovedMessage, legacyCrb, legacyCrb.Name, "v0.10.0")),
+				fmt.Sprintf("%s %s %s", corev1.EventTypeNormal, legacyResourceRemoved, fmt.Sprintf(legacyResourceRemovedMessage, legacyRole, legacyRole.Name, "v0.10.0")),
+			))
+			cr := &hppv1.HostPathProvisioner{}
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(cr.Status.ObservedVersion).To(gomega.Equal(firstCSIVersion))
+
+			ginkgo.By("Not removing anything once the new version is observed")
+			legacyRole = createLegacyClusterRole(cl, req.Name, true)
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			expectExists(cl, legacyRole, true)
+			gomega.Expect(removedEvents(r)).To(gomega.BeEmpty())
+		})
+
+		ginkgo.It("Should remove the leftovers even if the upgrade path is not supported", func() {
+			_, r, cl := createDeployedCr(createUpgradingCr())
+			legacyCrb := createLegacyClusterRoleBinding(cl, req.Name, req.Name, true)
+			legacyRole := createLegacyClusterRole(cl, req.Name, true)
+			setObservedVersion(cl, "v0.10.0")
+			version.VersionStringFunc = func() (string, error) {
+				return "v0.13.0", nil
+			}
+
+			_, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			expectExists(cl, legacyCrb, false)
+			expectExists(cl, legacyRole, false)
+			cr := &hppv1.HostPathProvisioner{}
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(cr.Status.ObservedVersion).To(gomega.Equal("v0.10.0"))
+			degraded := conditions.FindStatusCondition(cr.Status.Conditions, conditions.ConditionDegraded)
+			gomega.Expect(degraded).ToNot(gomega.BeNil())
+			gomega.Expect(degraded.Reason).To(gomega.Equal(unsupportedUpgradePath))
+		})
+
+		ginkgo.It("Should not remove the leftovers of an earlier CR with the same name", func() {
+			_, r, cl := createDeployedCr(createUpgradingCr())
+			legacyRole := createLegacyClusterRole(cl, req.Name, true)
+			legacyRole.OwnerReferences[0].UID = "earlier-uid"
+			err := cl.Update(context.TODO(), legacyRole)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			setObservedVersion(cl, "v0.10.0")
+			removedEvents(r)
+
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			expectExists(cl, legacyRole, true)
+			gomega.Expect(removedEvents(r)).To(gomega.BeEmpty())
+		})
+
+		ginkgo.It("Should leave the leftovers to the garbage collector if the operator may not delete them", func() {
+			_, r, cl := createDeployedCr(createUpgradingCr())
+			legacyCrb := createLegacyClusterRoleBinding(cl, req.Name, req.Name, true)
+			legacyRole := createLegacyClusterRole(cl, req.Name, true)
+			setObservedVersion(cl, "v0.10.0")
+			version.VersionStringFunc = func() (string, error) {
+				return firstCSIVersion, nil
+			}
+			removedEvents(r)
+			r.client = forbiddenRBACDeleteFakeCtrlRuntimeClient{Client: cl}
+
+			_, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			expectExists(cl, legacyCrb, true)
+			expectExists(cl, legacyRole, true)
+			gomega.Expect(removedEvents(r)).To(gomega.BeEmpty())
+			cr := &hppv1.HostPathProvisioner{}
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(cr.Status.ObservedVersion).To(gomega.Equal(firstCSIVersion))
+		})
+
+		ginkgo.It("Should not remove anything if the observed version is not pre-CSI", func() {
+			_, r, cl := createDeployedCr(createUpgradingCr())
+			legacyCrb := createLegacyClusterRoleBinding(cl, req.Name, req.Name, true)
+			legacyRole := createLegacyClusterRole(cl, req.Name, true)
+			removedEvents(r)
+
+			_, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			expectExists(cl, legacyCrb, true)
+			expectExists(cl, legacyRole, true)
+			gomega.Expect(removedEvents(r)).To(gomega.BeEmpty())
+		})
+
+		ginkgo.DescribeTable("Should only consider valid versions before the first CSI version pre-CSI", func(observed string, expected bool) {
+			gomega.Expect(isPreCSIVersion(observed)).To(gomega.Equal(expected))
+		},
+			ginkgo.Entry("new install", "", false),
+			ginkgo.Entry("not semver", "latest", false),
+			ginkgo.Entry("pre-CSI", "v0.10.1", true),
+			ginkgo.Entry("pre-CSI without v prefix", "0.9.0", true),
+			ginkgo.Entry("first CSI version", firstCSIVersion, false),
+			ginkgo.Entry("later version", "v1.0.1", false),
+		)
+	})
+})
+
+// forbiddenRBACDeleteFakeCtrlRuntimeClient denies the deletion of the ClusterRoles and ClusterRoleBindings that are not
+// named like the ones of the operator, like the RBAC of the operator does.
+type forbiddenRBACDeleteFakeCtrlRuntimeClient struct {
+	client.Client
+}
+
+func (p forbiddenRBACDeleteFakeCtrlRuntimeClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	allowed := map[string]bool{MultiPurposeHostPathProvisionerName: true, ProvisionerServiceAccountName: true, ProvisionerServiceAccountNameCsi: true}
+	switch obj.(type) {
+	case *rbacv1.ClusterRole, *rbacv1.ClusterRoleBinding:
+		if allowed[obj.GetName()] {
+			break
+		}
+		return errors.NewForbidden(rbacv1.Resource("clusterroles"), obj.GetName(),
+			fmt.Errorf("User \"system:serviceaccount:test-namespace:hostpath-provisioner-operator\" cannot delete resource"))
+	}
+	return p.Client.Delete(ctx, obj, opts...)
+}
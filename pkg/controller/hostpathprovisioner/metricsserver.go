@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+)
+
+// GetMetricsServerOptions returns the options of the metrics server from the environment. Without METRICS_BIND_ADDRESS
+// the server listens on the default address of the manager, :8080. If METRICS_TLS_CERT_FILE and METRICS_TLS_KEY_FILE
+// are set the server serves https with that certificate, otherwise plain http.
+func GetMetricsServerOptions(extraHandlers map[string]http.Handler) (metricsserver.Options, error) {
+	options := metricsserver.Options{
+		BindAddress:   os.Getenv(metricsBindAddressEnvVarName),
+		ExtraHandlers: extraHandlers,
+	}
+	certFile := os.Getenv(metricsTLSCertFileEnvVarName)
+	keyFile := os.Getenv(metricsTLSKeyFileEnvVarName)
+	if certFile == "" && keyFile == "" {
+		return options, nil
+	}
+	if certFile == "" || keyFile == "" {
+		return metricsserver.Options{}, fmt.Errorf("both %s and %s have to be set to serve the metrics with TLS", metricsTLSCertFileEnvVarName, metricsTLSKeyFileEnvVarName)
+	}
+	// The metrics server reads the certificate and the key relative to the certificate directory.
+	certDir := filepath.Dir(certFile)
+	keyName, err := filepath.Rel(certDir, keyFile)
+	if err != nil {
+		return metricsserver.Options{}, fmt.Errorf("invalid %s: %w", metricsTLSKeyFileEnvVarName, err)
+	}
+	options.SecureServing = true
+	options.CertDir = certDir
+	options.CertName = filepath.Base(certFile)
+	options.KeyName = keyName
+	return options, nil
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"net/http"
+	"os"
+
+	ginkgo "github.com/onsi/ginkgo/v2"
+	gomega "github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("Metrics server options", func() {
+	setEnv := func(name, value string) {
+		if value != "" {
+			os.Setenv(name, value)
+			ginkgo.DeferCleanup(os.Unsetenv, name)
+		}
+	}
+
+	ginkgo.It("Should keep the default address and plain http if nothing is set", func() {
+		extraHandlers := map[string]http.Handler{DiagnosticsPath: NewDiagnosticsHandler()}
+		options, err := GetMetricsServerOptions(extraHandlers)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(options.BindAddress).To(gomega.BeEmpty())
+		gomega.Expect(options.SecureServing).To(gomega.BeFalse())
+		gomega.Expect(options.CertDir).To(gomega.BeEmpty())
+		gomega.Expect(options.ExtraHandlers).To(gomega.Equal(extraHandlers))
+	})
+
+	ginkgo.It("Should set the bind address from the environment", func() {
+		setEnv(metricsBindAddressEnvVarName, ":8443")
+		options, err := GetMetricsServerOptions(nil)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(options.BindAddress).To(gomega.Equal(":8443"))
+		gomega.Expect(options.SecureServing).To(gomega.BeFalse())
+	})
+
+	ginkgo.DescribeTable("Should serve TLS with the certificate and key from the environment", func(certFile, keyFile, certDir, certName, keyName string) {
+		setEnv(metricsTLSCertFileEnvVarName, certFile)
+		setEnv(metricsTLSKeyFileEnvVarName, keyFile)
+		options, err := GetMetricsServerOptions(nil)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(options.SecureServing).To(gomega.BeTrue())
+		gomega.Expect(options.CertDir).To(gomega.Equal(certDir))
+		gomega.Expect(options.CertName).To(gomega.Equal(certName))
+		gomega.Expect(options.KeyName).To(gomega.Equal(keyName))
+	},
+		ginkgo.Entry("same directory", "/etc/metrics-tls/tls.crt", "/etc/metrics-tls/tls.key", "/etc/metrics-tls", "tls.crt", "tls.key"),
+		ginkgo.Entry("different directories", "/etc/metrics-tls/cert/tls.crt", "/etc/metrics-tls/key/tls.key", "/etc/metrics-tls/cert", "tls.crt", "../key/tls.key"),
+	)
+
+	ginkgo.DescribeTable("Should fail if only one of the certificate and key is set", func(certFile, keyFile string) {
+		setEnv(metricsTLSCertFileEnvVarName, certFile)
+		setEnv(metricsTLSKeyFileEnvVarName, keyFile)
+		_, err := GetMetricsServerOptions(nil)
+		gomega.Expect(err).To(gomega.HaveOccurred())
+		gomega.Expect(err.Error()).To(gomega.ContainSubstring(metricsTLSCertFileEnvVarName))
+	},
+		ginkgo.Entry("only the certificate", "/etc/metrics-tls/tls.crt", ""),
+		ginkgo.Entry("only the key", "", "/etc/metrics-tls/tls.key"),
+	)
+})
@@ -0,0 +1,64 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	hostpathprovisionerv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
+	"kubevirt.io/hostpath-provisioner-operator/pkg/util"
+)
+
+// repairControllerReference makes the CR the controller of a managed object that lost its controller owner reference,
+// for instance because it was restored from a backup without it. Without the reference the object is not garbage
+// collected with the CR, and its changes don't trigger a reconcile. Only objects with the k8s-app and managed-by labels
+// of the operator and no controller are repaired, objects of others are left alone. The found object is updated in
+// place.
+func (r *ReconcileHostPathProvisioner) repairControllerReference(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, found client.Object) error {
+	if metav1.GetControllerOf(found) != nil || !isManagedObject(found) {
+		return nil
+	}
+	base := found.DeepCopyObject().(client.Object)
+	if err := controllerutil.SetControllerReference(cr, found, r.scheme); err != nil {
+		return err
+	}
+	reqLogger.Info("Repairing the controller owner reference", "kind", fmt.Sprintf("%T", found), "namespace", found.GetNamespace(), "name", found.GetName())
+	if err := r.client.Patch(ctx, found, client.MergeFrom(base)); err != nil {
+		r.recorder.Event(cr, corev1.EventTypeWarning, updateResourceFailed, fmt.Sprintf(updateMessageFailed, found.GetName(), err))
+		return err
+	}
+	r.recorder.Event(cr, corev1.EventTypeNormal, ownerReferenceRepaired, fmt.Sprintf(ownerReferenceRepairedMessage, found, found.GetName()))
+	return nil
+}
+
+// isManagedObject returns true if the object has the labels the operator sets on the objects it manages.
+func isManagedObject(obj client.Object) bool {
+	labels := util.GetRecommendedLabels()
+	for _, key := range []string{"k8s-app", util.AppKubernetesManagedByLabel} {
+		if obj.GetLabels()[key] != labels[key] {
+			return false
+		}
+	}
+	return true
+}
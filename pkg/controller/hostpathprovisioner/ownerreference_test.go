@@ -0,0 +1,209 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ginkgo "github.com/onsi/ginkgo/v2"
+	gomega "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hppv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
+	"kubevirt.io/hostpath-provisioner-operator/version"
+)
+
+var _ = ginkgo.Describe("Controller reconcile loop", func() {
+	ginkgo.Context("owner references", func() {
+		ginkgo.BeforeEach(func() {
+			watchNamespaceFunc = func() (string, error) {
+				return testNamespace, nil
+			}
+			version.VersionStringFunc = func() (string, error) {
+				return versionString, nil
+			}
+		})
+
+		repairedEvents := func(r *ReconcileHostPathProvisioner) []string {
+			recorder, ok := r.recorder.(*record.FakeRecorder)
+			gomega.Expect(ok).To(gomega.BeTrue())
+			events := make([]string, 0)
+			for len(recorder.Events) > 0 {
+				if event := <-recorder.Events; strings.Contains(event, ownerReferenceRepaired) {
+					events = append(events, event)
+				}
+			}
+			return events
+		}
+
+		stripOwnerReferences := func(cl client.Client, obj client.Object) {
+			err := cl.Get(context.TODO(), client.ObjectKeyFromObject(obj), obj)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(metav1.GetControllerOf(obj)).ToNot(gomega.BeNil())
+			obj.SetOwnerReferences(nil)
+			err = cl.Update(context.TODO(), obj)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		}
+
+		ginkgo.It("Should restore the controller owner reference of a managed object", func() {
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cr)}
+			repairedEvents(r)
+			ds := &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName),
+					Namespace: testNamespace,
+				},
+			}
+			sa := &corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      ProvisionerServiceAccountNameCsi,
+					Namespace: testNamespace,
+				},
+			}
+			stripOwnerReferences(cl, ds)
+			stripOwnerReferences(cl, sa)
+
+			_, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			for _, obj := range []client.Object{ds, sa} {
+				err = cl.Get(context.TODO(), client.ObjectKeyFromObject(obj), obj)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(metav1.IsControlledBy(obj, cr)).To(gomega.BeTrue())
+			}
+			gomega.Expect(repairedEvents(r)).To(gomega.ConsistOf(
+				fmt.Sprintf("%s %s %s", corev1.EventTypeNormal, ownerReferenceRepaired, fmt.Sprintf(ownerReferenceRepairedMessage, ds, ds.Name)),
+				fmt.Sprintf("%s %s %s", corev1.EventTypeNormal, ownerReferenceRepaired, fmt.Sprintf(ownerReferenceRepairedMessage, sa, sa.Name)),
+			))
+			gomega.Expect(IsCrHealthy(cr)).To(gomega.BeTrue())
+
+			ginkgo.By("Not repairing the owner references again")
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(repairedEvents(r)).To(gomega.BeEmpty())
+		})
+
+		ginkgo.It("Should restore the controller owner reference of the cluster scoped RBAC and the CSIDriver", func() {
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cr)}
+			repairedEvents(r)
+			clusterRole := &rbacv1.ClusterRole{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: ProvisionerServiceAccountNameCsi,
+				},
+			}
+			csiDriver := &storagev1.CSIDriver{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: getDriverName(cr),
+				},
+			}
+			stripOwnerReferences(cl, clusterRole)
+			stripOwnerReferences(cl, csiDriver)
+
+			_, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			for _, obj := range []client.Object{clusterRole, csiDriver} {
+				err = cl.Get(context.TODO(), client.ObjectKeyFromObject(obj), obj)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(metav1.IsControlledBy(obj, cr)).To(gomega.BeTrue())
+			}
+			gomega.Expect(repairedEvents(r)).To(gomega.ConsistOf(
+				fmt.Sprintf("%s %s %s", corev1.EventTypeNormal, ownerReferenceRepaired, fmt.Sprintf(ownerReferenceRepairedMessage, clusterRole, clusterRole.Name)),
+				fmt.Sprintf("%s %s %s", corev1.EventTypeNormal, ownerReferenceRepaired, fmt.Sprintf(ownerReferenceRepairedMessage, csiDriver, csiDriver.Name)),
+			))
+			gomega.Expect(IsCrHealthy(cr)).To(gomega.BeTrue())
+		})
+
+		ginkgo.It("Should restore the controller owner reference of a StorageClass instead of reporting a conflict", func() {
+			cr, r, cl := createDeployedCr(createStorageClassCr(true))
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-name"}}
+			repairedEvents(r)
+			sc := &storagev1.StorageClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "local",
+				},
+			}
+			stripOwnerReferences(cl, sc)
+
+			_, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), client.ObjectKeyFromObject(sc), sc)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(metav1.IsControlledBy(sc, cr)).To(gomega.BeTrue())
+			gomega.Expect(repairedEvents(r)).To(gomega.HaveLen(1))
+		})
+
+		ginkgo.It("Should not take over a managed object controlled by something else", func() {
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			repairedEvents(r)
+			sa := &corev1.ServiceAccount{}
+			err := cl.Get(context.TODO(), types.NamespacedName{Name: ProvisionerServiceAccountNameCsi, Namespace: testNamespace}, sa)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			other := metav1.OwnerReference{
+				APIVersion: "v1",
+				Kind:       "ConfigMap",
+				Name:       "other",
+				UID:        "other-uid",
+				Controller: pointer.Bool(true),
+			}
+			sa.OwnerReferences = []metav1.OwnerReference{other}
+			err = cl.Update(context.TODO(), sa)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			err = r.repairControllerReference(context.TODO(), r.Log, cr, sa)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), client.ObjectKeyFromObject(sa), sa)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(sa.OwnerReferences).To(gomega.Equal([]metav1.OwnerReference{other}))
+			gomega.Expect(repairedEvents(r)).To(gomega.BeEmpty())
+		})
+
+		ginkgo.It("Should only consider objects with the labels of the operator managed", func() {
+			obj := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"k8s-app":                      MultiPurposeHostPathProvisionerName,
+						"app.kubernetes.io/managed-by": "hostpath-provisioner-operator",
+					},
+				},
+			}
+			gomega.Expect(isManagedObject(obj)).To(gomega.BeTrue())
+			obj.Labels["k8s-app"] = "other-app"
+			gomega.Expect(isManagedObject(obj)).To(gomega.BeFalse())
+			obj.Labels = map[string]string{"k8s-app": MultiPurposeHostPathProvisionerName}
+			gomega.Expect(isManagedObject(obj)).To(gomega.BeFalse())
+			gomega.Expect(isManagedObject(&hppv1.HostPathProvisioner{})).To(gomega.BeFalse())
+		})
+	})
+})
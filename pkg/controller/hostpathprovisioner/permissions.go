@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// permissionsRecheckInterval is how soon the permissions are checked again while some are missing, granting them does
+// not trigger a reconcile.
+const permissionsRecheckInterval = time.Minute
+
+// requiredPermissions are the key permissions the operator needs to deploy the hostpath provisioner. Without them the
+// reconcile fails halfway with an RBAC error. The namespaced ones are checked in the namespace of the operator.
+var requiredPermissions = []authorizationv1.ResourceAttributes{
+	{Verb: "create", Group: "apps", Resource: "daemonsets"},
+	{Verb: "create", Group: "", Resource: "serviceaccounts"},
+	{Verb: "create", Group: "rbac.authorization.k8s.io", Resource: "clusterroles"},
+	{Verb: "create", Group: "rbac.authorization.k8s.io", Resource: "clusterrolebindings"},
+	{Verb: "create", Group: "storage.k8s.io", Resource: "csidrivers"},
+	{Verb: "create", Group: "storage.k8s.io", Resource: "storageclasses"},
+}
+
+// sccPermission is only required if the cluster uses SecurityContextConstraints.
+var sccPermission = authorizationv1.ResourceAttributes{Verb: "create", Group: "security.openshift.io", Resource: "securitycontextconstraints"}
+
+var namespacedPermissionResources = map[string]bool{
+	"daemonsets":      true,
+	"serviceaccounts": true,
+}
+
+// accessReviewer returns whether the operator is allowed the access described by the attributes.
+type accessReviewer interface {
+	IsAllowed(ctx context.Context, attributes *authorizationv1.ResourceAttributes) (bool, error)
+}
+
+// selfSubjectAccessReviewer reviews the access of the ServiceAccount of the operator with SelfSubjectAccessReviews.
+type selfSubjectAccessReviewer struct {
+	client client.Client
+}
+
+func (s *selfSubjectAccessReviewer) IsAllowed(ctx context.Context, attributes *authorizationv1.ResourceAttributes) (bool, error) {
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: attributes,
+		},
+	}
+	if err := s.client.Create(ctx, review); err != nil {
+		return false, err
+	}
+	return review.Status.Allowed, nil
+}
+
+// validatePermissions returns a reason and message listing the missing permissions if the operator lacks some of the
+// key permissions it needs. Once all are granted the check is skipped until the reconcile period is over.
+func (r *ReconcileHostPathProvisioner) validatePermissions(ctx context.Context, namespace string) (string, string, error) {
+	if r.accessReviewer == nil {
+		return "", "", nil
+	}
+	if checked := r.permissionsGrantedAt.Load(); checked != 0 && time.Since(time.Unix(0, checked)) < r.getPermissionsCheckInterval() {
+		return "", "", nil
+	}
+	permissions := requiredPermissions
+	if used, err := r.checkSCCUsed(ctx); err != nil {
+		return "", "", err
+	} else if used {
+		permissions = append(append([]authorizationv1.ResourceAttributes{}, permissions...), sccPermission)
+	}
+	var missing []string
+	for _, permission := range permissions {
+		attributes := permission.DeepCopy()
+		if namespacedPermissionResources[attributes.Resource] {
+			attributes.Namespace = namespace
+		}
+		allowed, err := r.accessReviewer.IsAllowed(ctx, attributes)
+		if err != nil {
+			return "", "", err
+		}
+		if !allowed {
+			missing = append(missing, formatPermission(attributes))
+		}
+	}
+	if len(missing) > 0 {
+		r.permissionsGrantedAt.Store(0)
+		return insufficientPermissions, fmt.Sprintf(insufficientPermissionsMessage, strings.Join(missing, ", ")), nil
+	}
+	r.permissionsGrantedAt.Store(time.Now().UnixNano())
+	return "", "", nil
+}
+
+// getPermissionsCheckInterval returns how long granted permissions are trusted before they are checked again.
+func (r *ReconcileHostPathProvisioner) getPermissionsCheckInterval() time.Duration {
+	if r.reconcilePeriod > 0 {
+		return r.reconcilePeriod
+	}
+	return defaultReconcilePeriod
+}
+
+// formatPermission returns the permission like kubectl auth can-i takes it, for instance create daemonsets.apps.
+func formatPermission(attributes *authorizationv1.ResourceAttributes) string {
+	resource := attributes.Resource
+	if attributes.Group != "" {
+		resource = fmt.Sprintf("%s.%s", resource, attributes.Group)
+	}
+	if attributes.Namespace != "" {
+		return fmt.Sprintf("%s %s in %s", attributes.Verb, resource, attributes.Namespace)
+	}
+	return fmt.Sprintf("%s %s", attributes.Verb, resource)
+}
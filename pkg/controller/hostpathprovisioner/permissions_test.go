@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"context"
+	"fmt"
+
+	ginkgo "github.com/onsi/ginkgo/v2"
+	gomega "github.com/onsi/gomega"
+	conditions "github.com/openshift/custom-resource-status/conditions/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"kubevirt.io/hostpath-provisioner-operator/version"
+)
+
+var _ = ginkgo.Describe("Controller reconcile loop", func() {
+	ginkgo.Context("permissions", func() {
+		var (
+			req = reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+		)
+
+		ginkgo.BeforeEach(func() {
+			watchNamespaceFunc = func() (string, error) {
+				return testNamespace, nil
+			}
+			version.VersionStringFunc = func() (string, error) {
+				return versionString, nil
+			}
+		})
+
+		ginkgo.It("Should mark the CR degraded while the operator is missing permissions", func() {
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			reviewer := &fakeAccessReviewer{
+				denied: map[string]bool{
+					"create clusterroles.rbac.authorization.k8s.io":            true,
+					fmt.Sprintf("create daemonsets.apps in %s", testNamespace): true,
+				},
+			}
+			r.accessReviewer = reviewer
+			res, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(res.RequeueAfter).To(gomega.Equal(permissionsRecheckInterval))
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			degraded := conditions.FindStatusCondition(cr.Status.Conditions, conditions.ConditionDegraded)
+			gomega.Expect(degraded).ToNot(gomega.BeNil())
+			gomega.Expect(degraded.Status).To(gomega.Equal(corev1.ConditionTrue))
+			gomega.Expect(degraded.Reason).To(gomega.Equal(insufficientPermissions))
+			gomega.Expect(degraded.Message).To(gomega.Equal(fmt.Sprintf(insufficientPermissionsMessage,
+				fmt.Sprintf("create daemonsets.apps in %s, create clusterroles.rbac.authorization.k8s.io", testNamespace))))
+
+			ginkgo.By("Granting the permissions")
+			reviewer.denied = nil
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(IsCrHealthy(cr)).To(gomega.BeTrue())
+		})
+
+		ginkgo.It("Should not check the permissions again until the reconcile period is over once they are granted", func() {
+			_, r, _ := createDeployedCr(createStoragePoolWithTemplateCr())
+			reviewer := &fakeAccessReviewer{}
+			r.accessReviewer = reviewer
+			_, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(reviewer.reviewed).To(gomega.ContainElement("create csidrivers.storage.k8s.io"))
+			reviews := len(reviewer.reviewed)
+
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(reviewer.reviewed).To(gomega.HaveLen(reviews))
+		})
+	})
+})
+
+// fakeAccessReviewer allows all the access except the denied permissions, formatted like formatPermission.
+type fakeAccessReviewer struct {
+	denied   map[string]bool
+	reviewed []string
+}
+
+func (f *fakeAccessReviewer) IsAllowed(_ context.Context, attributes *authorizationv1.ResourceAttributes) (bool, error) {
+	permission := formatPermission(attributes)
+	f.reviewed = append(f.reviewed, permission)
+	return !f.denied[permission], nil
+}
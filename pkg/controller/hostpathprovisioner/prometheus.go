@@ -22,6 +22,7 @@ import (
 	"os"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -33,7 +34,9 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	hostpathprovisionerv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
@@ -48,42 +51,83 @@ const (
 	defaultMonitoringNs       = "monitoring"
 	defaultRunbookURLTemplate = "https://kubevirt.io/monitoring/runbooks/%s"
 	runbookURLTemplateEnv     = "RUNBOOK_URL_TEMPLATE"
+	notReadyAlertName         = "HPPNotReady"
+	// defaultNotReadyGracePeriod is the for duration of the HPPNotReady alert if the CR does not set one
+	defaultNotReadyGracePeriod = v1.Duration("5m")
 )
 
-func (r *ReconcileHostPathProvisioner) reconcilePrometheusInfra(reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) (reconcile.Result, error) {
-	if used, err := r.checkPrometheusUsed(); err != nil {
+func (r *ReconcileHostPathProvisioner) reconcilePrometheusInfra(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) (reconcile.Result, error) {
+	if used, err := r.checkPrometheusUsed(ctx); err != nil {
 		return reconcile.Result{}, err
 	} else if used == false {
 		return reconcile.Result{}, nil
 	}
-	rule, _ := createPrometheusRule(namespace)
-
-	if res, err := r.reconcilePrometheusResource(reqLogger, cr, rule, rule); err != nil {
+	if res, err := r.reconcilePrometheusRule(ctx, reqLogger, cr, namespace); err != nil {
 		return res, err
 	}
-	if res, err := r.reconcilePrometheusResource(reqLogger, cr, createPrometheusRole(namespace), createPrometheusRole(namespace)); err != nil {
+	if res, err := r.reconcilePrometheusResource(ctx, reqLogger, cr, createPrometheusRole(namespace), createPrometheusRole(namespace)); err != nil {
 		return res, err
 	}
-	if res, err := r.reconcilePrometheusResource(reqLogger, cr, createPrometheusRoleBinding(namespace), createPrometheusRoleBinding(namespace)); err != nil {
+	if res, err := r.reconcilePrometheusResource(ctx, reqLogger, cr, createPrometheusRoleBinding(namespace), createPrometheusRoleBinding(namespace)); err != nil {
 		return res, err
 	}
-	if res, err := r.reconcilePrometheusResource(reqLogger, cr, createPrometheusService(namespace), createPrometheusService(namespace)); err != nil {
+	if res, err := r.reconcilePrometheusResource(ctx, reqLogger, cr, createPrometheusService(namespace), createPrometheusService(namespace)); err != nil {
 		return res, err
 	}
-	return r.reconcilePrometheusResource(reqLogger, cr, createPrometheusServiceMonitor(namespace), createPrometheusServiceMonitor(namespace))
+	return r.reconcilePrometheusResource(ctx, reqLogger, cr, createPrometheusServiceMonitor(namespace), createPrometheusServiceMonitor(namespace))
+}
+
+// reconcilePrometheusRule creates the PrometheusRule with the alerts, unless the CR disables them. The ServiceMonitor
+// is reconciled independently, the metrics are still exposed when the alerts are managed elsewhere.
+func (r *ReconcileHostPathProvisioner) reconcilePrometheusRule(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) (reconcile.Result, error) {
+	if !isCreateAlertsEnabled(cr) {
+		return reconcile.Result{}, r.deletePrometheusRule(ctx, reqLogger, cr, namespace)
+	}
+	rule, _ := createPrometheusRule(namespace, getNotReadyGracePeriod(cr))
+	return r.reconcilePrometheusResource(ctx, reqLogger, cr, rule, rule.DeepCopy())
+}
+
+// deletePrometheusRule removes the PrometheusRule the operator created, a PrometheusRule with the same name that the
+// operator did not create is left alone.
+func (r *ReconcileHostPathProvisioner) deletePrometheusRule(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) error {
+	rule := &promv1.PrometheusRule{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: ruleName, Namespace: namespace}, rule); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if rule.GetLabels()[util.AppKubernetesManagedByLabel] != util.GetRecommendedLabels()[util.AppKubernetesManagedByLabel] {
+		return nil
+	}
+	reqLogger.Info("Deleting PrometheusRule", "Name", rule.GetName())
+	if err := r.client.Delete(ctx, rule); err != nil && !k8serrors.IsNotFound(err) {
+		r.recorder.Event(cr, corev1.EventTypeWarning, deleteResourceFailed, fmt.Sprintf(deleteMessageFailed, rule.GetName(), err))
+		return err
+	}
+	r.recorder.Event(cr, corev1.EventTypeNormal, deleteResourceSuccess, fmt.Sprintf(deleteMessageSucceeded, rule, rule.GetName()))
+	return nil
+}
+
+// isCreateAlertsEnabled returns true unless the CR disables the creation of the PrometheusRule.
+func isCreateAlertsEnabled(cr *hostpathprovisionerv1.HostPathProvisioner) bool {
+	return cr.Spec.Monitoring == nil || cr.Spec.Monitoring.CreateAlerts == nil || *cr.Spec.Monitoring.CreateAlerts
 }
 
-func (r *ReconcileHostPathProvisioner) reconcilePrometheusResource(reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, desired, found client.Object) (reconcile.Result, error) {
-	// Define a new PrometheusRule object
+func (r *ReconcileHostPathProvisioner) reconcilePrometheusResource(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, desired, found client.Object) (reconcile.Result, error) {
+	// Set HostPathProvisioner instance as the owner and controller
+	if err := controllerutil.SetControllerReference(cr, desired, r.scheme); err != nil {
+		return reconcile.Result{}, err
+	}
 	err := setLastAppliedConfiguration(desired)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 	// Check if this PrometheusRule already exists
-	err = r.client.Get(context.TODO(), client.ObjectKeyFromObject(found), found)
+	err = r.client.Get(ctx, client.ObjectKeyFromObject(found), found)
 	if err != nil && k8serrors.IsNotFound(err) {
 		reqLogger.Info("Creating a new PrometheusResource", "Name", found.GetName())
-		err = r.client.Create(context.TODO(), desired)
+		err = r.applyObject(ctx, desired, nil)
 		if err != nil {
 			r.recorder.Event(cr, corev1.EventTypeWarning, createResourceFailed, fmt.Sprintf(createMessageFailed, desired.GetName(), err))
 			return reconcile.Result{}, err
@@ -95,6 +139,14 @@ func (r *ReconcileHostPathProvisioner) reconcilePrometheusResource(reqLogger log
 		return reconcile.Result{}, err
 	}
 
+	if err := r.repairControllerReference(ctx, reqLogger, cr, found); err != nil {
+		return reconcile.Result{}, err
+	}
+	if r.isUpToDate(desired, found) {
+		reqLogger.V(3).Info("Skip reconcile: PrometheusResource is up to date", "Name", found.GetName())
+		return reconcile.Result{}, nil
+	}
+
 	// Keep a copy of the original for comparison later.
 	currentRuntimeObjCopy := found.DeepCopyObject()
 
@@ -112,7 +164,7 @@ func (r *ReconcileHostPathProvisioner) reconcilePrometheusResource(reqLogger log
 		logJSONDiff(reqLogger, currentRuntimeObjCopy, merged)
 		// Current is different from desired, update.
 		reqLogger.Info("Updating PrometheusResource", "Name", desired.GetName())
-		err = r.client.Update(context.TODO(), merged)
+		err = r.applyObject(ctx, desired, found)
 		if err != nil {
 			r.recorder.Event(cr, corev1.EventTypeWarning, updateResourceFailed, fmt.Sprintf(updateMessageFailed, desired.GetName(), err))
 			return reconcile.Result{}, err
@@ -121,12 +173,13 @@ func (r *ReconcileHostPathProvisioner) reconcilePrometheusResource(reqLogger log
 		return reconcile.Result{}, nil
 	}
 	// PrometheusResource already exists and matches the desired state - don't requeue
+	r.markUpToDate(found)
 	reqLogger.Info("Skip reconcile: PrometheusResource already exists", "Name", found.GetName())
 	return reconcile.Result{}, nil
 }
 
-func (r *ReconcileHostPathProvisioner) deletePrometheusResources(namespace string) error {
-	if used, err := r.checkPrometheusUsed(); used == false {
+func (r *ReconcileHostPathProvisioner) deletePrometheusResources(ctx context.Context, namespace string) error {
+	if used, err := r.checkPrometheusUsed(ctx); used == false {
 		return err
 	}
 
@@ -136,7 +189,7 @@ func (r *ReconcileHostPathProvisioner) deletePrometheusResources(namespace strin
 			Namespace: namespace,
 		},
 	}
-	if err := r.client.Delete(context.TODO(), rule); err != nil && !k8serrors.IsNotFound(err) {
+	if err := r.client.Delete(ctx, rule); err != nil && !k8serrors.IsNotFound(err) {
 		return err
 	}
 
@@ -146,7 +199,7 @@ func (r *ReconcileHostPathProvisioner) deletePrometheusResources(namespace strin
 			Namespace: namespace,
 		},
 	}
-	if err := r.client.Delete(context.TODO(), role); err != nil && !k8serrors.IsNotFound(err) {
+	if err := r.client.Delete(ctx, role); err != nil && !k8serrors.IsNotFound(err) {
 		return err
 	}
 
@@ -156,7 +209,7 @@ func (r *ReconcileHostPathProvisioner) deletePrometheusResources(namespace strin
 			Namespace: namespace,
 		},
 	}
-	if err := r.client.Delete(context.TODO(), roleBinding); err != nil && !k8serrors.IsNotFound(err) {
+	if err := r.client.Delete(ctx, roleBinding); err != nil && !k8serrors.IsNotFound(err) {
 		return err
 	}
 
@@ -166,7 +219,7 @@ func (r *ReconcileHostPathProvisioner) deletePrometheusResources(namespace strin
 			Namespace: namespace,
 		},
 	}
-	if err := r.client.Delete(context.TODO(), monitor); err != nil && !k8serrors.IsNotFound(err) {
+	if err := r.client.Delete(ctx, monitor); err != nil && !k8serrors.IsNotFound(err) {
 		return err
 	}
 
@@ -176,7 +229,7 @@ func (r *ReconcileHostPathProvisioner) deletePrometheusResources(namespace strin
 			Namespace: namespace,
 		},
 	}
-	if err := r.client.Delete(context.TODO(), service); err != nil && !k8serrors.IsNotFound(err) {
+	if err := r.client.Delete(ctx, service); err != nil && !k8serrors.IsNotFound(err) {
 		return err
 	}
 
@@ -311,10 +364,10 @@ func getMonitoringNamespace() string {
 	return defaultMonitoringNs
 }
 
-func (r *ReconcileHostPathProvisioner) checkPrometheusUsed() (bool, error) {
+func (r *ReconcileHostPathProvisioner) checkPrometheusUsed(ctx context.Context) (bool, error) {
 	// Check if we are using prometheus, if not return false.
 	listObj := &promv1.PrometheusRuleList{}
-	if err := r.client.List(context.TODO(), listObj); err != nil {
+	if err := r.client.List(ctx, listObj); err != nil {
 		if meta.IsNoMatchError(err) || strings.Contains(err.Error(), "failed to find API group") {
 			// prometheus not deployed
 			return false, nil
@@ -324,7 +377,25 @@ func (r *ReconcileHostPathProvisioner) checkPrometheusUsed() (bool, error) {
 	return true, nil
 }
 
-func createPrometheusRule(namespace string) (*v1.PrometheusRule, error) {
+// getNotReadyGracePeriod returns the for duration of the HPPNotReady alert, formatted as a prometheus duration.
+func getNotReadyGracePeriod(cr *hostpathprovisionerv1.HostPathProvisioner) v1.Duration {
+	if cr.Spec.Monitoring == nil || cr.Spec.Monitoring.NotReadyGracePeriod == nil {
+		return defaultNotReadyGracePeriod
+	}
+	d := cr.Spec.Monitoring.NotReadyGracePeriod.Duration
+	switch {
+	case d%time.Hour == 0 && d != 0:
+		return v1.Duration(fmt.Sprintf("%dh", d/time.Hour))
+	case d%time.Minute == 0 && d != 0:
+		return v1.Duration(fmt.Sprintf("%dm", d/time.Minute))
+	case d%time.Second == 0:
+		return v1.Duration(fmt.Sprintf("%ds", d/time.Second))
+	default:
+		return v1.Duration(fmt.Sprintf("%dms", d/time.Millisecond))
+	}
+}
+
+func createPrometheusRule(namespace string, notReadyGracePeriod v1.Duration) (*v1.PrometheusRule, error) {
 	if err := rules.SetupRules(namespace); err != nil {
 		return nil, errors.Wrap(err, "failed to setup monitoring rules")
 	}
@@ -333,6 +404,13 @@ func createPrometheusRule(namespace string) (*v1.PrometheusRule, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to build PrometheusRule")
 	}
+	for i := range promRule.Spec.Groups {
+		for j := range promRule.Spec.Groups[i].Rules {
+			if promRule.Spec.Groups[i].Rules[j].Alert == notReadyAlertName {
+				promRule.Spec.Groups[i].Rules[j].For = ptr.To(notReadyGracePeriod)
+			}
+		}
+	}
 
 	return promRule, nil
 }
@@ -1,13 +1,26 @@
 package hostpathprovisioner
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"time"
 
 	ginkgo "github.com/onsi/ginkgo/v2"
 	gomega "github.com/onsi/gomega"
+	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	hppv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
 	"kubevirt.io/hostpath-provisioner-operator/pkg/monitoring/rules"
+	"kubevirt.io/hostpath-provisioner-operator/version"
 )
 
 var _ = ginkgo.Describe("Prometheus", func() {
@@ -20,6 +33,7 @@ var _ = ginkgo.Describe("Prometheus", func() {
 	})
 
 	ginkgo.It("should use the default runbook URL template when no ENV Variable is set", func() {
+		gomega.Expect(rules.SetupRules("mynamespace")).To(gomega.Succeed())
 		promRule, err := rules.BuildPrometheusRule("mynamespace")
 		gomega.Expect(err).ToNot(gomega.HaveOccurred())
 
@@ -35,6 +49,7 @@ var _ = ginkgo.Describe("Prometheus", func() {
 	ginkgo.It("should use the desired runbook URL template when its ENV Variable is set", func() {
 		desiredRunbookURLTemplate := "desired/runbookURL/template/%s"
 		os.Setenv(runbookURLTemplateEnv, desiredRunbookURLTemplate)
+		gomega.Expect(rules.SetupRules("mynamespace")).To(gomega.Succeed())
 
 		promRule, err := rules.BuildPrometheusRule("mynamespace")
 		gomega.Expect(err).ToNot(gomega.HaveOccurred())
@@ -47,4 +62,146 @@ var _ = ginkgo.Describe("Prometheus", func() {
 			}
 		}
 	})
+
+	ginkgo.DescribeTable("should use the configured grace period for the HPPNotReady alert", func(gracePeriod *metav1.Duration, expected promv1.Duration) {
+		watchNamespaceFunc = func() (string, error) {
+			return testNamespace, nil
+		}
+		version.VersionStringFunc = func() (string, error) {
+			return versionString, nil
+		}
+		cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+		verifyNotReadyFor := func(expected promv1.Duration) {
+			rule := &promv1.PrometheusRule{}
+			err := cl.Get(context.TODO(), types.NamespacedName{Name: ruleName, Namespace: testNamespace}, rule)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			found := false
+			for _, group := range rule.Spec.Groups {
+				for _, alert := range group.Rules {
+					if alert.Alert == notReadyAlertName {
+						found = true
+						gomega.Expect(alert.For).ToNot(gomega.BeNil())
+						gomega.Expect(*alert.For).To(gomega.Equal(expected))
+					} else if alert.Alert == "HPPOperatorDown" {
+						gomega.Expect(*alert.For).To(gomega.Equal(promv1.Duration("5m")))
+					}
+				}
+			}
+			gomega.Expect(found).To(gomega.BeTrue())
+		}
+		verifyNotReadyFor(defaultNotReadyGracePeriod)
+
+		err := cl.Get(context.TODO(), client.ObjectKeyFromObject(cr), cr)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		cr.Spec.Monitoring = &hppv1.MonitoringConfig{
+			NotReadyGracePeriod: gracePeriod,
+		}
+		err = cl.Update(context.TODO(), cr)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		_, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cr)})
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		verifyNotReadyFor(expected)
+	},
+		ginkgo.Entry("not set", nil, promv1.Duration("5m")),
+		ginkgo.Entry("minutes", &metav1.Duration{Duration: 15 * time.Minute}, promv1.Duration("15m")),
+		ginkgo.Entry("hours", &metav1.Duration{Duration: 2 * time.Hour}, promv1.Duration("2h")),
+		ginkgo.Entry("seconds", &metav1.Duration{Duration: 90 * time.Second}, promv1.Duration("90s")),
+		ginkgo.Entry("milliseconds", &metav1.Duration{Duration: 1500 * time.Millisecond}, promv1.Duration("1500ms")),
+		ginkgo.Entry("zero", &metav1.Duration{}, promv1.Duration("0s")),
+	)
+
+	ginkgo.DescribeTable("should create the PrometheusRule and ServiceMonitor", func(prometheusInstalled bool, createAlerts *bool, expectRule, expectMonitor bool) {
+		watchNamespaceFunc = func() (string, error) {
+			return testNamespace, nil
+		}
+		version.VersionStringFunc = func() (string, error) {
+			return versionString, nil
+		}
+		cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+		ruleNN := types.NamespacedName{Name: ruleName, Namespace: testNamespace}
+		monitorNN := types.NamespacedName{Name: monitorName, Namespace: testNamespace}
+		if !prometheusInstalled {
+			for _, obj := range []client.Object{
+				&promv1.PrometheusRule{ObjectMeta: metav1.ObjectMeta{Name: ruleName, Namespace: testNamespace}},
+				&promv1.ServiceMonitor{ObjectMeta: metav1.ObjectMeta{Name: monitorName, Namespace: testNamespace}},
+			} {
+				gomega.Expect(cl.Delete(context.TODO(), obj)).To(gomega.Succeed())
+			}
+			r.client = noPrometheusFakeCtrlRuntimeClient{
+				Client: cl,
+			}
+		}
+
+		err := cl.Get(context.TODO(), client.ObjectKeyFromObject(cr), cr)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		cr.Spec.Monitoring = &hppv1.MonitoringConfig{
+			CreateAlerts: createAlerts,
+		}
+		err = cl.Update(context.TODO(), cr)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		_, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cr)})
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+		err = cl.Get(context.TODO(), ruleNN, &promv1.PrometheusRule{})
+		if expectRule {
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		} else {
+			gomega.Expect(k8serrors.IsNotFound(err)).To(gomega.BeTrue())
+		}
+		err = cl.Get(context.TODO(), monitorNN, &promv1.ServiceMonitor{})
+		if expectMonitor {
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		} else {
+			gomega.Expect(k8serrors.IsNotFound(err)).To(gomega.BeTrue())
+		}
+	},
+		ginkgo.Entry("alerts not configured", true, nil, true, true),
+		ginkgo.Entry("alerts enabled", true, ptr.To(true), true, true),
+		ginkgo.Entry("alerts disabled", true, ptr.To(false), false, true),
+		ginkgo.Entry("prometheus not installed", false, nil, false, false),
+		ginkgo.Entry("prometheus not installed and alerts disabled", false, ptr.To(false), false, false),
+	)
+
+	ginkgo.It("should not remove a PrometheusRule it does not own when alerts are disabled", func() {
+		watchNamespaceFunc = func() (string, error) {
+			return testNamespace, nil
+		}
+		version.VersionStringFunc = func() (string, error) {
+			return versionString, nil
+		}
+		cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+		rule := &promv1.PrometheusRule{}
+		err := cl.Get(context.TODO(), types.NamespacedName{Name: ruleName, Namespace: testNamespace}, rule)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		rule.Labels = map[string]string{"app.kubernetes.io/managed-by": "central-alerting"}
+		err = cl.Update(context.TODO(), rule)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+		err = cl.Get(context.TODO(), client.ObjectKeyFromObject(cr), cr)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		cr.Spec.Monitoring = &hppv1.MonitoringConfig{
+			CreateAlerts: ptr.To(false),
+		}
+		err = cl.Update(context.TODO(), cr)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		_, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cr)})
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		err = cl.Get(context.TODO(), client.ObjectKeyFromObject(rule), rule)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	})
 })
+
+// noPrometheusFakeCtrlRuntimeClient mimics a cluster without the prometheus operator CRDs.
+type noPrometheusFakeCtrlRuntimeClient struct {
+	client.Client
+}
+
+func (p noPrometheusFakeCtrlRuntimeClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if _, ok := list.(*promv1.PrometheusRuleList); ok {
+		return &meta.NoKindMatchError{
+			GroupKind:        schema.GroupKind{Group: promv1.SchemeGroupVersion.Group, Kind: "PrometheusRule"},
+			SearchedVersions: []string{promv1.SchemeGroupVersion.Version},
+		}
+	}
+	return p.Client.List(ctx, list, opts...)
+}
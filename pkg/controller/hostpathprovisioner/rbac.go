@@ -18,44 +18,52 @@ package hostpathprovisioner
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	hostpathprovisionerv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
 	"kubevirt.io/hostpath-provisioner-operator/pkg/util"
 )
 
-func (r *ReconcileHostPathProvisioner) reconcileClusterRoleBinding(reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) (reconcile.Result, error) {
+func (r *ReconcileHostPathProvisioner) reconcileClusterRoleBinding(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) (reconcile.Result, error) {
 	// Define a new ClusterRoleBinding object
-	if err := r.reconcileRbacResource(reqLogger.WithName("Provisioner RBAC"), createClusterRoleBindingObject(ProvisionerServiceAccountNameCsi, namespace, ProvisionerServiceAccountNameCsi), createClusterRoleBindingObject(ProvisionerServiceAccountNameCsi, namespace, ProvisionerServiceAccountNameCsi), cr); err != nil {
+	if err := r.reconcileRbacResource(ctx, reqLogger.WithName("Provisioner RBAC"), createClusterRoleBindingObject(ProvisionerServiceAccountNameCsi, namespace, getCsiServiceAccountName(cr)), createClusterRoleBindingObject(ProvisionerServiceAccountNameCsi, namespace, getCsiServiceAccountName(cr)), cr); err != nil {
 		return reconcile.Result{}, err
 	}
 	if r.isLegacy(cr) {
-		if err := r.reconcileRbacResource(reqLogger.WithName("Provisioner RBAC"), createClusterRoleBindingObject(MultiPurposeHostPathProvisionerName, namespace, ProvisionerServiceAccountName), createClusterRoleBindingObject(MultiPurposeHostPathProvisionerName, namespace, ProvisionerServiceAccountName), cr); err != nil {
+		if err := r.reconcileRbacResource(ctx, reqLogger.WithName("Provisioner RBAC"), createClusterRoleBindingObject(MultiPurposeHostPathProvisionerName, namespace, getServiceAccountName(cr)), createClusterRoleBindingObject(MultiPurposeHostPathProvisionerName, namespace, getServiceAccountName(cr)), cr); err != nil {
 			return reconcile.Result{}, err
 		}
 	} else {
-		if err := r.deleteClusterRoleBindingObject(MultiPurposeHostPathProvisionerName); err != nil && !errors.IsNotFound(err) {
+		if err := r.deleteClusterRoleBindingObject(ctx, MultiPurposeHostPathProvisionerName); err != nil && !errors.IsNotFound(err) {
 			return reconcile.Result{}, err
 		}
 	}
 	return reconcile.Result{}, nil
 }
 
-func (r *ReconcileHostPathProvisioner) reconcileRbacResource(reqLogger logr.Logger, desired, found client.Object, cr *hostpathprovisionerv1.HostPathProvisioner) error {
+func (r *ReconcileHostPathProvisioner) reconcileRbacResource(ctx context.Context, reqLogger logr.Logger, desired, found client.Object, cr *hostpathprovisionerv1.HostPathProvisioner) error {
+	// Set HostPathProvisioner instance as the owner and controller
+	if err := controllerutil.SetControllerReference(cr, desired, r.scheme); err != nil {
+		return err
+	}
 	setLastAppliedConfiguration(desired)
-	err := r.client.Get(context.TODO(), client.ObjectKeyFromObject(found), found)
+	err := r.client.Get(ctx, client.ObjectKeyFromObject(found), found)
 	if err != nil && errors.IsNotFound(err) {
 		reqLogger.Info("Creating a new Rbac Resource", "Name", desired.GetName())
-		err = r.client.Create(context.TODO(), desired)
+		err = r.applyObject(ctx, desired, nil)
 		if err != nil {
 			r.recorder.Event(cr, corev1.EventTypeWarning, createResourceFailed, fmt.Sprintf(createMessageFailed, desired.GetName(), err))
 			return err
@@ -68,8 +76,18 @@ func (r *ReconcileHostPathProvisioner) reconcileRbacResource(reqLogger logr.Logg
 		return err
 	}
 
+	if err := r.repairControllerReference(ctx, reqLogger, cr, found); err != nil {
+		return err
+	}
+	if r.isUpToDate(desired, found) {
+		reqLogger.V(3).Info("Skip reconcile: Rbac Resource is up to date", "Name", found.GetName())
+		return nil
+	}
+
 	// Keep a copy of the original for comparison later.
 	currentRuntimeObjCopy := found.DeepCopyObject()
+	// Before the merge, which replaces the last applied configuration with the desired one.
+	drift := hasRulesDrift(found)
 
 	// allow users to add new annotations (but not change ours)
 	mergeLabelsAndAnnotations(desired, found)
@@ -85,20 +103,42 @@ func (r *ReconcileHostPathProvisioner) reconcileRbacResource(reqLogger logr.Logg
 		logJSONDiff(reqLogger, currentRuntimeObjCopy, merged)
 		// Current is different from desired, update.
 		reqLogger.Info("Updating Rbac resouce", "Name", desired.GetName())
-		err = r.client.Update(context.TODO(), merged)
+		err = r.applyObject(ctx, desired, found)
 		if err != nil {
 			r.recorder.Event(cr, corev1.EventTypeWarning, updateResourceFailed, fmt.Sprintf(updateMessageFailed, desired.GetName(), err))
 			return err
 		}
 		r.recorder.Event(cr, corev1.EventTypeNormal, updateResourceSuccess, fmt.Sprintf(updateMessageSucceeded, desired, desired.GetName()))
+		if drift {
+			r.recorder.Event(cr, corev1.EventTypeWarning, rbacDriftCorrected, fmt.Sprintf(rbacDriftCorrectedMessage, desired, desired.GetName()))
+		}
 		return nil
 	}
 
 	// Rbac resource already exists and matches the desired state - don't requeue
+	r.markUpToDate(found)
 	reqLogger.V(3).Info("Skip reconcile: Rbac Resource already exists", "Name", found.GetName())
 	return nil
 }
 
+// hasRulesDrift returns true if the rules of a Role or ClusterRole are not the rules the operator applied last, so they
+// were changed outside of the operator. Rules changed by a new operator version are not drift.
+func hasRulesDrift(obj client.Object) bool {
+	lastApplied, ok := obj.GetAnnotations()[lastAppliedConfigAnnotation]
+	if !ok {
+		return false
+	}
+	switch o := obj.(type) {
+	case *rbacv1.ClusterRole:
+		applied := &rbacv1.ClusterRole{}
+		return json.Unmarshal([]byte(lastApplied), applied) == nil && !equality.Semantic.DeepEqual(o.Rules, applied.Rules)
+	case *rbacv1.Role:
+		applied := &rbacv1.Role{}
+		return json.Unmarshal([]byte(lastApplied), applied) == nil && !equality.Semantic.DeepEqual(o.Rules, applied.Rules)
+	}
+	return false
+}
+
 func createClusterRoleBindingObject(name, namespace, saName string) *rbacv1.ClusterRoleBinding {
 	labels := util.GetRecommendedLabels()
 	return &rbacv1.ClusterRoleBinding{
@@ -121,31 +161,31 @@ func createClusterRoleBindingObject(name, namespace, saName string) *rbacv1.Clus
 	}
 }
 
-func (r *ReconcileHostPathProvisioner) deleteClusterRoleBindingObject(name string) error {
+func (r *ReconcileHostPathProvisioner) deleteClusterRoleBindingObject(ctx context.Context, name string) error {
 	crb := &rbacv1.ClusterRoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: name,
 		},
 	}
 
-	if err := r.client.Delete(context.TODO(), crb); err != nil && !errors.IsNotFound(err) {
+	if err := r.client.Delete(ctx, crb); err != nil && !errors.IsNotFound(err) {
 		return err
 	}
 
 	return nil
 }
 
-func (r *ReconcileHostPathProvisioner) reconcileClusterRole(reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner) (reconcile.Result, error) {
+func (r *ReconcileHostPathProvisioner) reconcileClusterRole(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner) (reconcile.Result, error) {
 	if r.isLegacy(cr) {
-		if err := r.reconcileRbacResource(reqLogger.WithName("Provisioner RBAC"), createClusterRoleObjectProvisioner(), createClusterRoleObjectProvisioner(), cr); err != nil {
+		if err := r.reconcileRbacResource(ctx, reqLogger.WithName("Provisioner RBAC"), createClusterRoleObjectProvisioner(), createClusterRoleObjectProvisioner(), cr); err != nil {
 			return reconcile.Result{}, err
 		}
 	} else {
-		if err := r.deleteClusterRoleObject(MultiPurposeHostPathProvisionerName); err != nil && !errors.IsNotFound(err) {
+		if err := r.deleteClusterRoleObject(ctx, MultiPurposeHostPathProvisionerName); err != nil && !errors.IsNotFound(err) {
 			return reconcile.Result{}, err
 		}
 	}
-	if err := r.reconcileRbacResource(reqLogger.WithName("Provisioner RBAC"), r.createCsiClusterRoleObjectProvisioner(cr), r.createCsiClusterRoleObjectProvisioner(cr), cr); err != nil {
+	if err := r.reconcileRbacResource(ctx, reqLogger.WithName("Provisioner RBAC"), r.createCsiClusterRoleObjectProvisioner(cr), r.createCsiClusterRoleObjectProvisioner(cr), cr); err != nil {
 		return reconcile.Result{}, err
 	}
 	return reconcile.Result{}, nil
@@ -350,9 +390,52 @@ func (r *ReconcileHostPathProvisioner) createCsiClusterRoleObjectProvisioner(cr
 	if r.isFeatureGateEnabled(snapshotFeatureGate, cr) {
 		res.Rules = append(res.Rules, createSnapshotCsiClusterRoles()...)
 	}
+	if r.isFeatureGateEnabled(volumeExpansionFeatureGate, cr) {
+		res.Rules = append(res.Rules, createResizerCsiClusterRoles()...)
+	}
 	return res
 }
 
+func createResizerCsiClusterRoles() []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{
+				"",
+			},
+			Resources: []string{
+				"persistentvolumes",
+			},
+			Verbs: []string{
+				"patch",
+			},
+		},
+		{
+			APIGroups: []string{
+				"",
+			},
+			Resources: []string{
+				"persistentvolumeclaims/status",
+			},
+			Verbs: []string{
+				"patch",
+			},
+		},
+		{
+			APIGroups: []string{
+				"",
+			},
+			Resources: []string{
+				"pods",
+			},
+			Verbs: []string{
+				"get",
+				"list",
+				"watch",
+			},
+		},
+	}
+}
+
 func createSnapshotCsiClusterRoles() []rbacv1.PolicyRule {
 	return []rbacv1.PolicyRule{
 		{
@@ -411,28 +494,34 @@ func createSnapshotCsiClusterRoles() []rbacv1.PolicyRule {
 	}
 }
 
-func (r *ReconcileHostPathProvisioner) deleteClusterRoleObject(name string) error {
+func (r *ReconcileHostPathProvisioner) deleteClusterRoleObject(ctx context.Context, name string) error {
 	role := &rbacv1.ClusterRole{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: name,
 		},
 	}
 
-	if err := r.client.Delete(context.TODO(), role); err != nil && !errors.IsNotFound(err) {
+	if err := r.client.Delete(ctx, role); err != nil && !errors.IsNotFound(err) {
 		return err
 	}
 
 	return nil
 }
 
-func (r *ReconcileHostPathProvisioner) reconcileRoleBinding(reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) (reconcile.Result, error) {
-	if err := r.reconcileRbacResource(reqLogger.WithName("Provisioner RBAC"), createRoleBindingObject(ProvisionerServiceAccountNameCsi, namespace, ProvisionerServiceAccountNameCsi), createRoleBindingObject(ProvisionerServiceAccountNameCsi, namespace, ProvisionerServiceAccountNameCsi), cr); err != nil {
+func (r *ReconcileHostPathProvisioner) reconcileRoleBinding(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) (reconcile.Result, error) {
+	namespaces, err := r.getRbacNamespaces(ctx, cr, namespace)
+	if err != nil {
 		return reconcile.Result{}, err
 	}
-	return reconcile.Result{}, nil
+	for _, rbNamespace := range namespaces {
+		if err := r.reconcileRbacResource(ctx, reqLogger.WithName("Provisioner RBAC"), createRoleBindingObject(ProvisionerServiceAccountNameCsi, rbNamespace, getCsiServiceAccountName(cr), namespace), createRoleBindingObject(ProvisionerServiceAccountNameCsi, rbNamespace, getCsiServiceAccountName(cr), namespace), cr); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{}, r.deleteUnselectedRoleBindings(ctx, reqLogger, cr, namespaces)
 }
 
-func createRoleBindingObject(name, namespace, saName string) *rbacv1.RoleBinding {
+func createRoleBindingObject(name, namespace, saName, saNamespace string) *rbacv1.RoleBinding {
 	labels := util.GetRecommendedLabels()
 	return &rbacv1.RoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
@@ -444,7 +533,7 @@ func createRoleBindingObject(name, namespace, saName string) *rbacv1.RoleBinding
 			{
 				Kind:      "ServiceAccount",
 				Name:      saName,
-				Namespace: namespace,
+				Namespace: saNamespace,
 			},
 		},
 		RoleRef: rbacv1.RoleRef{
@@ -455,11 +544,82 @@ func createRoleBindingObject(name, namespace, saName string) *rbacv1.RoleBinding
 	}
 }
 
-func (r *ReconcileHostPathProvisioner) reconcileRole(reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) (reconcile.Result, error) {
-	if err := r.reconcileRbacResource(reqLogger.WithName("provisioner RBAC"), createRoleObjectProvisioner(namespace), createRoleObjectProvisioner(namespace), cr); err != nil {
+func (r *ReconcileHostPathProvisioner) reconcileRole(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) (reconcile.Result, error) {
+	namespaces, err := r.getRbacNamespaces(ctx, cr, namespace)
+	if err != nil {
 		return reconcile.Result{}, err
 	}
-	return reconcile.Result{}, nil
+	for _, roleNamespace := range namespaces {
+		if err := r.reconcileRbacResource(ctx, reqLogger.WithName("provisioner RBAC"), createRoleObjectProvisioner(roleNamespace), createRoleObjectProvisioner(roleNamespace), cr); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{}, r.deleteUnselectedRoles(ctx, reqLogger, cr, namespaces)
+}
+
+// getRbacNamespaces returns the namespaces the Role and RoleBinding of the CSI driver belong in, the namespace of the
+// operator followed by the namespaces matching spec.rbac.namespaceSelector.
+func (r *ReconcileHostPathProvisioner) getRbacNamespaces(ctx context.Context, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) ([]string, error) {
+	namespaces := []string{namespace}
+	if cr.Spec.RBAC == nil || cr.Spec.RBAC.NamespaceSelector == nil {
+		return namespaces, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(cr.Spec.RBAC.NamespaceSelector)
+	if err != nil {
+		return nil, err
+	}
+	namespaceList := &corev1.NamespaceList{}
+	if err := r.client.List(ctx, namespaceList, &client.ListOptions{LabelSelector: selector}); err != nil {
+		return nil, err
+	}
+	for _, ns := range namespaceList.Items {
+		if ns.GetName() != namespace {
+			namespaces = append(namespaces, ns.GetName())
+		}
+	}
+	return namespaces, nil
+}
+
+// deleteUnselectedRoles removes the Roles of the CSI driver from the namespaces not in namespaces, passing no namespaces
+// removes all of them.
+func (r *ReconcileHostPathProvisioner) deleteUnselectedRoles(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespaces []string) error {
+	roleList := &rbacv1.RoleList{}
+	if err := r.client.List(ctx, roleList, client.MatchingLabels{"k8s-app": MultiPurposeHostPathProvisionerName}); err != nil {
+		return err
+	}
+	for _, role := range roleList.Items {
+		if role.GetName() != ProvisionerServiceAccountNameCsi || slices.Contains(namespaces, role.GetNamespace()) {
+			continue
+		}
+		reqLogger.Info("Deleting Role of unselected namespace", "Role.Namespace", role.GetNamespace(), "Role.Name", role.GetName())
+		if err := r.client.Delete(ctx, &role); err != nil && !errors.IsNotFound(err) {
+			r.recorder.Event(cr, corev1.EventTypeWarning, deleteResourceFailed, fmt.Sprintf(deleteMessageFailed, role.GetName(), err))
+			return err
+		}
+		r.recorder.Event(cr, corev1.EventTypeNormal, deleteResourceSuccess, fmt.Sprintf(deleteMessageSucceeded, &role, role.GetName()))
+	}
+	return nil
+}
+
+// deleteUnselectedRoleBindings removes the RoleBindings of the CSI driver from the namespaces not in namespaces, passing
+// no namespaces removes all of them.
+func (r *ReconcileHostPathProvisioner) deleteUnselectedRoleBindings(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespaces []string) error {
+	roleBindingList := &rbacv1.RoleBindingList{}
+	if err := r.client.List(ctx, roleBindingList, client.MatchingLabels{"k8s-app": MultiPurposeHostPathProvisionerName}); err != nil {
+		return err
+	}
+	for _, roleBinding := range roleBindingList.Items {
+		if roleBinding.GetName() != ProvisionerServiceAccountNameCsi || slices.Contains(namespaces, roleBinding.GetNamespace()) {
+			continue
+		}
+		reqLogger.Info("Deleting RoleBinding of unselected namespace", "RoleBinding.Namespace", roleBinding.GetNamespace(), "RoleBinding.Name", roleBinding.GetName())
+		if err := r.client.Delete(ctx, &roleBinding); err != nil && !errors.IsNotFound(err) {
+			r.recorder.Event(cr, corev1.EventTypeWarning, deleteResourceFailed, fmt.Sprintf(deleteMessageFailed, roleBinding.GetName(), err))
+			return err
+		}
+		r.recorder.Event(cr, corev1.EventTypeNormal, deleteResourceSuccess, fmt.Sprintf(deleteMessageSucceeded, &roleBinding, roleBinding.GetName()))
+	}
+	return nil
 }
 
 func createRoleObjectProvisioner(namespace string) *rbacv1.Role {
@@ -515,7 +675,7 @@ func createRoleObjectProvisioner(namespace string) *rbacv1.Role {
 	}
 }
 
-func (r *ReconcileHostPathProvisioner) deleteRoleBindingObject(name, namespace string) error {
+func (r *ReconcileHostPathProvisioner) deleteRoleBindingObject(ctx context.Context, name, namespace string) error {
 	rb := &rbacv1.RoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
@@ -523,14 +683,14 @@ func (r *ReconcileHostPathProvisioner) deleteRoleBindingObject(name, namespace s
 		},
 	}
 
-	if err := r.client.Delete(context.TODO(), rb); err != nil && !errors.IsNotFound(err) {
+	if err := r.client.Delete(ctx, rb); err != nil && !errors.IsNotFound(err) {
 		return err
 	}
 
 	return nil
 }
 
-func (r *ReconcileHostPathProvisioner) deleteRoleObject(name, namespace string) error {
+func (r *ReconcileHostPathProvisioner) deleteRoleObject(ctx context.Context, name, namespace string) error {
 	role := &rbacv1.Role{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
@@ -538,7 +698,7 @@ func (r *ReconcileHostPathProvisioner) deleteRoleObject(name, namespace string)
 		},
 	}
 
-	if err := r.client.Delete(context.TODO(), role); err != nil && !errors.IsNotFound(err) {
+	if err := r.client.Delete(ctx, role); err != nil && !errors.IsNotFound(err) {
 		return err
 	}
 
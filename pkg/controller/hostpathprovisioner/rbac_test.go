@@ -17,11 +17,16 @@ package hostpathprovisioner
 
 import (
 	"context"
+	"fmt"
 
 	ginkgo "github.com/onsi/ginkgo/v2"
 	gomega "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	hppv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
@@ -42,8 +47,7 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 		ginkgo.DescribeTable("Should fix a changed ClusterRole", func(cr *hppv1.HostPathProvisioner) {
 			req := reconcile.Request{
 				NamespacedName: types.NamespacedName{
-					Name:      "test-name",
-					Namespace: testNamespace,
+					Name: "test-name",
 				},
 			}
 			name := ProvisionerServiceAccountNameCsi
@@ -66,6 +70,11 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 			// Verify the extra ability is there.
 			gomega.Expect(len(crole.Rules[1].Verbs)).To(gomega.Equal(5))
 			gomega.Expect(crole.Rules[1].Verbs[4]).To(gomega.Equal("delete"))
+			recorder, ok := r.recorder.(*record.FakeRecorder)
+			gomega.Expect(ok).To(gomega.BeTrue())
+			for len(recorder.Events) > 0 {
+				<-recorder.Events
+			}
 			// Run the reconcile loop
 			res, err := r.Reconcile(context.TODO(), req)
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
@@ -74,6 +83,11 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 			err = cl.Get(context.TODO(), croleNN, crole)
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			gomega.Expect(len(crole.Rules[1].Verbs)).To(gomega.Equal(4))
+			events := make([]string, 0)
+			for len(recorder.Events) > 0 {
+				events = append(events, <-recorder.Events)
+			}
+			gomega.Expect(events).To(gomega.ContainElement(fmt.Sprintf("%s %s %s", corev1.EventTypeWarning, rbacDriftCorrected, fmt.Sprintf(rbacDriftCorrectedMessage, crole, name))))
 		},
 			ginkgo.Entry("legacyCr", createLegacyCr()),
 			ginkgo.Entry("legacyStoragePoolCr", createLegacyStoragePoolCr()),
@@ -83,8 +97,7 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 		ginkgo.DescribeTable("Should modify ClusterRole if snapshot enabled", func(cr *hppv1.HostPathProvisioner) {
 			req := reconcile.Request{
 				NamespacedName: types.NamespacedName{
-					Name:      "test-name",
-					Namespace: testNamespace,
+					Name: "test-name",
 				},
 			}
 			cr, r, cl := createDeployedCr(cr)
@@ -97,12 +110,21 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 			cr.Spec.FeatureGates = append(cr.Spec.FeatureGates, snapshotFeatureGate)
 			err = cl.Update(context.TODO(), cr)
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			recorder, ok := r.recorder.(*record.FakeRecorder)
+			gomega.Expect(ok).To(gomega.BeTrue())
+			for len(recorder.Events) > 0 {
+				<-recorder.Events
+			}
 			// Run the reconcile loop
 			res, err := r.Reconcile(context.TODO(), req)
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			gomega.Expect(res.Requeue).To(gomega.BeFalse())
 
 			verifyCreateCSIClusterRole(cl, true)
+			ginkgo.By("Not reporting the changed rules as drift")
+			for len(recorder.Events) > 0 {
+				gomega.Expect(<-recorder.Events).ToNot(gomega.ContainSubstring(rbacDriftCorrected))
+			}
 		},
 			ginkgo.Entry("legacyCr", createLegacyCr()),
 			ginkgo.Entry("legacyStoragePoolCr", createLegacyStoragePoolCr()),
@@ -112,8 +134,7 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 		ginkgo.DescribeTable("Should fix a changed ClusterRoleBinding", func(cr *hppv1.HostPathProvisioner) {
 			req := reconcile.Request{
 				NamespacedName: types.NamespacedName{
-					Name:      "test-name",
-					Namespace: testNamespace,
+					Name: "test-name",
 				},
 			}
 			name := ProvisionerServiceAccountNameCsi
@@ -148,5 +169,143 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 			ginkgo.Entry("legacyStoragePoolCr", createLegacyStoragePoolCr()),
 			ginkgo.Entry("storagePoolCr", createStoragePoolWithTemplateCr()),
 		)
+
+		ginkgo.It("Should correct a narrowed Role and report the drift", func() {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			roleNN := types.NamespacedName{
+				Name:      ProvisionerServiceAccountNameCsi,
+				Namespace: testNamespace,
+			}
+			_, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			role := &rbacv1.Role{}
+			err := cl.Get(context.TODO(), roleNN, role)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			desiredRules := role.Rules
+			role.Rules = role.Rules[:1]
+			err = cl.Update(context.TODO(), role)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			recorder, ok := r.recorder.(*record.FakeRecorder)
+			gomega.Expect(ok).To(gomega.BeTrue())
+			for len(recorder.Events) > 0 {
+				<-recorder.Events
+			}
+
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), roleNN, role)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(role.Rules).To(gomega.Equal(desiredRules))
+			events := make([]string, 0)
+			for len(recorder.Events) > 0 {
+				events = append(events, <-recorder.Events)
+			}
+			gomega.Expect(events).To(gomega.ContainElement(fmt.Sprintf("%s %s %s", corev1.EventTypeWarning, rbacDriftCorrected, fmt.Sprintf(rbacDriftCorrectedMessage, role, role.Name))))
+		})
+
+		ginkgo.It("Should only create the Role and RoleBinding in the namespaces matching the namespace selector", func() {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			for name, labels := range map[string]map[string]string{
+				"selected-a": {"hpp": "enabled"},
+				"selected-b": {"hpp": "enabled"},
+				"other":      {"hpp": "disabled"},
+			} {
+				err := cl.Create(context.TODO(), &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   name,
+						Labels: labels,
+					},
+				})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			}
+			err := cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.RBAC = &hppv1.RBACConfig{
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"hpp": "enabled"},
+				},
+			}
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			verifyRbacNamespaces(cl, testNamespace, "selected-a", "selected-b")
+
+			ginkgo.By("Removing the label of a namespace, its Role and RoleBinding should be removed")
+			ns := &corev1.Namespace{}
+			err = cl.Get(context.TODO(), types.NamespacedName{Name: "selected-b"}, ns)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			ns.Labels = nil
+			err = cl.Update(context.TODO(), ns)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			verifyRbacNamespaces(cl, testNamespace, "selected-a")
+
+			ginkgo.By("Removing the namespace selector, only the operator namespace should be left")
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.RBAC = nil
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			verifyRbacNamespaces(cl, testNamespace)
+		})
+
+		ginkgo.It("Should only map namespaces to the HPP if it selects namespaces", func() {
+			cr := createStoragePoolWithTemplateCr()
+			_, cl := createReconciler(cr)
+			mapFn := hppNamespaceMapFunc(cl)
+			ns := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "selected-a",
+				},
+			}
+			gomega.Expect(mapFn(context.TODO(), ns)).To(gomega.BeEmpty())
+			cr.Spec.RBAC = &hppv1.RBACConfig{
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"hpp": "enabled"},
+				},
+			}
+			err := cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(mapFn(context.TODO(), ns)).To(gomega.Equal([]reconcile.Request{{NamespacedName: types.NamespacedName{Name: "test-name"}}}))
+		})
 	})
 })
+
+func verifyRbacNamespaces(cl client.Client, namespaces ...string) {
+	roleList := &rbacv1.RoleList{}
+	err := cl.List(context.TODO(), roleList, client.MatchingLabels{"k8s-app": MultiPurposeHostPathProvisionerName})
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	roleNamespaces := make([]string, 0)
+	for _, role := range roleList.Items {
+		if role.GetName() == ProvisionerServiceAccountNameCsi {
+			roleNamespaces = append(roleNamespaces, role.GetNamespace())
+		}
+	}
+	gomega.Expect(roleNamespaces).To(gomega.ConsistOf(namespaces))
+
+	roleBindingList := &rbacv1.RoleBindingList{}
+	err = cl.List(context.TODO(), roleBindingList, client.MatchingLabels{"k8s-app": MultiPurposeHostPathProvisionerName})
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	roleBindingNamespaces := make([]string, 0)
+	for _, roleBinding := range roleBindingList.Items {
+		if roleBinding.GetName() != ProvisionerServiceAccountNameCsi {
+			continue
+		}
+		roleBindingNamespaces = append(roleBindingNamespaces, roleBinding.GetNamespace())
+		gomega.Expect(roleBinding.Subjects).To(gomega.HaveLen(1))
+		gomega.Expect(roleBinding.Subjects[0].Namespace).To(gomega.Equal(testNamespace))
+	}
+	gomega.Expect(roleBindingNamespaces).To(gomega.ConsistOf(namespaces))
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	goerrors "errors"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// classifyReconcileError returns the reason of the Degraded condition of a failed reconcile, and whether retrying the
+// reconcile can succeed. Transient API errors, and errors that cannot be classified, are retried with backoff. The
+// objects the API server rejects are built from the CR, they are rejected again until the CR is changed, which
+// triggers a reconcile. Missing permissions are not retried either, the caller checks again after a while. The other
+// Forbidden errors, like an admission denial or a terminating namespace, are retried and keep the server message.
+func classifyReconcileError(err error) (string, bool) {
+	switch {
+	case errors.IsConflict(err), errors.IsServerTimeout(err), errors.IsTimeout(err), errors.IsTooManyRequests(err),
+		errors.IsServiceUnavailable(err), errors.IsInternalError(err), errors.IsUnexpectedServerError(err):
+		return reconcileTransientError, true
+	case errors.IsInvalid(err), errors.IsBadRequest(err), errors.IsRequestEntityTooLargeError(err):
+		return reconcileInvalidSpec, false
+	case isRBACDenied(err):
+		return insufficientPermissions, false
+	}
+	return reconcileFailed, true
+}
+
+// isRBACDenied returns whether the API server rejected the request because the operator is not authorized to make it.
+// Admission plugins and webhooks also return Forbidden, only the message of the authorizer names the denied user.
+func isRBACDenied(err error) bool {
+	var statusErr errors.APIStatus
+	if !errors.IsForbidden(err) || !goerrors.As(err, &statusErr) {
+		return false
+	}
+	message := statusErr.Status().Message
+	return strings.Contains(message, "User \"") && strings.Contains(message, " cannot ")
+}
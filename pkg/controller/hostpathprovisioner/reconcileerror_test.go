@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"context"
+	"fmt"
+
+	ginkgo "github.com/onsi/ginkgo/v2"
+	gomega "github.com/onsi/gomega"
+	conditions "github.com/openshift/custom-resource-status/conditions/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hppv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
+	"kubevirt.io/hostpath-provisioner-operator/version"
+)
+
+var _ = ginkgo.Describe("Controller reconcile loop", func() {
+	ginkgo.Context("reconcile errors", func() {
+		var (
+			req = reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			daemonSets = schema.GroupResource{Group: "apps", Resource: "daemonsets"}
+		)
+
+		ginkgo.BeforeEach(func() {
+			watchNamespaceFunc = func() (string, error) {
+				return testNamespace, nil
+			}
+			version.VersionStringFunc = func() (string, error) {
+				return versionString, nil
+			}
+		})
+
+		ginkgo.DescribeTable("Should classify the reconcile errors", func(err error, expectedReason string, expectedRetry bool) {
+			reason, retry := classifyReconcileError(err)
+			gomega.Expect(reason).To(gomega.Equal(expectedReason))
+			gomega.Expect(retry).To(gomega.Equal(expectedRetry))
+		},
+			ginkgo.Entry("conflict", errors.NewConflict(daemonSets, "test", fmt.Errorf("modified")), reconcileTransientError, true),
+			ginkgo.Entry("server timeout", errors.NewServerTimeout(daemonSets, "create", 1), reconcileTransientError, true),
+			ginkgo.Entry("timeout", errors.NewTimeoutError("timed out", 1), reconcileTransientError, true),
+			ginkgo.Entry("too many requests", errors.NewTooManyRequests("slow down", 1), reconcileTransientError, true),
+			ginkgo.Entry("service unavailable", errors.NewServiceUnavailable("unavailable"), reconcileTransientError, true),
+			ginkgo.Entry("internal error", errors.NewInternalError(fmt.Errorf("etcd")), reconcileTransientError, true),
+			ginkgo.Entry("wrapped conflict", fmt.Errorf("unable to apply: %w", errors.NewConflict(daemonSets, "test", fmt.Errorf("modified"))), reconcileTransientError, true),
+			ginkgo.Entry("invalid", errors.NewInvalid(schema.GroupKind{Group: "apps", Kind: "DaemonSet"}, "test", field.ErrorList{field.Invalid(field.NewPath("spec"), "", "invalid")}), reconcileInvalidSpec, false),
+			ginkgo.Entry("bad request", errors.NewBadRequest("bad"), reconcileInvalidSpec, false),
+			ginkgo.Entry("forbidden by RBAC", errors.NewForbidden(daemonSets, "test", fmt.Errorf("User \"system:serviceaccount:test:operator\" cannot create resource \"daemonsets\" in API group \"apps\" in the namespace \"test\"")), insufficientPermissions, false),
+			ginkgo.Entry("forbidden by admission", errors.NewForbidden(daemonSets, "test", fmt.Errorf("violates PodSecurity \"restricted:latest\": privileged")), reconcileFailed, true),
+			ginkgo.Entry("forbidden in a terminating namespace", errors.NewForbidden(daemonSets, "test", fmt.Errorf("unable to create new content in namespace test because it is being terminated")), reconcileFailed, true),
+			ginkgo.Entry("not an API error", fmt.Errorf("create failed"), reconcileFailed, true),
+		)
+
+		ginkgo.It("Should mark the CR failed without retrying if an object built from the CR is invalid", func() {
+			_, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			ds := &appsv1.DaemonSet{}
+			dsNN := types.NamespacedName{
+				Name:      fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName),
+				Namespace: testNamespace,
+			}
+			err := cl.Get(context.TODO(), dsNN, ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Delete(context.TODO(), ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			r.client = erroringFakeCtrlRuntimeClient{
+				Client: cl,
+				err:    errors.NewInvalid(schema.GroupKind{Group: "apps", Kind: "DaemonSet"}, dsNN.Name, field.ErrorList{field.Invalid(field.NewPath("spec", "template", "spec", "nodeSelector"), "", "invalid label value")}),
+			}
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr := &hppv1.HostPathProvisioner{}
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(conditions.IsStatusConditionTrue(cr.Status.Conditions, conditions.ConditionProgressing)).To(gomega.BeFalse())
+			degraded := conditions.FindStatusCondition(cr.Status.Conditions, conditions.ConditionDegraded)
+			gomega.Expect(degraded).ToNot(gomega.BeNil())
+			gomega.Expect(degraded.Status).To(gomega.Equal(corev1.ConditionTrue))
+			gomega.Expect(degraded.Reason).To(gomega.Equal(reconcileInvalidSpec))
+		})
+
+		ginkgo.It("Should retry a transient reconcile error", func() {
+			_, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			ds := &appsv1.DaemonSet{}
+			dsNN := types.NamespacedName{
+				Name:      fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName),
+				Namespace: testNamespace,
+			}
+			err := cl.Get(context.TODO(), dsNN, ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Delete(context.TODO(), ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			r.client = erroringFakeCtrlRuntimeClient{
+				Client: cl,
+				err:    errors.NewServerTimeout(daemonSets, "create", 1),
+			}
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			cr := &hppv1.HostPathProvisioner{}
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(conditions.IsStatusConditionTrue(cr.Status.Conditions, conditions.ConditionProgressing)).To(gomega.BeTrue())
+			gomega.Expect(conditions.FindStatusCondition(cr.Status.Conditions, conditions.ConditionDegraded).Reason).To(gomega.Equal(reconcileTransientError))
+		})
+
+		ginkgo.It("Should keep the server message of a Forbidden error that is not an RBAC denial", func() {
+			_, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			ds := &appsv1.DaemonSet{}
+			dsNN := types.NamespacedName{
+				Name:      fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName),
+				Namespace: testNamespace,
+			}
+			err := cl.Get(context.TODO(), dsNN, ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Delete(context.TODO(), ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			r.client = erroringFakeCtrlRuntimeClient{
+				Client: cl,
+				err:    errors.NewForbidden(daemonSets, dsNN.Name, fmt.Errorf("violates PodSecurity \"restricted:latest\": privileged")),
+			}
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			cr := &hppv1.HostPathProvisioner{}
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			degraded := conditions.FindStatusCondition(cr.Status.Conditions, conditions.ConditionDegraded)
+			gomega.Expect(degraded).ToNot(gomega.BeNil())
+			gomega.Expect(degraded.Reason).To(gomega.Equal(reconcileFailed))
+			gomega.Expect(degraded.Message).To(gomega.ContainSubstring("violates PodSecurity"))
+		})
+	})
+})
@@ -29,40 +29,86 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	hostpathprovisionerv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
 	"kubevirt.io/hostpath-provisioner-operator/pkg/util"
 )
 
-func (r *ReconcileHostPathProvisioner) reconcileSecurityContextConstraints(reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) (reconcile.Result, error) {
-	if used, err := r.checkSCCUsed(); err != nil {
+func (r *ReconcileHostPathProvisioner) reconcileSecurityContextConstraints(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) (reconcile.Result, error) {
+	if used, err := r.checkSCCUsed(ctx); err != nil {
 		return reconcile.Result{}, err
 	} else if used == false {
 		return reconcile.Result{}, nil
 	}
 	if r.isLegacy(cr) {
-		if res, err := r.reconcileSecurityContextConstraintsDesired(reqLogger, cr, createSecurityContextConstraintsObject(namespace)); err != nil {
-			return res, err
+		desired := createSecurityContextConstraintsObject(namespace, getServiceAccountName(cr))
+		applyHostNetworkToSCC(cr, desired)
+		if res, err := r.reconcileSecurityContextConstraintsDesired(ctx, reqLogger, cr, desired); err != nil {
+			return res, newSCCError(desired.Name, err)
 		}
 	} else {
-		if err := r.deleteSCC(MultiPurposeHostPathProvisionerName); err != nil {
+		if err := r.deleteSCC(ctx, MultiPurposeHostPathProvisionerName); err != nil {
 			return reconcile.Result{}, err
 		}
 	}
-	return r.reconcileSecurityContextConstraintsDesired(reqLogger, cr, createCsiSecurityContextConstraintsObject(namespace))
+	desired := createCsiSecurityContextConstraintsObject(namespace, getCsiServiceAccountName(cr), getStoragePoolNamespaces(cr, namespace)...)
+	applyHostNetworkToSCC(cr, desired)
+	if res, err := r.reconcileSecurityContextConstraintsDesired(ctx, reqLogger, cr, desired); err != nil {
+		return res, newSCCError(desired.Name, err)
+	}
+	return reconcile.Result{}, nil
+}
+
+// sccError is a failure to reconcile a SecurityContextConstraints, it carries the Degraded reason and message, so the CR
+// shows why the pods cannot start instead of a generic reconcile failure.
+type sccError struct {
+	reason  string
+	message string
+	err     error
+}
+
+func newSCCError(name string, err error) *sccError {
+	reason := sccPending
+	if isRBACDenied(err) {
+		reason = sccDenied
+	}
+	return &sccError{
+		reason:  reason,
+		message: fmt.Sprintf(sccFailedMessage, name, err),
+		err:     err,
+	}
+}
+
+func (e *sccError) Error() string {
+	return e.err.Error()
+}
+
+func (e *sccError) Unwrap() error {
+	return e.err
+}
+
+// applyHostNetworkToSCC allows the host network, and the container ports that become host ports, if the workload uses
+// host networking.
+func applyHostNetworkToSCC(cr *hostpathprovisionerv1.HostPathProvisioner, scc *secv1.SecurityContextConstraints) {
+	scc.AllowHostNetwork = cr.Spec.Workload.HostNetwork
+	scc.AllowHostPorts = cr.Spec.Workload.HostNetwork
 }
 
-func (r *ReconcileHostPathProvisioner) reconcileSecurityContextConstraintsDesired(reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, desired *secv1.SecurityContextConstraints) (reconcile.Result, error) {
-	// Define a new SecurityContextConstraints object
+func (r *ReconcileHostPathProvisioner) reconcileSecurityContextConstraintsDesired(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, desired *secv1.SecurityContextConstraints) (reconcile.Result, error) {
+	// Set HostPathProvisioner instance as the owner and controller
+	if err := controllerutil.SetControllerReference(cr, desired, r.scheme); err != nil {
+		return reconcile.Result{}, err
+	}
 	setLastAppliedConfiguration(desired)
 
 	// Check if this SecurityContextConstraints already exists
 	found := &secv1.SecurityContextConstraints{}
-	err := r.client.Get(context.TODO(), types.NamespacedName{Name: desired.Name}, found)
+	err := r.client.Get(ctx, types.NamespacedName{Name: desired.Name}, found)
 	if err != nil && errors.IsNotFound(err) {
 		reqLogger.Info("Creating a new SecurityContextConstraints", "SecurityContextConstraints.Name", desired.Name)
-		err = r.client.Create(context.TODO(), desired)
+		err = r.applyObject(ctx, desired, nil)
 		if err != nil {
 			r.recorder.Event(cr, corev1.EventTypeWarning, createResourceFailed, fmt.Sprintf(createMessageFailed, desired.Name, err))
 			return reconcile.Result{}, err
@@ -74,6 +120,14 @@ func (r *ReconcileHostPathProvisioner) reconcileSecurityContextConstraintsDesire
 		return reconcile.Result{}, err
 	}
 
+	if err := r.repairControllerReference(ctx, reqLogger, cr, found); err != nil {
+		return reconcile.Result{}, err
+	}
+	if r.isUpToDate(desired, found) {
+		reqLogger.V(3).Info("Skip reconcile: SecurityContextConstraints is up to date", "SecurityContextConstraints.Name", found.Name)
+		return reconcile.Result{}, nil
+	}
+
 	// Keep a copy of the original for comparison later.
 	currentRuntimeObjCopy := found.DeepCopyObject()
 
@@ -91,7 +145,7 @@ func (r *ReconcileHostPathProvisioner) reconcileSecurityContextConstraintsDesire
 		logJSONDiff(reqLogger, currentRuntimeObjCopy, merged)
 		// Current is different from desired, update.
 		reqLogger.Info("Updating SecurityContextConstraints", "SecurityContextConstraints.Name", desired.Name)
-		err = r.client.Update(context.TODO(), merged)
+		err = r.applyObject(ctx, desired, found)
 		if err != nil {
 			r.recorder.Event(cr, corev1.EventTypeWarning, updateResourceFailed, fmt.Sprintf(updateMessageFailed, desired.Name, err))
 			return reconcile.Result{}, err
@@ -100,12 +154,13 @@ func (r *ReconcileHostPathProvisioner) reconcileSecurityContextConstraintsDesire
 		return reconcile.Result{}, nil
 	}
 	// SecurityContextConstraints already exists and matches the desired state - don't requeue
+	r.markUpToDate(found)
 	reqLogger.Info("Skip reconcile: SecurityContextConstraints already exists", "SecurityContextConstraints.Name", found.Name)
 	return reconcile.Result{}, nil
 }
 
-func (r *ReconcileHostPathProvisioner) deleteSCC(name string) error {
-	if used, err := r.checkSCCUsed(); used == false {
+func (r *ReconcileHostPathProvisioner) deleteSCC(ctx context.Context, name string) error {
+	if used, err := r.checkSCCUsed(ctx); used == false {
 		return err
 	}
 	// Check if this SecurityContextConstraints already exists
@@ -115,15 +170,19 @@ func (r *ReconcileHostPathProvisioner) deleteSCC(name string) error {
 		},
 	}
 
-	if err := r.client.Delete(context.TODO(), scc); err != nil && !errors.IsNotFound(err) {
+	if err := r.client.Delete(ctx, scc); err != nil && !errors.IsNotFound(err) {
+		if meta.IsNoMatchError(err) || strings.Contains(err.Error(), "failed to find API group") {
+			// The security API got removed, so the SCC is gone as well
+			return nil
+		}
 		return err
 	}
 
 	return nil
 }
 
-func createSecurityContextConstraintsObject(namespace string) *secv1.SecurityContextConstraints {
-	saName := fmt.Sprintf("system:serviceaccount:%s:%s", namespace, ProvisionerServiceAccountName)
+func createSecurityContextConstraintsObject(namespace, serviceAccountName string) *secv1.SecurityContextConstraints {
+	saName := fmt.Sprintf("system:serviceaccount:%s:%s", namespace, serviceAccountName)
 	res := &secv1.SecurityContextConstraints{
 		Groups: []string{},
 		TypeMeta: metav1.TypeMeta{
@@ -166,8 +225,13 @@ func createSecurityContextConstraintsObject(namespace string) *secv1.SecurityCon
 	return res
 }
 
-func createCsiSecurityContextConstraintsObject(namespace string) *secv1.SecurityContextConstraints {
-	saName := fmt.Sprintf("system:serviceaccount:%s:%s", namespace, ProvisionerServiceAccountNameCsi)
+func createCsiSecurityContextConstraintsObject(namespace, serviceAccountName string, storagePoolNamespaces ...string) *secv1.SecurityContextConstraints {
+	users := []string{
+		fmt.Sprintf("system:serviceaccount:%s:%s", namespace, serviceAccountName),
+	}
+	for _, poolNamespace := range storagePoolNamespaces {
+		users = append(users, fmt.Sprintf("system:serviceaccount:%s:%s", poolNamespace, serviceAccountName))
+	}
 	return &secv1.SecurityContextConstraints{
 		Groups: []string{},
 		TypeMeta: metav1.TypeMeta{
@@ -198,19 +262,17 @@ func createCsiSecurityContextConstraintsObject(namespace string) *secv1.Security
 			Type: secv1.SupplementalGroupsStrategyRunAsAny,
 		},
 		AllowHostDirVolumePlugin: true,
-		Users: []string{
-			saName,
-		},
+		Users:                    users,
 		Volumes: []secv1.FSType{
 			secv1.FSTypeAll,
 		},
 	}
 }
 
-func (r *ReconcileHostPathProvisioner) checkSCCUsed() (bool, error) {
+func (r *ReconcileHostPathProvisioner) checkSCCUsed(ctx context.Context) (bool, error) {
 	// Check if we are using security context constraints, if not return false.
 	listObj := &secv1.SecurityContextConstraintsList{}
-	if err := r.client.List(context.TODO(), listObj); err != nil {
+	if err := r.client.List(ctx, listObj); err != nil {
 		if meta.IsNoMatchError(err) || strings.Contains(err.Error(), "failed to find API group") {
 			// not using SCCs
 			return false, nil
@@ -22,7 +22,13 @@ import (
 	ginkgo "github.com/onsi/ginkgo/v2"
 	gomega "github.com/onsi/gomega"
 	secv1 "github.com/openshift/api/security/v1"
+	conditions "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	hppv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
@@ -43,8 +49,7 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 		ginkgo.DescribeTable("Should fix a changed SecurityContextConstraints", func(cr *hppv1.HostPathProvisioner, names ...string) {
 			req := reconcile.Request{
 				NamespacedName: types.NamespacedName{
-					Name:      "test-name",
-					Namespace: testNamespace,
+					Name: "test-name",
 				},
 			}
 			for _, name := range names {
@@ -84,5 +89,123 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 			ginkgo.Entry("legacyStoragePoolCr", createLegacyStoragePoolCr(), fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)),
 			ginkgo.Entry("storagePoolCr", createStoragePoolWithTemplateCr(), fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)),
 		)
+
+		ginkgo.DescribeTable("Should allow the host network when the workload uses host networking", func(name string) {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			sccNN := types.NamespacedName{
+				Name: name,
+			}
+			cr, r, cl := createDeployedCr(createLegacyCr())
+			scc := &secv1.SecurityContextConstraints{}
+			err := cl.Get(context.TODO(), sccNN, scc)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(scc.AllowHostNetwork).To(gomega.BeFalse())
+			gomega.Expect(scc.AllowHostPorts).To(gomega.BeFalse())
+
+			cr = &hppv1.HostPathProvisioner{}
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.Workload.HostNetwork = true
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			err = cl.Get(context.TODO(), sccNN, scc)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(scc.AllowHostNetwork).To(gomega.BeTrue())
+			gomega.Expect(scc.AllowHostPorts).To(gomega.BeTrue())
+		},
+			ginkgo.Entry("legacy", MultiPurposeHostPathProvisionerName),
+			ginkgo.Entry("csi", fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)),
+		)
+
+		ginkgo.DescribeTable("Should report why the SecurityContextConstraints cannot be created", func(sccErr error, expectedReason string) {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			sccName := fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			err := cl.Delete(context.TODO(), &secv1.SecurityContextConstraints{ObjectMeta: metav1.ObjectMeta{Name: sccName}})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			r.client = failingSCCFakeCtrlRuntimeClient{
+				Client: cl,
+				err:    sccErr,
+			}
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			degraded := conditions.FindStatusCondition(cr.Status.Conditions, conditions.ConditionDegraded)
+			gomega.Expect(degraded).ToNot(gomega.BeNil())
+			gomega.Expect(degraded.Status).To(gomega.Equal(corev1.ConditionTrue))
+			gomega.Expect(degraded.Reason).To(gomega.Equal(expectedReason))
+			gomega.Expect(degraded.Message).To(gomega.Equal(fmt.Sprintf(sccFailedMessage, sccName, sccErr)))
+			gomega.Expect(conditions.IsStatusConditionTrue(cr.Status.Conditions, conditions.ConditionProgressing)).To(gomega.BeTrue())
+
+			ginkgo.By("Clearing the reason once the SecurityContextConstraints can be created")
+			r.client = cl
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(conditions.FindStatusCondition(cr.Status.Conditions, conditions.ConditionDegraded).Reason).ToNot(gomega.Equal(expectedReason))
+		},
+			ginkgo.Entry("denied", errors.NewForbidden(secv1.GroupVersion.WithResource("securitycontextconstraints").GroupResource(), "hostpath-provisioner-csi", fmt.Errorf("User \"system:serviceaccount:test:operator\" cannot update resource \"securitycontextconstraints\" in API group \"security.openshift.io\" at the cluster scope")), sccDenied),
+			ginkgo.Entry("denied by admission", errors.NewForbidden(secv1.GroupVersion.WithResource("securitycontextconstraints").GroupResource(), "hostpath-provisioner-csi", fmt.Errorf("admission webhook \"policy.example.com\" denied the request")), sccPending),
+			ginkgo.Entry("pending", errors.NewServiceUnavailable("security API not ready"), sccPending),
+		)
+
+		ginkgo.It("Should remove the finalizer if the SecurityContextConstraints API is gone", func() {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			cr, r, cl := createDeployedCr(createLegacyCr())
+			err := cl.Delete(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			r.client = noMatchSCCFakeCtrlRuntimeClient{
+				Client: cl,
+			}
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(errors.IsNotFound(err)).To(gomega.BeTrue())
+		})
 	})
 })
+
+// noMatchSCCFakeCtrlRuntimeClient mimics the SecurityContextConstraints CRD being removed in between listing and deleting.
+type noMatchSCCFakeCtrlRuntimeClient struct {
+	client.Client
+}
+
+func (p noMatchSCCFakeCtrlRuntimeClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if _, ok := obj.(*secv1.SecurityContextConstraints); ok {
+		return &meta.NoKindMatchError{
+			GroupKind:        secv1.GroupVersion.WithKind("SecurityContextConstraints").GroupKind(),
+			SearchedVersions: []string{secv1.GroupVersion.Version},
+		}
+	}
+	return p.Client.Delete(ctx, obj, opts...)
+}
+
+// failingSCCFakeCtrlRuntimeClient fails creating and updating SecurityContextConstraints with err.
+type failingSCCFakeCtrlRuntimeClient struct {
+	client.Client
+	err error
+}
+
+func (p failingSCCFakeCtrlRuntimeClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if _, ok := obj.(*secv1.SecurityContextConstraints); ok {
+		return p.err
+	}
+	return p.Client.Patch(ctx, obj, patch, opts...)
+}
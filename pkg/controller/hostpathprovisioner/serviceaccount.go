@@ -18,6 +18,7 @@ package hostpathprovisioner
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 
@@ -36,29 +37,38 @@ import (
 	"kubevirt.io/hostpath-provisioner-operator/pkg/util"
 )
 
-func (r *ReconcileHostPathProvisioner) reconcileServiceAccount(reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) (reconcile.Result, error) {
+func (r *ReconcileHostPathProvisioner) reconcileServiceAccount(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) (reconcile.Result, error) {
 	// Previous versions created resources with names that depend on the CR, whereas now, we have fixed names for those.
 	// We will remove those and have the next loop create the resources with fixed names so we don't end up with two sets of hpp resources.
-	dups, err := r.getDuplicateServiceAccount(cr.Name, namespace)
+	dups, err := r.getDuplicateServiceAccount(ctx, cr.Name, namespace)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 	for _, dup := range dups {
 		reqLogger.Info("Deleting extra service account", "namespace", namespace, "name", dup.Name)
-		if err := r.deleteServiceAccount(dup.Name, namespace); err != nil {
+		if err := r.deleteServiceAccount(ctx, dup.Name, namespace); err != nil {
 			return reconcile.Result{}, err
 		}
 	}
 
+	if hasExternalServiceAccount(cr) {
+		// The ServiceAccount is managed outside of the operator, remove the ones the operator created before.
+		return reconcile.Result{}, r.deleteOwnServiceAccounts(ctx, reqLogger, cr, namespace)
+	}
+
 	accounts := make([]*corev1.ServiceAccount, 0)
 	if r.isLegacy(cr) {
-		accounts = append(accounts, createServiceAccountObject(namespace))
+		accounts = append(accounts, createServiceAccountObject(namespace, cr.Spec.Workload.ImagePullSecrets))
 	} else {
-		if err := r.deleteServiceAccount(ProvisionerServiceAccountName, namespace); err != nil {
+		if err := r.deleteServiceAccount(ctx, ProvisionerServiceAccountName, namespace); err != nil {
 			return reconcile.Result{}, err
 		}
 	}
-	accounts = append(accounts, createCsiServiceAccountObject(namespace))
+	accounts = append(accounts, createCsiServiceAccountObject(namespace, cr.Spec.Workload.ImagePullSecrets))
+	// The storage pool deployments and cleanup jobs in other namespaces run with the csi service account of that namespace.
+	for _, poolNamespace := range getStoragePoolNamespaces(cr, namespace) {
+		accounts = append(accounts, createCsiServiceAccountObject(poolNamespace, cr.Spec.Workload.ImagePullSecrets))
+	}
 	for _, desired := range accounts {
 		// Define a new Service Account object
 		setLastAppliedConfiguration(desired)
@@ -70,10 +80,10 @@ func (r *ReconcileHostPathProvisioner) reconcileServiceAccount(reqLogger logr.Lo
 
 		// Check if this ServiceAccount already exists
 		found := &corev1.ServiceAccount{}
-		err = r.client.Get(context.TODO(), types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, found)
+		err = r.client.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, found)
 		if err != nil && errors.IsNotFound(err) {
 			reqLogger.Info("Creating a new Service Account", "ServiceAccount.Namespace", desired.Namespace, "ServiceAccount.Name", desired.Name)
-			err = r.client.Create(context.TODO(), desired)
+			err = r.applyObject(ctx, desired, nil)
 			if err != nil {
 				r.recorder.Event(cr, corev1.EventTypeWarning, createResourceFailed, fmt.Sprintf(createMessageFailed, desired.Name, err))
 				return reconcile.Result{}, err
@@ -81,7 +91,7 @@ func (r *ReconcileHostPathProvisioner) reconcileServiceAccount(reqLogger logr.Lo
 
 			// Service Account created successfully - don't requeue
 			r.recorder.Event(cr, corev1.EventTypeNormal, createResourceSuccess, fmt.Sprintf(createMessageSucceeded, desired, desired.Name))
-			if err := r.deleteRunningPodsWithSa(desired.Name, cr.Namespace); err != nil {
+			if err := r.deleteRunningPodsWithSa(ctx, desired.Name, cr.Namespace); err != nil {
 				return reconcile.Result{}, err
 			}
 			continue
@@ -89,9 +99,20 @@ func (r *ReconcileHostPathProvisioner) reconcileServiceAccount(reqLogger logr.Lo
 			return reconcile.Result{}, err
 		}
 
+		if err := r.repairControllerReference(ctx, reqLogger, cr, found); err != nil {
+			return reconcile.Result{}, err
+		}
+		if r.isUpToDate(desired, found) {
+			reqLogger.V(3).Info("Skip reconcile: Service Account is up to date", "ServiceAccount.Namespace", found.Namespace, "ServiceAccount.Name", found.Name)
+			continue
+		}
+
 		// Keep a copy of the original for comparison later.
 		currentRuntimeObjCopy := found.DeepCopyObject()
 
+		// keep pull secrets added by others (for instance the OpenShift dockercfg secret)
+		preserveImagePullSecrets(desired, found)
+
 		// allow users to add new annotations (but not change ours)
 		mergeLabelsAndAnnotations(desired, found)
 
@@ -106,7 +127,7 @@ func (r *ReconcileHostPathProvisioner) reconcileServiceAccount(reqLogger logr.Lo
 			logJSONDiff(log, currentRuntimeObjCopy, merged)
 			// Current is different from desired, update.
 			reqLogger.Info("Updating Service Account", "ServiceAccount.Name", desired.Name)
-			err = r.client.Update(context.TODO(), merged)
+			err = r.applyObject(ctx, desired, found)
 			if err != nil {
 				r.recorder.Event(cr, corev1.EventTypeWarning, updateResourceFailed, fmt.Sprintf(updateMessageFailed, desired.Name, err))
 				return reconcile.Result{}, err
@@ -116,34 +137,100 @@ func (r *ReconcileHostPathProvisioner) reconcileServiceAccount(reqLogger logr.Lo
 		}
 
 		// Service Account already exists and matches desired - don't requeue
+		r.markUpToDate(found)
 		reqLogger.V(3).Info("Skip reconcile: Service Account already exists", "ServiceAccount.Namespace", found.Namespace, "ServiceAccount.Name", found.Name)
 	}
 	return reconcile.Result{}, nil
 }
 
-func (r *ReconcileHostPathProvisioner) deleteRunningPodsWithSa(name, namespace string) error {
+// deleteOwnServiceAccounts removes the ServiceAccounts created by the operator, in the namespace of the operator and the
+// storage pools. A ServiceAccount that is not controlled by the HostPathProvisioner is left alone, the external
+// ServiceAccount can have the same name as one of ours.
+func (r *ReconcileHostPathProvisioner) deleteOwnServiceAccounts(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) error {
+	for _, saNamespace := range append([]string{namespace}, getStoragePoolNamespaces(cr, namespace)...) {
+		for _, name := range []string{ProvisionerServiceAccountName, ProvisionerServiceAccountNameCsi} {
+			sa := &corev1.ServiceAccount{}
+			if err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: saNamespace}, sa); errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return err
+			}
+			if name == cr.Spec.Workload.ServiceAccountName || !metav1.IsControlledBy(sa, cr) {
+				continue
+			}
+			reqLogger.Info("Deleting Service Account replaced by the external Service Account", "ServiceAccount.Namespace", saNamespace, "ServiceAccount.Name", name)
+			if err := r.deleteServiceAccount(ctx, name, saNamespace); err != nil {
+				r.recorder.Event(cr, corev1.EventTypeWarning, deleteResourceFailed, fmt.Sprintf(deleteMessageFailed, name, err))
+				return err
+			}
+			r.recorder.Event(cr, corev1.EventTypeNormal, deleteResourceSuccess, fmt.Sprintf(deleteMessageSucceeded, sa, name))
+		}
+	}
+	return nil
+}
+
+// validateExternalServiceAccount returns a reason and message if the external ServiceAccount of the CR is missing in the
+// namespace of the operator or one of the storage pool namespaces. Nothing is returned without an external ServiceAccount.
+func (r *ReconcileHostPathProvisioner) validateExternalServiceAccount(ctx context.Context, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) (string, string, error) {
+	if !hasExternalServiceAccount(cr) {
+		return "", "", nil
+	}
+	for _, saNamespace := range append([]string{namespace}, getStoragePoolNamespaces(cr, namespace)...) {
+		sa := &corev1.ServiceAccount{}
+		if err := r.client.Get(ctx, types.NamespacedName{Name: cr.Spec.Workload.ServiceAccountName, Namespace: saNamespace}, sa); errors.IsNotFound(err) {
+			return serviceAccountNotFound, fmt.Sprintf(serviceAccountNotFoundMessage, cr.Spec.Workload.ServiceAccountName, saNamespace), nil
+		} else if err != nil {
+			return "", "", err
+		}
+	}
+	return "", "", nil
+}
+
+// hasExternalServiceAccount returns true if the provisioner pods run with a ServiceAccount the operator does not manage.
+func hasExternalServiceAccount(cr *hostpathprovisionerv1.HostPathProvisioner) bool {
+	return cr.Spec.Workload.ServiceAccountName != ""
+}
+
+// getServiceAccountName returns the name of the ServiceAccount of the legacy provisioner.
+func getServiceAccountName(cr *hostpathprovisionerv1.HostPathProvisioner) string {
+	if hasExternalServiceAccount(cr) {
+		return cr.Spec.Workload.ServiceAccountName
+	}
+	return ProvisionerServiceAccountName
+}
+
+// getCsiServiceAccountName returns the name of the ServiceAccount of the csi driver, the storage pool deployments and
+// the cleanup jobs.
+func getCsiServiceAccountName(cr *hostpathprovisionerv1.HostPathProvisioner) string {
+	if hasExternalServiceAccount(cr) {
+		return cr.Spec.Workload.ServiceAccountName
+	}
+	return ProvisionerServiceAccountNameCsi
+}
+
+func (r *ReconcileHostPathProvisioner) deleteRunningPodsWithSa(ctx context.Context, name, namespace string) error {
 	// If there are running pods while the sa gets deleted, these pods are no longer authenticated
 	// we need to delete those pods and let the appropriate daemonset/deployment(s) recreate them. Since
 	// we don't have permission to delete pods, we should delete the daemonset/deployment(s) instead and
 	// let the operator recreate them.
 	r.Log.WithValues("Service Account Name", name, "Namespace", namespace).Info("Checking for daemonsets")
-	if err := r.deleteDaemonSetWithSa(name, namespace); err != nil {
+	if err := r.deleteDaemonSetWithSa(ctx, name, namespace); err != nil {
 		return err
 	}
 	r.Log.WithValues("Service Account Name", name, "Namespace", namespace).Info("Checking for deployments")
-	return r.deleteDeploymentsWithSa(name, namespace)
+	return r.deleteDeploymentsWithSa(ctx, name, namespace)
 }
 
-func (r *ReconcileHostPathProvisioner) deleteDaemonSetWithSa(name, namespace string) error {
+func (r *ReconcileHostPathProvisioner) deleteDaemonSetWithSa(ctx context.Context, name, namespace string) error {
 	dsList := &appsv1.DaemonSetList{}
-	if err := r.client.List(context.TODO(), dsList, &client.ListOptions{Namespace: namespace}); err != nil && !errors.IsNotFound(err) {
+	if err := r.client.List(ctx, dsList, &client.ListOptions{Namespace: namespace}); err != nil && !errors.IsNotFound(err) {
 		r.Log.Error(err, "Error with list", "error")
 		return err
 	}
 
 	for _, ds := range dsList.Items {
 		if ds.Spec.Template.Spec.ServiceAccountName == name && ds.Status.NumberAvailable > 0 {
-			if err := r.client.Delete(context.TODO(), &ds); err != nil && !errors.IsNotFound(err) {
+			if err := r.client.Delete(ctx, &ds); err != nil && !errors.IsNotFound(err) {
 				r.Log.Error(err, "Error with delete", "error")
 				return err
 			}
@@ -152,15 +239,15 @@ func (r *ReconcileHostPathProvisioner) deleteDaemonSetWithSa(name, namespace str
 	return nil
 }
 
-func (r *ReconcileHostPathProvisioner) deleteDeploymentsWithSa(name, namespace string) error {
+func (r *ReconcileHostPathProvisioner) deleteDeploymentsWithSa(ctx context.Context, name, namespace string) error {
 	deploymentList := &appsv1.DeploymentList{}
-	if err := r.client.List(context.TODO(), deploymentList, &client.ListOptions{Namespace: namespace}); err != nil && !errors.IsNotFound(err) {
+	if err := r.client.List(ctx, deploymentList, &client.ListOptions{Namespace: namespace}); err != nil && !errors.IsNotFound(err) {
 		return err
 	}
 
 	for _, deployment := range deploymentList.Items {
 		if deployment.Spec.Template.Spec.ServiceAccountName == name && deployment.Status.ReadyReplicas > 0 {
-			if err := r.client.Delete(context.TODO(), &deployment); err != nil && !errors.IsNotFound(err) {
+			if err := r.client.Delete(ctx, &deployment); err != nil && !errors.IsNotFound(err) {
 				return err
 			}
 		}
@@ -168,7 +255,7 @@ func (r *ReconcileHostPathProvisioner) deleteDeploymentsWithSa(name, namespace s
 	return nil
 }
 
-func (r *ReconcileHostPathProvisioner) deleteServiceAccount(name, namespace string) error {
+func (r *ReconcileHostPathProvisioner) deleteServiceAccount(ctx context.Context, name, namespace string) error {
 	// Check if this ServiceAccount already exists
 	sa := &corev1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
@@ -177,7 +264,7 @@ func (r *ReconcileHostPathProvisioner) deleteServiceAccount(name, namespace stri
 		},
 	}
 
-	if err := r.client.Delete(context.TODO(), sa); err != nil && !errors.IsNotFound(err) {
+	if err := r.client.Delete(ctx, sa); err != nil && !errors.IsNotFound(err) {
 		return err
 	}
 
@@ -185,30 +272,60 @@ func (r *ReconcileHostPathProvisioner) deleteServiceAccount(name, namespace stri
 }
 
 // createServiceAccount returns a new Service Account object in the same namespace as the cr.
-func createServiceAccountObject(namespace string) *corev1.ServiceAccount {
+func createServiceAccountObject(namespace string, pullSecrets []corev1.LocalObjectReference) *corev1.ServiceAccount {
 	labels := util.GetRecommendedLabels()
-	return createServiceAccount(ProvisionerServiceAccountName, namespace, labels)
+	return createServiceAccount(ProvisionerServiceAccountName, namespace, labels, pullSecrets)
 }
 
 // createServiceAccount returns a new Service Account object in the same namespace as the cr.
-func createCsiServiceAccountObject(namespace string) *corev1.ServiceAccount {
+func createCsiServiceAccountObject(namespace string, pullSecrets []corev1.LocalObjectReference) *corev1.ServiceAccount {
 	labels := util.GetRecommendedLabels()
-	return createServiceAccount(ProvisionerServiceAccountNameCsi, namespace, labels)
+	return createServiceAccount(ProvisionerServiceAccountNameCsi, namespace, labels, pullSecrets)
 }
 
-func createServiceAccount(name, namespace string, labels map[string]string) *corev1.ServiceAccount {
-	return &corev1.ServiceAccount{
+func createServiceAccount(name, namespace string, labels map[string]string, pullSecrets []corev1.LocalObjectReference) *corev1.ServiceAccount {
+	sa := &corev1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
 			Labels:    labels,
 		},
 	}
+	if len(pullSecrets) > 0 {
+		sa.ImagePullSecrets = append([]corev1.LocalObjectReference{}, pullSecrets...)
+	}
+	return sa
+}
+
+// preserveImagePullSecrets adds the pull secrets of the current ServiceAccount that were not applied by the operator
+// to the desired ServiceAccount. Secrets that were previously applied by the operator but are no longer in the CR are
+// dropped.
+func preserveImagePullSecrets(desired, current *corev1.ServiceAccount) {
+	applied := &corev1.ServiceAccount{}
+	if v, ok := current.GetAnnotations()[lastAppliedConfigAnnotation]; ok {
+		if err := json.Unmarshal([]byte(v), applied); err != nil {
+			applied = &corev1.ServiceAccount{}
+		}
+	}
+	for _, secret := range current.ImagePullSecrets {
+		if !containsLocalObjectReference(desired.ImagePullSecrets, secret) && !containsLocalObjectReference(applied.ImagePullSecrets, secret) {
+			desired.ImagePullSecrets = append(desired.ImagePullSecrets, secret)
+		}
+	}
+}
+
+func containsLocalObjectReference(refs []corev1.LocalObjectReference, ref corev1.LocalObjectReference) bool {
+	for _, r := range refs {
+		if r.Name == ref.Name {
+			return true
+		}
+	}
+	return false
 }
 
 // getDuplicateServiceAccount will give us duplicate ServiceAccounts from a previous version if they exist.
 // This is possible from a previous HPP version where the resources (DaemonSet, RBAC) were named depending on the CR, whereas now, we have fixed names for those.
-func (r *ReconcileHostPathProvisioner) getDuplicateServiceAccount(customCrName, namespace string) ([]corev1.ServiceAccount, error) {
+func (r *ReconcileHostPathProvisioner) getDuplicateServiceAccount(ctx context.Context, customCrName, namespace string) ([]corev1.ServiceAccount, error) {
 	saList := &corev1.ServiceAccountList{}
 	dups := make([]corev1.ServiceAccount, 0)
 
@@ -217,7 +334,7 @@ func (r *ReconcileHostPathProvisioner) getDuplicateServiceAccount(customCrName,
 		return dups, err
 	}
 	lo := &client.ListOptions{LabelSelector: ls, Namespace: namespace}
-	if err := r.client.List(context.TODO(), saList, lo); err != nil {
+	if err := r.client.List(ctx, saList, lo); err != nil {
 		return dups, err
 	}
 
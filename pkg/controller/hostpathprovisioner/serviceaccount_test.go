@@ -17,10 +17,15 @@ package hostpathprovisioner
 
 import (
 	"context"
+	"fmt"
 
 	ginkgo "github.com/onsi/ginkgo/v2"
 	gomega "github.com/onsi/gomega"
+	conditions "github.com/openshift/custom-resource-status/conditions/v1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -44,8 +49,7 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 		ginkgo.DescribeTable("Should fix a changed service account", func(cr *hppv1.HostPathProvisioner, saNames ...string) {
 			req := reconcile.Request{
 				NamespacedName: types.NamespacedName{
-					Name:      "test-name",
-					Namespace: testNamespace,
+					Name: "test-name",
 				},
 			}
 			_, r, cl := createDeployedCr(cr)
@@ -92,5 +96,165 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 			ginkgo.Entry("legacyStoragePoolCr", createLegacyStoragePoolCr()),
 			ginkgo.Entry("storagePoolCr", createStoragePoolWithTemplateCr()),
 		)
+
+		ginkgo.DescribeTable("Should add image pull secrets to the service accounts", func(cr *hppv1.HostPathProvisioner, saNames ...string) {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			pullSecrets := []corev1.LocalObjectReference{{Name: "registry-secret"}}
+			cr.Spec.Workload.ImagePullSecrets = pullSecrets
+			_, r, cl := createDeployedCr(cr)
+			for _, saName := range saNames {
+				sa := &corev1.ServiceAccount{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      saName,
+						Namespace: testNamespace,
+					},
+				}
+				err := cl.Get(context.TODO(), client.ObjectKeyFromObject(sa), sa)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(sa.ImagePullSecrets).To(gomega.Equal(pullSecrets))
+				// Remove the secret, and add one that is not managed by the operator.
+				sa.ImagePullSecrets = []corev1.LocalObjectReference{{Name: "external-secret"}}
+				err = cl.Update(context.TODO(), sa)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				// Run the reconcile loop
+				res, err := r.Reconcile(context.TODO(), req)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(res.Requeue).To(gomega.BeFalse())
+				err = cl.Get(context.TODO(), client.ObjectKeyFromObject(sa), sa)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(sa.ImagePullSecrets).To(gomega.ConsistOf(pullSecrets[0], corev1.LocalObjectReference{Name: "external-secret"}))
+			}
+
+			// Change the secrets in the CR, the old secret should be removed.
+			cr = &hppv1.HostPathProvisioner{}
+			err := cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.Workload.ImagePullSecrets = []corev1.LocalObjectReference{{Name: "new-secret"}}
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			res, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(res.Requeue).To(gomega.BeFalse())
+			for _, saName := range saNames {
+				sa := &corev1.ServiceAccount{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      saName,
+						Namespace: testNamespace,
+					},
+				}
+				err := cl.Get(context.TODO(), client.ObjectKeyFromObject(sa), sa)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(sa.ImagePullSecrets).To(gomega.ConsistOf(corev1.LocalObjectReference{Name: "new-secret"}, corev1.LocalObjectReference{Name: "external-secret"}))
+			}
+		},
+			ginkgo.Entry("legacyCr", createLegacyCr(), ProvisionerServiceAccountName, ProvisionerServiceAccountNameCsi),
+			ginkgo.Entry("storagePoolCr", createStoragePoolWithTemplateCr(), ProvisionerServiceAccountNameCsi),
+		)
+
+		ginkgo.DescribeTable("Should run the workloads with an external service account", func(cr *hppv1.HostPathProvisioner, dsNames ...string) {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			cr, r, cl := createDeployedCr(cr)
+			external := &corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "external-sa",
+					Namespace: testNamespace,
+				},
+			}
+			err := cl.Create(context.TODO(), external)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.Workload.ServiceAccountName = external.Name
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			for _, dsName := range dsNames {
+				ds := &appsv1.DaemonSet{}
+				err = cl.Get(context.TODO(), types.NamespacedName{Name: dsName, Namespace: testNamespace}, ds)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(ds.Spec.Template.Spec.ServiceAccountName).To(gomega.Equal(external.Name))
+			}
+			crb := &rbacv1.ClusterRoleBinding{}
+			err = cl.Get(context.TODO(), types.NamespacedName{Name: ProvisionerServiceAccountNameCsi}, crb)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(crb.Subjects[0].Name).To(gomega.Equal(external.Name))
+			rb := &rbacv1.RoleBinding{}
+			err = cl.Get(context.TODO(), types.NamespacedName{Name: ProvisionerServiceAccountNameCsi, Namespace: testNamespace}, rb)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(rb.Subjects[0].Name).To(gomega.Equal(external.Name))
+
+			ginkgo.By("Not owning the external service account, and removing our own")
+			err = cl.Get(context.TODO(), client.ObjectKeyFromObject(external), external)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(external.GetOwnerReferences()).To(gomega.BeEmpty())
+			for _, saName := range []string{ProvisionerServiceAccountName, ProvisionerServiceAccountNameCsi} {
+				err = cl.Get(context.TODO(), types.NamespacedName{Name: saName, Namespace: testNamespace}, &corev1.ServiceAccount{})
+				gomega.Expect(errors.IsNotFound(err)).To(gomega.BeTrue())
+			}
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(IsCrHealthy(cr)).To(gomega.BeTrue())
+		},
+			ginkgo.Entry("legacyCr", createLegacyCr(), MultiPurposeHostPathProvisionerName, fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)),
+			ginkgo.Entry("storagePoolCr", createStoragePoolWithTemplateCr(), fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)),
+		)
+
+		ginkgo.It("Should mark the CR degraded if the external service account does not exist", func() {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			err := cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.Workload.ServiceAccountName = "external-sa"
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			degraded := conditions.FindStatusCondition(cr.Status.Conditions, conditions.ConditionDegraded)
+			gomega.Expect(degraded).ToNot(gomega.BeNil())
+			gomega.Expect(degraded.Status).To(gomega.Equal(corev1.ConditionTrue))
+			gomega.Expect(degraded.Reason).To(gomega.Equal(serviceAccountNotFound))
+			gomega.Expect(degraded.Message).To(gomega.Equal(fmt.Sprintf(serviceAccountNotFoundMessage, "external-sa", testNamespace)))
+			ds := &appsv1.DaemonSet{}
+			err = cl.Get(context.TODO(), types.NamespacedName{Name: fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName), Namespace: testNamespace}, ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ds.Spec.Template.Spec.ServiceAccountName).To(gomega.Equal(ProvisionerServiceAccountNameCsi))
+
+			ginkgo.By("Creating the service account")
+			mapFn := hppServiceAccountMapFunc(cl)
+			external := &corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "external-sa",
+					Namespace: testNamespace,
+				},
+			}
+			gomega.Expect(mapFn(context.TODO(), external)).To(gomega.HaveLen(1))
+			gomega.Expect(mapFn(context.TODO(), createCsiServiceAccountObject(testNamespace, nil))).To(gomega.BeEmpty())
+			err = cl.Create(context.TODO(), external)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(IsCrHealthy(cr)).To(gomega.BeTrue())
+			err = cl.Get(context.TODO(), client.ObjectKeyFromObject(ds), ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ds.Spec.Template.Spec.ServiceAccountName).To(gomega.Equal(external.Name))
+		})
 	})
 })
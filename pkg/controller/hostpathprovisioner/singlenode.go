@@ -0,0 +1,209 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hostpathprovisionerv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
+)
+
+const (
+	controlPlaneNodeLabel = "node-role.kubernetes.io/control-plane"
+	// masterNodeLabel is the control plane node role of older clusters
+	masterNodeLabel = "node-role.kubernetes.io/master"
+)
+
+var (
+	controlPlaneToleration = corev1.Toleration{
+		Key:      controlPlaneNodeLabel,
+		Operator: corev1.TolerationOpExists,
+		Effect:   corev1.TaintEffectNoSchedule,
+	}
+	masterToleration = corev1.Toleration{
+		Key:      masterNodeLabel,
+		Operator: corev1.TolerationOpExists,
+		Effect:   corev1.TaintEffectNoSchedule,
+	}
+)
+
+func isSingleNode(cr *hostpathprovisionerv1.HostPathProvisioner) bool {
+	return cr.Spec.Workload.SingleNode
+}
+
+// reconcileWorkload reconciles the passed in DaemonSet, or its single replica Deployment equivalent if the CR is in single node mode.
+func (r *ReconcileHostPathProvisioner) reconcileWorkload(ctx context.Context, reqLogger logr.Logger, desired *appsv1.DaemonSet, cr *hostpathprovisionerv1.HostPathProvisioner) (reconcile.Result, error) {
+	if isSingleNode(cr) {
+		if err := r.deleteDaemonSet(ctx, desired.Name, desired.Namespace); err != nil {
+			return reconcile.Result{}, err
+		}
+		return r.reconcileSingleNodeDeployment(ctx, reqLogger, createSingleNodeDeploymentObject(desired), cr)
+	}
+	if err := r.deleteSingleNodeDeployment(ctx, desired.Name, desired.Namespace); err != nil {
+		return reconcile.Result{}, err
+	}
+	return r.reconcileDaemonSetForSa(ctx, reqLogger, desired, cr)
+}
+
+func (r *ReconcileHostPathProvisioner) reconcileSingleNodeDeployment(ctx context.Context, reqLogger logr.Logger, desired *appsv1.Deployment, cr *hostpathprovisionerv1.HostPathProvisioner) (reconcile.Result, error) {
+	setLastAppliedConfiguration(desired)
+
+	// Set HostPathProvisioner instance as the owner and controller
+	if err := controllerutil.SetControllerReference(cr, desired, r.scheme); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// Check if this Deployment already exists
+	found := &appsv1.Deployment{}
+	err := r.client.Get(ctx, client.ObjectKeyFromObject(desired), found)
+	if err != nil && errors.IsNotFound(err) {
+		reqLogger.Info("Creating a new single node Deployment", "Deployment.Namespace", desired.Namespace, "Deployment.Name", desired.Name)
+		err = r.applyObject(ctx, desired, nil)
+		if err != nil {
+			r.recorder.Event(cr, corev1.EventTypeWarning, createResourceFailed, fmt.Sprintf(createMessageFailed, desired.Name, err))
+			return reconcile.Result{}, err
+		}
+
+		// Deployment created successfully - don't requeue
+		r.recorder.Event(cr, corev1.EventTypeNormal, createResourceSuccess, fmt.Sprintf(createMessageSucceeded, desired, desired.Name))
+		return reconcile.Result{}, nil
+	} else if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err := r.repairControllerReference(ctx, reqLogger, cr, found); err != nil {
+		return reconcile.Result{}, err
+	}
+	if r.isUpToDate(desired, found) {
+		reqLogger.V(3).Info("Skip reconcile: single node Deployment is up to date", "Deployment.Namespace", found.Namespace, "Deployment.Name", found.Name)
+		return reconcile.Result{}, nil
+	}
+
+	// Keep a copy of the original for comparison later.
+	currentRuntimeObjCopy := found.DeepCopyObject()
+
+	// allow users to add new annotations (but not change ours)
+	mergeLabelsAndAnnotations(desired, found)
+
+	desired.Spec.Template.Spec.DeprecatedServiceAccount = found.Spec.Template.Spec.DeprecatedServiceAccount
+	desired.Spec.Template.Spec.SchedulerName = found.Spec.Template.Spec.SchedulerName
+	// spec.selector is immutable
+	desired.Spec.Selector = found.Spec.Selector.DeepCopy()
+	found.Spec = *desired.Spec.DeepCopy()
+
+	if !reflect.DeepEqual(currentRuntimeObjCopy, found) {
+		logJSONDiff(reqLogger, currentRuntimeObjCopy, found)
+		// Current is different from desired, update.
+		reqLogger.Info("Updating single node Deployment", "Deployment.Name", desired.Name)
+		err = r.applyObject(ctx, desired, found)
+		if err != nil {
+			r.recorder.Event(cr, corev1.EventTypeWarning, updateResourceFailed, fmt.Sprintf(updateMessageFailed, desired.Name, err))
+			return reconcile.Result{}, err
+		}
+		r.recorder.Event(cr, corev1.EventTypeNormal, updateResourceSuccess, fmt.Sprintf(updateMessageSucceeded, desired, desired.Name))
+		return reconcile.Result{}, nil
+	}
+
+	// Deployment already exists and matches the desired state - don't requeue
+	r.markUpToDate(found)
+	reqLogger.V(3).Info("Skip reconcile: single node Deployment already exists", "Deployment.Namespace", found.Namespace, "Deployment.Name", found.Name)
+	return reconcile.Result{}, nil
+}
+
+// createSingleNodeDeploymentObject returns a single replica Deployment using the pod template of the passed in DaemonSet
+func createSingleNodeDeploymentObject(ds *appsv1.DaemonSet) *appsv1.Deployment {
+	replicaCount := int32(1)
+	progressDeadline := int32(600)
+	template := ds.Spec.Template.DeepCopy()
+	template.Spec.Tolerations = appendToleration(template.Spec.Tolerations, controlPlaneToleration)
+	if len(template.Spec.NodeSelector) == 0 && template.Spec.Affinity == nil {
+		template.Spec.NodeSelector = map[string]string{
+			controlPlaneNodeLabel: "",
+		}
+	}
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ds.Name,
+			Namespace: ds.Namespace,
+			Labels:    ds.Labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: ds.Spec.Selector.DeepCopy(),
+			Replicas: &replicaCount,
+			// The pod uses the csi socket on the host, make sure the old pod is gone before starting a new one.
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RecreateDeploymentStrategyType,
+			},
+			ProgressDeadlineSeconds: &progressDeadline,
+			RevisionHistoryLimit:    ds.Spec.RevisionHistoryLimit,
+			Template:                *template,
+		},
+	}
+}
+
+func (r *ReconcileHostPathProvisioner) deleteSingleNodeDeployment(ctx context.Context, name, namespace string) error {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+
+	if err := r.client.Delete(ctx, deployment); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+// isSingleNodeDeployment checks if the deployment is one of the provisioner deployments instead of a storage pool deployment.
+func isSingleNodeDeployment(deployment *appsv1.Deployment) bool {
+	return deployment.GetName() == MultiPurposeHostPathProvisionerName || deployment.GetName() == fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)
+}
+
+// isPodOwnedByWorkload checks if the pod belongs to the DaemonSet, or to one of the ReplicaSets of the single node Deployment.
+func isPodOwnedByWorkload(pod *corev1.Pod, owner client.Object) bool {
+	if deployment, ok := owner.(*appsv1.Deployment); ok {
+		controllerRef := metav1.GetControllerOf(pod)
+		return controllerRef != nil && controllerRef.Kind == "ReplicaSet" &&
+			controllerRef.Name == fmt.Sprintf("%s-%s", deployment.GetName(), pod.GetLabels()[appsv1.DefaultDeploymentUniqueLabelKey])
+	}
+	return metav1.IsControlledBy(pod, owner)
+}
+
+func checkDeploymentReady(deployment *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return deployment.Status.ReadyReplicas > 0 && deployment.Status.ReadyReplicas >= replicas
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"context"
+	"fmt"
+
+	ginkgo "github.com/onsi/ginkgo/v2"
+	gomega "github.com/onsi/gomega"
+	conditions "github.com/openshift/custom-resource-status/conditions/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hppv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
+	"kubevirt.io/hostpath-provisioner-operator/version"
+)
+
+var _ = ginkgo.Describe("Controller reconcile loop", func() {
+	ginkgo.Context("single node", func() {
+		ginkgo.BeforeEach(func() {
+			watchNamespaceFunc = func() (string, error) {
+				return testNamespace, nil
+			}
+			version.VersionStringFunc = func() (string, error) {
+				return versionString, nil
+			}
+		})
+
+		ginkgo.DescribeTable("Should switch between daemonsets and deployments", func(cr *hppv1.HostPathProvisioner, names []string) {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			cr, r, cl := createDeployedCr(cr)
+			cr = setSingleNode(cr, true, req, r, cl)
+
+			for _, name := range names {
+				ds := &appsv1.DaemonSet{}
+				err := cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: testNamespace}, ds)
+				gomega.Expect(errors.IsNotFound(err)).To(gomega.BeTrue())
+
+				deployment := &appsv1.Deployment{}
+				err = cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: testNamespace}, deployment)
+				gomega.Expect(err).ToNot(gomega.HaveOccurred())
+				gomega.Expect(metav1.IsControlledBy(deployment, cr)).To(gomega.BeTrue())
+				gomega.Expect(*deployment.Spec.Replicas).To(gomega.Equal(int32(1)))
+				gomega.Expect(deployment.Spec.Strategy.Type).To(gomega.Equal(appsv1.RecreateDeploymentStrategyType))
+				gomega.Expect(deployment.Spec.Template.Spec.NodeSelector).To(gomega.HaveKeyWithValue(controlPlaneNodeLabel, ""))
+				gomega.Expect(deployment.Spec.Template.Spec.Tolerations).To(gomega.ContainElement(corev1.Toleration{
+					Key:      controlPlaneNodeLabel,
+					Operator: corev1.TolerationOpExists,
+					Effect:   corev1.TaintEffectNoSchedule,
+				}))
+			}
+
+			ginkgo.By("Making the deployments ready, the CR should become available")
+			for _, name := range names {
+				deployment := &appsv1.Deployment{}
+				err := cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: testNamespace}, deployment)
+				gomega.Expect(err).ToNot(gomega.HaveOccurred())
+				deployment.Status.Replicas = 1
+				deployment.Status.ReadyReplicas = 1
+				err = cl.Status().Update(context.TODO(), deployment)
+				gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			}
+			_, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(conditions.IsStatusConditionTrue(cr.Status.Conditions, conditions.ConditionAvailable)).To(gomega.BeTrue())
+			gomega.Expect(conditions.IsStatusConditionTrue(cr.Status.Conditions, conditions.ConditionDegraded)).To(gomega.BeFalse())
+
+			ginkgo.By("Disabling single node, the daemonsets should come back")
+			setSingleNode(cr, false, req, r, cl)
+			for _, name := range names {
+				deployment := &appsv1.Deployment{}
+				err := cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: testNamespace}, deployment)
+				gomega.Expect(errors.IsNotFound(err)).To(gomega.BeTrue())
+
+				ds := &appsv1.DaemonSet{}
+				err = cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: testNamespace}, ds)
+				gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			}
+		},
+			ginkgo.Entry("legacyCr", createLegacyCr(), []string{MultiPurposeHostPathProvisionerName, fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)}),
+			ginkgo.Entry("storagePoolCr", createStoragePoolWithTemplateCr(), []string{fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)}),
+		)
+
+		ginkgo.It("Should not pin the deployment to the control plane if node placement is specified", func() {
+			ds := &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      MultiPurposeHostPathProvisionerName,
+					Namespace: testNamespace,
+				},
+				Spec: appsv1.DaemonSetSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: selectorLabels,
+					},
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							NodeSelector: map[string]string{"kubernetes.io/arch": "ppc64le"},
+						},
+					},
+				},
+			}
+			deployment := createSingleNodeDeploymentObject(ds)
+			gomega.Expect(deployment.Spec.Template.Spec.NodeSelector).To(gomega.Equal(map[string]string{"kubernetes.io/arch": "ppc64le"}))
+			gomega.Expect(ds.Spec.Template.Spec.Tolerations).To(gomega.BeEmpty())
+		})
+
+		ginkgo.It("Should create storage pool deployments for the node the single node deployment runs on", func() {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			cr = setSingleNode(cr, true, req, r, cl)
+			addNodesToCluster(1, 2, cl)
+
+			deployment := &appsv1.Deployment{}
+			err := cl.Get(context.TODO(), types.NamespacedName{Name: fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName), Namespace: testNamespace}, deployment)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			deployment.Status.Replicas = 1
+			deployment.Status.ReadyReplicas = 1
+			err = cl.Status().Update(context.TODO(), deployment)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			controller := true
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod1",
+					Namespace: testNamespace,
+					Labels: map[string]string{
+						"k8s-app":                              MultiPurposeHostPathProvisionerName,
+						appsv1.DefaultDeploymentUniqueLabelKey: "abcd",
+					},
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							Kind:       "ReplicaSet",
+							Name:       fmt.Sprintf("%s-abcd", deployment.GetName()),
+							Controller: &controller,
+						},
+					},
+				},
+				Spec: corev1.PodSpec{
+					NodeName: "node1",
+				},
+			}
+			err = cl.Create(context.TODO(), pod)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			gomega.Expect(err.Error()).To(gomega.ContainSubstring("instead of Bound"))
+			verifyDeploymentsAndPVCs(1, 1, cr, r, cl)
+			err = cl.Get(context.TODO(), client.ObjectKeyFromObject(deployment), deployment)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		})
+	})
+})
+
+func setSingleNode(cr *hppv1.HostPathProvisioner, singleNode bool, req reconcile.Request, r *ReconcileHostPathProvisioner, cl client.Client) *hppv1.HostPathProvisioner {
+	err := cl.Get(context.TODO(), req.NamespacedName, cr)
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	cr.Spec.Workload.SingleNode = singleNode
+	err = cl.Update(context.TODO(), cr)
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	_, err = r.Reconcile(context.TODO(), req)
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	err = cl.Get(context.TODO(), req.NamespacedName, cr)
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	return cr
+}
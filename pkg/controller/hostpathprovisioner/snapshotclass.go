@@ -0,0 +1,199 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hostpathprovisionerv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
+	"kubevirt.io/hostpath-provisioner-operator/pkg/util"
+)
+
+const (
+	snapshotClassName              = "hostpath-csi-snapclass"
+	defaultSnapshotClassAnnotation = "snapshot.storage.kubernetes.io/is-default-class"
+	defaultSnapshotDeletionPolicy  = "Delete"
+)
+
+// The snapshot API is not part of kubernetes, the VolumeSnapshotClass is managed as an unstructured object so the
+// operator does not depend on the external-snapshotter client.
+var volumeSnapshotClassGVK = schema.GroupVersionKind{
+	Group:   "snapshot.storage.k8s.io",
+	Version: "v1",
+	Kind:    "VolumeSnapshotClass",
+}
+
+// reconcileSnapshotClass creates the default VolumeSnapshotClass of the CSI driver while the Snapshotting feature gate
+// is enabled, and removes it once the feature gate is disabled.
+func (r *ReconcileHostPathProvisioner) reconcileSnapshotClass(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner) (reconcile.Result, error) {
+	if !r.isFeatureGateEnabled(snapshotFeatureGate, cr) {
+		r.forgetEvent(snapshotClassUnavailable)
+		r.forgetEvent(snapshotClassConflict)
+		return reconcile.Result{}, r.deleteSnapshotClass(ctx, reqLogger, cr)
+	}
+	desired := createSnapshotClassObject(cr)
+	// Set HostPathProvisioner instance as the owner and controller
+	if err := controllerutil.SetControllerReference(cr, desired, r.scheme); err != nil {
+		return reconcile.Result{}, err
+	}
+	setLastAppliedConfiguration(desired)
+
+	// Check if this VolumeSnapshotClass already exists
+	found := newSnapshotClassObject()
+	err := r.client.Get(ctx, client.ObjectKeyFromObject(desired), found)
+	if meta.IsNoMatchError(err) {
+		// Without the snapshot CRDs no snapshots can be taken either, there is nothing to fail.
+		reqLogger.V(3).Info("VolumeSnapshotClass CRD is not installed, skipping", "VolumeSnapshotClass.Name", snapshotClassName)
+		r.recordEventOnce(cr, corev1.EventTypeWarning, snapshotClassUnavailable, snapshotClassUnavailableMessage)
+		return reconcile.Result{}, nil
+	}
+	r.forgetEvent(snapshotClassUnavailable)
+	if err != nil && errors.IsNotFound(err) {
+		r.forgetEvent(snapshotClassConflict)
+		reqLogger.Info("Creating a new VolumeSnapshotClass", "VolumeSnapshotClass.Name", desired.GetName())
+		if err := r.applyObject(ctx, desired, nil); err != nil {
+			r.recorder.Event(cr, corev1.EventTypeWarning, createResourceFailed, fmt.Sprintf(createMessageFailed, desired.GetName(), err))
+			return reconcile.Result{}, err
+		}
+		r.recorder.Event(cr, corev1.EventTypeNormal, createResourceSuccess, fmt.Sprintf(createMessageSucceeded, desired, desired.GetName()))
+		return reconcile.Result{}, nil
+	} else if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !isManagedSnapshotClass(found) {
+		// Never take over a VolumeSnapshotClass the user created.
+		reqLogger.V(3).Info("Skipping VolumeSnapshotClass not owned by the HostPathProvisioner", "VolumeSnapshotClass.Name", found.GetName())
+		r.recordEventOnce(cr, corev1.EventTypeWarning, snapshotClassConflict, fmt.Sprintf(snapshotClassConflictMessage, found.GetName()))
+		return reconcile.Result{}, nil
+	}
+	r.forgetEvent(snapshotClassConflict)
+	if err := r.repairControllerReference(ctx, reqLogger, cr, found); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if found.Object["driver"] != desired.Object["driver"] {
+		// The driver is immutable, recreate the VolumeSnapshotClass once the driver name changes.
+		reqLogger.Info("Recreating VolumeSnapshotClass to change the driver", "VolumeSnapshotClass.Name", found.GetName())
+		if err := r.client.Delete(ctx, found); err != nil && !errors.IsNotFound(err) {
+			r.recorder.Event(cr, corev1.EventTypeWarning, deleteResourceFailed, fmt.Sprintf(deleteMessageFailed, found.GetName(), err))
+			return reconcile.Result{}, err
+		}
+		if err := r.applyObject(ctx, desired, nil); err != nil {
+			r.recorder.Event(cr, corev1.EventTypeWarning, createResourceFailed, fmt.Sprintf(createMessageFailed, desired.GetName(), err))
+			return reconcile.Result{}, err
+		}
+		r.recorder.Event(cr, corev1.EventTypeNormal, createResourceSuccess, fmt.Sprintf(createMessageSucceeded, desired, desired.GetName()))
+		return reconcile.Result{}, nil
+	}
+
+	if r.isUpToDate(desired, found) {
+		reqLogger.V(3).Info("Skip reconcile: VolumeSnapshotClass is up to date", "VolumeSnapshotClass.Name", found.GetName())
+		return reconcile.Result{}, nil
+	}
+
+	// Keep a copy of the original for comparison later.
+	currentRuntimeObjCopy := found.DeepCopy()
+
+	// allow users to add new annotations (but not change ours)
+	mergeLabelsAndAnnotations(desired, found)
+	// The driver is immutable, only the deletion policy can change.
+	found.Object["deletionPolicy"] = desired.Object["deletionPolicy"]
+
+	if !reflect.DeepEqual(currentRuntimeObjCopy, found) {
+		logJSONDiff(reqLogger, currentRuntimeObjCopy, found)
+		// Current is different from desired, update.
+		reqLogger.Info("Updating VolumeSnapshotClass", "VolumeSnapshotClass.Name", desired.GetName())
+		if err := r.applyObject(ctx, desired, found); err != nil {
+			r.recorder.Event(cr, corev1.EventTypeWarning, updateResourceFailed, fmt.Sprintf(updateMessageFailed, desired.GetName(), err))
+			return reconcile.Result{}, err
+		}
+		r.recorder.Event(cr, corev1.EventTypeNormal, updateResourceSuccess, fmt.Sprintf(updateMessageSucceeded, desired, desired.GetName()))
+		return reconcile.Result{}, nil
+	}
+
+	// VolumeSnapshotClass already exists and matches the desired state - don't requeue
+	r.markUpToDate(found)
+	reqLogger.V(3).Info("Skip reconcile: VolumeSnapshotClass already exists", "VolumeSnapshotClass.Name", found.GetName())
+	return reconcile.Result{}, nil
+}
+
+// deleteSnapshotClass removes the VolumeSnapshotClass of the CSI driver, a VolumeSnapshotClass with the same name that
+// the operator did not create is left alone.
+func (r *ReconcileHostPathProvisioner) deleteSnapshotClass(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner) error {
+	found := newSnapshotClassObject()
+	err := r.client.Get(ctx, types.NamespacedName{Name: snapshotClassName}, found)
+	if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if !isManagedSnapshotClass(found) {
+		return nil
+	}
+	reqLogger.Info("Deleting VolumeSnapshotClass", "VolumeSnapshotClass.Name", found.GetName())
+	if err := r.client.Delete(ctx, found); err != nil && !errors.IsNotFound(err) {
+		r.recorder.Event(cr, corev1.EventTypeWarning, deleteResourceFailed, fmt.Sprintf(deleteMessageFailed, found.GetName(), err))
+		return err
+	}
+	r.recorder.Event(cr, corev1.EventTypeNormal, deleteResourceSuccess, fmt.Sprintf(deleteMessageSucceeded, found, found.GetName()))
+	return nil
+}
+
+// isManagedSnapshotClass returns true if the operator created the VolumeSnapshotClass. Like the CSIDriver it has no
+// owner reference, it is removed explicitly when the HostPathProvisioner is deleted.
+func isManagedSnapshotClass(snapshotClass *unstructured.Unstructured) bool {
+	return snapshotClass.GetLabels()[util.AppKubernetesManagedByLabel] == util.GetRecommendedLabels()[util.AppKubernetesManagedByLabel]
+}
+
+func getSnapshotDeletionPolicy(cr *hostpathprovisionerv1.HostPathProvisioner) string {
+	if cr.Spec.CSIDriver != nil && cr.Spec.CSIDriver.SnapshotClass != nil && cr.Spec.CSIDriver.SnapshotClass.DeletionPolicy != "" {
+		return cr.Spec.CSIDriver.SnapshotClass.DeletionPolicy
+	}
+	return defaultSnapshotDeletionPolicy
+}
+
+func newSnapshotClassObject() *unstructured.Unstructured {
+	snapshotClass := &unstructured.Unstructured{}
+	snapshotClass.SetGroupVersionKind(volumeSnapshotClassGVK)
+	return snapshotClass
+}
+
+func createSnapshotClassObject(cr *hostpathprovisionerv1.HostPathProvisioner) *unstructured.Unstructured {
+	snapshotClass := newSnapshotClassObject()
+	snapshotClass.SetName(snapshotClassName)
+	snapshotClass.SetLabels(util.GetRecommendedLabels())
+	snapshotClass.SetAnnotations(map[string]string{
+		defaultSnapshotClassAnnotation: "true",
+	})
+	snapshotClass.Object["driver"] = getDriverName(cr)
+	snapshotClass.Object["deletionPolicy"] = getSnapshotDeletionPolicy(cr)
+	return snapshotClass
+}
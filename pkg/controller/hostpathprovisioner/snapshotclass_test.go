@@ -0,0 +1,243 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"context"
+	"fmt"
+
+	ginkgo "github.com/onsi/ginkgo/v2"
+	gomega "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hppv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
+	"kubevirt.io/hostpath-provisioner-operator/version"
+)
+
+var _ = ginkgo.Describe("Controller reconcile loop", func() {
+	ginkgo.Context("snapshotclass", func() {
+		var (
+			req = reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			snapshotClassNN = types.NamespacedName{
+				Name: snapshotClassName,
+			}
+		)
+
+		ginkgo.BeforeEach(func() {
+			watchNamespaceFunc = func() (string, error) {
+				return testNamespace, nil
+			}
+			version.VersionStringFunc = func() (string, error) {
+				return versionString, nil
+			}
+		})
+
+		setSnapshotting := func(cl client.Client, cr *hppv1.HostPathProvisioner, enabled bool) {
+			err := cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.FeatureGates = nil
+			if enabled {
+				cr.Spec.FeatureGates = []string{snapshotFeatureGate}
+			}
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		}
+
+		drainEvents := func(r *ReconcileHostPathProvisioner) []string {
+			recorder, ok := r.recorder.(*record.FakeRecorder)
+			gomega.Expect(ok).To(gomega.BeTrue())
+			events := make([]string, 0)
+			for len(recorder.Events) > 0 {
+				events = append(events, <-recorder.Events)
+			}
+			return events
+		}
+
+		ginkgo.It("Should create the VolumeSnapshotClass when snapshotting is enabled, and remove it when disabled", func() {
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			snapshotClass := newSnapshotClassObject()
+			err := cl.Get(context.TODO(), snapshotClassNN, snapshotClass)
+			gomega.Expect(errors.IsNotFound(err)).To(gomega.BeTrue())
+
+			ginkgo.By("Enabling the Snapshotting feature gate")
+			setSnapshotting(cl, cr, true)
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), snapshotClassNN, snapshotClass)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(snapshotClass.Object["driver"]).To(gomega.Equal(driverName))
+			gomega.Expect(snapshotClass.Object["deletionPolicy"]).To(gomega.Equal(defaultSnapshotDeletionPolicy))
+			gomega.Expect(snapshotClass.GetAnnotations()).To(gomega.HaveKeyWithValue(defaultSnapshotClassAnnotation, "true"))
+			gomega.Expect(isManagedSnapshotClass(snapshotClass)).To(gomega.BeTrue())
+
+			ginkgo.By("Disabling the Snapshotting feature gate")
+			setSnapshotting(cl, cr, false)
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), snapshotClassNN, newSnapshotClassObject())
+			gomega.Expect(errors.IsNotFound(err)).To(gomega.BeTrue())
+		})
+
+		ginkgo.It("Should update the deletion policy of the VolumeSnapshotClass", func() {
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			setSnapshotting(cl, cr, true)
+			_, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.CSIDriver = &hppv1.CSIDriverConfig{
+				SnapshotClass: &hppv1.SnapshotClassConfig{
+					DeletionPolicy: "Retain",
+				},
+			}
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			snapshotClass := newSnapshotClassObject()
+			err = cl.Get(context.TODO(), snapshotClassNN, snapshotClass)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(snapshotClass.Object["deletionPolicy"]).To(gomega.Equal("Retain"))
+		})
+
+		ginkgo.It("Should recreate the VolumeSnapshotClass when the driver name changes", func() {
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			setSnapshotting(cl, cr, true)
+			_, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.CSIDriver = &hppv1.CSIDriverConfig{
+				DriverName: "hostpath.csi.example.com",
+			}
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			snapshotClass := newSnapshotClassObject()
+			err = cl.Get(context.TODO(), snapshotClassNN, snapshotClass)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(snapshotClass.Object["driver"]).To(gomega.Equal("hostpath.csi.example.com"))
+		})
+
+		ginkgo.It("Should not take over or remove a VolumeSnapshotClass it does not own", func() {
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			snapshotClass := newSnapshotClassObject()
+			snapshotClass.SetName(snapshotClassName)
+			snapshotClass.Object["driver"] = driverName
+			snapshotClass.Object["deletionPolicy"] = "Retain"
+			err := cl.Create(context.TODO(), snapshotClass)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			setSnapshotting(cl, cr, true)
+			drainEvents(r)
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), snapshotClassNN, snapshotClass)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(snapshotClass.Object["deletionPolicy"]).To(gomega.Equal("Retain"))
+			gomega.Expect(isManagedSnapshotClass(snapshotClass)).To(gomega.BeFalse())
+			conflict := fmt.Sprintf("%s %s %s", corev1.EventTypeWarning, snapshotClassConflict, fmt.Sprintf(snapshotClassConflictMessage, snapshotClassName))
+			gomega.Expect(drainEvents(r)).To(gomega.ContainElement(conflict))
+
+			ginkgo.By("Not warning again while the conflict lasts")
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(drainEvents(r)).ToNot(gomega.ContainElement(conflict))
+
+			setSnapshotting(cl, cr, false)
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), snapshotClassNN, snapshotClass)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("Should remove the VolumeSnapshotClass when the CR is deleted", func() {
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			setSnapshotting(cl, cr, true)
+			_, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), snapshotClassNN, newSnapshotClassObject())
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Delete(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), snapshotClassNN, newSnapshotClassObject())
+			gomega.Expect(errors.IsNotFound(err)).To(gomega.BeTrue())
+		})
+
+		ginkgo.It("Should not fail if the VolumeSnapshotClass CRD is not installed", func() {
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			setSnapshotting(cl, cr, true)
+			r.client = noMatchSnapshotClassFakeCtrlRuntimeClient{
+				Client: cl,
+			}
+			drainEvents(r)
+			_, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(IsCrHealthy(cr)).To(gomega.BeTrue())
+			unavailable := fmt.Sprintf("%s %s %s", corev1.EventTypeWarning, snapshotClassUnavailable, snapshotClassUnavailableMessage)
+			gomega.Expect(drainEvents(r)).To(gomega.ContainElement(unavailable))
+
+			ginkgo.By("Not warning again while the CRD is missing")
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(drainEvents(r)).ToNot(gomega.ContainElement(unavailable))
+
+			ginkgo.By("Warning again once the CRD is gone again after it was installed")
+			r.client = cl
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			r.client = noMatchSnapshotClassFakeCtrlRuntimeClient{
+				Client: cl,
+			}
+			drainEvents(r)
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(drainEvents(r)).To(gomega.ContainElement(unavailable))
+		})
+	})
+})
+
+// noMatchSnapshotClassFakeCtrlRuntimeClient mimics a cluster without the snapshot CRDs.
+type noMatchSnapshotClassFakeCtrlRuntimeClient struct {
+	client.Client
+}
+
+func (p noMatchSnapshotClassFakeCtrlRuntimeClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if u, ok := obj.(*unstructured.Unstructured); ok && u.GroupVersionKind() == volumeSnapshotClassGVK {
+		return &meta.NoKindMatchError{
+			GroupKind:        volumeSnapshotClassGVK.GroupKind(),
+			SearchedVersions: []string{volumeSnapshotClassGVK.Version},
+		}
+	}
+	return p.Client.Get(ctx, key, obj, opts...)
+}
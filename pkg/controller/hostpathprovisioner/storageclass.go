@@ -0,0 +1,196 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hostpathprovisionerv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
+	"kubevirt.io/hostpath-provisioner-operator/pkg/util"
+)
+
+const (
+	storagePoolParameterName = "storagePool"
+)
+
+// reconcileStorageClasses creates a StorageClass for each storage pool that has createStorageClass set, and removes
+// the StorageClasses of storage pools that no longer exist or no longer want one.
+func (r *ReconcileHostPathProvisioner) reconcileStorageClasses(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner) (reconcile.Result, error) {
+	desiredNames := make(map[string]bool)
+	for _, storagePool := range cr.Spec.StoragePools {
+		if !storagePool.CreateStorageClass {
+			continue
+		}
+		desiredNames[storagePool.Name] = true
+		if err := r.reconcileStorageClass(ctx, reqLogger, cr, createStorageClassObject(storagePool.Name, getDriverName(cr), r.isFeatureGateEnabled(volumeExpansionFeatureGate, cr))); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	currentStorageClasses, err := r.currentStorageClasses(ctx, cr)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	for _, sc := range currentStorageClasses {
+		if desiredNames[sc.GetName()] {
+			continue
+		}
+		reqLogger.Info("Deleting unused StorageClass", "StorageClass.Name", sc.GetName())
+		if err := r.client.Delete(ctx, &sc); err != nil && !errors.IsNotFound(err) {
+			r.recorder.Event(cr, corev1.EventTypeWarning, deleteResourceFailed, fmt.Sprintf(deleteMessageFailed, sc.GetName(), err))
+			return reconcile.Result{}, err
+		}
+		r.recorder.Event(cr, corev1.EventTypeNormal, deleteResourceSuccess, fmt.Sprintf(deleteMessageSucceeded, &sc, sc.GetName()))
+	}
+	return reconcile.Result{}, nil
+}
+
+func (r *ReconcileHostPathProvisioner) reconcileStorageClass(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, desired *storagev1.StorageClass) error {
+	setLastAppliedConfiguration(desired)
+
+	// Set HostPathProvisioner instance as the owner and controller
+	if err := controllerutil.SetControllerReference(cr, desired, r.scheme); err != nil {
+		return err
+	}
+
+	// Check if this StorageClass already exists
+	found := &storagev1.StorageClass{}
+	err := r.client.Get(ctx, client.ObjectKeyFromObject(desired), found)
+	if err != nil && errors.IsNotFound(err) {
+		return r.createStorageClass(ctx, reqLogger, cr, desired)
+	} else if err != nil {
+		return err
+	}
+
+	if err := r.repairControllerReference(ctx, reqLogger, cr, found); err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(found, cr) {
+		// Never take over a StorageClass the user created.
+		reqLogger.Info("Skipping StorageClass not owned by the HostPathProvisioner", "StorageClass.Name", found.Name)
+		r.recorder.Event(cr, corev1.EventTypeWarning, storageClassConflict, fmt.Sprintf(storageClassConflictMessage, found.Name))
+		return nil
+	}
+
+	if !hasSameImmutableStorageClassFields(desired, found) {
+		// The provisioner, parameters, reclaim policy and binding mode cannot be updated, recreate the StorageClass.
+		reqLogger.Info("Recreating StorageClass", "StorageClass.Name", found.Name)
+		if err := r.client.Delete(ctx, found); err != nil && !errors.IsNotFound(err) {
+			r.recorder.Event(cr, corev1.EventTypeWarning, deleteResourceFailed, fmt.Sprintf(deleteMessageFailed, found.Name, err))
+			return err
+		}
+		return r.createStorageClass(ctx, reqLogger, cr, desired)
+	}
+
+	if r.isUpToDate(desired, found) {
+		reqLogger.V(3).Info("Skip reconcile: StorageClass is up to date", "StorageClass.Name", found.Name)
+		return nil
+	}
+
+	// Keep a copy of the original for comparison later.
+	currentRuntimeObjCopy := found.DeepCopyObject()
+
+	// allow users to add new annotations (but not change ours)
+	mergeLabelsAndAnnotations(desired, found)
+	found.AllowVolumeExpansion = desired.AllowVolumeExpansion
+	found.OwnerReferences = desired.OwnerReferences
+
+	if !reflect.DeepEqual(currentRuntimeObjCopy, found) {
+		logJSONDiff(reqLogger, currentRuntimeObjCopy, found)
+		// Current is different from desired, update.
+		reqLogger.Info("Updating StorageClass", "StorageClass.Name", desired.Name)
+		if err := r.applyObject(ctx, desired, found); err != nil {
+			r.recorder.Event(cr, corev1.EventTypeWarning, updateResourceFailed, fmt.Sprintf(updateMessageFailed, desired.Name, err))
+			return err
+		}
+		r.recorder.Event(cr, corev1.EventTypeNormal, updateResourceSuccess, fmt.Sprintf(updateMessageSucceeded, desired, desired.Name))
+		return nil
+	}
+
+	// StorageClass already exists and matches the desired state - don't requeue
+	r.markUpToDate(found)
+	reqLogger.V(3).Info("Skip reconcile: StorageClass already exists", "StorageClass.Name", found.Name)
+	return nil
+}
+
+func (r *ReconcileHostPathProvisioner) createStorageClass(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, desired *storagev1.StorageClass) error {
+	reqLogger.Info("Creating a new StorageClass", "StorageClass.Name", desired.Name)
+	if err := r.applyObject(ctx, desired, nil); err != nil {
+		r.recorder.Event(cr, corev1.EventTypeWarning, createResourceFailed, fmt.Sprintf(createMessageFailed, desired.Name, err))
+		return err
+	}
+	r.recorder.Event(cr, corev1.EventTypeNormal, createResourceSuccess, fmt.Sprintf(createMessageSucceeded, desired, desired.Name))
+	return nil
+}
+
+// currentStorageClasses returns the storage pool StorageClasses controlled by the passed in CR.
+func (r *ReconcileHostPathProvisioner) currentStorageClasses(ctx context.Context, cr *hostpathprovisionerv1.HostPathProvisioner) ([]storagev1.StorageClass, error) {
+	storageClassList := &storagev1.StorageClassList{}
+	if err := r.client.List(ctx, storageClassList, client.HasLabels{storagePoolLabelKey}); err != nil {
+		return nil, err
+	}
+	res := make([]storagev1.StorageClass, 0)
+	for _, sc := range storageClassList.Items {
+		if metav1.IsControlledBy(&sc, cr) {
+			res = append(res, sc)
+		}
+	}
+	return res, nil
+}
+
+func hasSameImmutableStorageClassFields(desired, current *storagev1.StorageClass) bool {
+	return desired.Provisioner == current.Provisioner &&
+		reflect.DeepEqual(desired.Parameters, current.Parameters) &&
+		reflect.DeepEqual(desired.ReclaimPolicy, current.ReclaimPolicy) &&
+		reflect.DeepEqual(desired.VolumeBindingMode, current.VolumeBindingMode)
+}
+
+func createStorageClassObject(storagePoolName, provisioner string, allowVolumeExpansion bool) *storagev1.StorageClass {
+	labels := util.GetRecommendedLabels()
+	labels[storagePoolLabelKey] = storagePoolName
+	reclaimPolicy := corev1.PersistentVolumeReclaimDelete
+	volumeBindingMode := storagev1.VolumeBindingWaitForFirstConsumer
+
+	return &storagev1.StorageClass{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "storage.k8s.io/v1",
+			Kind:       "StorageClass",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   storagePoolName,
+			Labels: labels,
+		},
+		Provisioner: provisioner,
+		Parameters: map[string]string{
+			storagePoolParameterName: storagePoolName,
+		},
+		ReclaimPolicy:        &reclaimPolicy,
+		VolumeBindingMode:    &volumeBindingMode,
+		AllowVolumeExpansion: &allowVolumeExpansion,
+	}
+}
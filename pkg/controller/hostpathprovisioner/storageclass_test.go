@@ -0,0 +1,195 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"context"
+
+	ginkgo "github.com/onsi/ginkgo/v2"
+	gomega "github.com/onsi/gomega"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hppv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
+	"kubevirt.io/hostpath-provisioner-operator/version"
+)
+
+var _ = ginkgo.Describe("Controller reconcile loop", func() {
+	ginkgo.Context("storageclass", func() {
+		var (
+			req = reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			scNN = types.NamespacedName{
+				Name: "local",
+			}
+		)
+
+		ginkgo.BeforeEach(func() {
+			watchNamespaceFunc = func() (string, error) {
+				return testNamespace, nil
+			}
+			version.VersionStringFunc = func() (string, error) {
+				return versionString, nil
+			}
+		})
+
+		ginkgo.It("Should not create a StorageClass if not requested", func() {
+			_, _, cl := createDeployedCr(createStorageClassCr(false))
+			sc := &storagev1.StorageClass{}
+			err := cl.Get(context.TODO(), scNN, sc)
+			gomega.Expect(errors.IsNotFound(err)).To(gomega.BeTrue())
+		})
+
+		ginkgo.It("Should create a StorageClass for the storage pool", func() {
+			cr, _, cl := createDeployedCr(createStorageClassCr(true))
+			sc := &storagev1.StorageClass{}
+			err := cl.Get(context.TODO(), scNN, sc)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(sc.Provisioner).To(gomega.Equal(driverName))
+			gomega.Expect(sc.Parameters).To(gomega.Equal(map[string]string{storagePoolParameterName: "local"}))
+			gomega.Expect(*sc.VolumeBindingMode).To(gomega.Equal(storagev1.VolumeBindingWaitForFirstConsumer))
+			gomega.Expect(*sc.AllowVolumeExpansion).To(gomega.BeFalse())
+			gomega.Expect(sc.GetLabels()).To(gomega.HaveKeyWithValue(storagePoolLabelKey, "local"))
+			gomega.Expect(metav1.IsControlledBy(sc, cr)).To(gomega.BeTrue())
+		})
+
+		ginkgo.It("Should allow volume expansion if the VolumeExpansion feature gate is enabled", func() {
+			cr, r, cl := createDeployedCr(createStorageClassCr(true))
+			err := cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.FeatureGates = []string{volumeExpansionFeatureGate}
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			sc := &storagev1.StorageClass{}
+			err = cl.Get(context.TODO(), scNN, sc)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(*sc.AllowVolumeExpansion).To(gomega.BeTrue())
+		})
+
+		ginkgo.It("Should fix a changed StorageClass", func() {
+			cr, r, cl := createDeployedCr(createStorageClassCr(true))
+			sc := &storagev1.StorageClass{}
+			err := cl.Get(context.TODO(), scNN, sc)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			allowVolumeExpansion := true
+			sc.AllowVolumeExpansion = &allowVolumeExpansion
+			err = cl.Update(context.TODO(), sc)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), scNN, sc)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(*sc.AllowVolumeExpansion).To(gomega.BeFalse())
+
+			ginkgo.By("Changing an immutable field, the StorageClass should be recreated")
+			sc.Parameters[storagePoolParameterName] = "other"
+			err = cl.Update(context.TODO(), sc)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), scNN, sc)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(sc.Parameters).To(gomega.HaveKeyWithValue(storagePoolParameterName, "local"))
+			gomega.Expect(metav1.IsControlledBy(sc, cr)).To(gomega.BeTrue())
+		})
+
+		ginkgo.It("Should remove the StorageClass when no longer requested", func() {
+			cr, r, cl := createDeployedCr(createStorageClassCr(true))
+			sc := &storagev1.StorageClass{}
+			err := cl.Get(context.TODO(), scNN, sc)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.StoragePools[0].CreateStorageClass = false
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), scNN, sc)
+			gomega.Expect(errors.IsNotFound(err)).To(gomega.BeTrue())
+		})
+
+		ginkgo.It("Should remove the StorageClass when the storage pool is removed", func() {
+			cr := createStorageClassCr(true)
+			cr.Spec.StoragePools = append(cr.Spec.StoragePools, hppv1.StoragePool{
+				Name:               "other",
+				Path:               "/tmp/other",
+				CreateStorageClass: true,
+			})
+			cr, r, cl := createDeployedCr(cr)
+			otherNN := types.NamespacedName{Name: "other"}
+			sc := &storagev1.StorageClass{}
+			err := cl.Get(context.TODO(), otherNN, sc)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.StoragePools = cr.Spec.StoragePools[:1]
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), otherNN, sc)
+			gomega.Expect(errors.IsNotFound(err)).To(gomega.BeTrue())
+			err = cl.Get(context.TODO(), scNN, sc)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("Should not take over a StorageClass it doesn't own", func() {
+			cr, r, cl := createDeployedCr(createStorageClassCr(false))
+			userSc := &storagev1.StorageClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "local",
+				},
+				Provisioner: "kubernetes.io/no-provisioner",
+			}
+			err := cl.Create(context.TODO(), userSc)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.StoragePools[0].CreateStorageClass = true
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			sc := &storagev1.StorageClass{}
+			err = cl.Get(context.TODO(), client.ObjectKeyFromObject(userSc), sc)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(sc.Provisioner).To(gomega.Equal("kubernetes.io/no-provisioner"))
+			gomega.Expect(metav1.GetControllerOf(sc)).To(gomega.BeNil())
+		})
+	})
+})
+
+// createStorageClassCr returns a cluster scoped CR, StorageClasses cannot be owned by a namespaced object.
+func createStorageClassCr(createStorageClass bool) *hppv1.HostPathProvisioner {
+	cr := createStoragePoolWithTemplateCr()
+	cr.SetNamespace("")
+	cr.Spec.StoragePools[0].CreateStorageClass = createStorageClass
+	return cr
+}
@@ -33,12 +33,14 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	hostpathprovisionerv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
+	"kubevirt.io/hostpath-provisioner-operator/pkg/monitoring/metrics"
 	"kubevirt.io/hostpath-provisioner-operator/pkg/util"
 )
 
@@ -50,32 +52,56 @@ const (
 	defaultStorageClassName = "default"
 	hppPoolPrefix           = "hpp-pool"
 	maxNameLength           = 63
+	// storagePoolAnnotationKey is the annotation the CSI driver sets on the PVs it provisions, if enabled for the storage pool.
+	storagePoolAnnotationKey = "hostpathprovisioner.kubevirt.io/pool"
+	// defaultCleanupJobBackoffLimit and defaultCleanupJobTTLSecondsAfterFinished are used if the workload doesn't configure the cleanup jobs.
+	defaultCleanupJobBackoffLimit            = int32(6)
+	defaultCleanupJobTTLSecondsAfterFinished = int32(300)
+	// jobReasonDeadlineExceeded is the reason of the Failed condition of a job that ran longer than its active deadline.
+	jobReasonDeadlineExceeded = "DeadlineExceeded"
 )
 
-// StoragePoolInfo contains the name and path of a hostpath storage pool.
+// findOverlappingStoragePools returns the first two storage pools with the same path, or with the path of one under the
+// path of the other. Their volume directories would end up in the same host directory, and the cleanup of one storage
+// pool would remove the volumes of the other.
+func findOverlappingStoragePools(cr *hostpathprovisionerv1.HostPathProvisioner) (hostpathprovisionerv1.StoragePool, hostpathprovisionerv1.StoragePool, bool) {
+	for i, storagePool := range cr.Spec.StoragePools {
+		for _, other := range cr.Spec.StoragePools[:i] {
+			if util.PathsOverlap(other.Path, storagePool.Path) {
+				return other, storagePool, true
+			}
+		}
+	}
+	return hostpathprovisionerv1.StoragePool{}, hostpathprovisionerv1.StoragePool{}, false
+}
+
+// StoragePoolInfo contains the name and path of a hostpath storage pool, and the annotations the CSI driver
+// adds to the PVs provisioned from it.
 type StoragePoolInfo struct {
-	Name string `json:"name"`
-	Path string `json:"path"`
+	Name              string            `json:"name"`
+	Path              string            `json:"path"`
+	VolumeAnnotations map[string]string `json:"volumeAnnotations,omitempty"`
 }
 
-func (r *ReconcileHostPathProvisioner) reconcileStoragePools(logger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) (reconcile.Result, error) {
-	usedNodes, err := r.getNodesByDaemonSet(logger, namespace)
+func (r *ReconcileHostPathProvisioner) reconcileStoragePools(ctx context.Context, logger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) (reconcile.Result, error) {
+	usedNodes, err := r.getNodesByWorkload(ctx, logger, cr, namespace)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 	logger.V(3).Info("Checking if storage pools are configured", "current nodes number of used nodes", len(usedNodes))
-	currentStoragePoolDeployments, err := r.currentStoragePoolDeployments(cr, namespace)
+	currentStoragePoolDeployments, err := r.currentStoragePoolDeployments(ctx, cr)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 	for _, storagePool := range cr.Spec.StoragePools {
 		logger.V(3).Info("Checking storage pool", "pool.Name", storagePool.Name)
 		if storagePool.PVCTemplate != nil {
+			poolNamespace := getStoragePoolNamespace(&storagePool, namespace)
 			for _, node := range usedNodes {
-				if err := r.reconcileStoragePoolPVCByNode(logger, cr, namespace, &storagePool, &node); err != nil {
+				if err := r.reconcileStoragePoolPVCByNode(ctx, logger, cr, poolNamespace, &storagePool, &node); err != nil {
 					return reconcile.Result{}, err
 				}
-				if err := r.reconcileStoragePoolDeploymentByNode(logger, cr, namespace, &storagePool, &node, currentStoragePoolDeployments); err != nil {
+				if err := r.reconcileStoragePoolDeploymentByNode(ctx, logger, cr, poolNamespace, &storagePool, &node, currentStoragePoolDeployments); err != nil {
 					return reconcile.Result{}, err
 				}
 			}
@@ -83,17 +109,20 @@ func (r *ReconcileHostPathProvisioner) reconcileStoragePools(logger logr.Logger,
 	}
 	// Clean up any deployments that are no longer used.
 	for _, ds := range currentStoragePoolDeployments {
-		logger.V(3).Info("Deleting unused deployment", "deployment name", ds.GetName())
-		if err := r.client.Delete(context.TODO(), &ds); err != nil && !errors.IsNotFound(err) {
+		logger.V(3).Info("Deleting unused deployment", "deployment namespace", ds.GetNamespace(), "deployment name", ds.GetName())
+		if err := r.client.Delete(ctx, &ds); err != nil && !errors.IsNotFound(err) {
 			return reconcile.Result{}, err
 		}
 		sp := r.getStoragePoolForDeployment(cr, &ds)
 		if sp != nil {
-			if _, err := r.createCleanupJobForDeployment(logger, cr, namespace, &ds, sp); err != nil {
+			if _, err := r.createCleanupJobForDeployment(ctx, logger, cr, ds.GetNamespace(), &ds, sp); err != nil {
 				return reconcile.Result{}, err
 			}
 		}
 	}
+	if err := r.removeOrphanedCleanUpJobs(ctx, logger, cr); err != nil {
+		return reconcile.Result{}, err
+	}
 	return reconcile.Result{}, nil
 }
 
@@ -106,27 +135,27 @@ func (r *ReconcileHostPathProvisioner) getStoragePoolForDeployment(cr *hostpathp
 	return nil
 }
 
-func (r *ReconcileHostPathProvisioner) cleanDeployments(logger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) error {
+func (r *ReconcileHostPathProvisioner) cleanDeployments(ctx context.Context, logger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner) error {
 	logger.V(3).Info("Cleaning up storage pools")
 	for _, storagePool := range cr.Spec.StoragePools {
 		if storagePool.PVCTemplate != nil {
-			currentStoragePoolDeployments, err := r.currentStoragePoolDeployments(cr, namespace)
+			currentStoragePoolDeployments, err := r.currentStoragePoolDeployments(ctx, cr)
 			if err != nil {
 				return err
 			}
 			for _, deployment := range currentStoragePoolDeployments {
-				node, err := r.createCleanupJobForDeployment(logger, cr, namespace, &deployment, &storagePool)
+				node, err := r.createCleanupJobForDeployment(ctx, logger, cr, deployment.GetNamespace(), &deployment, &storagePool)
 				if err != nil {
 					return err
 				}
-				desired := r.storagePoolDeploymentByNode(logger, cr, &storagePool, namespace, node)
+				desired := r.storagePoolDeploymentByNode(logger, cr, &storagePool, deployment.GetNamespace(), node)
 
 				// delete deployment
 				found := &appsv1.Deployment{}
-				if err := r.client.Get(context.TODO(), client.ObjectKeyFromObject(desired), found); err != nil && !errors.IsNotFound(err) {
+				if err := r.client.Get(ctx, client.ObjectKeyFromObject(desired), found); err != nil && !errors.IsNotFound(err) {
 					return err
 				} else if err == nil {
-					if err := r.client.Delete(context.TODO(), found); err != nil && !errors.IsNotFound(err) {
+					if err := r.client.Delete(ctx, found); err != nil && !errors.IsNotFound(err) {
 						return err
 					}
 				}
@@ -136,33 +165,33 @@ func (r *ReconcileHostPathProvisioner) cleanDeployments(logger logr.Logger, cr *
 	return nil
 }
 
-func (r *ReconcileHostPathProvisioner) createCleanupJobForDeployment(logger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string, deployment *appsv1.Deployment, storagePool *hostpathprovisionerv1.StoragePool) (*corev1.Node, error) {
+func (r *ReconcileHostPathProvisioner) createCleanupJobForDeployment(ctx context.Context, logger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string, deployment *appsv1.Deployment, storagePool *hostpathprovisionerv1.StoragePool) (*corev1.Node, error) {
 	node := &corev1.Node{
 		ObjectMeta: v1.ObjectMeta{
 			Name: deployment.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0].Values[0],
 		},
 	}
-	if err := r.client.Get(context.TODO(), client.ObjectKeyFromObject(node), node); err != nil {
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(node), node); err != nil {
 		if errors.IsNotFound(err) {
 			return nil, nil
 		}
 		return nil, err
 	}
 	logger.V(3).Info("for node", "name", node.Name)
-	if err := r.createCleanupJobForNode(logger, cr, namespace, storagePool, node); err != nil && !errors.IsAlreadyExists(err) {
+	if err := r.createCleanupJobForNode(ctx, logger, cr, namespace, storagePool, node); err != nil && !errors.IsAlreadyExists(err) {
 		return nil, err
 	}
 	return node, nil
 }
 
-func (r *ReconcileHostPathProvisioner) reconcileStoragePoolPVCByNode(logger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string, storagePool *hostpathprovisionerv1.StoragePool, node *corev1.Node) error {
+func (r *ReconcileHostPathProvisioner) reconcileStoragePoolPVCByNode(ctx context.Context, logger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string, storagePool *hostpathprovisionerv1.StoragePool, node *corev1.Node) error {
 	desired := r.storagePoolPVCByNode(storagePool, namespace, node)
 	// Check if this PersistentVolumeClaim already exists
 	found := &corev1.PersistentVolumeClaim{}
-	err := r.client.Get(context.TODO(), client.ObjectKeyFromObject(desired), found)
+	err := r.client.Get(ctx, client.ObjectKeyFromObject(desired), found)
 	if err != nil && errors.IsNotFound(err) {
 		logger.Info("Creating a new storage pool pvc on node", "storagepool.Name", storagePool.Name, "node.Name", node.GetName())
-		err = r.client.Create(context.TODO(), desired)
+		err = r.client.Create(ctx, desired)
 		if err != nil {
 			r.recorder.Event(cr, corev1.EventTypeWarning, createResourceFailed, fmt.Sprintf(createMessageFailed, desired.GetName(), err))
 			return err
@@ -175,7 +204,7 @@ func (r *ReconcileHostPathProvisioner) reconcileStoragePoolPVCByNode(logger logr
 	return nil
 }
 
-func (r *ReconcileHostPathProvisioner) reconcileStoragePoolDeploymentByNode(logger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string, storagePool *hostpathprovisionerv1.StoragePool, node *corev1.Node, currentStoragePoolDeployments map[string]appsv1.Deployment) error {
+func (r *ReconcileHostPathProvisioner) reconcileStoragePoolDeploymentByNode(ctx context.Context, logger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string, storagePool *hostpathprovisionerv1.StoragePool, node *corev1.Node, currentStoragePoolDeployments map[string]appsv1.Deployment) error {
 	// Create stateful set that mounts the volume to the node
 	desired := r.storagePoolDeploymentByNode(logger, cr, storagePool, namespace, node)
 	setLastAppliedConfiguration(desired)
@@ -186,10 +215,10 @@ func (r *ReconcileHostPathProvisioner) reconcileStoragePoolDeploymentByNode(logg
 
 	// Check if this Deployment already exists
 	found := &appsv1.Deployment{}
-	err := r.client.Get(context.TODO(), client.ObjectKeyFromObject(desired), found)
+	err := r.client.Get(ctx, client.ObjectKeyFromObject(desired), found)
 	if err != nil && errors.IsNotFound(err) {
 		logger.Info("Creating a new storage pool deployment on node", "storagepool.Name", storagePool.Name, "node.Name", node.GetName())
-		err = r.client.Create(context.TODO(), desired)
+		err = r.applyObject(ctx, desired, nil)
 		if err != nil {
 			r.recorder.Event(cr, corev1.EventTypeWarning, createResourceFailed, fmt.Sprintf(createMessageFailed, desired.GetName(), err))
 			return err
@@ -200,7 +229,14 @@ func (r *ReconcileHostPathProvisioner) reconcileStoragePoolDeploymentByNode(logg
 	} else if err != nil {
 		return err
 	}
-	delete(currentStoragePoolDeployments, desired.GetName())
+	delete(currentStoragePoolDeployments, client.ObjectKeyFromObject(desired).String())
+	if err := r.repairControllerReference(ctx, logger, cr, found); err != nil {
+		return err
+	}
+	if r.isUpToDate(desired, found) {
+		logger.V(3).Info("Skip reconcile: storage pool deployment is up to date", "deployment.Name", found.GetName(), "node.Name", node.GetName())
+		return nil
+	}
 
 	// Keep a copy of the original for comparison later.
 	currentRuntimeObjCopy := found.DeepCopyObject()
@@ -214,13 +250,15 @@ func (r *ReconcileHostPathProvisioner) reconcileStoragePoolDeploymentByNode(logg
 		logJSONDiff(logger, currentRuntimeObjCopy, found)
 		// Current is different from desired, update.
 		logger.V(3).Info("Updating Deployment for node", "deployment.Name", desired.GetName(), "node.Name", node.GetName())
-		err = r.client.Update(context.TODO(), found)
+		err = r.applyObject(ctx, desired, found)
 		if err != nil {
 			r.recorder.Event(cr, corev1.EventTypeWarning, updateResourceFailed, fmt.Sprintf(updateMessageFailed, desired.GetName(), err))
 			return err
 		}
 		r.recorder.Event(cr, corev1.EventTypeNormal, updateResourceSuccess, fmt.Sprintf(updateMessageSucceeded, desired, desired.GetName()))
+		return nil
 	}
+	r.markUpToDate(found)
 	return nil
 }
 
@@ -229,7 +267,9 @@ func copyIgnoredStoragePoolFields(desired, current *appsv1.Deployment) *appsv1.D
 	return desired
 }
 
-func (r *ReconcileHostPathProvisioner) currentStoragePoolDeployments(cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) (map[string]appsv1.Deployment, error) {
+// currentStoragePoolDeployments returns the storage pool deployments controlled by the CR in all namespaces, keyed by
+// namespace/name since storage pools can live in different namespaces.
+func (r *ReconcileHostPathProvisioner) currentStoragePoolDeployments(ctx context.Context, cr *hostpathprovisionerv1.HostPathProvisioner) (map[string]appsv1.Deployment, error) {
 	res := make(map[string]appsv1.Deployment)
 	selector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
 		MatchLabels: map[string]string{
@@ -240,40 +280,48 @@ func (r *ReconcileHostPathProvisioner) currentStoragePoolDeployments(cr *hostpat
 		return res, err
 	}
 	deploymentList := &appsv1.DeploymentList{}
-	if err := r.client.List(context.TODO(), deploymentList, &client.ListOptions{
+	if err := r.client.List(ctx, deploymentList, &client.ListOptions{
 		LabelSelector: client.MatchingLabelsSelector{
 			Selector: selector,
 		},
-		Namespace: namespace,
+		Namespace: metav1.NamespaceAll,
 	}); err != nil {
 		return res, err
 	}
 	for _, deployment := range deploymentList.Items {
-		if metav1.IsControlledBy(&deployment, cr) {
-			res[deployment.GetName()] = deployment
+		if metav1.IsControlledBy(&deployment, cr) && !isSingleNodeDeployment(&deployment) {
+			res[client.ObjectKeyFromObject(&deployment).String()] = deployment
 		}
 	}
 
 	return res, nil
 }
 
-func (r *ReconcileHostPathProvisioner) getNodesByDaemonSet(logger logr.Logger, namespace string) ([]corev1.Node, error) {
+func (r *ReconcileHostPathProvisioner) getNodesByWorkload(ctx context.Context, logger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) ([]corev1.Node, error) {
 	res := make([]corev1.Node, 0)
 	dsArgs := getDaemonSetArgs(logger, namespace, false)
-	ds := &appsv1.DaemonSet{
+	var workload client.Object = &appsv1.DaemonSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: dsArgs.namespace,
 			Name:      dsArgs.name,
 		},
 	}
+	if isSingleNode(cr) {
+		workload = &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: dsArgs.namespace,
+				Name:      dsArgs.name,
+			},
+		}
+	}
 
-	if err := r.client.Get(context.TODO(), client.ObjectKeyFromObject(ds), ds); err != nil {
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(workload), workload); err != nil {
 		if errors.IsNotFound(err) {
 			return res, nil
 		}
 		return res, err
 	}
-	logger.V(3).Info("Finding pods associated with daemonset", "daemonSet", ds.GetName())
+	logger.V(3).Info("Finding pods associated with workload", "workload", workload.GetName())
 
 	selector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
 		MatchLabels: map[string]string{
@@ -284,7 +332,7 @@ func (r *ReconcileHostPathProvisioner) getNodesByDaemonSet(logger logr.Logger, n
 		return res, err
 	}
 	podList := &corev1.PodList{}
-	if err := r.client.List(context.TODO(), podList, &client.ListOptions{
+	if err := r.client.List(ctx, podList, &client.ListOptions{
 		LabelSelector: client.MatchingLabelsSelector{
 			Selector: selector,
 		},
@@ -294,7 +342,8 @@ func (r *ReconcileHostPathProvisioner) getNodesByDaemonSet(logger logr.Logger, n
 	}
 	nodeNames := make(map[string]struct{})
 	for _, pod := range podList.Items {
-		if metav1.IsControlledBy(&pod, ds) && pod.DeletionTimestamp == nil {
+		// Pending pods are not on a node yet.
+		if isPodOwnedByWorkload(&pod, workload) && pod.DeletionTimestamp == nil && pod.Spec.NodeName != "" {
 			nodeNames[pod.Spec.NodeName] = struct{}{}
 		}
 	}
@@ -305,7 +354,7 @@ func (r *ReconcileHostPathProvisioner) getNodesByDaemonSet(logger logr.Logger, n
 				Name: nodeName,
 			},
 		}
-		if err := r.client.Get(context.TODO(), client.ObjectKeyFromObject(node), node); err != nil {
+		if err := r.client.Get(ctx, client.ObjectKeyFromObject(node), node); err != nil {
 			return res, err
 		}
 		res = append(res, *node)
@@ -326,6 +375,33 @@ func (r *ReconcileHostPathProvisioner) storagePoolPVCByNode(storagePool *hostpat
 	}
 }
 
+// getStoragePoolDeploymentStrategy returns the update strategy of the storage pool deployments. A RollingUpdate
+// without parameters gets the default parameters, so the API server doesn't default them differently.
+func getStoragePoolDeploymentStrategy(storagePool *hostpathprovisionerv1.StoragePool) appsv1.DeploymentStrategy {
+	if storagePool.DeploymentStrategy != nil && storagePool.DeploymentStrategy.Type == appsv1.RecreateDeploymentStrategyType {
+		return appsv1.DeploymentStrategy{
+			Type: appsv1.RecreateDeploymentStrategyType,
+		}
+	}
+	if storagePool.DeploymentStrategy != nil && storagePool.DeploymentStrategy.RollingUpdate != nil {
+		return appsv1.DeploymentStrategy{
+			Type:          appsv1.RollingUpdateDeploymentStrategyType,
+			RollingUpdate: storagePool.DeploymentStrategy.RollingUpdate.DeepCopy(),
+		}
+	}
+	return appsv1.DeploymentStrategy{
+		Type: appsv1.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateDeployment{
+			MaxUnavailable: &intstr.IntOrString{
+				IntVal: int32(1),
+			},
+			MaxSurge: &intstr.IntOrString{
+				IntVal: int32(2),
+			},
+		},
+	}
+}
+
 func (r *ReconcileHostPathProvisioner) storagePoolDeploymentByNode(logger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, sourceStoragePool *hostpathprovisionerv1.StoragePool, namespace string, node *corev1.Node) *appsv1.Deployment {
 	args := getDaemonSetArgs(logger, namespace, false)
 	labels := util.GetRecommendedLabels()
@@ -338,7 +414,6 @@ func (r *ReconcileHostPathProvisioner) storagePoolDeploymentByNode(logger logr.L
 	privileged := true
 	defaultGracePeriod := int64(30)
 	progressDeadline := int32(600)
-	revisionHistoryLimit := int32(10)
 
 	dataMountPath := blockDataMountPath
 	if sourceStoragePool.PVCTemplate.VolumeMode == nil || *sourceStoragePool.PVCTemplate.VolumeMode == corev1.PersistentVolumeFilesystem {
@@ -357,20 +432,10 @@ func (r *ReconcileHostPathProvisioner) storagePoolDeploymentByNode(logger logr.L
 					hppPoolPrefix: resourceName,
 				},
 			},
-			Replicas: &replicaCount,
-			Strategy: appsv1.DeploymentStrategy{
-				Type: appsv1.RollingUpdateDeploymentStrategyType,
-				RollingUpdate: &appsv1.RollingUpdateDeployment{
-					MaxUnavailable: &intstr.IntOrString{
-						IntVal: int32(1),
-					},
-					MaxSurge: &intstr.IntOrString{
-						IntVal: int32(2),
-					},
-				},
-			},
+			Replicas:                &replicaCount,
+			Strategy:                getStoragePoolDeploymentStrategy(sourceStoragePool),
 			ProgressDeadlineSeconds: &progressDeadline,
-			RevisionHistoryLimit:    &revisionHistoryLimit,
+			RevisionHistoryLimit:    getRevisionHistoryLimit(cr),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: v1.ObjectMeta{
 					Name:      resourceName,
@@ -378,12 +443,13 @@ func (r *ReconcileHostPathProvisioner) storagePoolDeploymentByNode(logger logr.L
 					Labels:    labels,
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName:            ProvisionerServiceAccountNameCsi,
+					ServiceAccountName:            getCsiServiceAccountName(cr),
 					RestartPolicy:                 corev1.RestartPolicyAlways,
 					SchedulerName:                 corev1.DefaultSchedulerName,
 					TerminationGracePeriodSeconds: &defaultGracePeriod,
 					DNSPolicy:                     corev1.DNSClusterFirst,
-					SecurityContext:               &corev1.PodSecurityContext{},
+					SecurityContext:               getPodSecurityContext(cr),
+					TopologySpreadConstraints:     sourceStoragePool.TopologySpreadConstraints,
 					Affinity: &corev1.Affinity{
 						NodeAffinity: &corev1.NodeAffinity{
 							RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
@@ -476,11 +542,35 @@ func (r *ReconcileHostPathProvisioner) storagePoolDeploymentByNode(logger logr.L
 	return deployment
 }
 
+// getStoragePoolNamespace returns the namespace of the storage pool PVCs and deployments.
+func getStoragePoolNamespace(storagePool *hostpathprovisionerv1.StoragePool, namespace string) string {
+	if storagePool.Namespace != "" {
+		return storagePool.Namespace
+	}
+	return namespace
+}
+
+// getStoragePoolNamespaces returns the sorted namespaces other than the operator namespace that are used by storage pools.
+func getStoragePoolNamespaces(cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) []string {
+	namespaces := make(map[string]struct{})
+	for _, storagePool := range cr.Spec.StoragePools {
+		if poolNamespace := getStoragePoolNamespace(&storagePool, namespace); storagePool.PVCTemplate != nil && poolNamespace != namespace {
+			namespaces[poolNamespace] = struct{}{}
+		}
+	}
+	res := make([]string, 0, len(namespaces))
+	for poolNamespace := range namespaces {
+		res = append(res, poolNamespace)
+	}
+	sort.Strings(res)
+	return res
+}
+
 func getStoragePoolPVCName(poolName, nodeName string) string {
 	return getResourceNameWithMaxLength(hppPoolPrefix, fmt.Sprintf("%s-%s", poolName, nodeName), maxNameLength)
 }
 
-func (r *ReconcileHostPathProvisioner) storagePoolDeploymentsByStoragePool(cr *hostpathprovisionerv1.HostPathProvisioner, namespace string, storagePool *hostpathprovisionerv1.StoragePool) ([]appsv1.Deployment, error) {
+func (r *ReconcileHostPathProvisioner) storagePoolDeploymentsByStoragePool(ctx context.Context, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string, storagePool *hostpathprovisionerv1.StoragePool) ([]appsv1.Deployment, error) {
 	res := make([]appsv1.Deployment, 0)
 	selector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
 		MatchLabels: map[string]string{
@@ -492,7 +582,7 @@ func (r *ReconcileHostPathProvisioner) storagePoolDeploymentsByStoragePool(cr *h
 		return res, err
 	}
 	deploymentList := &appsv1.DeploymentList{}
-	if err := r.client.List(context.TODO(), deploymentList, &client.ListOptions{
+	if err := r.client.List(ctx, deploymentList, &client.ListOptions{
 		LabelSelector: client.MatchingLabelsSelector{
 			Selector: selector,
 		},
@@ -510,7 +600,48 @@ func (r *ReconcileHostPathProvisioner) storagePoolDeploymentsByStoragePool(cr *h
 	return res, nil
 }
 
-func (r *ReconcileHostPathProvisioner) getClaimStatusesByStoragePool(storagePool *hostpathprovisionerv1.StoragePool, namespace string) ([]hostpathprovisionerv1.ClaimStatus, error) {
+// getStoragePoolNodes returns the sorted names of the nodes the storage pool deployments have a running pod on.
+func (r *ReconcileHostPathProvisioner) getStoragePoolNodes(ctx context.Context, storagePool *hostpathprovisionerv1.StoragePool, namespace string, deployments []appsv1.Deployment) ([]string, error) {
+	if len(deployments) == 0 {
+		return nil, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			"k8s-app":           MultiPurposeHostPathProvisionerName,
+			storagePoolLabelKey: getResourceNameWithMaxLength(storagePool.Name, "hpp", maxNameLength),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	podList := &corev1.PodList{}
+	if err := r.client.List(ctx, podList, &client.ListOptions{
+		LabelSelector: client.MatchingLabelsSelector{
+			Selector: selector,
+		},
+		Namespace: namespace,
+	}); err != nil {
+		return nil, err
+	}
+	nodeNames := sets.New[string]()
+	for _, pod := range podList.Items {
+		if pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning || pod.Spec.NodeName == "" {
+			continue
+		}
+		for i := range deployments {
+			if isPodOwnedByWorkload(&pod, &deployments[i]) {
+				nodeNames.Insert(pod.Spec.NodeName)
+				break
+			}
+		}
+	}
+	if nodeNames.Len() == 0 {
+		return nil, nil
+	}
+	return sets.List(nodeNames), nil
+}
+
+func (r *ReconcileHostPathProvisioner) getClaimStatusesByStoragePool(ctx context.Context, storagePool *hostpathprovisionerv1.StoragePool, namespace string) ([]hostpathprovisionerv1.ClaimStatus, error) {
 	res := make([]hostpathprovisionerv1.ClaimStatus, 0)
 	selector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
 		MatchLabels: map[string]string{
@@ -522,7 +653,7 @@ func (r *ReconcileHostPathProvisioner) getClaimStatusesByStoragePool(storagePool
 		return res, err
 	}
 	pvcList := &corev1.PersistentVolumeClaimList{}
-	if err := r.client.List(context.TODO(), pvcList, &client.ListOptions{
+	if err := r.client.List(ctx, pvcList, &client.ListOptions{
 		LabelSelector: client.MatchingLabelsSelector{
 			Selector: selector,
 		},
@@ -540,7 +671,7 @@ func (r *ReconcileHostPathProvisioner) getClaimStatusesByStoragePool(storagePool
 	return res, nil
 }
 
-func (r *ReconcileHostPathProvisioner) reconcileStoragePoolStatus(logger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) error {
+func (r *ReconcileHostPathProvisioner) reconcileStoragePoolStatus(ctx context.Context, logger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) error {
 	// Check the template of the storage pool
 	newStoragePoolStatuses := make([]hostpathprovisionerv1.StoragePoolStatus, 0)
 	if cr.Spec.PathConfig != nil {
@@ -551,7 +682,8 @@ func (r *ReconcileHostPathProvisioner) reconcileStoragePoolStatus(logger logr.Lo
 	} else {
 		for _, storagePool := range cr.Spec.StoragePools {
 			if storagePool.PVCTemplate != nil {
-				deployments, err := r.storagePoolDeploymentsByStoragePool(cr, namespace, &storagePool)
+				poolNamespace := getStoragePoolNamespace(&storagePool, namespace)
+				deployments, err := r.storagePoolDeploymentsByStoragePool(ctx, cr, poolNamespace, &storagePool)
 				if err != nil {
 					return err
 				}
@@ -563,7 +695,7 @@ func (r *ReconcileHostPathProvisioner) reconcileStoragePoolStatus(logger logr.Lo
 					}
 				}
 				logger.V(5).WithName("Status").Info("Number of deployments for pool ready", "storage pool", storagePool.Name, "deployment count", currentReady)
-				claimStatuses, err := r.getClaimStatusesByStoragePool(&storagePool, namespace)
+				claimStatuses, err := r.getClaimStatusesByStoragePool(ctx, &storagePool, poolNamespace)
 				if err != nil {
 					return err
 				}
@@ -572,12 +704,17 @@ func (r *ReconcileHostPathProvisioner) reconcileStoragePoolStatus(logger logr.Lo
 						return fmt.Errorf("error: Pool PVC %s is %s instead of %s", s.Name, phase, corev1.ClaimBound)
 					}
 				}
+				nodes, err := r.getStoragePoolNodes(ctx, &storagePool, poolNamespace, deployments)
+				if err != nil {
+					return err
+				}
 				newStoragePoolStatuses = append(newStoragePoolStatuses, hostpathprovisionerv1.StoragePoolStatus{
 					Name:          storagePool.Name,
 					Phase:         hostpathprovisionerv1.StoragePoolReady,
 					DesiredReady:  len(deployments),
 					CurrentReady:  currentReady,
 					ClaimStatuses: claimStatuses,
+					Nodes:         nodes,
 				})
 			} else {
 				newStoragePoolStatuses = append(newStoragePoolStatuses, hostpathprovisionerv1.StoragePoolStatus{
@@ -594,30 +731,78 @@ func (r *ReconcileHostPathProvisioner) reconcileStoragePoolStatus(logger logr.Lo
 	return nil
 }
 
-func (r *ReconcileHostPathProvisioner) hasCleanUpFinished(namespace string) (bool, error) {
-	jobs, err := r.getCleanUpJobs(namespace)
+// reconcileProvisionedVolumesMetric updates the number of provisioned volumes of the storage pools in the status.
+func (r *ReconcileHostPathProvisioner) reconcileProvisionedVolumesMetric(ctx context.Context, cr *hostpathprovisionerv1.HostPathProvisioner) error {
+	volumes, err := r.getProvisionedVolumes(ctx, cr, cr.Status.StoragePoolStatuses)
+	if err != nil {
+		return err
+	}
+	metrics.SetProvisionedVolumes(volumes)
+	return nil
+}
+
+// getProvisionedVolumes returns the number of PVs the CSI driver provisioned for each storage pool, the storage pools
+// without PVs have 0. The storage pool of a PV is the annotation the driver sets if the storage pool annotates its volumes,
+// or the storage pool parameter of the StorageClass otherwise.
+func (r *ReconcileHostPathProvisioner) getProvisionedVolumes(ctx context.Context, cr *hostpathprovisionerv1.HostPathProvisioner, storagePoolStatuses []hostpathprovisionerv1.StoragePoolStatus) (map[string]int, error) {
+	volumes := make(map[string]int)
+	for _, status := range storagePoolStatuses {
+		volumes[status.Name] = 0
+	}
+	pvList := &corev1.PersistentVolumeList{}
+	if err := r.client.List(ctx, pvList); err != nil {
+		return nil, err
+	}
+	for _, pv := range pvList.Items {
+		if pv.Spec.CSI == nil || pv.Spec.CSI.Driver != getDriverName(cr) {
+			continue
+		}
+		storagePool := pv.GetAnnotations()[storagePoolAnnotationKey]
+		if storagePool == "" {
+			storagePool = pv.Spec.CSI.VolumeAttributes[storagePoolParameterName]
+		}
+		if storagePool != "" {
+			volumes[storagePool]++
+		}
+	}
+	return volumes, nil
+}
+
+func (r *ReconcileHostPathProvisioner) hasCleanUpFinished(ctx context.Context) (bool, error) {
+	jobs, err := r.getCleanUpJobs(ctx)
 	if err != nil {
 		return false, err
 	}
 	finished := true
 	for _, job := range jobs {
-		if job.Status.Succeeded == int32(0) {
+		if job.Status.Succeeded == int32(0) && !isJobDeadlineExceeded(&job) {
 			finished = false
 		}
 	}
 	return finished, nil
 }
 
-func (r *ReconcileHostPathProvisioner) removeCleanUpJobs(logger logr.Logger, namespace string) error {
+// isJobDeadlineExceeded returns true if the job was terminated because it ran longer than its active deadline. It will
+// not run again, so waiting for it would block the cleanup forever.
+func isJobDeadlineExceeded(job *batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue && condition.Reason == jobReasonDeadlineExceeded {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ReconcileHostPathProvisioner) removeCleanUpJobs(ctx context.Context, logger logr.Logger) error {
 	deletePropagationBackground := metav1.DeletePropagationBackground
-	jobs, err := r.getCleanUpJobs(namespace)
+	jobs, err := r.getCleanUpJobs(ctx)
 	if err != nil {
 		return err
 	}
 	logger.V(3).Info("Found jobs", "count", len(jobs))
 	for _, job := range jobs {
 		logger.V(3).Info("Deleting job", "name", job.GetName())
-		if err := r.client.Delete(context.TODO(), &job, &client.DeleteOptions{
+		if err := r.client.Delete(ctx, &job, &client.DeleteOptions{
 			PropagationPolicy: &deletePropagationBackground,
 		}); err != nil && !errors.IsNotFound(err) {
 			return err
@@ -626,7 +811,39 @@ func (r *ReconcileHostPathProvisioner) removeCleanUpJobs(logger logr.Logger, nam
 	return nil
 }
 
-func (r *ReconcileHostPathProvisioner) getCleanUpJobs(namespace string) ([]batchv1.Job, error) {
+// removeOrphanedCleanUpJobs deletes the cleanup jobs that belong to storage pools that are no longer in the CR.
+func (r *ReconcileHostPathProvisioner) removeOrphanedCleanUpJobs(ctx context.Context, logger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner) error {
+	deletePropagationBackground := metav1.DeletePropagationBackground
+	jobs, err := r.getCleanUpJobs(ctx)
+	if err != nil {
+		return err
+	}
+	storagePools := make(map[string]bool)
+	for _, storagePool := range cr.Spec.StoragePools {
+		storagePools[getResourceNameWithMaxLength(storagePool.Name, "hpp", maxNameLength)] = true
+	}
+	for _, job := range jobs {
+		storagePoolName, ok := job.GetLabels()[storagePoolLabelKey]
+		if !ok || storagePools[storagePoolName] {
+			continue
+		}
+		logger.Info("Deleting orphaned cleanup job", "name", job.GetName(), "storagePool", storagePoolName)
+		if err := r.client.Delete(ctx, &job, &client.DeleteOptions{
+			PropagationPolicy: &deletePropagationBackground,
+		}); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			r.recorder.Event(cr, corev1.EventTypeWarning, deleteResourceFailed, fmt.Sprintf(deleteMessageFailed, job.GetName(), err))
+			return err
+		}
+		r.recorder.Event(cr, corev1.EventTypeNormal, deleteResourceSuccess, fmt.Sprintf(deleteMessageSucceeded, &job, job.GetName()))
+	}
+	return nil
+}
+
+// getCleanUpJobs returns the cleanup jobs in all namespaces, the jobs run in the namespace of the storage pool.
+func (r *ReconcileHostPathProvisioner) getCleanUpJobs(ctx context.Context) ([]batchv1.Job, error) {
 	selector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
 		MatchLabels: map[string]string{
 			AppKubernetesManagedByLabel: "hostpath-provisioner-operator",
@@ -636,18 +853,55 @@ func (r *ReconcileHostPathProvisioner) getCleanUpJobs(namespace string) ([]batch
 		return make([]batchv1.Job, 0), err
 	}
 	jobList := &batchv1.JobList{}
-	if err := r.client.List(context.TODO(), jobList, &client.ListOptions{
+	if err := r.client.List(ctx, jobList, &client.ListOptions{
 		LabelSelector: selector,
-		Namespace:     namespace,
+		Namespace:     metav1.NamespaceAll,
 	}); err != nil {
 		return make([]batchv1.Job, 0), err
 	}
 	return jobList.Items, nil
 }
 
-func (r *ReconcileHostPathProvisioner) createCleanupJobForNode(logger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string, sourceStoragePool *hostpathprovisionerv1.StoragePool, node *corev1.Node) error {
+// getCleanupJobTolerations returns the tolerations of the workload, so the cleanup job can run on any tainted node the workload ran on.
+// The job is pinned to the node of the storage pool, the workload node selector and affinity are not used since the node might no
+// longer match them, which is why the storage pool is being cleaned up in the first place.
+func getCleanupJobTolerations(cr *hostpathprovisionerv1.HostPathProvisioner) []corev1.Toleration {
+	tolerations := getWorkloadTolerations(cr)
+	if isSingleNode(cr) {
+		tolerations = appendToleration(tolerations, controlPlaneToleration)
+	}
+	return tolerations
+}
+
+// getCleanupJobBackoffLimit returns the configured backoff limit of the cleanup jobs, or the default.
+func getCleanupJobBackoffLimit(cr *hostpathprovisionerv1.HostPathProvisioner) *int32 {
+	if cr.Spec.Workload.CleanupJob != nil && cr.Spec.Workload.CleanupJob.BackoffLimit != nil {
+		return pointer.Int32(*cr.Spec.Workload.CleanupJob.BackoffLimit)
+	}
+	return pointer.Int32(defaultCleanupJobBackoffLimit)
+}
+
+// getCleanupJobTTLSecondsAfterFinished returns the configured TTL of finished cleanup jobs, or the default. Jobs removed by the TTL
+// controller before the operator checks them are not listed anymore, and count as finished.
+func getCleanupJobTTLSecondsAfterFinished(cr *hostpathprovisionerv1.HostPathProvisioner) *int32 {
+	if cr.Spec.Workload.CleanupJob != nil && cr.Spec.Workload.CleanupJob.TTLSecondsAfterFinished != nil {
+		return pointer.Int32(*cr.Spec.Workload.CleanupJob.TTLSecondsAfterFinished)
+	}
+	return pointer.Int32(defaultCleanupJobTTLSecondsAfterFinished)
+}
+
+// getCleanupJobActiveDeadlineSeconds returns the configured active deadline of the cleanup jobs, nil if not set.
+func getCleanupJobActiveDeadlineSeconds(cr *hostpathprovisionerv1.HostPathProvisioner) *int64 {
+	if cr.Spec.Workload.CleanupJob != nil && cr.Spec.Workload.CleanupJob.ActiveDeadlineSeconds != nil {
+		return pointer.Int64(*cr.Spec.Workload.CleanupJob.ActiveDeadlineSeconds)
+	}
+	return nil
+}
+
+func (r *ReconcileHostPathProvisioner) createCleanupJobForNode(ctx context.Context, logger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string, sourceStoragePool *hostpathprovisionerv1.StoragePool, node *corev1.Node) error {
 	args := getDaemonSetArgs(logger, namespace, false)
 	labels := util.GetRecommendedLabels()
+	labels[storagePoolLabelKey] = getResourceNameWithMaxLength(sourceStoragePool.Name, "hpp", maxNameLength)
 	directory := corev1.HostPathDirectory
 	bidirectional := corev1.MountPropagationBidirectional
 	cleanupJob := &batchv1.Job{
@@ -657,17 +911,21 @@ func (r *ReconcileHostPathProvisioner) createCleanupJobForNode(logger logr.Logge
 			Labels:    labels,
 		},
 		Spec: batchv1.JobSpec{
+			BackoffLimit:            getCleanupJobBackoffLimit(cr),
+			TTLSecondsAfterFinished: getCleanupJobTTLSecondsAfterFinished(cr),
+			ActiveDeadlineSeconds:   getCleanupJobActiveDeadlineSeconds(cr),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: v1.ObjectMeta{
 					Labels: labels,
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName:            ProvisionerServiceAccountNameCsi,
+					ServiceAccountName:            getCsiServiceAccountName(cr),
 					RestartPolicy:                 corev1.RestartPolicyOnFailure,
 					SchedulerName:                 corev1.DefaultSchedulerName,
 					TerminationGracePeriodSeconds: pointer.Int64(30),
 					DNSPolicy:                     corev1.DNSClusterFirst,
 					SecurityContext:               &corev1.PodSecurityContext{},
+					Tolerations:                   getCleanupJobTolerations(cr),
 					Affinity: &corev1.Affinity{
 						NodeAffinity: &corev1.NodeAffinity{
 							RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
@@ -737,7 +995,7 @@ func (r *ReconcileHostPathProvisioner) createCleanupJobForNode(logger logr.Logge
 		},
 	}
 	logger.V(3).Info("Creating cleanup job", "name", cleanupJob.Name)
-	if err := r.client.Create(context.TODO(), cleanupJob); err != nil && !errors.IsAlreadyExists(err) {
+	if err := r.client.Create(ctx, cleanupJob); err != nil && !errors.IsAlreadyExists(err) {
 		logger.Error(err, "Unable to create cleanup job", "name", cleanupJob.GetName())
 	}
 	return nil
@@ -18,19 +18,27 @@ package hostpathprovisioner
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 
 	ginkgo "github.com/onsi/ginkgo/v2"
 	gomega "github.com/onsi/gomega"
+	secv1 "github.com/openshift/api/security/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	runtimemetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	hppv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
+	"kubevirt.io/hostpath-provisioner-operator/pkg/monitoring/metrics"
+	"kubevirt.io/hostpath-provisioner-operator/pkg/util"
 	"kubevirt.io/hostpath-provisioner-operator/version"
 )
 
@@ -110,8 +118,7 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 			gomega.Expect(deployment.Spec.Template.Spec.Containers[0].Name).To(gomega.Equal("failure"))
 			req := reconcile.Request{
 				NamespacedName: types.NamespacedName{
-					Name:      "test-name",
-					Namespace: testNamespace,
+					Name: "test-name",
 				},
 			}
 			_, err = r.Reconcile(context.TODO(), req)
@@ -121,6 +128,220 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 			gomega.Expect(deployment.Spec.Template.Spec.Containers[0].Name).To(gomega.Equal("mounter"))
 		})
 
+		ginkgo.It("Should add the topology spread constraints of the storage pool to the deployments", func() {
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			scaleClusterNodesAndDsUp(1, 1, cr, r, cl)
+			verifyDeploymentsAndPVCs(1, 1, cr, r, cl)
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			constraints := []corev1.TopologySpreadConstraint{
+				{
+					MaxSkew:           1,
+					TopologyKey:       corev1.LabelTopologyZone,
+					WhenUnsatisfiable: corev1.ScheduleAnyway,
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{storagePoolLabelKey: "local"},
+					},
+				},
+			}
+			err := cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			cr.Spec.StoragePools[0].TopologySpreadConstraints = constraints
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			deployment := &appsv1.Deployment{}
+			err = cl.Get(context.TODO(), types.NamespacedName{Name: "hpp-pool-local-node1", Namespace: testNamespace}, deployment)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(deployment.Spec.Template.Spec.TopologySpreadConstraints).To(gomega.Equal(constraints))
+
+			ginkgo.By("Removing the constraints from the storage pool")
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			cr.Spec.StoragePools[0].TopologySpreadConstraints = nil
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), client.ObjectKeyFromObject(deployment), deployment)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(deployment.Spec.Template.Spec.TopologySpreadConstraints).To(gomega.BeEmpty())
+		})
+
+		ginkgo.It("Should apply the deployment strategy of the storage pool to the deployments", func() {
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			scaleClusterNodesAndDsUp(1, 1, cr, r, cl)
+			verifyDeploymentsAndPVCs(1, 1, cr, r, cl)
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			deployment := &appsv1.Deployment{}
+			err := cl.Get(context.TODO(), types.NamespacedName{Name: "hpp-pool-local-node1", Namespace: testNamespace}, deployment)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(deployment.Spec.Strategy.Type).To(gomega.Equal(appsv1.RollingUpdateDeploymentStrategyType))
+			gomega.Expect(deployment.Spec.Strategy.RollingUpdate.MaxSurge.IntValue()).To(gomega.Equal(2))
+
+			ginkgo.By("Setting the Recreate strategy on the storage pool")
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			cr.Spec.StoragePools[0].DeploymentStrategy = &appsv1.DeploymentStrategy{
+				Type: appsv1.RecreateDeploymentStrategyType,
+			}
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), client.ObjectKeyFromObject(deployment), deployment)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(deployment.Spec.Strategy).To(gomega.Equal(appsv1.DeploymentStrategy{
+				Type: appsv1.RecreateDeploymentStrategyType,
+			}))
+
+			ginkgo.By("Setting the RollingUpdate parameters on the storage pool")
+			maxUnavailable := intstr.FromInt32(0)
+			maxSurge := intstr.FromInt32(1)
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			cr.Spec.StoragePools[0].DeploymentStrategy = &appsv1.DeploymentStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{
+					MaxUnavailable: &maxUnavailable,
+					MaxSurge:       &maxSurge,
+				},
+			}
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), client.ObjectKeyFromObject(deployment), deployment)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(deployment.Spec.Strategy).To(gomega.Equal(*cr.Spec.StoragePools[0].DeploymentStrategy))
+		})
+
+		ginkgo.It("Should apply the fsGroup of the workload to the storage pool deployments", func() {
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			scaleClusterNodesAndDsUp(1, 1, cr, r, cl)
+			verifyDeploymentsAndPVCs(1, 1, cr, r, cl)
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			deployment := &appsv1.Deployment{}
+			err := cl.Get(context.TODO(), types.NamespacedName{Name: "hpp-pool-local-node1", Namespace: testNamespace}, deployment)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(deployment.Spec.Template.Spec.SecurityContext.FSGroup).To(gomega.BeNil())
+
+			for _, fsGroup := range []*int64{pointer.Int64(107), nil} {
+				ginkgo.By(fmt.Sprintf("Setting fsGroup to %v", fsGroup))
+				err = cl.Get(context.TODO(), req.NamespacedName, cr)
+				gomega.Expect(err).ToNot(gomega.HaveOccurred())
+				cr.Spec.Workload.FSGroup = fsGroup
+				err = cl.Update(context.TODO(), cr)
+				gomega.Expect(err).ToNot(gomega.HaveOccurred())
+				_, err = r.Reconcile(context.TODO(), req)
+				gomega.Expect(err).ToNot(gomega.HaveOccurred())
+				err = cl.Get(context.TODO(), client.ObjectKeyFromObject(deployment), deployment)
+				gomega.Expect(err).ToNot(gomega.HaveOccurred())
+				gomega.Expect(deployment.Spec.Template.Spec.SecurityContext.FSGroup).To(gomega.Equal(fsGroup))
+			}
+		})
+
+		ginkgo.It("Should apply the revision history limit to the storage pool deployments", func() {
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			scaleClusterNodesAndDsUp(1, 1, cr, r, cl)
+			verifyDeploymentsAndPVCs(1, 1, cr, r, cl)
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			deployment := &appsv1.Deployment{}
+			err := cl.Get(context.TODO(), types.NamespacedName{Name: "hpp-pool-local-node1", Namespace: testNamespace}, deployment)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(deployment.Spec.RevisionHistoryLimit).To(gomega.Equal(pointer.Int32(defaultRevisionHistoryLimit)))
+
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			cr.Spec.Workload.RevisionHistoryLimit = pointer.Int32(5)
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), client.ObjectKeyFromObject(deployment), deployment)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(deployment.Spec.RevisionHistoryLimit).To(gomega.Equal(pointer.Int32(5)))
+		})
+
+		ginkgo.It("Should export the number of provisioned volumes of each storage pool", func() {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			metrics.SetLeader(true)
+			defer metrics.SetLeader(false)
+			cr := createStoragePoolWithTemplateCr()
+			cr.Spec.StoragePools = append(cr.Spec.StoragePools, hppv1.StoragePool{
+				Name: "fast",
+				Path: "/fast",
+			})
+			cr, r, cl := createDeployedCr(cr)
+			gomega.Expect(provisionedVolumesValues()).To(gomega.Equal(map[string]float64{"fast": 0, "local": 0}))
+
+			createPV := func(name, driver string, annotations, volumeAttributes map[string]string) {
+				pv := &corev1.PersistentVolume{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        name,
+						Annotations: annotations,
+					},
+					Spec: corev1.PersistentVolumeSpec{
+						PersistentVolumeSource: corev1.PersistentVolumeSource{
+							CSI: &corev1.CSIPersistentVolumeSource{
+								Driver:           driver,
+								VolumeHandle:     name,
+								VolumeAttributes: volumeAttributes,
+							},
+						},
+					},
+				}
+				gomega.Expect(cl.Create(context.TODO(), pv)).To(gomega.Succeed())
+			}
+			createPV("pv1", driverName, map[string]string{storagePoolAnnotationKey: "local"}, nil)
+			createPV("pv2", driverName, nil, map[string]string{storagePoolParameterName: "local"})
+			createPV("pv3", driverName, nil, map[string]string{storagePoolParameterName: "fast"})
+			createPV("other-driver", "other.csi.example.com", nil, map[string]string{storagePoolParameterName: "fast"})
+
+			ginkgo.By("Not updating the metric when serving the diagnostics")
+			h := NewDiagnosticsHandler()
+			h.SetClient(cl)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, DiagnosticsPath, nil))
+			gomega.Expect(rec.Code).To(gomega.Equal(http.StatusOK))
+			gomega.Expect(provisionedVolumesValues()).To(gomega.Equal(map[string]float64{"fast": 0, "local": 0}))
+
+			_, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(provisionedVolumesValues()).To(gomega.Equal(map[string]float64{"fast": 1, "local": 2}))
+
+			ginkgo.By("Removing a storage pool")
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			cr.Spec.StoragePools = cr.Spec.StoragePools[:1]
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(cl.Delete(context.TODO(), &corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: "pv3"}})).To(gomega.Succeed())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(provisionedVolumesValues()).To(gomega.Equal(map[string]float64{"local": 2}))
+		})
+
 		ginkgo.It("Should create cleanup jobs, if CR is marked for deletion", func() {
 			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
 			scaleClusterNodesAndDsUp(1, 1, cr, r, cl)
@@ -134,8 +355,7 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 			gomega.Expect(err).ToNot(gomega.HaveOccurred())
 			req := reconcile.Request{
 				NamespacedName: types.NamespacedName{
-					Name:      "test-name",
-					Namespace: testNamespace,
+					Name: "test-name",
 				},
 			}
 			_, err = r.Reconcile(context.TODO(), req)
@@ -151,6 +371,260 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 			gomega.Expect(jobList.Items[0].Spec.Template.Spec.Containers[0].SecurityContext).ToNot(gomega.BeNil())
 			gomega.Expect(jobList.Items[0].Spec.Template.Spec.Containers[0].SecurityContext.RunAsUser).To(gomega.Equal(pointer.Int64(0)))
 			gomega.Expect(jobList.Items[0].Spec.Template.Spec.Containers[0].SecurityContext.Privileged).To(gomega.Equal(pointer.Bool(true)))
+			gomega.Expect(jobList.Items[0].Spec.BackoffLimit).To(gomega.Equal(pointer.Int32(defaultCleanupJobBackoffLimit)))
+			gomega.Expect(jobList.Items[0].Spec.TTLSecondsAfterFinished).To(gomega.Equal(pointer.Int32(defaultCleanupJobTTLSecondsAfterFinished)))
+		})
+
+		ginkgo.It("Should apply the workload cleanupJob configuration to cleanup jobs", func() {
+			cr := createStoragePoolWithTemplateCr()
+			cr.Spec.Workload.CleanupJob = &hppv1.CleanupJobConfig{
+				BackoffLimit:            pointer.Int32(2),
+				TTLSecondsAfterFinished: pointer.Int32(0),
+				ActiveDeadlineSeconds:   pointer.Int64(600),
+			}
+			cr, r, cl := createDeployedCr(cr)
+			scaleClusterNodesAndDsUp(1, 1, cr, r, cl)
+			verifyDeploymentsAndPVCs(1, 1, cr, r, cl)
+
+			ginkgo.By("Marking CR as deleted, it should generate cleanup jobs after reconcile")
+			err := cl.Delete(context.TODO(), cr)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			jobList := &batchv1.JobList{}
+			err = r.client.List(context.TODO(), jobList)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(jobList.Items).To(gomega.HaveLen(1))
+			gomega.Expect(jobList.Items[0].Spec.BackoffLimit).To(gomega.Equal(pointer.Int32(2)))
+			gomega.Expect(jobList.Items[0].Spec.TTLSecondsAfterFinished).To(gomega.Equal(pointer.Int32(0)))
+			gomega.Expect(jobList.Items[0].Spec.ActiveDeadlineSeconds).To(gomega.Equal(pointer.Int64(600)))
+		})
+
+		ginkgo.It("Should treat cleanup jobs that exceeded their deadline as finished", func() {
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			scaleClusterNodesAndDsUp(1, 1, cr, r, cl)
+			verifyDeploymentsAndPVCs(1, 1, cr, r, cl)
+
+			ginkgo.By("Marking CR as deleted, it should generate cleanup jobs after reconcile")
+			err := cl.Delete(context.TODO(), cr)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			jobList := &batchv1.JobList{}
+			err = r.client.List(context.TODO(), jobList)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(jobList.Items).To(gomega.HaveLen(1))
+			finished, err := r.hasCleanUpFinished(context.TODO())
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(finished).To(gomega.BeFalse())
+
+			ginkgo.By("Failing the job on its deadline")
+			job := &jobList.Items[0]
+			job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{
+				Type:   batchv1.JobFailed,
+				Status: corev1.ConditionTrue,
+				Reason: jobReasonDeadlineExceeded,
+			})
+			err = cl.Status().Update(context.TODO(), job)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			finished, err = r.hasCleanUpFinished(context.TODO())
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(finished).To(gomega.BeTrue())
+		})
+
+		ginkgo.It("Should pin cleanup jobs to the storage pool node, with the workload tolerations", func() {
+			toleration := corev1.Toleration{
+				Key:      "storage",
+				Operator: corev1.TolerationOpEqual,
+				Value:    "dedicated",
+				Effect:   corev1.TaintEffectNoSchedule,
+			}
+			cr := createStoragePoolWithTemplateCr()
+			cr.Spec.Workload.Tolerations = []corev1.Toleration{toleration}
+			cr, r, cl := createDeployedCr(cr)
+			scaleClusterNodesAndDsUp(1, 1, cr, r, cl)
+			verifyDeploymentsAndPVCs(1, 1, cr, r, cl)
+
+			ginkgo.By("Marking CR as deleted, it should generate cleanup jobs after reconcile")
+			err := cl.Delete(context.TODO(), cr)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			jobList := &batchv1.JobList{}
+			err = r.client.List(context.TODO(), jobList)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(jobList.Items).To(gomega.HaveLen(1))
+			podSpec := jobList.Items[0].Spec.Template.Spec
+			gomega.Expect(podSpec.Tolerations).To(gomega.Equal([]corev1.Toleration{toleration}))
+			gomega.Expect(podSpec.NodeSelector).To(gomega.BeEmpty())
+			gomega.Expect(podSpec.Affinity).ToNot(gomega.BeNil())
+			gomega.Expect(podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(gomega.Equal([]corev1.NodeSelectorTerm{
+				{
+					MatchExpressions: []corev1.NodeSelectorRequirement{
+						{
+							Key:      corev1.LabelHostname,
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   []string{"node1"},
+						},
+					},
+				},
+			}))
+		})
+
+		ginkgo.It("Should add the control plane toleration to cleanup jobs in single node mode", func() {
+			cr := createStoragePoolWithTemplateCr()
+			cr.Spec.Workload.SingleNode = true
+			gomega.Expect(getCleanupJobTolerations(cr)).To(gomega.Equal([]corev1.Toleration{controlPlaneToleration}))
+		})
+
+		ginkgo.It("Should add the control plane tolerations to cleanup jobs if the workload tolerates the control plane", func() {
+			cr := createStoragePoolWithTemplateCr()
+			cr.Spec.Workload.TolerateControlPlane = true
+			gomega.Expect(getCleanupJobTolerations(cr)).To(gomega.Equal([]corev1.Toleration{controlPlaneToleration, masterToleration}))
+			cr.Spec.Workload.SingleNode = true
+			gomega.Expect(getCleanupJobTolerations(cr)).To(gomega.Equal([]corev1.Toleration{controlPlaneToleration, masterToleration}))
+		})
+
+		ginkgo.It("Should remove orphaned cleanup jobs of storage pools that no longer exist", func() {
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			scaleClusterNodesAndDsUp(1, 1, cr, r, cl)
+			verifyDeploymentsAndPVCs(1, 1, cr, r, cl)
+
+			ginkgo.By("Creating cleanup jobs for an existing and a missing storage pool")
+			for _, poolName := range []string{"local", "missing"} {
+				labels := util.GetRecommendedLabels()
+				labels[storagePoolLabelKey] = getResourceNameWithMaxLength(poolName, "hpp", maxNameLength)
+				job := &batchv1.Job{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fmt.Sprintf("cleanup-pool-%s-node1", poolName),
+						Namespace: testNamespace,
+						Labels:    labels,
+					},
+				}
+				err := cl.Create(context.TODO(), job)
+				gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			}
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			_, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			jobList := &batchv1.JobList{}
+			err = cl.List(context.TODO(), jobList)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(jobList.Items).To(gomega.HaveLen(1))
+			gomega.Expect(jobList.Items[0].GetName()).To(gomega.Equal("cleanup-pool-local-node1"))
+
+			recorder, ok := r.recorder.(*record.FakeRecorder)
+			gomega.Expect(ok).To(gomega.BeTrue())
+			events := make([]string, 0)
+			for len(recorder.Events) > 0 {
+				events = append(events, <-recorder.Events)
+			}
+			gomega.Expect(events).To(gomega.ContainElement(fmt.Sprintf("%s %s %s", corev1.EventTypeNormal, deleteResourceSuccess, fmt.Sprintf(deleteMessageSucceeded, &batchv1.Job{}, "cleanup-pool-missing-node1"))))
+		})
+
+		ginkgo.It("Should create the storage pools in the namespace of the storage pool", func() {
+			tenantNamespace := "tenant-a"
+			cr := createStoragePoolWithTemplateCr()
+			// Cross namespace owner references require a cluster scoped CR.
+			cr.SetNamespace("")
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			cr, r, cl := createDeployedCr(cr)
+
+			ginkgo.By("Adding a storage pool in the tenant namespace and a node to the csi daemonset")
+			err := cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			tenantPool := *cr.Spec.StoragePools[0].DeepCopy()
+			tenantPool.Name = "tenant"
+			tenantPool.Path = "/tmp/tenant"
+			tenantPool.Namespace = tenantNamespace
+			cr.Spec.StoragePools = append(cr.Spec.StoragePools, tenantPool)
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			addNodesToCluster(1, 1, cl)
+			csiDs := &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName),
+					Namespace: testNamespace,
+				},
+			}
+			err = cl.Get(context.TODO(), client.ObjectKeyFromObject(csiDs), csiDs)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			createCsiDsPods(1, 1, csiDs, cl)
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			gomega.Expect(err.Error()).To(gomega.ContainSubstring("instead of Bound"))
+			pvcList := &corev1.PersistentVolumeClaimList{}
+			err = cl.List(context.TODO(), pvcList)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(pvcList.Items).To(gomega.HaveLen(2))
+			for _, pvc := range pvcList.Items {
+				pvc.Status.Phase = corev1.ClaimBound
+				err = cl.Status().Update(context.TODO(), &pvc)
+				gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			}
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+			for poolName, namespace := range map[string]string{"local": testNamespace, "tenant": tenantNamespace} {
+				key := types.NamespacedName{Name: fmt.Sprintf("hpp-pool-%s-node1", poolName), Namespace: namespace}
+				err = cl.Get(context.TODO(), key, &corev1.PersistentVolumeClaim{})
+				gomega.Expect(err).ToNot(gomega.HaveOccurred())
+				deployment := &appsv1.Deployment{}
+				err = cl.Get(context.TODO(), key, deployment)
+				gomega.Expect(err).ToNot(gomega.HaveOccurred())
+				gomega.Expect(metav1.IsControlledBy(deployment, cr)).To(gomega.BeTrue())
+				err = cl.Get(context.TODO(), types.NamespacedName{Name: ProvisionerServiceAccountNameCsi, Namespace: namespace}, &corev1.ServiceAccount{})
+				gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			}
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(cr.Status.StoragePoolStatuses).To(gomega.HaveLen(2))
+			for _, status := range cr.Status.StoragePoolStatuses {
+				gomega.Expect(status.DesiredReady).To(gomega.Equal(1), status.Name)
+			}
+			scc := &secv1.SecurityContextConstraints{}
+			err = cl.Get(context.TODO(), types.NamespacedName{Name: fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName)}, scc)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(scc.Users).To(gomega.ConsistOf(
+				fmt.Sprintf("system:serviceaccount:%s:%s", testNamespace, ProvisionerServiceAccountNameCsi),
+				fmt.Sprintf("system:serviceaccount:%s:%s", tenantNamespace, ProvisionerServiceAccountNameCsi),
+			))
+
+			ginkgo.By("Removing the tenant storage pool, its deployment should be removed from the tenant namespace")
+			cr.Spec.StoragePools = cr.Spec.StoragePools[:1]
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			deploymentList := &appsv1.DeploymentList{}
+			err = cl.List(context.TODO(), deploymentList, &client.ListOptions{Namespace: tenantNamespace})
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(deploymentList.Items).To(gomega.BeEmpty())
+			err = cl.Get(context.TODO(), types.NamespacedName{Name: "hpp-pool-local-node1", Namespace: testNamespace}, &appsv1.Deployment{})
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
 		})
 
 		ginkgo.It("Status length should remain at one with legacy CR", func() {
@@ -160,8 +634,7 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 			gomega.Expect(len(cr.Status.StoragePoolStatuses)).To(gomega.Equal(1))
 			req := reconcile.Request{
 				NamespacedName: types.NamespacedName{
-					Name:      "test-name",
-					Namespace: testNamespace,
+					Name: "test-name",
 				},
 			}
 			for i := 0; i < 10; i++ {
@@ -173,6 +646,92 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 			gomega.Expect(len(cr.Status.StoragePoolStatuses)).To(gomega.Equal(1))
 		})
 
+		ginkgo.It("Should summarize the readiness of the storage pools", func() {
+			blockMode := corev1.PersistentVolumeBlock
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateVolumeModeAndBasicCr("template", &blockMode))
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			scaleClusterNodesAndDsUp(1, 3, cr, r, cl)
+			_, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), client.ObjectKeyFromObject(cr), cr)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(cr.Status.Summary).To(gomega.Equal(fmt.Sprintf("1/2 pools ready, %s", versionString)))
+
+			ginkgo.By("Making the deployments of the template storage pool ready")
+			deployments := appsv1.DeploymentList{}
+			err = cl.List(context.TODO(), &deployments)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(deployments.Items).To(gomega.HaveLen(3))
+			for _, deployment := range deployments.Items {
+				deployment.Status.ReadyReplicas = int32(1)
+				err = cl.Status().Update(context.TODO(), &deployment)
+				gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			}
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), client.ObjectKeyFromObject(cr), cr)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(cr.Status.Summary).To(gomega.Equal(fmt.Sprintf("2/2 pools ready, %s", versionString)))
+		})
+
+		ginkgo.It("Should report the nodes the storage pool pods are running on", func() {
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			scaleClusterNodesAndDsUp(1, 3, cr, r, cl)
+			gomega.Expect(cr.Status.StoragePoolStatuses).To(gomega.HaveLen(1))
+			gomega.Expect(cr.Status.StoragePoolStatuses[0].Nodes).To(gomega.BeEmpty())
+
+			ginkgo.By("Creating pods of the storage pool deployments on two nodes, and a pending one")
+			deployments := appsv1.DeploymentList{}
+			err := cl.List(context.TODO(), &deployments)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(deployments.Items).To(gomega.HaveLen(3))
+			phases := []corev1.PodPhase{corev1.PodRunning, corev1.PodRunning, corev1.PodPending}
+			for i, deployment := range deployments.Items {
+				podLabels := map[string]string{appsv1.DefaultDeploymentUniqueLabelKey: "abcd"}
+				for k, v := range deployment.Spec.Template.Labels {
+					podLabels[k] = v
+				}
+				pod := &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fmt.Sprintf("%s-abcd-xyz", deployment.Name),
+						Namespace: deployment.Namespace,
+						Labels:    podLabels,
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								APIVersion: "apps/v1",
+								Kind:       "ReplicaSet",
+								Name:       fmt.Sprintf("%s-abcd", deployment.Name),
+								Controller: pointer.Bool(true),
+							},
+						},
+					},
+					Spec: corev1.PodSpec{
+						NodeName: fmt.Sprintf("node%d", 3-i),
+					},
+					Status: corev1.PodStatus{
+						Phase: phases[i],
+					},
+				}
+				err = cl.Create(context.TODO(), pod)
+				gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			}
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), client.ObjectKeyFromObject(cr), cr)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(cr.Status.StoragePoolStatuses).To(gomega.HaveLen(1))
+			gomega.Expect(cr.Status.StoragePoolStatuses[0].Nodes).To(gomega.Equal([]string{"node2", "node3"}))
+		})
+
 		ginkgo.It("should allow creation and deletion of mixed CR", func() {
 			blockMode := corev1.PersistentVolumeBlock
 			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateVolumeModeAndBasicCr("template", &blockMode))
@@ -181,8 +740,7 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 			gomega.Expect(len(cr.Status.StoragePoolStatuses)).To(gomega.Equal(2))
 			req := reconcile.Request{
 				NamespacedName: types.NamespacedName{
-					Name:      "test-name",
-					Namespace: testNamespace,
+					Name: "test-name",
 				},
 			}
 			gomega.Expect(len(cr.Status.StoragePoolStatuses)).To(gomega.Equal(2))
@@ -224,8 +782,7 @@ var _ = ginkgo.Describe("Controller reconcile loop", func() {
 func scaleClusterNodesAndDsUp(start, end int, cr *hppv1.HostPathProvisioner, r *ReconcileHostPathProvisioner, cl client.Client) {
 	req := reconcile.Request{
 		NamespacedName: types.NamespacedName{
-			Name:      "test-name",
-			Namespace: testNamespace,
+			Name: "test-name",
 		},
 	}
 	addNodesToCluster(start, end, cl)
@@ -272,8 +829,7 @@ func scaleClusterNodesAndDsUp(start, end int, cr *hppv1.HostPathProvisioner, r *
 func scaleClusterNodesAndDsDown(start, end, newCount int, _ *hppv1.HostPathProvisioner, r *ReconcileHostPathProvisioner, cl client.Client) {
 	req := reconcile.Request{
 		NamespacedName: types.NamespacedName{
-			Name:      "test-name",
-			Namespace: testNamespace,
+			Name: "test-name",
 		},
 	}
 	removeNodesFromCluster(start, end, cl)
@@ -405,3 +961,22 @@ func deleteCsiDsPods(start, end int, _ *appsv1.DaemonSet, cl client.Client) {
 		gomega.Expect(err).ToNot(gomega.HaveOccurred())
 	}
 }
+
+func provisionedVolumesValues() map[string]float64 {
+	families, err := runtimemetrics.Registry.Gather()
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	values := make(map[string]float64)
+	for _, family := range families {
+		if family.GetName() != "kubevirt_hpp_provisioned_volumes" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "storage_pool" {
+					values[label.GetValue()] = metric.GetGauge().GetValue()
+				}
+			}
+		}
+	}
+	return values
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hostpathprovisionerv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
+)
+
+const (
+	// managedStorageProfileAnnotation marks the StorageProfiles the operator sets the claim property sets of. CDI
+	// creates a StorageProfile for every StorageClass itself, so the profile is not labeled like the other managed
+	// objects, the labels belong to CDI.
+	managedStorageProfileAnnotation = "hostpathprovisioner.kubevirt.io/managedStorageProfile"
+)
+
+// The CDI API is not part of kubernetes, the StorageProfile is managed as an unstructured object so the operator
+// does not depend on the CDI client.
+var storageProfileGVK = schema.GroupVersionKind{
+	Group:   "cdi.kubevirt.io",
+	Version: "v1beta1",
+	Kind:    "StorageProfile",
+}
+
+// reconcileStorageProfiles sets the claim property sets of the CDI StorageProfiles of the StorageClasses the operator
+// creates while the CDI integration is enabled, and removes the managed StorageProfiles that are no longer desired.
+// CDI recreates a removed StorageProfile with its own defaults.
+func (r *ReconcileHostPathProvisioner) reconcileStorageProfiles(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner) (reconcile.Result, error) {
+	enabled := isCDIIntegrationEnabled(cr)
+	current, err := r.currentStorageProfiles(ctx)
+	if meta.IsNoMatchError(err) && enabled {
+		// Without CDI nothing uses the StorageProfiles, there is nothing to fail.
+		reqLogger.V(3).Info("StorageProfile CRD is not installed, skipping")
+		r.recordEventOnce(cr, corev1.EventTypeWarning, storageProfileUnavailable, storageProfileUnavailableMessage)
+		return reconcile.Result{}, nil
+	}
+	r.forgetEvent(storageProfileUnavailable)
+	if meta.IsNoMatchError(err) {
+		return reconcile.Result{}, nil
+	} else if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	desiredNames := make(map[string]bool)
+	if enabled {
+		for _, storagePool := range cr.Spec.StoragePools {
+			if !storagePool.CreateStorageClass {
+				continue
+			}
+			desiredNames[storagePool.Name] = true
+			if err := r.reconcileStorageProfile(ctx, reqLogger, cr, createStorageProfileObject(storagePool.Name)); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+	}
+	for i := range current {
+		if desiredNames[current[i].GetName()] {
+			continue
+		}
+		if err := r.deleteStorageProfile(ctx, reqLogger, cr, &current[i]); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{}, nil
+}
+
+func (r *ReconcileHostPathProvisioner) reconcileStorageProfile(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, desired *unstructured.Unstructured) error {
+	setLastAppliedConfiguration(desired)
+
+	// Check if this StorageProfile already exists
+	found := newStorageProfileObject()
+	err := r.client.Get(ctx, client.ObjectKeyFromObject(desired), found)
+	if err != nil && errors.IsNotFound(err) {
+		// CDI has not created the StorageProfile yet, it keeps the claim property sets once it does.
+		reqLogger.Info("Creating a new StorageProfile", "StorageProfile.Name", desired.GetName())
+		if err := r.applyObject(ctx, desired, nil); err != nil {
+			r.recorder.Event(cr, corev1.EventTypeWarning, createResourceFailed, fmt.Sprintf(createMessageFailed, desired.GetName(), err))
+			return err
+		}
+		r.recorder.Event(cr, corev1.EventTypeNormal, createResourceSuccess, fmt.Sprintf(createMessageSucceeded, desired, desired.GetName()))
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if r.isUpToDate(desired, found) {
+		reqLogger.V(3).Info("Skip reconcile: StorageProfile is up to date", "StorageProfile.Name", found.GetName())
+		return nil
+	}
+
+	// Keep a copy of the original for comparison later.
+	currentRuntimeObjCopy := found.DeepCopy()
+
+	// allow users to add new annotations (but not change ours)
+	mergeLabelsAndAnnotations(desired, found)
+	// The rest of the spec, like the clone strategy, is left to CDI and the users.
+	claimPropertySets, _, _ := unstructured.NestedSlice(desired.Object, "spec", "claimPropertySets")
+	if err := unstructured.SetNestedSlice(found.Object, claimPropertySets, "spec", "claimPropertySets"); err != nil {
+		return err
+	}
+
+	if !reflect.DeepEqual(currentRuntimeObjCopy, found) {
+		logJSONDiff(reqLogger, currentRuntimeObjCopy, found)
+		// Current is different from desired, update.
+		reqLogger.Info("Updating StorageProfile", "StorageProfile.Name", desired.GetName())
+		if err := r.applyObject(ctx, desired, found); err != nil {
+			r.recorder.Event(cr, corev1.EventTypeWarning, updateResourceFailed, fmt.Sprintf(updateMessageFailed, desired.GetName(), err))
+			return err
+		}
+		r.recorder.Event(cr, corev1.EventTypeNormal, updateResourceSuccess, fmt.Sprintf(updateMessageSucceeded, desired, desired.GetName()))
+		return nil
+	}
+
+	// StorageProfile already exists and matches the desired state - don't requeue
+	r.markUpToDate(found)
+	reqLogger.V(3).Info("Skip reconcile: StorageProfile already exists", "StorageProfile.Name", found.GetName())
+	return nil
+}
+
+// deleteStorageProfiles removes the StorageProfiles the operator manages.
+func (r *ReconcileHostPathProvisioner) deleteStorageProfiles(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner) error {
+	current, err := r.currentStorageProfiles(ctx)
+	if meta.IsNoMatchError(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	for i := range current {
+		if err := r.deleteStorageProfile(ctx, reqLogger, cr, &current[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ReconcileHostPathProvisioner) deleteStorageProfile(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, storageProfile *unstructured.Unstructured) error {
+	reqLogger.Info("Deleting StorageProfile", "StorageProfile.Name", storageProfile.GetName())
+	if err := r.client.Delete(ctx, storageProfile); err != nil && !errors.IsNotFound(err) {
+		r.recorder.Event(cr, corev1.EventTypeWarning, deleteResourceFailed, fmt.Sprintf(deleteMessageFailed, storageProfile.GetName(), err))
+		return err
+	}
+	r.recorder.Event(cr, corev1.EventTypeNormal, deleteResourceSuccess, fmt.Sprintf(deleteMessageSucceeded, storageProfile, storageProfile.GetName()))
+	return nil
+}
+
+// currentStorageProfiles returns the StorageProfiles the operator manages.
+func (r *ReconcileHostPathProvisioner) currentStorageProfiles(ctx context.Context) ([]unstructured.Unstructured, error) {
+	storageProfileList := &unstructured.UnstructuredList{}
+	storageProfileList.SetGroupVersionKind(storageProfileGVK.GroupVersion().WithKind(storageProfileGVK.Kind + "List"))
+	if err := r.client.List(ctx, storageProfileList); err != nil {
+		return nil, err
+	}
+	var managed []unstructured.Unstructured
+	for _, storageProfile := range storageProfileList.Items {
+		if isManagedStorageProfile(&storageProfile) {
+			managed = append(managed, storageProfile)
+		}
+	}
+	return managed, nil
+}
+
+func isManagedStorageProfile(storageProfile *unstructured.Unstructured) bool {
+	return storageProfile.GetAnnotations()[managedStorageProfileAnnotation] == "true"
+}
+
+func isCDIIntegrationEnabled(cr *hostpathprovisionerv1.HostPathProvisioner) bool {
+	return cr.Spec.Integrations != nil && cr.Spec.Integrations.CDI
+}
+
+func newStorageProfileObject() *unstructured.Unstructured {
+	storageProfile := &unstructured.Unstructured{}
+	storageProfile.SetGroupVersionKind(storageProfileGVK)
+	return storageProfile
+}
+
+// createStorageProfileObject returns the StorageProfile of the StorageClass. The hostpath volumes are directories on
+// a single node, so the volumes are ReadWriteOnce filesystem volumes.
+func createStorageProfileObject(name string) *unstructured.Unstructured {
+	storageProfile := newStorageProfileObject()
+	storageProfile.SetName(name)
+	storageProfile.SetAnnotations(map[string]string{
+		managedStorageProfileAnnotation: "true",
+	})
+	storageProfile.Object["spec"] = map[string]interface{}{
+		"claimPropertySets": []interface{}{
+			map[string]interface{}{
+				"accessModes": []interface{}{string(corev1.ReadWriteOnce)},
+				"volumeMode":  string(corev1.PersistentVolumeFilesystem),
+			},
+		},
+	}
+	return storageProfile
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"context"
+	"fmt"
+
+	ginkgo "github.com/onsi/ginkgo/v2"
+	gomega "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hppv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
+	"kubevirt.io/hostpath-provisioner-operator/version"
+)
+
+var _ = ginkgo.Describe("Controller reconcile loop", func() {
+	ginkgo.Context("storageprofile", func() {
+		var (
+			req = reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			storageProfileNN = types.NamespacedName{
+				Name: "local",
+			}
+		)
+
+		ginkgo.BeforeEach(func() {
+			watchNamespaceFunc = func() (string, error) {
+				return testNamespace, nil
+			}
+			version.VersionStringFunc = func() (string, error) {
+				return versionString, nil
+			}
+		})
+
+		setCDIIntegration := func(cl client.Client, cr *hppv1.HostPathProvisioner, enabled bool) {
+			err := cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.Integrations = &hppv1.IntegrationsConfig{
+				CDI: enabled,
+			}
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		}
+
+		getClaimPropertySets := func(storageProfile *unstructured.Unstructured) []interface{} {
+			claimPropertySets, found, err := unstructured.NestedSlice(storageProfile.Object, "spec", "claimPropertySets")
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(found).To(gomega.BeTrue())
+			return claimPropertySets
+		}
+
+		claimPropertySet := func(accessMode corev1.PersistentVolumeAccessMode) interface{} {
+			return map[string]interface{}{
+				"accessModes": []interface{}{string(accessMode)},
+				"volumeMode":  string(corev1.PersistentVolumeFilesystem),
+			}
+		}
+
+		ginkgo.It("Should create the StorageProfile when the CDI integration is enabled, and remove it when disabled", func() {
+			cr, r, cl := createDeployedCr(createStorageClassCr(true))
+			err := cl.Get(context.TODO(), storageProfileNN, newStorageProfileObject())
+			gomega.Expect(errors.IsNotFound(err)).To(gomega.BeTrue())
+
+			ginkgo.By("Enabling the CDI integration")
+			setCDIIntegration(cl, cr, true)
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			storageProfile := newStorageProfileObject()
+			err = cl.Get(context.TODO(), storageProfileNN, storageProfile)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(getClaimPropertySets(storageProfile)).To(gomega.Equal([]interface{}{claimPropertySet(corev1.ReadWriteOnce)}))
+			gomega.Expect(isManagedStorageProfile(storageProfile)).To(gomega.BeTrue())
+
+			ginkgo.By("Disabling the CDI integration")
+			setCDIIntegration(cl, cr, false)
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), storageProfileNN, newStorageProfileObject())
+			gomega.Expect(errors.IsNotFound(err)).To(gomega.BeTrue())
+		})
+
+		ginkgo.It("Should set the claim property sets of the StorageProfile created by CDI", func() {
+			cr, r, cl := createDeployedCr(createStorageClassCr(true))
+			storageProfile := newStorageProfileObject()
+			storageProfile.SetName(storageProfileNN.Name)
+			storageProfile.Object["spec"] = map[string]interface{}{}
+			err := cl.Create(context.TODO(), storageProfile)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			setCDIIntegration(cl, cr, true)
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), storageProfileNN, storageProfile)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(getClaimPropertySets(storageProfile)).To(gomega.Equal([]interface{}{claimPropertySet(corev1.ReadWriteOnce)}))
+			gomega.Expect(isManagedStorageProfile(storageProfile)).To(gomega.BeTrue())
+		})
+
+		ginkgo.It("Should not touch a StorageProfile of another StorageClass", func() {
+			cr, r, cl := createDeployedCr(createStorageClassCr(true))
+			storageProfile := newStorageProfileObject()
+			storageProfile.SetName("other")
+			err := cl.Create(context.TODO(), storageProfile)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			setCDIIntegration(cl, cr, true)
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			setCDIIntegration(cl, cr, false)
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), types.NamespacedName{Name: "other"}, storageProfile)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(isManagedStorageProfile(storageProfile)).To(gomega.BeFalse())
+		})
+
+		ginkgo.It("Should remove the StorageProfile when the CR is deleted", func() {
+			cr, r, cl := createDeployedCr(createStorageClassCr(true))
+			setCDIIntegration(cl, cr, true)
+			_, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), storageProfileNN, newStorageProfileObject())
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Delete(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), storageProfileNN, newStorageProfileObject())
+			gomega.Expect(errors.IsNotFound(err)).To(gomega.BeTrue())
+		})
+
+		ginkgo.It("Should not fail if the StorageProfile CRD is not installed", func() {
+			cr, r, cl := createDeployedCr(createStorageClassCr(true))
+			recorder, ok := r.recorder.(*record.FakeRecorder)
+			gomega.Expect(ok).To(gomega.BeTrue())
+			for len(recorder.Events) > 0 {
+				<-recorder.Events
+			}
+			setCDIIntegration(cl, cr, true)
+			r.client = noMatchStorageProfileFakeCtrlRuntimeClient{
+				Client: cl,
+			}
+			_, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(IsCrHealthy(cr)).To(gomega.BeTrue())
+			unavailable := fmt.Sprintf("%s %s %s", corev1.EventTypeWarning, storageProfileUnavailable, storageProfileUnavailableMessage)
+			gomega.Expect(recorder.Events).To(gomega.Receive(gomega.Equal(unavailable)))
+
+			ginkgo.By("Not warning again while the CRD is missing")
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			for len(recorder.Events) > 0 {
+				gomega.Expect(<-recorder.Events).ToNot(gomega.Equal(unavailable))
+			}
+
+			ginkgo.By("Deleting the CR without the StorageProfile CRD")
+			err = cl.Delete(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+	})
+})
+
+// noMatchStorageProfileFakeCtrlRuntimeClient mimics a cluster without the CDI CRDs.
+type noMatchStorageProfileFakeCtrlRuntimeClient struct {
+	client.Client
+}
+
+func (p noMatchStorageProfileFakeCtrlRuntimeClient) noMatchError() error {
+	return &meta.NoKindMatchError{
+		GroupKind:        storageProfileGVK.GroupKind(),
+		SearchedVersions: []string{storageProfileGVK.Version},
+	}
+}
+
+func (p noMatchStorageProfileFakeCtrlRuntimeClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if u, ok := obj.(*unstructured.Unstructured); ok && u.GroupVersionKind() == storageProfileGVK {
+		return p.noMatchError()
+	}
+	return p.Client.Get(ctx, key, obj, opts...)
+}
+
+func (p noMatchStorageProfileFakeCtrlRuntimeClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if u, ok := list.(*unstructured.UnstructuredList); ok && u.GroupVersionKind().GroupKind() == (storageProfileGVK.GroupVersion().WithKind(storageProfileGVK.Kind+"List")).GroupKind() {
+		return p.noMatchError()
+	}
+	return p.Client.List(ctx, list, opts...)
+}
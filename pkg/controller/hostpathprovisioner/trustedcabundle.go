@@ -0,0 +1,216 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	hostpathprovisionerv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
+	"kubevirt.io/hostpath-provisioner-operator/pkg/util"
+)
+
+const (
+	// trustedCABundleConfigMapName is the name of the ConfigMap the operator creates for OpenShift to inject the cluster
+	// trust bundle into.
+	trustedCABundleConfigMapName = "hostpath-provisioner-trusted-ca-bundle"
+	injectTrustedCABundleLabel   = "config.openshift.io/inject-trusted-cabundle"
+	// defaultTrustedCABundleKey is the key OpenShift injects the cluster trust bundle under.
+	defaultTrustedCABundleKey = "ca-bundle.crt"
+	trustedCABundleVolumeName = "trusted-ca-bundle"
+	// The bundle replaces the extracted trust store of the images.
+	trustedCABundleMountPath = "/etc/pki/ca-trust/extracted/pem"
+	trustedCABundleFileName  = "tls-ca-bundle.pem"
+	// trustedCABundleHashAnnotation is set on the pod template, so the pods are restarted when the bundle changes. The
+	// processes only read the trust store when they start.
+	trustedCABundleHashAnnotation = "hostpathprovisioner.kubevirt.io/trusted-ca-bundle-hash"
+)
+
+// reconcileTrustedCABundle creates the ConfigMap OpenShift injects the cluster trust bundle into while the workload asks
+// for it, and removes it otherwise. A ConfigMap referenced by configMapName is managed by the user.
+func (r *ReconcileHostPathProvisioner) reconcileTrustedCABundle(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) error {
+	if !isTrustedCABundleInjected(cr) {
+		return r.deleteTrustedCABundleConfigMap(ctx, reqLogger, cr, namespace)
+	}
+	desired := createTrustedCABundleConfigMapObject(namespace)
+	setLastAppliedConfiguration(desired)
+	if err := controllerutil.SetControllerReference(cr, desired, r.scheme); err != nil {
+		return err
+	}
+
+	found := &corev1.ConfigMap{}
+	err := r.client.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, found)
+	if err != nil && errors.IsNotFound(err) {
+		reqLogger.Info("Creating a new ConfigMap", "ConfigMap.Namespace", desired.Namespace, "ConfigMap.Name", desired.Name)
+		if err := r.applyObject(ctx, desired, nil); err != nil {
+			r.recorder.Event(cr, corev1.EventTypeWarning, createResourceFailed, fmt.Sprintf(createMessageFailed, desired.Name, err))
+			return err
+		}
+		r.recorder.Event(cr, corev1.EventTypeNormal, createResourceSuccess, fmt.Sprintf(createMessageSucceeded, desired, desired.Name))
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if err := r.repairControllerReference(ctx, reqLogger, cr, found); err != nil {
+		return err
+	}
+	if r.isUpToDate(desired, found) {
+		reqLogger.V(3).Info("Skip reconcile: ConfigMap is up to date", "ConfigMap.Namespace", found.Namespace, "ConfigMap.Name", found.Name)
+		return nil
+	}
+
+	// Keep a copy of the original for comparison later.
+	currentRuntimeObjCopy := found.DeepCopy()
+	// allow users to add new annotations (but not change ours), the data is injected by OpenShift.
+	mergeLabelsAndAnnotations(desired, found)
+	if !reflect.DeepEqual(currentRuntimeObjCopy, found) {
+		logJSONDiff(reqLogger, currentRuntimeObjCopy, found)
+		reqLogger.Info("Updating ConfigMap", "ConfigMap.Name", desired.Name)
+		if err := r.applyObject(ctx, desired, found); err != nil {
+			r.recorder.Event(cr, corev1.EventTypeWarning, updateResourceFailed, fmt.Sprintf(updateMessageFailed, desired.Name, err))
+			return err
+		}
+		r.recorder.Event(cr, corev1.EventTypeNormal, updateResourceSuccess, fmt.Sprintf(updateMessageSucceeded, desired, desired.Name))
+		return nil
+	}
+	r.markUpToDate(found)
+	reqLogger.V(3).Info("Skip reconcile: ConfigMap already exists", "ConfigMap.Namespace", found.Namespace, "ConfigMap.Name", found.Name)
+	return nil
+}
+
+// deleteTrustedCABundleConfigMap removes the ConfigMap of the injected trust bundle, if the HostPathProvisioner controls it.
+func (r *ReconcileHostPathProvisioner) deleteTrustedCABundleConfigMap(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) error {
+	found := &corev1.ConfigMap{}
+	err := r.client.Get(ctx, types.NamespacedName{Name: trustedCABundleConfigMapName, Namespace: namespace}, found)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(found, cr) {
+		return nil
+	}
+	reqLogger.Info("Deleting ConfigMap", "ConfigMap.Name", found.Name)
+	if err := r.client.Delete(ctx, found); err != nil && !errors.IsNotFound(err) {
+		r.recorder.Event(cr, corev1.EventTypeWarning, deleteResourceFailed, fmt.Sprintf(deleteMessageFailed, found.Name, err))
+		return err
+	}
+	r.recorder.Event(cr, corev1.EventTypeNormal, deleteResourceSuccess, fmt.Sprintf(deleteMessageSucceeded, found, found.Name))
+	return nil
+}
+
+// getTrustedCABundleHash returns the hash of the trusted CA bundle, empty if no bundle is configured or the ConfigMap
+// doesn't have it yet. Without the ConfigMap the pods cannot start, which shows in the status of the DaemonSets.
+func (r *ReconcileHostPathProvisioner) getTrustedCABundleHash(ctx context.Context, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) (string, error) {
+	if cr.Spec.Workload.TrustedCABundle == nil {
+		return "", nil
+	}
+	configMap := &corev1.ConfigMap{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: getTrustedCABundleConfigMapName(cr), Namespace: namespace}, configMap); err != nil {
+		if errors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	bundle, ok := configMap.Data[getTrustedCABundleKey(cr)]
+	if !ok {
+		return "", nil
+	}
+	hash := sha256.Sum256([]byte(bundle))
+	return hex.EncodeToString(hash[:]), nil
+}
+
+func isTrustedCABundleInjected(cr *hostpathprovisionerv1.HostPathProvisioner) bool {
+	return cr.Spec.Workload.TrustedCABundle != nil && cr.Spec.Workload.TrustedCABundle.InjectClusterTrustBundle
+}
+
+func getTrustedCABundleConfigMapName(cr *hostpathprovisionerv1.HostPathProvisioner) string {
+	if isTrustedCABundleInjected(cr) || cr.Spec.Workload.TrustedCABundle == nil {
+		return trustedCABundleConfigMapName
+	}
+	return cr.Spec.Workload.TrustedCABundle.ConfigMapName
+}
+
+func getTrustedCABundleKey(cr *hostpathprovisionerv1.HostPathProvisioner) string {
+	if cr.Spec.Workload.TrustedCABundle != nil && cr.Spec.Workload.TrustedCABundle.Key != "" {
+		return cr.Spec.Workload.TrustedCABundle.Key
+	}
+	return defaultTrustedCABundleKey
+}
+
+// addTrustedCABundle mounts the trusted CA bundle in all the containers of the pod template.
+func addTrustedCABundle(cr *hostpathprovisionerv1.HostPathProvisioner, hash string, template *corev1.PodTemplateSpec) {
+	if cr.Spec.Workload.TrustedCABundle == nil {
+		return
+	}
+	template.Spec.Volumes = append(template.Spec.Volumes, corev1.Volume{
+		Name: trustedCABundleVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: getTrustedCABundleConfigMapName(cr),
+				},
+				Items: []corev1.KeyToPath{
+					{
+						Key:  getTrustedCABundleKey(cr),
+						Path: trustedCABundleFileName,
+					},
+				},
+			},
+		},
+	})
+	for i := range template.Spec.Containers {
+		template.Spec.Containers[i].VolumeMounts = append(template.Spec.Containers[i].VolumeMounts, corev1.VolumeMount{
+			Name:      trustedCABundleVolumeName,
+			MountPath: trustedCABundleMountPath,
+			ReadOnly:  true,
+		})
+	}
+	if hash != "" {
+		if template.Annotations == nil {
+			template.Annotations = make(map[string]string)
+		}
+		template.Annotations[trustedCABundleHashAnnotation] = hash
+	}
+}
+
+func createTrustedCABundleConfigMapObject(namespace string) *corev1.ConfigMap {
+	labels := util.GetRecommendedLabels()
+	labels[injectTrustedCABundleLabel] = "true"
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      trustedCABundleConfigMapName,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+	}
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"context"
+	"fmt"
+
+	ginkgo "github.com/onsi/ginkgo/v2"
+	gomega "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hppv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
+	"kubevirt.io/hostpath-provisioner-operator/version"
+)
+
+var _ = ginkgo.Describe("Controller reconcile loop", func() {
+	ginkgo.Context("trusted CA bundle", func() {
+		var (
+			req = reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			dsNN = types.NamespacedName{
+				Name:      fmt.Sprintf("%s-csi", MultiPurposeHostPathProvisionerName),
+				Namespace: testNamespace,
+			}
+			configMapNN = types.NamespacedName{
+				Name:      trustedCABundleConfigMapName,
+				Namespace: testNamespace,
+			}
+		)
+
+		ginkgo.BeforeEach(func() {
+			watchNamespaceFunc = func() (string, error) {
+				return testNamespace, nil
+			}
+			version.VersionStringFunc = func() (string, error) {
+				return versionString, nil
+			}
+		})
+
+		getCSIDaemonSet := func(cl client.Client) *appsv1.DaemonSet {
+			ds := &appsv1.DaemonSet{}
+			err := cl.Get(context.TODO(), dsNN, ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			return ds
+		}
+
+		verifyTrustedCABundleMounted := func(ds *appsv1.DaemonSet, configMapName, key string) {
+			gomega.Expect(ds.Spec.Template.Spec.Volumes).To(gomega.ContainElement(corev1.Volume{
+				Name: trustedCABundleVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: configMapName},
+						Items:                []corev1.KeyToPath{{Key: key, Path: trustedCABundleFileName}},
+					},
+				},
+			}))
+			for _, container := range ds.Spec.Template.Spec.Containers {
+				gomega.Expect(container.VolumeMounts).To(gomega.ContainElement(corev1.VolumeMount{
+					Name:      trustedCABundleVolumeName,
+					MountPath: trustedCABundleMountPath,
+					ReadOnly:  true,
+				}), container.Name)
+			}
+		}
+
+		ginkgo.It("Should not mount a trusted CA bundle by default", func() {
+			_, _, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			ds := getCSIDaemonSet(cl)
+			for _, volume := range ds.Spec.Template.Spec.Volumes {
+				gomega.Expect(volume.Name).ToNot(gomega.Equal(trustedCABundleVolumeName))
+			}
+			gomega.Expect(ds.Spec.Template.Annotations).ToNot(gomega.HaveKey(trustedCABundleHashAnnotation))
+			err := cl.Get(context.TODO(), configMapNN, &corev1.ConfigMap{})
+			gomega.Expect(errors.IsNotFound(err)).To(gomega.BeTrue())
+		})
+
+		ginkgo.It("Should mount the configured ConfigMap, and hash the bundle in the pod template", func() {
+			cr := createStoragePoolWithTemplateCr()
+			cr.Spec.Workload.TrustedCABundle = &hppv1.TrustedCABundleConfig{
+				ConfigMapName: "internal-ca",
+				Key:           "ca.pem",
+			}
+			_, r, cl := createDeployedCr(cr)
+			ds := getCSIDaemonSet(cl)
+			verifyTrustedCABundleMounted(ds, "internal-ca", "ca.pem")
+			gomega.Expect(ds.Spec.Template.Annotations).ToNot(gomega.HaveKey(trustedCABundleHashAnnotation))
+
+			ginkgo.By("Creating the ConfigMap with the bundle")
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "internal-ca",
+					Namespace: testNamespace,
+				},
+				Data: map[string]string{
+					"ca.pem": "first",
+				},
+			}
+			err := cl.Create(context.TODO(), configMap)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(getCSIDaemonSet(cl).Spec.Template.Annotations[trustedCABundleHashAnnotation]).ToNot(gomega.BeEmpty())
+
+			ginkgo.By("Not creating a ConfigMap of its own")
+			err = cl.Get(context.TODO(), configMapNN, &corev1.ConfigMap{})
+			gomega.Expect(errors.IsNotFound(err)).To(gomega.BeTrue())
+			gomega.Expect(hppTrustedCABundleMapFunc(cl)(context.TODO(), configMap)).To(gomega.HaveLen(1))
+			gomega.Expect(hppTrustedCABundleMapFunc(cl)(context.TODO(), &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "other"}})).To(gomega.BeEmpty())
+		})
+
+		ginkgo.It("Should roll out the DaemonSet when the ConfigMap of the bundle is edited", func() {
+			cr := createStoragePoolWithTemplateCr()
+			cr.Spec.Workload.TrustedCABundle = &hppv1.TrustedCABundleConfig{
+				ConfigMapName: "internal-ca",
+				Key:           "ca.pem",
+			}
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "internal-ca",
+					Namespace: testNamespace,
+				},
+				Data: map[string]string{
+					"ca.pem": "first",
+				},
+			}
+			_, r, cl := createDeployedCr(cr)
+			err := cl.Create(context.TODO(), configMap)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			ds := getCSIDaemonSet(cl)
+			firstHash := ds.Spec.Template.Annotations[trustedCABundleHashAnnotation]
+			gomega.Expect(firstHash).ToNot(gomega.BeEmpty())
+
+			ginkgo.By("Editing the bundle in the ConfigMap")
+			configMap.Data["ca.pem"] = "second"
+			err = cl.Update(context.TODO(), configMap)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(hppTrustedCABundleMapFunc(cl)(context.TODO(), configMap)).To(gomega.ConsistOf(reconcile.Request{NamespacedName: types.NamespacedName{Name: cr.Name}}))
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			rolled := getCSIDaemonSet(cl)
+			gomega.Expect(rolled.Spec.Template.Annotations[trustedCABundleHashAnnotation]).ToNot(gomega.BeEmpty())
+			gomega.Expect(rolled.Spec.Template.Annotations[trustedCABundleHashAnnotation]).ToNot(gomega.Equal(firstHash))
+			verifyTrustedCABundleMounted(rolled, "internal-ca", "ca.pem")
+
+			ginkgo.By("Not rolling out again if the bundle is unchanged")
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(getCSIDaemonSet(cl).Spec.Template).To(gomega.Equal(rolled.Spec.Template))
+		})
+
+		ginkgo.It("Should create the ConfigMap for the injected cluster trust bundle, and remove it when disabled", func() {
+			cr := createStoragePoolWithTemplateCr()
+			cr.Spec.Workload.TrustedCABundle = &hppv1.TrustedCABundleConfig{
+				InjectClusterTrustBundle: true,
+			}
+			cr, r, cl := createDeployedCr(cr)
+			configMap := &corev1.ConfigMap{}
+			err := cl.Get(context.TODO(), configMapNN, configMap)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(configMap.Labels).To(gomega.HaveKeyWithValue(injectTrustedCABundleLabel, "true"))
+			gomega.Expect(metav1.IsControlledBy(configMap, cr)).To(gomega.BeTrue())
+			verifyTrustedCABundleMounted(getCSIDaemonSet(cl), trustedCABundleConfigMapName, defaultTrustedCABundleKey)
+
+			ginkgo.By("Disabling the trusted CA bundle")
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.Workload.TrustedCABundle = nil
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), configMapNN, &corev1.ConfigMap{})
+			gomega.Expect(errors.IsNotFound(err)).To(gomega.BeTrue())
+			for _, volume := range getCSIDaemonSet(cl).Spec.Template.Spec.Volumes {
+				gomega.Expect(volume.Name).ToNot(gomega.Equal(trustedCABundleVolumeName))
+			}
+		})
+
+		ginkgo.It("Should mount the trusted CA bundle in the legacy provisioner", func() {
+			cr := createLegacyCr()
+			cr.Spec.Workload.TrustedCABundle = &hppv1.TrustedCABundleConfig{
+				ConfigMapName: "internal-ca",
+			}
+			_, _, cl := createDeployedCr(cr)
+			ds := &appsv1.DaemonSet{}
+			err := cl.Get(context.TODO(), types.NamespacedName{Name: MultiPurposeHostPathProvisionerName, Namespace: testNamespace}, ds)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			verifyTrustedCABundleMounted(ds, "internal-ca", defaultTrustedCABundleKey)
+		})
+	})
+})
@@ -35,6 +35,9 @@ const (
 	createVersionLabel          = "hostpathprovisioner.kubevirt.io/createVersion"
 	updateVersionLabel          = "hostpathprovisioner.kubevirt.io/updateVersion"
 	lastAppliedConfigAnnotation = "hostpathprovisioner.kubevirt.io/lastAppliedConfiguration"
+	// desiredHashAnnotation is the hash of the last applied configuration, so an unchanged desired state can be
+	// detected without comparing the objects.
+	desiredHashAnnotation = "hostpathprovisioner.kubevirt.io/desiredHash"
 )
 
 func mergeLabelsAndAnnotations(src, dest metav1.Object) {
@@ -129,6 +132,9 @@ func setLastAppliedConfiguration(obj metav1.Object) error {
 	}
 
 	obj.GetAnnotations()[lastAppliedConfigAnnotation] = string(bytes)
+	hash := fnv.New64a()
+	hash.Write(bytes)
+	obj.GetAnnotations()[desiredHashAnnotation] = fmt.Sprintf("%x", hash.Sum64())
 
 	return nil
 }
@@ -0,0 +1,291 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	conditions "github.com/openshift/custom-resource-status/conditions/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hostpathprovisionerv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
+	"kubevirt.io/hostpath-provisioner-operator/pkg/util"
+)
+
+const (
+	// verifyProvisioningName is the name of the verification Job and of the PVC it provisions.
+	verifyProvisioningName = "hpp-verify-provisioning"
+	// verifyProvisioningMountPath is where the verification Job mounts its volume.
+	verifyProvisioningMountPath = "/data"
+	// verifyProvisioningActiveDeadlineSeconds fails the verification if the volume is not provisioned and written in
+	// time, for instance because the PVC cannot be bound.
+	verifyProvisioningActiveDeadlineSeconds = 300
+	// verifyProvisioningStorageClassAnnotation records the StorageClass the verification Job provisions from.
+	verifyProvisioningStorageClassAnnotation = "hostpathprovisioner.kubevirt.io/verifyStorageClass"
+)
+
+// reconcileProvisioningVerification runs the verification Job once the provisioner is deployed while
+// spec.verifyProvisioning is set, and reports its result in the ProvisioningVerified condition. The result is kept
+// until the verification is disabled, the Job and its PVC are removed once it is known. The CR object needs to be
+// updated by the caller afterwards.
+func (r *ReconcileHostPathProvisioner) reconcileProvisioningVerification(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string, degraded bool) error {
+	if !cr.Spec.VerifyProvisioning {
+		conditions.RemoveStatusCondition(&cr.Status.Conditions, ConditionProvisioningVerified)
+		return r.deleteProvisioningVerification(ctx, reqLogger, cr, namespace)
+	}
+	if condition := conditions.FindStatusCondition(cr.Status.Conditions, ConditionProvisioningVerified); condition != nil && condition.Status != corev1.ConditionUnknown {
+		return r.deleteProvisioningVerification(ctx, reqLogger, cr, namespace)
+	}
+	storageClassName := getVerifyProvisioningStorageClassName(cr)
+	if storageClassName == "" {
+		setCrCondition(cr, conditions.Condition{
+			Type:    ConditionProvisioningVerified,
+			Status:  corev1.ConditionFalse,
+			Reason:  noVerificationStorageClass,
+			Message: noVerificationStorageClassMessage,
+		})
+		return nil
+	}
+
+	job := &batchv1.Job{}
+	err := r.client.Get(ctx, types.NamespacedName{Name: verifyProvisioningName, Namespace: namespace}, job)
+	if errors.IsNotFound(err) {
+		if degraded {
+			// Wait for the provisioner pods to be ready, the volume cannot be provisioned without them.
+			return nil
+		}
+		if err := r.createProvisioningVerification(ctx, reqLogger, cr, namespace, storageClassName); err != nil {
+			return err
+		}
+		setCrCondition(cr, conditions.Condition{
+			Type:    ConditionProvisioningVerified,
+			Status:  corev1.ConditionUnknown,
+			Reason:  provisioningVerificationRunning,
+			Message: fmt.Sprintf(provisioningVerificationRunningMessage, storageClassName),
+		})
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	condition, finished := getProvisioningVerificationResult(job)
+	setCrCondition(cr, condition)
+	if !finished {
+		return nil
+	}
+	r.recorder.Event(cr, getProvisioningVerificationEventType(condition), condition.Reason, condition.Message)
+	return r.deleteProvisioningVerification(ctx, reqLogger, cr, namespace)
+}
+
+// getProvisioningVerificationResult returns the ProvisioningVerified condition of the verification Job, and whether
+// the Job finished.
+func getProvisioningVerificationResult(job *batchv1.Job) (conditions.Condition, bool) {
+	for _, jobCondition := range job.Status.Conditions {
+		if jobCondition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch jobCondition.Type {
+		case batchv1.JobComplete:
+			return conditions.Condition{
+				Type:    ConditionProvisioningVerified,
+				Status:  corev1.ConditionTrue,
+				Reason:  provisioningVerified,
+				Message: provisioningVerifiedMessage,
+			}, true
+		case batchv1.JobFailed:
+			return conditions.Condition{
+				Type:    ConditionProvisioningVerified,
+				Status:  corev1.ConditionFalse,
+				Reason:  provisioningVerificationFailed,
+				Message: fmt.Sprintf(provisioningVerificationFailedMessage, jobCondition.Reason, jobCondition.Message),
+			}, true
+		}
+	}
+	return conditions.Condition{
+		Type:    ConditionProvisioningVerified,
+		Status:  corev1.ConditionUnknown,
+		Reason:  provisioningVerificationRunning,
+		Message: fmt.Sprintf(provisioningVerificationRunningMessage, getVerifyProvisioningJobStorageClassName(job)),
+	}, false
+}
+
+func getProvisioningVerificationEventType(condition conditions.Condition) string {
+	if condition.Status == corev1.ConditionTrue {
+		return corev1.EventTypeNormal
+	}
+	return corev1.EventTypeWarning
+}
+
+func (r *ReconcileHostPathProvisioner) createProvisioningVerification(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace, storageClassName string) error {
+	pvc := createProvisioningVerificationPVCObject(namespace, storageClassName)
+	reqLogger.Info("Creating provisioning verification PVC", "PersistentVolumeClaim.Name", pvc.Name)
+	if err := r.client.Create(ctx, pvc); err != nil && !errors.IsAlreadyExists(err) {
+		r.recorder.Event(cr, corev1.EventTypeWarning, createResourceFailed, fmt.Sprintf(createMessageFailed, pvc.Name, err))
+		return err
+	}
+	job := createProvisioningVerificationJobObject(cr, reqLogger, namespace, storageClassName)
+	reqLogger.Info("Creating provisioning verification Job", "Job.Name", job.Name)
+	if err := r.client.Create(ctx, job); err != nil && !errors.IsAlreadyExists(err) {
+		r.recorder.Event(cr, corev1.EventTypeWarning, createResourceFailed, fmt.Sprintf(createMessageFailed, job.Name, err))
+		return err
+	}
+	r.recorder.Event(cr, corev1.EventTypeNormal, createResourceSuccess, fmt.Sprintf(createMessageSucceeded, job, job.Name))
+	return nil
+}
+
+// deleteProvisioningVerification removes the verification Job and its PVC, the PV is removed by the reclaim policy of
+// the StorageClass.
+func (r *ReconcileHostPathProvisioner) deleteProvisioningVerification(ctx context.Context, reqLogger logr.Logger, cr *hostpathprovisionerv1.HostPathProvisioner, namespace string) error {
+	deletePropagationBackground := metav1.DeletePropagationBackground
+	key := types.NamespacedName{Name: verifyProvisioningName, Namespace: namespace}
+	objects := []client.Object{&batchv1.Job{}, &corev1.PersistentVolumeClaim{}}
+	for _, obj := range objects {
+		if err := r.client.Get(ctx, key, obj); errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		reqLogger.Info("Deleting provisioning verification resource", "name", obj.GetName())
+		if err := r.client.Delete(ctx, obj, &client.DeleteOptions{
+			PropagationPolicy: &deletePropagationBackground,
+		}); err != nil && !errors.IsNotFound(err) {
+			r.recorder.Event(cr, corev1.EventTypeWarning, deleteResourceFailed, fmt.Sprintf(deleteMessageFailed, obj.GetName(), err))
+			return err
+		}
+		r.recorder.Event(cr, corev1.EventTypeNormal, deleteResourceSuccess, fmt.Sprintf(deleteMessageSucceeded, obj, obj.GetName()))
+	}
+	return nil
+}
+
+// getVerifyProvisioningStorageClassName returns the StorageClass the operator creates for the first storage pool with
+// createStorageClass, empty if there is none.
+func getVerifyProvisioningStorageClassName(cr *hostpathprovisionerv1.HostPathProvisioner) string {
+	for _, storagePool := range cr.Spec.StoragePools {
+		if storagePool.CreateStorageClass {
+			return storagePool.Name
+		}
+	}
+	return ""
+}
+
+func getVerifyProvisioningJobStorageClassName(job *batchv1.Job) string {
+	return job.GetAnnotations()[verifyProvisioningStorageClassAnnotation]
+}
+
+func createProvisioningVerificationPVCObject(namespace, storageClassName string) *corev1.PersistentVolumeClaim {
+	volumeMode := corev1.PersistentVolumeFilesystem
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      verifyProvisioningName,
+			Namespace: namespace,
+			Labels:    util.GetRecommendedLabels(),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: &storageClassName,
+			VolumeMode:       &volumeMode,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("1Mi"),
+				},
+			},
+		},
+	}
+}
+
+// createProvisioningVerificationJobObject returns the Job that writes a file to the verification volume and reads it
+// back. The pod is placed like the provisioner pods, so the volume is provisioned on a node the CSI driver runs on.
+func createProvisioningVerificationJobObject(cr *hostpathprovisionerv1.HostPathProvisioner, reqLogger logr.Logger, namespace, storageClassName string) *batchv1.Job {
+	args := getDaemonSetArgs(reqLogger, namespace, false)
+	labels := util.GetRecommendedLabels()
+	verifyFile := fmt.Sprintf("%s/verify", verifyProvisioningMountPath)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      verifyProvisioningName,
+			Namespace: namespace,
+			Labels:    labels,
+			Annotations: map[string]string{
+				verifyProvisioningStorageClassAnnotation: storageClassName,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          pointer.Int32(0),
+			ActiveDeadlineSeconds: pointer.Int64(verifyProvisioningActiveDeadlineSeconds),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName:            getCsiServiceAccountName(cr),
+					RestartPolicy:                 corev1.RestartPolicyNever,
+					TerminationGracePeriodSeconds: pointer.Int64(30),
+					NodeSelector:                  cr.Spec.Workload.NodeSelector,
+					Affinity:                      cr.Spec.Workload.Affinity,
+					Tolerations:                   getWorkloadTolerations(cr),
+					SecurityContext:               &corev1.PodSecurityContext{},
+					Containers: []corev1.Container{
+						{
+							Name:            "verify",
+							Image:           args.operatorImage,
+							ImagePullPolicy: cr.Spec.ImagePullPolicy,
+							Command: []string{
+								"/bin/sh",
+								"-c",
+								fmt.Sprintf("echo %[1]s > %[2]s && grep -q %[1]s %[2]s && rm %[2]s", verifyProvisioningName, verifyFile),
+							},
+							SecurityContext: &corev1.SecurityContext{
+								RunAsUser: pointer.Int64(0),
+							},
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("10m"),
+									corev1.ResourceMemory: resource.MustParse("32Mi"),
+								},
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "data",
+									MountPath: verifyProvisioningMountPath,
+								},
+							},
+							TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "data",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: verifyProvisioningName,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
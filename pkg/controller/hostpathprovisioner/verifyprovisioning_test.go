@@ -0,0 +1,194 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"context"
+	"fmt"
+
+	ginkgo "github.com/onsi/ginkgo/v2"
+	gomega "github.com/onsi/gomega"
+	conditions "github.com/openshift/custom-resource-status/conditions/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hppv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
+	"kubevirt.io/hostpath-provisioner-operator/version"
+)
+
+var _ = ginkgo.Describe("Controller reconcile loop", func() {
+	ginkgo.Context("provisioning verification", func() {
+		var (
+			req = reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+			verifyNN = types.NamespacedName{
+				Name:      verifyProvisioningName,
+				Namespace: testNamespace,
+			}
+		)
+
+		ginkgo.BeforeEach(func() {
+			watchNamespaceFunc = func() (string, error) {
+				return testNamespace, nil
+			}
+			version.VersionStringFunc = func() (string, error) {
+				return versionString, nil
+			}
+		})
+
+		getVerifiedCondition := func(cl client.Client) *conditions.Condition {
+			cr := &hppv1.HostPathProvisioner{}
+			err := cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			return conditions.FindStatusCondition(cr.Status.Conditions, ConditionProvisioningVerified)
+		}
+
+		enableVerification := func(cl client.Client, verifyProvisioning bool) {
+			cr := &hppv1.HostPathProvisioner{}
+			err := cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.VerifyProvisioning = verifyProvisioning
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		}
+
+		expectVerificationDeleted := func(cl client.Client) {
+			err := cl.Get(context.TODO(), verifyNN, &batchv1.Job{})
+			gomega.Expect(errors.IsNotFound(err)).To(gomega.BeTrue())
+			err = cl.Get(context.TODO(), verifyNN, &corev1.PersistentVolumeClaim{})
+			gomega.Expect(errors.IsNotFound(err)).To(gomega.BeTrue())
+		}
+
+		ginkgo.It("Should not verify the provisioning unless verifyProvisioning is set", func() {
+			_, r, cl := createDeployedCr(createStorageClassCr(true))
+			_, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(getVerifiedCondition(cl)).To(gomega.BeNil())
+			expectVerificationDeleted(cl)
+		})
+
+		ginkgo.It("Should run the verification Job and report its result", func() {
+			_, r, cl := createDeployedCr(createStorageClassCr(true))
+			enableVerification(cl, true)
+			_, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			condition := getVerifiedCondition(cl)
+			gomega.Expect(condition).ToNot(gomega.BeNil())
+			gomega.Expect(condition.Status).To(gomega.Equal(corev1.ConditionUnknown))
+			gomega.Expect(condition.Reason).To(gomega.Equal(provisioningVerificationRunning))
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = cl.Get(context.TODO(), verifyNN, pvc)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(*pvc.Spec.StorageClassName).To(gomega.Equal("local"))
+			job := &batchv1.Job{}
+			err = cl.Get(context.TODO(), verifyNN, job)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			ginkgo.By("Completing the Job")
+			job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+			err = cl.Status().Update(context.TODO(), job)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			condition = getVerifiedCondition(cl)
+			gomega.Expect(condition.Status).To(gomega.Equal(corev1.ConditionTrue))
+			gomega.Expect(condition.Reason).To(gomega.Equal(provisioningVerified))
+			expectVerificationDeleted(cl)
+
+			ginkgo.By("Not running the Job again once the result is known")
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(getVerifiedCondition(cl).Status).To(gomega.Equal(corev1.ConditionTrue))
+			expectVerificationDeleted(cl)
+
+			ginkgo.By("Disabling the verification")
+			enableVerification(cl, false)
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(getVerifiedCondition(cl)).To(gomega.BeNil())
+		})
+
+		ginkgo.It("Should report that there is no StorageClass to verify the provisioning with", func() {
+			_, r, cl := createDeployedCr(createStorageClassCr(false))
+			enableVerification(cl, true)
+			_, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			condition := getVerifiedCondition(cl)
+			gomega.Expect(condition).ToNot(gomega.BeNil())
+			gomega.Expect(condition.Status).To(gomega.Equal(corev1.ConditionFalse))
+			gomega.Expect(condition.Reason).To(gomega.Equal(noVerificationStorageClass))
+			expectVerificationDeleted(cl)
+		})
+
+		ginkgo.It("Should create the verification Job like the provisioner pods", func() {
+			cr := createStorageClassCr(true)
+			cr.Spec.ImagePullPolicy = corev1.PullIfNotPresent
+			cr.Spec.Workload = hppv1.NodePlacement{
+				NodeSelector: map[string]string{"kubernetes.io/os": "linux"},
+				Tolerations:  []corev1.Toleration{{Key: "storage", Operator: corev1.TolerationOpExists}},
+			}
+			reqLogger := logf.Log.WithName("verify-provisioning-test")
+			args := getDaemonSetArgs(reqLogger, testNamespace, false)
+			job := createProvisioningVerificationJobObject(cr, reqLogger, testNamespace, "local")
+			gomega.Expect(job.Namespace).To(gomega.Equal(testNamespace))
+			gomega.Expect(getVerifyProvisioningJobStorageClassName(job)).To(gomega.Equal("local"))
+			gomega.Expect(*job.Spec.BackoffLimit).To(gomega.Equal(int32(0)))
+			podSpec := job.Spec.Template.Spec
+			gomega.Expect(podSpec.RestartPolicy).To(gomega.Equal(corev1.RestartPolicyNever))
+			gomega.Expect(podSpec.ServiceAccountName).To(gomega.Equal(ProvisionerServiceAccountNameCsi))
+			gomega.Expect(podSpec.NodeSelector).To(gomega.Equal(cr.Spec.Workload.NodeSelector))
+			gomega.Expect(podSpec.Tolerations).To(gomega.ContainElement(cr.Spec.Workload.Tolerations[0]))
+			gomega.Expect(podSpec.Containers).To(gomega.HaveLen(1))
+			gomega.Expect(podSpec.Containers[0].Image).To(gomega.Equal(args.operatorImage))
+			gomega.Expect(podSpec.Containers[0].ImagePullPolicy).To(gomega.Equal(corev1.PullIfNotPresent))
+			gomega.Expect(podSpec.Containers[0].Command[2]).To(gomega.ContainSubstring(fmt.Sprintf("%s/verify", verifyProvisioningMountPath)))
+			gomega.Expect(podSpec.Volumes).To(gomega.HaveLen(1))
+			gomega.Expect(podSpec.Volumes[0].PersistentVolumeClaim.ClaimName).To(gomega.Equal(verifyProvisioningName))
+
+			pvc := createProvisioningVerificationPVCObject(testNamespace, "local")
+			gomega.Expect(pvc.Name).To(gomega.Equal(verifyProvisioningName))
+			gomega.Expect(*pvc.Spec.StorageClassName).To(gomega.Equal("local"))
+			gomega.Expect(pvc.Spec.AccessModes).To(gomega.ConsistOf(corev1.ReadWriteOnce))
+		})
+
+		ginkgo.DescribeTable("Should interpret the result of the verification Job", func(jobConditions []batchv1.JobCondition, expectedStatus corev1.ConditionStatus, expectedReason string, expectedFinished bool) {
+			job := &batchv1.Job{
+				Status: batchv1.JobStatus{
+					Conditions: jobConditions,
+				},
+			}
+			condition, finished := getProvisioningVerificationResult(job)
+			gomega.Expect(condition.Type).To(gomega.Equal(ConditionProvisioningVerified))
+			gomega.Expect(condition.Status).To(gomega.Equal(expectedStatus))
+			gomega.Expect(condition.Reason).To(gomega.Equal(expectedReason))
+			gomega.Expect(finished).To(gomega.Equal(expectedFinished))
+		},
+			ginkgo.Entry("running", nil, corev1.ConditionUnknown, provisioningVerificationRunning, false),
+			ginkgo.Entry("complete", []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}, corev1.ConditionTrue, provisioningVerified, true),
+			ginkgo.Entry("failed", []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "DeadlineExceeded"}}, corev1.ConditionFalse, provisioningVerificationFailed, true),
+			ginkgo.Entry("not yet failed", []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionFalse}}, corev1.ConditionUnknown, provisioningVerificationRunning, false),
+		)
+	})
+})
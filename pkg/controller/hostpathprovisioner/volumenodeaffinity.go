@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	conditions "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hostpathprovisionerv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
+)
+
+const (
+	// FailedSchedulingReason is the reason of the events the scheduler records for pods it cannot place.
+	FailedSchedulingReason = "FailedScheduling"
+	// volumeNodeAffinityConflictMessage is part of the scheduling failure message of a pod whose volume is bound to a
+	// node the pod cannot be scheduled on, for instance because the PVC was bound on a different node.
+	volumeNodeAffinityConflictMessage = "volume node affinity conflict"
+)
+
+// isVolumeNodeAffinityConflictEvent returns true if the event is a scheduling failure of a pod caused by a volume
+// node affinity conflict.
+func isVolumeNodeAffinityConflictEvent(event *corev1.Event) bool {
+	return event.Type == corev1.EventTypeWarning &&
+		event.Reason == FailedSchedulingReason &&
+		event.InvolvedObject.Kind == "Pod" &&
+		strings.Contains(event.Message, volumeNodeAffinityConflictMessage)
+}
+
+// reconcileVolumeNodeAffinityConflicts sets the VolumeNodeAffinityConflict condition from the scheduling failure
+// events of the pods with a volume node affinity conflict that use volumes of the HPP. The condition is removed if the
+// feature gate is disabled.
+// The CR object needs to be updated by the caller afterwards.
+func (r *ReconcileHostPathProvisioner) reconcileVolumeNodeAffinityConflicts(ctx context.Context, cr *hostpathprovisionerv1.HostPathProvisioner) error {
+	if !r.isFeatureGateEnabled(volumeNodeAffinityConflictFeatureGate, cr) {
+		conditions.RemoveStatusCondition(&cr.Status.Conditions, ConditionVolumeNodeAffinityConflict)
+		return nil
+	}
+	eventList := &corev1.EventList{}
+	if err := r.client.List(ctx, eventList); err != nil {
+		return err
+	}
+	pods := sets.New[types.NamespacedName]()
+	failures := int32(0)
+	for i := range eventList.Items {
+		event := &eventList.Items[i]
+		if !isVolumeNodeAffinityConflictEvent(event) {
+			continue
+		}
+		pod := types.NamespacedName{Namespace: event.InvolvedObject.Namespace, Name: event.InvolvedObject.Name}
+		if usesHpp, err := r.usesHppVolumes(ctx, cr, pod); err != nil {
+			return err
+		} else if !usesHpp {
+			// The volumes of other storage can have the same conflict.
+			continue
+		}
+		pods.Insert(pod)
+		// The count of an event recorded by a serialized event series is kept in the series.
+		count := event.Count
+		if event.Series != nil && event.Series.Count > count {
+			count = event.Series.Count
+		}
+		if count < 1 {
+			count = 1
+		}
+		failures += count
+	}
+	if pods.Len() == 0 {
+		setCrCondition(cr, conditions.Condition{
+			Type:   ConditionVolumeNodeAffinityConflict,
+			Status: corev1.ConditionFalse,
+			Reason: noVolumeNodeAffinityConflict,
+		})
+		return nil
+	}
+	setCrCondition(cr, conditions.Condition{
+		Type:    ConditionVolumeNodeAffinityConflict,
+		Status:  corev1.ConditionTrue,
+		Reason:  volumeNodeAffinityConflict,
+		Message: fmt.Sprintf(volumeNodeAffinityConflictConditionMessage, pods.Len(), failures),
+	})
+	return nil
+}
+
+// usesHppVolumes returns true if the pod has a PVC bound to a PV of the CSI driver of the HPP, or a PVC of a
+// StorageClass with the CSI driver as the provisioner.
+func (r *ReconcileHostPathProvisioner) usesHppVolumes(ctx context.Context, cr *hostpathprovisionerv1.HostPathProvisioner, podName types.NamespacedName) (bool, error) {
+	pod := &corev1.Pod{}
+	// Only the pods of the provisioner are in the cache.
+	if err := r.apiReader.Get(ctx, podName, pod); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil {
+			continue
+		}
+		pvc := &corev1.PersistentVolumeClaim{}
+		if err := r.client.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: volume.PersistentVolumeClaim.ClaimName}, pvc); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return false, err
+		}
+		if pvc.Spec.VolumeName != "" {
+			pv := &corev1.PersistentVolume{}
+			if err := r.client.Get(ctx, types.NamespacedName{Name: pvc.Spec.VolumeName}, pv); err != nil && !errors.IsNotFound(err) {
+				return false, err
+			} else if err == nil && pv.Spec.CSI != nil && pv.Spec.CSI.Driver == getDriverName(cr) {
+				return true, nil
+			}
+		}
+		if pvc.Spec.StorageClassName != nil && *pvc.Spec.StorageClassName != "" {
+			sc := &storagev1.StorageClass{}
+			if err := r.client.Get(ctx, types.NamespacedName{Name: *pvc.Spec.StorageClassName}, sc); err != nil && !errors.IsNotFound(err) {
+				return false, err
+			} else if err == nil && sc.Provisioner == getDriverName(cr) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// hppVolumeNodeAffinityConflictMapFunc returns a map function that maps the scheduling failure events of pods with a
+// volume node affinity conflict to a reconcile request of the HPP, if the HPP enables the detection.
+func hppVolumeNodeAffinityConflictMapFunc(c client.Client) handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []reconcile.Request {
+		event, ok := o.(*corev1.Event)
+		if !ok || !isVolumeNodeAffinityConflictEvent(event) {
+			return nil
+		}
+		hppList, err := getHppList(ctx, c)
+		if err != nil {
+			log.Error(err, "Error getting HPPs")
+			return nil
+		}
+		if size := len(hppList.Items); size != 1 {
+			return nil
+		}
+		if !slices.Contains(hppList.Items[0].Spec.FeatureGates, volumeNodeAffinityConflictFeatureGate) {
+			return nil
+		}
+		return []reconcile.Request{
+			{
+				NamespacedName: types.NamespacedName{
+					Name: hppList.Items[0].Name,
+				},
+			},
+		}
+	}
+}
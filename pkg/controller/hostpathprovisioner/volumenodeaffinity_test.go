@@ -0,0 +1,198 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostpathprovisioner
+
+import (
+	"context"
+	"fmt"
+
+	ginkgo "github.com/onsi/ginkgo/v2"
+	gomega "github.com/onsi/gomega"
+	conditions "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hppv1 "kubevirt.io/hostpath-provisioner-operator/pkg/apis/hostpathprovisioner/v1beta1"
+	"kubevirt.io/hostpath-provisioner-operator/version"
+)
+
+var _ = ginkgo.Describe("Controller reconcile loop", func() {
+	ginkgo.Context("volume node affinity conflicts", func() {
+		var (
+			req = reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: "test-name",
+				},
+			}
+		)
+
+		ginkgo.BeforeEach(func() {
+			watchNamespaceFunc = func() (string, error) {
+				return testNamespace, nil
+			}
+			version.VersionStringFunc = func() (string, error) {
+				return versionString, nil
+			}
+		})
+
+		setDetection := func(cl client.Client, cr *hppv1.HostPathProvisioner, enabled bool) {
+			err := cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			cr.Spec.FeatureGates = nil
+			if enabled {
+				cr.Spec.FeatureGates = []string{volumeNodeAffinityConflictFeatureGate}
+			}
+			err = cl.Update(context.TODO(), cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		}
+
+		createSchedulingEvent := func(cl client.Client, namespace, pod, message string, count int32) *corev1.Event {
+			event := &corev1.Event{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("%s.%d", pod, count),
+					Namespace: namespace,
+				},
+				InvolvedObject: corev1.ObjectReference{
+					Kind:      "Pod",
+					Name:      pod,
+					Namespace: namespace,
+				},
+				Reason:  FailedSchedulingReason,
+				Message: message,
+				Count:   count,
+				Type:    corev1.EventTypeWarning,
+			}
+			err := cl.Create(context.TODO(), event)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			return event
+		}
+
+		createPodWithVolume := func(cl client.Client, namespace, pod, driver string) {
+			pv := &corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: fmt.Sprintf("pv-%s-%s", namespace, pod),
+				},
+				Spec: corev1.PersistentVolumeSpec{
+					PersistentVolumeSource: corev1.PersistentVolumeSource{
+						CSI: &corev1.CSIPersistentVolumeSource{
+							Driver:       driver,
+							VolumeHandle: pod,
+						},
+					},
+				},
+			}
+			pvc := &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      pod,
+					Namespace: namespace,
+				},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					VolumeName: pv.Name,
+				},
+			}
+			p := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      pod,
+					Namespace: namespace,
+				},
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{
+						{
+							Name: "disk",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: pvc.Name},
+							},
+						},
+					},
+				},
+			}
+			for _, obj := range []client.Object{pv, pvc, p} {
+				gomega.Expect(cl.Create(context.TODO(), obj)).To(gomega.Succeed())
+			}
+		}
+
+		ginkgo.It("Should report the pods with a volume node affinity conflict", func() {
+			cr, r, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			conflict := "0/3 nodes are available: 1 node(s) had volume node affinity conflict, 2 node(s) didn't match Pod's node affinity/selector."
+			createPodWithVolume(cl, "default", "vm-a", driverName)
+			createPodWithVolume(cl, "other", "vm-b", driverName)
+			createPodWithVolume(cl, "default", "vm-c", driverName)
+			createPodWithVolume(cl, "default", "vm-d", "other.csi.example.com")
+			first := createSchedulingEvent(cl, "default", "vm-a", conflict, 3)
+			second := createSchedulingEvent(cl, "other", "vm-b", conflict, 1)
+			createSchedulingEvent(cl, "default", "vm-c", "0/3 nodes are available: 3 Insufficient cpu.", 5)
+			// Neither the conflicts of the volumes of other storage, nor of pods that are gone, are reported.
+			createSchedulingEvent(cl, "default", "vm-d", conflict, 7)
+			createSchedulingEvent(cl, "default", "vm-e", conflict, 2)
+
+			ginkgo.By("Not reporting the conflicts with the feature gate disabled")
+			_, err := r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(conditions.FindStatusCondition(cr.Status.Conditions, ConditionVolumeNodeAffinityConflict)).To(gomega.BeNil())
+
+			ginkgo.By("Enabling the VolumeNodeAffinityConflictDetection feature gate")
+			setDetection(cl, cr, true)
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			condition := conditions.FindStatusCondition(cr.Status.Conditions, ConditionVolumeNodeAffinityConflict)
+			gomega.Expect(condition).ToNot(gomega.BeNil())
+			gomega.Expect(condition.Status).To(gomega.Equal(corev1.ConditionTrue))
+			gomega.Expect(condition.Reason).To(gomega.Equal(volumeNodeAffinityConflict))
+			gomega.Expect(condition.Message).To(gomega.Equal(fmt.Sprintf(volumeNodeAffinityConflictConditionMessage, 2, 4)))
+			gomega.Expect(IsCrHealthy(cr)).To(gomega.BeTrue())
+
+			ginkgo.By("Clearing the condition once the events are gone")
+			gomega.Expect(cl.Delete(context.TODO(), first)).To(gomega.Succeed())
+			gomega.Expect(cl.Delete(context.TODO(), second)).To(gomega.Succeed())
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			condition = conditions.FindStatusCondition(cr.Status.Conditions, ConditionVolumeNodeAffinityConflict)
+			gomega.Expect(condition).ToNot(gomega.BeNil())
+			gomega.Expect(condition.Status).To(gomega.Equal(corev1.ConditionFalse))
+			gomega.Expect(condition.Reason).To(gomega.Equal(noVolumeNodeAffinityConflict))
+
+			ginkgo.By("Removing the condition when the feature gate is disabled")
+			setDetection(cl, cr, false)
+			_, err = r.Reconcile(context.TODO(), req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			err = cl.Get(context.TODO(), req.NamespacedName, cr)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(conditions.FindStatusCondition(cr.Status.Conditions, ConditionVolumeNodeAffinityConflict)).To(gomega.BeNil())
+		})
+
+		ginkgo.It("Should only map the volume node affinity conflicts to the HPP if the detection is enabled", func() {
+			cr, _, cl := createDeployedCr(createStoragePoolWithTemplateCr())
+			conflict := createSchedulingEvent(cl, "default", "vm-a", "0/1 nodes are available: 1 node(s) had volume node affinity conflict.", 1)
+			other := createSchedulingEvent(cl, "default", "vm-b", "0/1 nodes are available: 1 Insufficient memory.", 1)
+			mapFn := hppVolumeNodeAffinityConflictMapFunc(cl)
+			gomega.Expect(mapFn(context.TODO(), conflict)).To(gomega.BeEmpty())
+
+			setDetection(cl, cr, true)
+			gomega.Expect(mapFn(context.TODO(), conflict)).To(gomega.Equal([]reconcile.Request{{NamespacedName: types.NamespacedName{Name: cr.Name}}}))
+			gomega.Expect(mapFn(context.TODO(), other)).To(gomega.BeEmpty())
+		})
+	})
+})
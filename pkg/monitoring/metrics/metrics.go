@@ -18,17 +18,34 @@ limitations under the License.
 package metrics
 
 import (
+	"sync/atomic"
+
 	"github.com/machadovilaca/operator-observability/pkg/operatormetrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
 
+var isLeader atomic.Bool
+
 // SetupMetrics register the metrics
 func SetupMetrics() error {
 	operatormetrics.Register = metrics.Registry.Register
 
-	return operatormetrics.RegisterMetrics(
+	if err := operatormetrics.RegisterMetrics(
 		operatorMetrics,
-	)
+	); err != nil {
+		return err
+	}
+	resetOperatorMetrics()
+	return nil
+}
+
+// SetLeader marks whether this operator instance is the elected leader. Only the leader updates the metric values,
+// standby instances keep exposing the neutral values so they don't conflict with the leader in the scrape.
+func SetLeader(leader bool) {
+	isLeader.Store(leader)
+	if !leader {
+		resetOperatorMetrics()
+	}
 }
 
 // ListMetrics list the metrics opts
@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/machadovilaca/operator-observability/pkg/operatormetrics"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestMetrics(t *testing.T) {
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "Metrics Suite")
+}
+
+var _ = ginkgo.BeforeSuite(func() {
+	gomega.Expect(SetupMetrics()).To(gomega.Succeed())
+})
+
+var _ = ginkgo.Describe("Operator metrics", func() {
+	ginkgo.AfterEach(func() {
+		SetLeader(false)
+	})
+
+	ginkgo.It("Should expose the neutral ready value on a standby replica", func() {
+		gomega.Expect(readyGaugeValue()).To(gomega.Equal(float64(readyGaugeNeutralValue)))
+		SetReadyGaugeValue(0)
+		gomega.Expect(readyGaugeValue()).To(gomega.Equal(float64(readyGaugeNeutralValue)))
+	})
+
+	ginkgo.It("Should update the ready value on the leader", func() {
+		SetLeader(true)
+		SetReadyGaugeValue(1)
+		gomega.Expect(readyGaugeValue()).To(gomega.Equal(float64(1)))
+		SetReadyGaugeValue(0)
+		gomega.Expect(readyGaugeValue()).To(gomega.Equal(float64(0)))
+	})
+
+	ginkgo.It("Should reset the ready value when losing leadership", func() {
+		SetLeader(true)
+		SetReadyGaugeValue(1)
+		SetLeader(false)
+		gomega.Expect(readyGaugeValue()).To(gomega.Equal(float64(readyGaugeNeutralValue)))
+	})
+
+	ginkgo.It("Should only update the last successful reconcile on the leader", func() {
+		reconciled := time.Unix(1700000000, 0)
+		SetLastSuccessfulReconcile(reconciled)
+		gomega.Expect(gaugeValue(lastSuccessfulReconcileGauge)).To(gomega.Equal(float64(0)))
+		SetLeader(true)
+		SetLastSuccessfulReconcile(reconciled)
+		gomega.Expect(gaugeValue(lastSuccessfulReconcileGauge)).To(gomega.Equal(float64(1700000000)))
+		SetLeader(false)
+		gomega.Expect(gaugeValue(lastSuccessfulReconcileGauge)).To(gomega.Equal(float64(0)))
+	})
+
+	ginkgo.It("Should only update the clock skew on the leader", func() {
+		SetClockSkew(time.Hour)
+		gomega.Expect(gaugeValue(clockSkewGauge)).To(gomega.Equal(float64(0)))
+		SetLeader(true)
+		SetClockSkew(time.Hour)
+		gomega.Expect(gaugeValue(clockSkewGauge)).To(gomega.Equal(float64(3600)))
+		SetLeader(false)
+		gomega.Expect(gaugeValue(clockSkewGauge)).To(gomega.Equal(float64(0)))
+	})
+
+	ginkgo.It("Should expose the operator namespace on all replicas", func() {
+		SetOperatorNamespace("hpp")
+		gomega.Expect(gaugeVecValue(operatorInfoGauge, "hpp")).To(gomega.Equal(float64(1)))
+		ginkgo.By("Replacing the previous namespace")
+		SetOperatorNamespace("other")
+		gomega.Expect(gaugeVecValue(operatorInfoGauge, "other")).To(gomega.Equal(float64(1)))
+		gomega.Expect(gaugeVecValue(operatorInfoGauge, "hpp")).To(gomega.Equal(float64(0)))
+	})
+
+	ginkgo.It("Should expose the build info on all replicas", func() {
+		SetBuildInfo("0123456789abcdef", "2024-05-01T10:00:00Z")
+		gomega.Expect(gaugeVecValue(buildInfoGauge, "0123456789abcdef", "2024-05-01T10:00:00Z")).To(gomega.Equal(float64(1)))
+		ginkgo.By("Replacing the previous build")
+		SetBuildInfo("fedcba9876543210", "2024-06-01T10:00:00Z")
+		gomega.Expect(testutil.CollectAndCount(buildInfoGauge)).To(gomega.Equal(1))
+	})
+
+	ginkgo.It("Should only expose the provisioned volumes on the leader", func() {
+		SetProvisionedVolumes(map[string]int{"local": 2})
+		gomega.Expect(testutil.CollectAndCount(provisionedVolumesGauge)).To(gomega.BeZero())
+		SetLeader(true)
+		SetProvisionedVolumes(map[string]int{"local": 2, "fast": 0})
+		gomega.Expect(gaugeVecValue(provisionedVolumesGauge, "local")).To(gomega.Equal(float64(2)))
+		gomega.Expect(gaugeVecValue(provisionedVolumesGauge, "fast")).To(gomega.Equal(float64(0)))
+		ginkgo.By("Removing the storage pools that are gone")
+		SetProvisionedVolumes(map[string]int{"fast": 1})
+		gomega.Expect(testutil.CollectAndCount(provisionedVolumesGauge)).To(gomega.Equal(1))
+		SetLeader(false)
+		gomega.Expect(testutil.CollectAndCount(provisionedVolumesGauge)).To(gomega.BeZero())
+	})
+})
+
+func readyGaugeValue() float64 {
+	return gaugeValue(readyGauge)
+}
+
+func gaugeValue(gauge *operatormetrics.Gauge) float64 {
+	m := &dto.Metric{}
+	gomega.Expect(gauge.Write(m)).To(gomega.Succeed())
+	return m.GetGauge().GetValue()
+}
+
+func gaugeVecValue(gauge *operatormetrics.GaugeVec, labels ...string) float64 {
+	m := &dto.Metric{}
+	gomega.Expect(gauge.WithLabelValues(labels...).Write(m)).To(gomega.Succeed())
+	return m.GetGauge().GetValue()
+}
@@ -17,23 +17,124 @@ limitations under the License.
 package metrics
 
 import (
+	"time"
+
 	"github.com/machadovilaca/operator-observability/pkg/operatormetrics"
 )
 
+// 0 is our 'something bad is going on' value for alert to start firing, so can't default to that
+const readyGaugeNeutralValue = -1
+
 var (
 	operatorMetrics = []operatormetrics.Metric{
 		readyGauge,
+		lastSuccessfulReconcileGauge,
+		operatorInfoGauge,
+		buildInfoGauge,
+		clockSkewGauge,
+		provisionedVolumesGauge,
 	}
 
 	readyGauge = operatormetrics.NewGauge(
 		operatormetrics.MetricOpts{
 			Name: "kubevirt_hpp_cr_ready",
-			Help: "HPP CR Ready",
+			Help: "HPP CR Ready, standby operator replicas report -1",
+		},
+	)
+
+	lastSuccessfulReconcileGauge = operatormetrics.NewGauge(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_hpp_last_successful_reconcile_timestamp_seconds",
+			Help: "The Unix time of the last successful reconcile of the HPP CR, standby operator replicas report 0",
+		},
+	)
+
+	clockSkewGauge = operatormetrics.NewGauge(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_hpp_clock_skew_seconds",
+			Help: "The number of seconds the newest condition heartbeat of the HPP CR is ahead of the operator clock, standby operator replicas report 0",
 		},
 	)
+
+	operatorInfoGauge = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_hpp_operator_info",
+			Help: "Information about the HPP operator, always 1, the namespace label is the namespace the operator deploys to",
+		},
+		[]string{"namespace"},
+	)
+
+	buildInfoGauge = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_hpp_build_info",
+			Help: "The build of the HPP operator, always 1, the labels are the git commit and the build date",
+		},
+		[]string{"git_commit", "build_date"},
+	)
+
+	provisionedVolumesGauge = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_hpp_provisioned_volumes",
+			Help: "The number of PersistentVolumes provisioned by the hostpath provisioner, by storage pool, standby operator replicas report none",
+		},
+		[]string{"storage_pool"},
+	)
 )
 
-// SetReadyGaugeValue sets the ReadyGauge metric to a desired value
+// SetReadyGaugeValue sets the ReadyGauge metric to a desired value, this is a no-op if not the leader
 func SetReadyGaugeValue(value int) {
+	if !isLeader.Load() {
+		return
+	}
 	readyGauge.Set(float64(value))
 }
+
+// SetLastSuccessfulReconcile sets the time of the last successful reconcile, this is a no-op if not the leader. The
+// gauge is a timestamp rather than a duration, so it keeps aging at scrape time while no reconcile succeeds.
+func SetLastSuccessfulReconcile(t time.Time) {
+	if !isLeader.Load() {
+		return
+	}
+	lastSuccessfulReconcileGauge.Set(float64(t.Unix()))
+}
+
+// SetClockSkew sets how far the condition heartbeats are ahead of the clock of the operator, this is a no-op if not the
+// leader
+func SetClockSkew(skew time.Duration) {
+	if !isLeader.Load() {
+		return
+	}
+	clockSkewGauge.Set(skew.Seconds())
+}
+
+// SetOperatorNamespace sets the namespace label of the info metric. All replicas watch the same namespace, so this is
+// also set on standby replicas.
+func SetOperatorNamespace(namespace string) {
+	operatorInfoGauge.Reset()
+	operatorInfoGauge.WithLabelValues(namespace).Set(1)
+}
+
+// SetBuildInfo sets the labels of the build info metric. All replicas run the same build, so this is also set on
+// standby replicas.
+func SetBuildInfo(gitCommit, buildDate string) {
+	buildInfoGauge.Reset()
+	buildInfoGauge.WithLabelValues(gitCommit, buildDate).Set(1)
+}
+
+// SetProvisionedVolumes replaces the number of provisioned volumes of each storage pool, this is a no-op if not the leader
+func SetProvisionedVolumes(volumes map[string]int) {
+	if !isLeader.Load() {
+		return
+	}
+	provisionedVolumesGauge.Reset()
+	for storagePool, count := range volumes {
+		provisionedVolumesGauge.WithLabelValues(storagePool).Set(float64(count))
+	}
+}
+
+func resetOperatorMetrics() {
+	readyGauge.Set(readyGaugeNeutralValue)
+	lastSuccessfulReconcileGauge.Set(0)
+	clockSkewGauge.Set(0)
+	provisionedVolumesGauge.Reset()
+}
@@ -0,0 +1,24 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import "strings"
+
+// GetFlagName returns the name of a command line flag without the dashes and the value, --datadir=/tmp becomes datadir.
+func GetFlagName(arg string) string {
+	return strings.SplitN(strings.TrimLeft(arg, "-"), "=", 2)[0]
+}
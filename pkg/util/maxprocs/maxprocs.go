@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package maxprocs aligns GOMAXPROCS with the CPU quota of the container
+package maxprocs
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+const (
+	// The cgroup of the container is mounted at the root with a cgroup namespace, which is the default of the runtimes.
+	cgroupV2CPUMaxPath = "/sys/fs/cgroup/cpu.max"
+	cgroupV1QuotaPath  = "/sys/fs/cgroup/cpu/cpu.cfs_quota_us"
+	cgroupV1PeriodPath = "/sys/fs/cgroup/cpu/cpu.cfs_period_us"
+	maxProcsEnvVarName = "GOMAXPROCS"
+)
+
+// readFileFunc reads the cgroup files, it is replaced in the tests.
+var readFileFunc = os.ReadFile
+
+// Set sets GOMAXPROCS to the CPU quota of the cgroup, rounded down with a minimum of 1. By default the runtime uses
+// the number of CPUs of the node, so on large nodes the operator runs far more threads than its quota allows and gets
+// throttled. GOMAXPROCS in the environment takes precedence, and without a quota the runtime default is kept. It
+// returns the resulting GOMAXPROCS.
+func Set() (int, error) {
+	if _, ok := os.LookupEnv(maxProcsEnvVarName); ok {
+		return runtime.GOMAXPROCS(0), nil
+	}
+	quota, ok, err := cpuQuota()
+	if err != nil || !ok {
+		return runtime.GOMAXPROCS(0), err
+	}
+	procs := int(quota)
+	if procs < 1 {
+		procs = 1
+	}
+	if procs < runtime.NumCPU() {
+		runtime.GOMAXPROCS(procs)
+	}
+	return runtime.GOMAXPROCS(0), nil
+}
+
+// cpuQuota returns the CPU quota of the cgroup in CPUs, false if there is no quota or no cgroup CPU controller.
+func cpuQuota() (float64, bool, error) {
+	content, err := readFileFunc(cgroupV2CPUMaxPath)
+	if err == nil {
+		// cpu.max has the quota and the period, the quota is max without a limit.
+		fields := strings.Fields(string(content))
+		if len(fields) != 2 {
+			return 0, false, fmt.Errorf("invalid %s: %q", cgroupV2CPUMaxPath, string(content))
+		}
+		if fields[0] == "max" {
+			return 0, false, nil
+		}
+		return parseQuota(fields[0], fields[1])
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return 0, false, err
+	}
+
+	quota, err := readFileFunc(cgroupV1QuotaPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, err
+	}
+	period, err := readFileFunc(cgroupV1PeriodPath)
+	if err != nil {
+		return 0, false, err
+	}
+	// The quota is -1 without a limit.
+	if strings.TrimSpace(string(quota)) == "-1" {
+		return 0, false, nil
+	}
+	return parseQuota(string(quota), string(period))
+}
+
+func parseQuota(quota, period string) (float64, bool, error) {
+	q, err := strconv.ParseInt(strings.TrimSpace(quota), 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid CPU quota %q: %w", quota, err)
+	}
+	p, err := strconv.ParseInt(strings.TrimSpace(period), 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid CPU period %q: %w", period, err)
+	}
+	if q <= 0 || p <= 0 {
+		return 0, false, nil
+	}
+	return float64(q) / float64(p), true, nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maxprocs
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"runtime"
+
+	ginkgo "github.com/onsi/ginkgo/v2"
+	gomega "github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("MaxProcs", func() {
+	var (
+		orgReadFileFunc func(string) ([]byte, error)
+		orgMaxProcs     int
+	)
+
+	// setCgroupFiles makes the cgroup files read the given content, other files don't exist.
+	setCgroupFiles := func(files map[string]string) {
+		readFileFunc = func(name string) ([]byte, error) {
+			if content, ok := files[name]; ok {
+				return []byte(content), nil
+			}
+			return nil, fmt.Errorf("open %s: %w", name, fs.ErrNotExist)
+		}
+	}
+
+	ginkgo.BeforeEach(func() {
+		orgReadFileFunc = readFileFunc
+		orgMaxProcs = runtime.GOMAXPROCS(0)
+		if value, ok := os.LookupEnv(maxProcsEnvVarName); ok {
+			gomega.Expect(os.Unsetenv(maxProcsEnvVarName)).To(gomega.Succeed())
+			ginkgo.DeferCleanup(os.Setenv, maxProcsEnvVarName, value)
+		}
+	})
+
+	ginkgo.AfterEach(func() {
+		readFileFunc = orgReadFileFunc
+		runtime.GOMAXPROCS(orgMaxProcs)
+	})
+
+	ginkgo.DescribeTable("should read the CPU quota of the cgroup", func(files map[string]string, expectedQuota float64, expectedOk bool) {
+		setCgroupFiles(files)
+		quota, ok, err := cpuQuota()
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(ok).To(gomega.Equal(expectedOk))
+		gomega.Expect(quota).To(gomega.Equal(expectedQuota))
+	},
+		ginkgo.Entry("cgroup v2 with a quota", map[string]string{cgroupV2CPUMaxPath: "150000 100000\n"}, 1.5, true),
+		ginkgo.Entry("cgroup v2 without a quota", map[string]string{cgroupV2CPUMaxPath: "max 100000\n"}, float64(0), false),
+		ginkgo.Entry("cgroup v1 with a quota", map[string]string{cgroupV1QuotaPath: "200000\n", cgroupV1PeriodPath: "100000\n"}, float64(2), true),
+		ginkgo.Entry("cgroup v1 without a quota", map[string]string{cgroupV1QuotaPath: "-1\n", cgroupV1PeriodPath: "100000\n"}, float64(0), false),
+		ginkgo.Entry("no cgroup CPU controller", map[string]string{}, float64(0), false),
+	)
+
+	ginkgo.It("should fail on an invalid cpu.max", func() {
+		setCgroupFiles(map[string]string{cgroupV2CPUMaxPath: "100000"})
+		_, _, err := cpuQuota()
+		gomega.Expect(err).To(gomega.HaveOccurred())
+		_, err = Set()
+		gomega.Expect(err).To(gomega.HaveOccurred())
+		gomega.Expect(runtime.GOMAXPROCS(0)).To(gomega.Equal(orgMaxProcs))
+	})
+
+	ginkgo.It("should set GOMAXPROCS to the CPU quota, with a minimum of 1", func() {
+		if runtime.NumCPU() < 2 {
+			ginkgo.Skip("needs at least 2 CPUs")
+		}
+		setCgroupFiles(map[string]string{cgroupV2CPUMaxPath: "150000 100000"})
+		procs, err := Set()
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(procs).To(gomega.Equal(1))
+		gomega.Expect(runtime.GOMAXPROCS(0)).To(gomega.Equal(1))
+
+		setCgroupFiles(map[string]string{cgroupV2CPUMaxPath: "10000 100000"})
+		procs, err = Set()
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(procs).To(gomega.Equal(1))
+	})
+
+	ginkgo.It("should not raise GOMAXPROCS above the number of CPUs", func() {
+		runtime.GOMAXPROCS(runtime.NumCPU())
+		setCgroupFiles(map[string]string{cgroupV2CPUMaxPath: fmt.Sprintf("%d 100000", (runtime.NumCPU()+1)*100000)})
+		procs, err := Set()
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(procs).To(gomega.Equal(runtime.NumCPU()))
+	})
+
+	ginkgo.It("should keep GOMAXPROCS from the environment", func() {
+		ginkgo.DeferCleanup(os.Unsetenv, maxProcsEnvVarName)
+		gomega.Expect(os.Setenv(maxProcsEnvVarName, "3")).To(gomega.Succeed())
+		setCgroupFiles(map[string]string{cgroupV2CPUMaxPath: "100000 100000"})
+		procs, err := Set()
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(procs).To(gomega.Equal(orgMaxProcs))
+	})
+})
@@ -0,0 +1,28 @@
+/*
+Copyright 2024 The hostpath provisioner operator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"path"
+	"strings"
+)
+
+// PathsOverlap returns true if the paths are the same directory, or if one of them is a directory under the other.
+func PathsOverlap(first, second string) bool {
+	first, second = path.Clean(first), path.Clean(second)
+	return first == second || strings.HasPrefix(first, strings.TrimSuffix(second, "/")+"/") || strings.HasPrefix(second, strings.TrimSuffix(first, "/")+"/")
+}
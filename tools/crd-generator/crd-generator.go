@@ -8,6 +8,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/ghodss/yaml"
 	appsv1 "k8s.io/api/apps/v1"
@@ -38,7 +39,8 @@ func main() {
 	fileScanner := bufio.NewScanner(file)
 	buf := make([]byte, fileInfo.Size())
 	fileScanner.Buffer(buf, len(buf))
-	searchBytes := []byte("---")
+	// The separator is matched at the start of a line, descriptions can contain ---.
+	searchBytes := []byte("\n---\n")
     searchLen := len(searchBytes)
 	fileScanner.Split(func(data []byte, atEOF bool) (advance int, token []byte, err error) {
         dataLen := len(data)
@@ -107,7 +109,8 @@ func generateCrdGoFile(outputDir string, crd *extv1.CustomResourceDefinition) {
 
 	crd.Status = extv1.CustomResourceDefinitionStatus{}
 	b, _ := yaml.Marshal(crd)
-	file.WriteString(string(b))
+	// The descriptions can contain backticks, which cannot be in a raw string literal.
+	file.WriteString(strings.ReplaceAll(string(b), "`", "` + \"`\" + `"))
 	file.WriteString("`\n")
 
 }
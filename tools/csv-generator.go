@@ -52,6 +52,7 @@ var (
 	csiLivenessProbeImage       = flag.String("csi-liveness-probe-image-name", hostpathprovisioner.LivenessProbeImageDefault, "optional")
 	csiExternalProvisionerImage = flag.String("csi-external-provisioner-image-name", hostpathprovisioner.CsiSigStorageProvisionerImageDefault, "optional")
 	csiSnapshotterImage         = flag.String("csi-snapshotter-image-name", hostpathprovisioner.SnapshotterImageDefault, "optional")
+	csiResizerImage             = flag.String("csi-resizer-image-name", hostpathprovisioner.ResizerImageDefault, "optional")
 
 	dumpCRDs = flag.Bool("dump-crds", false, "optional - dumps operator related crd manifests to stdout")
 )
@@ -76,6 +77,7 @@ func main() {
 		CsiLivenessProbeImage:       *csiLivenessProbeImage,
 		CsiExternalProvisionerImage: *csiExternalProvisionerImage,
 		CsiSnapshotterImage:         *csiSnapshotterImage,
+		CsiResizerImage:             *csiResizerImage,
 	}
 
 	csv, err := createClusterServiceVersion(&data)
@@ -18,6 +18,7 @@ rules:
   - watch
   - create
   - delete
+  - patch
 - apiGroups:
   - ""
   resources:
@@ -28,6 +29,13 @@ rules:
   - watch
   - create
   - update
+  - delete
+- apiGroups:
+  - ""
+  resources:
+  - persistentvolumeclaims/status
+  verbs:
+  - patch
 - apiGroups:
   - ""
   resources:
@@ -49,6 +57,7 @@ rules:
   - clusterrolebindings
   verbs:
   - update
+  - patch
   - delete
 - apiGroups:
   - rbac.authorization.k8s.io
@@ -78,6 +87,7 @@ rules:
   - clusterroles
   verbs:
   - update
+  - patch
   - delete
 - apiGroups:
   - apps
@@ -112,10 +122,12 @@ rules:
   verbs:
   - delete
   - update
+  - patch
 - apiGroups:
   - config.openshift.io
   resources:
   - apiservers
+  - proxies
   verbs:
   - get
   - list
@@ -128,6 +140,10 @@ rules:
   - list
   - get
   - watch
+  - create
+  - update
+  - patch
+  - delete
 - apiGroups:
   - ""
   resources:
@@ -153,15 +169,9 @@ rules:
   - create
   - get
   - watch
-- apiGroups:
-  - storage.k8s.io
-  resourceNames:
-  - kubevirt.io.hostpath-provisioner
-  resources:
-  - csidrivers
-  verbs:
   - delete
   - update
+  - patch
 - apiGroups:
   - storage.k8s.io
   resources:
@@ -185,6 +195,22 @@ rules:
   - get
   - list
   - watch
+  - create
+  - update
+  - patch
+  - delete
+- apiGroups:
+  - cdi.kubevirt.io
+  resources:
+  - storageprofiles
+  verbs:
+  - get
+  - list
+  - watch
+  - create
+  - update
+  - patch
+  - delete
 - apiGroups:
   - snapshot.storage.k8s.io
   resources:
@@ -218,4 +244,82 @@ rules:
   - get
   - list
   - watch
+- apiGroups:
+  - apps
+  resources:
+  - deployments
+  verbs:
+  - list
+  - get
+  - watch
+  - create
+  - delete
+  - update
+  - patch
+- apiGroups:
+  - batch
+  resources:
+  - jobs
+  verbs:
+  - create
+  - delete
+  - list
+  - watch
+- apiGroups:
+  - ""
+  resources:
+  - serviceaccounts
+  verbs:
+  - list
+  - get
+  - create
+  - watch
+- apiGroups:
+  - ""
+  resourceNames:
+  - hostpath-provisioner-admin-csi
+  resources:
+  - serviceaccounts
+  verbs:
+  - update
+  - patch
+  - delete
+- apiGroups:
+  - ""
+  resources:
+  - namespaces
+  verbs:
+  - get
+  - list
+  - watch
+- apiGroups:
+  - rbac.authorization.k8s.io
+  resources:
+  - roles
+  - rolebindings
+  verbs:
+  - list
+  - get
+  - watch
+  - create
+- apiGroups:
+  - rbac.authorization.k8s.io
+  resourceNames:
+  - hostpath-provisioner-admin-csi
+  resources:
+  - roles
+  - rolebindings
+  verbs:
+  - update
+  - patch
+  - delete
+- apiGroups:
+  - rbac.authorization.k8s.io
+  resourceNames:
+  - hostpath-provisioner-admin-csi
+  resources:
+  - roles
+  verbs:
+  - bind
+  - escalate
 `
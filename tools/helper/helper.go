@@ -37,6 +37,7 @@ type OperatorArgs struct {
 	CsiLivenessProbeImage         string
 	CsiExternalProvisionerImage   string
 	CsiSnapshotterImage           string
+	CsiResizerImage               string
 }
 
 const (
@@ -64,6 +65,7 @@ func CreateOperatorDeployment(args *OperatorArgs) *appsv1.Deployment {
 	setEnvVariable("LIVENESS_PROBE_IMAGE", args.CsiLivenessProbeImage, deployment.Spec.Template.Spec.Containers[0].Env)
 	setEnvVariable("CSI_SIG_STORAGE_PROVISIONER_IMAGE", args.CsiExternalProvisionerImage, deployment.Spec.Template.Spec.Containers[0].Env)
 	setEnvVariable("CSI_SNAPSHOT_IMAGE", args.CsiSnapshotterImage, deployment.Spec.Template.Spec.Containers[0].Env)
+	setEnvVariable("CSI_RESIZER_IMAGE", args.CsiResizerImage, deployment.Spec.Template.Spec.Containers[0].Env)
 	return &deployment
 }
 
@@ -56,6 +56,8 @@ spec:
           value: registry.k8s.io/sig-storage/livenessprobe:v2.3.0
         - name: CSI_SNAPSHOT_IMAGE
           value: registry.k8s.io/sig-storage/csi-snapshotter:v4.2.1
+        - name: CSI_RESIZER_IMAGE
+          value: registry.k8s.io/sig-storage/csi-resizer:v1.7.0
         - name: CSI_SIG_STORAGE_PROVISIONER_IMAGE
           value: registry.k8s.io/sig-storage/csi-provisioner:v3.4.1
         - name: VERBOSITY
@@ -27,6 +27,7 @@ rules:
   verbs:
   - delete
   - update
+  - patch
 - apiGroups:
   - apps
   resources:
@@ -38,6 +39,7 @@ rules:
   - create
   - delete
   - update
+  - patch
 - apiGroups:
   - ""
   resources:
@@ -63,6 +65,7 @@ rules:
   - services
   verbs:
   - update
+  - patch
   - delete
 - apiGroups:
   - ""
@@ -71,6 +74,8 @@ rules:
   verbs:
   - create
   - get
+  - list
+  - watch
 - apiGroups:
   - ""
   resourceNames:
@@ -79,6 +84,16 @@ rules:
   - configmaps
   verbs:
   - update
+- apiGroups:
+  - ""
+  resourceNames:
+  - hostpath-provisioner-trusted-ca-bundle
+  resources:
+  - configmaps
+  verbs:
+  - update
+  - patch
+  - delete
 - apiGroups:
   - ""
   resources:
@@ -97,6 +112,7 @@ rules:
   - serviceaccounts
   verbs:
   - update
+  - patch
   - delete
 - apiGroups:
   - coordination.k8s.io
@@ -159,6 +175,7 @@ rules:
   - rolebindings
   verbs:
   - update
+  - patch
   - delete
 - apiGroups:
   - rbac.authorization.k8s.io
@@ -171,6 +188,7 @@ rules:
   - roles
   verbs:
   - update
+  - patch
   - delete
 - apiGroups:
   - batch
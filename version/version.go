@@ -19,6 +19,7 @@ package version
 import (
 	"bufio"
 	"os"
+	"runtime/debug"
 	"strings"
 
 	"github.com/blang/semver"
@@ -27,8 +28,42 @@ import (
 // VersionStringFunc is the function that feeds the version string into GetVersion
 var (
 	VersionStringFunc = getStringFromVersionTxt
+	// BuildInfoFunc is the function that returns the build of the running operator
+	BuildInfoFunc = getBuildInfo
 )
 
+// gitCommit and buildDate are set when building the operator, with -ldflags "-X ...".
+var (
+	gitCommit string
+	buildDate string
+)
+
+// BuildInfo identifies the build of the operator.
+type BuildInfo struct {
+	GitCommit string
+	BuildDate string
+}
+
+// getBuildInfo returns the git commit and build date set at build time. If they are not set, the commit and commit
+// time go build stamps into the binary are used instead.
+func getBuildInfo() BuildInfo {
+	info := BuildInfo{
+		GitCommit: gitCommit,
+		BuildDate: buildDate,
+	}
+	if buildInfo, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range buildInfo.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && info.GitCommit == "":
+				info.GitCommit = setting.Value
+			case setting.Key == "vcs.time" && info.BuildDate == "":
+				info.BuildDate = setting.Value
+			}
+		}
+	}
+	return info
+}
+
 // GetVersion reads the version.txt and returns the version as a semver.Version.
 func GetVersion() (*semver.Version, error) {
 	versionString, err := VersionStringFunc()
@@ -61,6 +61,23 @@ var _ = ginkgo.Describe("Version", func() {
 	})
 })
 
+var _ = ginkgo.Describe("BuildInfo", func() {
+	ginkgo.AfterEach(func() {
+		gitCommit = ""
+		buildDate = ""
+	})
+
+	ginkgo.It("should return the git commit and build date set at build time", func() {
+		gitCommit = "0123456789abcdef"
+		buildDate = "2024-05-01T10:00:00Z"
+		result := BuildInfoFunc()
+		gomega.Expect(result).To(gomega.Equal(BuildInfo{
+			GitCommit: "0123456789abcdef",
+			BuildDate: "2024-05-01T10:00:00Z",
+		}))
+	})
+})
+
 var _ = ginkgo.Describe("GetStringFromFile", func() {
 	ginkgo.It("should return nil on invalid file", func() {
 		result, err := GetStringFromFile("invalid")